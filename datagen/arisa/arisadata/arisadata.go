@@ -0,0 +1,51 @@
+// Package arisadata embeds the x86 and JVM instruction datasets, so Go
+// programs can look instructions up without shipping or locating the JSON
+// files. The datasets are compressed into the binary and decoded the first
+// time they are used; the lookup tables are generated ahead of time.
+//
+// After regenerating a dataset, refresh the package with:
+//
+//	go generate ./arisadata
+package arisadata
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"arisa/dataset"
+)
+
+//go:generate go run ./internal/gen
+
+//go:embed x86.json.zst
+var x86Blob []byte
+
+//go:embed jvm_instructions.json.zst
+var jvmBlob []byte
+
+// decode decompresses an embedded dataset into records.
+func decode[T any](name string, blob []byte) ([]T, error) {
+	reader, err := dataset.NewReader(bytes.NewReader(blob))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded %s: %w", name, err)
+	}
+	defer reader.Close()
+
+	var records []T
+	if err := json.NewDecoder(reader).Decode(&records); err != nil {
+		return nil, fmt.Errorf("failed to decode embedded %s: %w", name, err)
+	}
+	return records, nil
+}
+
+var (
+	loadX86 = sync.OnceValues(func() ([]X86Instruction, error) {
+		return decode[X86Instruction]("x86 dataset", x86Blob)
+	})
+	loadJVM = sync.OnceValues(func() ([]JVMInstruction, error) {
+		return decode[JVMInstruction]("JVM dataset", jvmBlob)
+	})
+)
@@ -0,0 +1,2084 @@
+// Code generated by internal/gen. DO NOT EDIT.
+
+package arisadata
+
+var x86Index = map[string][]int{
+	"AAA":                  {0},
+	"AAD":                  {1},
+	"AAM":                  {2},
+	"AAS":                  {3},
+	"ADC":                  {4},
+	"ADCX":                 {5},
+	"ADD":                  {6},
+	"ADDPD":                {7},
+	"ADDPS":                {8},
+	"ADDSD":                {9},
+	"ADDSS":                {10},
+	"ADDSUBPD":             {11},
+	"ADDSUBPS":             {12},
+	"ADOX":                 {13},
+	"AESDEC":               {14},
+	"AESDEC128KL":          {15},
+	"AESDEC256KL":          {16},
+	"AESDECLAST":           {17},
+	"AESDECWIDE128KL":      {18},
+	"AESDECWIDE256KL":      {19},
+	"AESENC":               {20},
+	"AESENC128KL":          {21},
+	"AESENC256KL":          {22},
+	"AESENCLAST":           {23},
+	"AESENCWIDE128KL":      {24},
+	"AESENCWIDE256KL":      {25},
+	"AESIMC":               {26},
+	"AESKEYGENASSIST":      {27},
+	"AND":                  {28},
+	"ANDN":                 {29},
+	"ANDNPD":               {30},
+	"ANDNPS":               {31},
+	"ANDPD":                {32},
+	"ANDPS":                {33},
+	"ARPL":                 {34},
+	"BEXTR":                {35},
+	"BLENDPD":              {36},
+	"BLENDPS":              {37},
+	"BLENDVPD":             {38},
+	"BLENDVPS":             {39},
+	"BLSI":                 {40},
+	"BLSMSK":               {41},
+	"BLSR":                 {42},
+	"BNDCL":                {43},
+	"BNDCN":                {44},
+	"BNDCU":                {44},
+	"BNDLDX":               {45},
+	"BNDMK":                {46},
+	"BNDMOV":               {47},
+	"BNDSTX":               {48},
+	"BOUND":                {49},
+	"BSF":                  {50},
+	"BSR":                  {51},
+	"BSWAP":                {52},
+	"BT":                   {53},
+	"BTC":                  {54},
+	"BTR":                  {55},
+	"BTS":                  {56},
+	"BZHI":                 {57},
+	"CALL":                 {58},
+	"CBW":                  {59},
+	"CDQ":                  {137},
+	"CDQE":                 {59},
+	"CLAC":                 {60},
+	"CLC":                  {61},
+	"CLD":                  {62},
+	"CLDEMOTE":             {63},
+	"CLFLUSH":              {64},
+	"CLFLUSHOPT":           {65},
+	"CLI":                  {66},
+	"CLRSSBSY":             {67},
+	"CLTS":                 {68},
+	"CLUI":                 {69},
+	"CLWB":                 {70},
+	"CMC":                  {71},
+	"CMOVA":                {72},
+	"CMOVAE":               {73},
+	"CMOVB":                {74},
+	"CMOVBE":               {75},
+	"CMOVC":                {76},
+	"CMOVCC":               {77},
+	"CMOVE":                {78},
+	"CMOVG":                {79},
+	"CMOVGE":               {80},
+	"CMOVL":                {81},
+	"CMOVLE":               {82},
+	"CMOVNA":               {83},
+	"CMOVNAE":              {84},
+	"CMOVNB":               {85},
+	"CMOVNBE":              {86},
+	"CMOVNC":               {87},
+	"CMOVNE":               {88},
+	"CMOVNG":               {89},
+	"CMOVNGE":              {90},
+	"CMOVNL":               {91},
+	"CMOVNLE":              {92},
+	"CMOVNO":               {93},
+	"CMOVNP":               {94},
+	"CMOVNS":               {95},
+	"CMOVNZ":               {96},
+	"CMOVO":                {97},
+	"CMOVP":                {98},
+	"CMOVPE":               {99},
+	"CMOVPO":               {100},
+	"CMOVS":                {101},
+	"CMOVZ":                {102},
+	"CMP":                  {103},
+	"CMPPD":                {104},
+	"CMPPS":                {105},
+	"CMPS":                 {106},
+	"CMPSB":                {106},
+	"CMPSD":                {106, 107},
+	"CMPSQ":                {106},
+	"CMPSS":                {108},
+	"CMPSW":                {106},
+	"CMPXCHG":              {109},
+	"CMPXCHG16B":           {110},
+	"CMPXCHG8B":            {110},
+	"COMISD":               {111},
+	"COMISS":               {112},
+	"CPUID":                {113},
+	"CQO":                  {137},
+	"CRC32":                {114},
+	"CVTDQ2PD":             {115},
+	"CVTDQ2PS":             {116},
+	"CVTPD2DQ":             {117},
+	"CVTPD2PI":             {118},
+	"CVTPD2PS":             {119},
+	"CVTPI2PD":             {120},
+	"CVTPI2PS":             {121},
+	"CVTPS2DQ":             {122},
+	"CVTPS2PD":             {123},
+	"CVTPS2PI":             {124},
+	"CVTSD2SI":             {125},
+	"CVTSD2SS":             {126},
+	"CVTSI2SD":             {127},
+	"CVTSI2SS":             {128},
+	"CVTSS2SD":             {129},
+	"CVTSS2SI":             {130},
+	"CVTTPD2DQ":            {131},
+	"CVTTPD2PI":            {132},
+	"CVTTPS2DQ":            {133},
+	"CVTTPS2PI":            {134},
+	"CVTTSD2SI":            {135},
+	"CVTTSS2SI":            {136},
+	"CWD":                  {137},
+	"CWDE":                 {59},
+	"DAA":                  {138},
+	"DAS":                  {139},
+	"DEC":                  {140},
+	"DIV":                  {141},
+	"DIVPD":                {142},
+	"DIVPS":                {143},
+	"DIVSD":                {144},
+	"DIVSS":                {145},
+	"DPPD":                 {146},
+	"DPPS":                 {147},
+	"EACCEPT":              {148},
+	"EACCEPTCOPY":          {149},
+	"EADD":                 {150},
+	"EAUG":                 {151},
+	"EAX":                  {148, 149, 150, 151, 152, 153, 154, 155, 156, 157, 158, 160, 161, 162, 163, 164, 166, 167, 168, 179, 180, 181, 182, 183, 184, 185, 186, 187},
+	"EBLOCK":               {152},
+	"ECREATE":              {153},
+	"EDBGRD":               {154},
+	"EDBGWR":               {155},
+	"EDECCSSA":             {156},
+	"EDECVIRTCHILD":        {157},
+	"EENTER":               {158},
+	"EEXIT":                {159},
+	"EEXTEND":              {160},
+	"EGETKEY":              {161},
+	"EINCVIRTCHILD":        {162},
+	"EINIT":                {163},
+	"ELDB":                 {164},
+	"ELDBC":                {164},
+	"ELDU":                 {164},
+	"ELDUC":                {164},
+	"EMMS":                 {165},
+	"EMODPE":               {166},
+	"EMODPR":               {167},
+	"EMODT":                {168},
+	"ENCLS":                {169},
+	"ENCLU":                {170},
+	"ENCLV":                {171},
+	"ENCODEKEY128":         {172},
+	"ENCODEKEY256":         {173},
+	"ENDBR32":              {174},
+	"ENDBR64":              {175},
+	"ENQCMD":               {176},
+	"ENQCMDS":              {177},
+	"ENTER":                {178},
+	"EPA":                  {179},
+	"ERDINFO":              {180},
+	"EREMOVE":              {181},
+	"EREPORT":              {182},
+	"ERESUME":              {183},
+	"ESETCONTEXT":          {184},
+	"ETRACK":               {185},
+	"ETRACKC":              {186},
+	"EWB":                  {187},
+	"EXTRACTPS":            {188},
+	"F2XM1":                {189},
+	"FABS":                 {190},
+	"FADD":                 {191},
+	"FADDP":                {191},
+	"FBLD":                 {192},
+	"FBSTP":                {193},
+	"FCHS":                 {194},
+	"FCLEX":                {195},
+	"FCMOVB":               {196},
+	"FCMOVBE":              {197},
+	"FCMOVCC":              {198},
+	"FCMOVE":               {199},
+	"FCMOVNB":              {200},
+	"FCMOVNBE":             {201},
+	"FCMOVNE":              {202},
+	"FCMOVNU":              {203},
+	"FCMOVU":               {204},
+	"FCOM":                 {205},
+	"FCOMI":                {206},
+	"FCOMIP":               {206},
+	"FCOMP":                {205},
+	"FCOMPP":               {205},
+	"FCOS":                 {207},
+	"FDECSTP":              {208},
+	"FDIV":                 {209},
+	"FDIVP":                {209},
+	"FDIVR":                {210},
+	"FDIVRP":               {210},
+	"FFREE":                {211},
+	"FIADD":                {191},
+	"FICOM":                {212},
+	"FICOMP":               {212},
+	"FIDIV":                {209},
+	"FIDIVR":               {210},
+	"FILD":                 {213},
+	"FIMUL":                {222},
+	"FINCSTP":              {214},
+	"FINIT":                {215},
+	"FIST":                 {216},
+	"FISTP":                {216},
+	"FISTTP":               {217},
+	"FISUB":                {239},
+	"FISUBR":               {240},
+	"FLD":                  {218},
+	"FLD1":                 {219},
+	"FLDCW":                {220},
+	"FLDENV":               {221},
+	"FLDL2E":               {219},
+	"FLDL2T":               {219},
+	"FLDLG2":               {219},
+	"FLDLN2":               {219},
+	"FLDPI":                {219},
+	"FLDZ":                 {219},
+	"FMUL":                 {222},
+	"FMULP":                {222},
+	"FNCLEX":               {195},
+	"FNCLEX1":              {195},
+	"FNINIT":               {215},
+	"FNINIT1":              {215},
+	"FNOP":                 {223},
+	"FNSAVE":               {230},
+	"FNSAVE1":              {230},
+	"FNSTCW":               {236},
+	"FNSTCW1":              {236},
+	"FNSTENV":              {237},
+	"FNSTENV1":             {237},
+	"FNSTSW":               {238},
+	"FNSTSW1":              {238},
+	"FPATAN":               {224},
+	"FPREM":                {225},
+	"FPREM1":               {226},
+	"FPTAN":                {227},
+	"FRNDINT":              {228},
+	"FRSTOR":               {229},
+	"FSAVE":                {230},
+	"FSCALE":               {231},
+	"FSIN":                 {232},
+	"FSINCOS":              {233},
+	"FSQRT":                {234},
+	"FST":                  {235},
+	"FSTCW":                {236},
+	"FSTENV":               {237},
+	"FSTP":                 {235},
+	"FSTSW":                {238},
+	"FSUB":                 {239},
+	"FSUBP":                {239},
+	"FSUBR":                {240},
+	"FSUBRP":               {240},
+	"FTST":                 {241},
+	"FUCOM":                {242},
+	"FUCOMI":               {206},
+	"FUCOMIP":              {206},
+	"FUCOMP":               {242},
+	"FUCOMPP":              {242},
+	"FWAIT":                {912},
+	"FXAM":                 {243},
+	"FXCH":                 {244},
+	"FXRSTOR":              {245},
+	"FXRSTOR64":            {245},
+	"FXSAVE":               {246},
+	"FXSAVE64":             {246},
+	"FXTRACT":              {247},
+	"FYL2X":                {248},
+	"FYL2XP1":              {249},
+	"GETSEC[CAPABILITIES]": {250},
+	"GETSEC[ENTERACCS]":    {251},
+	"GETSEC[EXITAC]":       {252},
+	"GETSEC[PARAMETERS]":   {253},
+	"GETSEC[SENTER]":       {254},
+	"GETSEC[SEXIT]":        {255},
+	"GETSEC[SMCTRL]":       {256},
+	"GETSEC[WAKEUP]":       {257},
+	"GF2P8AFFINEINVQB":     {258},
+	"GF2P8AFFINEQB":        {259},
+	"GF2P8MULB":            {260},
+	"HADDPD":               {261},
+	"HADDPS":               {262},
+	"HLT":                  {263},
+	"HRESET":               {264},
+	"HSUBPD":               {265},
+	"HSUBPS":               {266},
+	"IDIV":                 {267},
+	"IMUL":                 {268},
+	"IN":                   {269},
+	"INC":                  {270},
+	"INCSSPD":              {271},
+	"INCSSPQ":              {271},
+	"INS":                  {272},
+	"INSB":                 {272},
+	"INSD":                 {272},
+	"INSERTPS":             {273},
+	"INSW":                 {272},
+	"INT":                  {274},
+	"INT1":                 {274},
+	"INT3":                 {274},
+	"INTN":                 {274},
+	"INTO":                 {274},
+	"INVD":                 {275},
+	"INVEPT":               {276},
+	"INVLPG":               {277},
+	"INVPCID":              {278},
+	"INVVPID":              {279},
+	"IRET":                 {280},
+	"IRETD":                {280},
+	"IRETQ":                {280},
+	"JA":                   {281},
+	"JAE":                  {282},
+	"JB":                   {283},
+	"JBE":                  {284},
+	"JC":                   {285},
+	"JCC":                  {286},
+	"JCXZ":                 {287},
+	"JE":                   {288},
+	"JECXZ":                {289},
+	"JG":                   {290},
+	"JGE":                  {291},
+	"JL":                   {292},
+	"JLE":                  {293},
+	"JMP":                  {294},
+	"JNA":                  {295},
+	"JNAE":                 {296},
+	"JNB":                  {297},
+	"JNBE":                 {298},
+	"JNC":                  {299},
+	"JNE":                  {300},
+	"JNG":                  {301},
+	"JNGE":                 {302},
+	"JNL":                  {303},
+	"JNLE":                 {304},
+	"JNO":                  {305},
+	"JNP":                  {306},
+	"JNS":                  {307},
+	"JNZ":                  {308},
+	"JO":                   {309},
+	"JP":                   {310},
+	"JPE":                  {311},
+	"JPO":                  {312},
+	"JRCXZ":                {313},
+	"JS":                   {314},
+	"JZ":                   {315},
+	"KADDB":                {316},
+	"KADDD":                {316},
+	"KADDQ":                {316},
+	"KADDW":                {316},
+	"KANDB":                {318},
+	"KANDD":                {318},
+	"KANDNB":               {317},
+	"KANDND":               {317},
+	"KANDNQ":               {317},
+	"KANDNW":               {317},
+	"KANDQ":                {318},
+	"KANDW":                {318},
+	"KMOVB":                {319},
+	"KMOVD":                {319},
+	"KMOVQ":                {319},
+	"KMOVW":                {319},
+	"KNOTB":                {320},
+	"KNOTD":                {320},
+	"KNOTQ":                {320},
+	"KNOTW":                {320},
+	"KORB":                 {322},
+	"KORD":                 {322},
+	"KORQ":                 {322},
+	"KORTESTB":             {321},
+	"KORTESTD":             {321},
+	"KORTESTQ":             {321},
+	"KORTESTW":             {321},
+	"KORW":                 {322},
+	"KSHIFTLB":             {323},
+	"KSHIFTLD":             {323},
+	"KSHIFTLQ":             {323},
+	"KSHIFTLW":             {323},
+	"KSHIFTRB":             {324},
+	"KSHIFTRD":             {324},
+	"KSHIFTRQ":             {324},
+	"KSHIFTRW":             {324},
+	"KTESTB":               {325},
+	"KTESTD":               {325},
+	"KTESTQ":               {325},
+	"KTESTW":               {325},
+	"KUNPCKBW":             {326},
+	"KUNPCKDQ":             {326},
+	"KUNPCKWD":             {326},
+	"KXNORB":               {327},
+	"KXNORD":               {327},
+	"KXNORQ":               {327},
+	"KXNORW":               {327},
+	"KXORB":                {328},
+	"KXORD":                {328},
+	"KXORQ":                {328},
+	"KXORW":                {328},
+	"LAHF":                 {329},
+	"LAR":                  {330},
+	"LDDQU":                {331},
+	"LDMXCSR":              {332},
+	"LDS":                  {333},
+	"LDTILECFG":            {334},
+	"LEA":                  {335},
+	"LEAVE":                {336},
+	"LES":                  {333},
+	"LFENCE":               {337},
+	"LFS":                  {333},
+	"LGDT":                 {338},
+	"LGS":                  {333},
+	"LIDT":                 {338},
+	"LLDT":                 {339},
+	"LMSW":                 {340},
+	"LOADIWKEY":            {341},
+	"LOCK":                 {342},
+	"LODS":                 {343},
+	"LODSB":                {343},
+	"LODSD":                {343},
+	"LODSQ":                {343},
+	"LODSW":                {343},
+	"LOOP":                 {344, 345},
+	"LOOPCC":               {345},
+	"LOOPE":                {346},
+	"LOOPNE":               {347},
+	"LSL":                  {348},
+	"LSS":                  {333},
+	"LTR":                  {349},
+	"LZCNT":                {350},
+	"MASKMOVDQU":           {351},
+	"MASKMOVQ":             {352},
+	"MAXPD":                {353},
+	"MAXPS":                {354},
+	"MAXSD":                {355},
+	"MAXSS":                {356},
+	"MFENCE":               {357},
+	"MINPD":                {358},
+	"MINPS":                {359},
+	"MINSD":                {360},
+	"MINSS":                {361},
+	"MONITOR":              {362},
+	"MOV":                  {363, 364, 365},
+	"MOVAPD":               {366},
+	"MOVAPS":               {367},
+	"MOVBE":                {368},
+	"MOVD":                 {369},
+	"MOVDDUP":              {370},
+	"MOVDIR64B":            {371},
+	"MOVDIRI":              {372},
+	"MOVDQ2Q":              {373},
+	"MOVDQA":               {374},
+	"MOVDQU":               {375},
+	"MOVHLPS":              {376},
+	"MOVHPD":               {377},
+	"MOVHPS":               {378},
+	"MOVLHPS":              {379},
+	"MOVLPD":               {380},
+	"MOVLPS":               {381},
+	"MOVMSKPD":             {382},
+	"MOVMSKPS":             {383},
+	"MOVNTDQ":              {384},
+	"MOVNTDQA":             {385},
+	"MOVNTI":               {386},
+	"MOVNTPD":              {387},
+	"MOVNTPS":              {388},
+	"MOVNTQ":               {389},
+	"MOVQ":                 {369, 390},
+	"MOVQ2DQ":              {391},
+	"MOVS":                 {392},
+	"MOVSB":                {392},
+	"MOVSD":                {392, 393},
+	"MOVSHDUP":             {394},
+	"MOVSLDUP":             {395},
+	"MOVSQ":                {392},
+	"MOVSS":                {396},
+	"MOVSW":                {392},
+	"MOVSX":                {397},
+	"MOVSXD":               {397},
+	"MOVUPD":               {398},
+	"MOVUPS":               {399},
+	"MOVZX":                {400},
+	"MPSADBW":              {401},
+	"MUL":                  {402},
+	"MULPD":                {403},
+	"MULPS":                {404},
+	"MULSD":                {405},
+	"MULSS":                {406},
+	"MULX":                 {407},
+	"MWAIT":                {408},
+	"NEG":                  {409},
+	"NOP":                  {410},
+	"NOT":                  {411},
+	"OR":                   {412},
+	"ORPD":                 {413},
+	"ORPS":                 {414},
+	"OUT":                  {415},
+	"OUTS":                 {416},
+	"OUTSB":                {416},
+	"OUTSD":                {416},
+	"OUTSW":                {416},
+	"PABSB":                {417},
+	"PABSD":                {417},
+	"PABSQ":                {417},
+	"PABSW":                {417},
+	"PACKSSDW":             {418},
+	"PACKSSWB":             {418},
+	"PACKUSDW":             {419},
+	"PACKUSWB":             {420},
+	"PADDB":                {421},
+	"PADDD":                {421},
+	"PADDQ":                {421},
+	"PADDSB":               {422},
+	"PADDSW":               {422},
+	"PADDUSB":              {423},
+	"PADDUSW":              {423},
+	"PADDW":                {421},
+	"PALIGNR":              {424},
+	"PAND":                 {425},
+	"PANDN":                {426},
+	"PAUSE":                {427},
+	"PAVGB":                {428},
+	"PAVGW":                {428},
+	"PBLENDVB":             {429},
+	"PBLENDW":              {430},
+	"PCLMULQDQ":            {431},
+	"PCMPEQB":              {432},
+	"PCMPEQD":              {432},
+	"PCMPEQQ":              {433},
+	"PCMPEQW":              {432},
+	"PCMPESTRI":            {434},
+	"PCMPESTRM":            {435},
+	"PCMPGTB":              {436},
+	"PCMPGTD":              {436},
+	"PCMPGTQ":              {437},
+	"PCMPGTW":              {436},
+	"PCMPISTRI":            {438},
+	"PCMPISTRM":            {439},
+	"PCONFIG":              {440},
+	"PDEP":                 {441},
+	"PEXT":                 {442},
+	"PEXTRB":               {443},
+	"PEXTRD":               {443},
+	"PEXTRQ":               {443},
+	"PEXTRW":               {444},
+	"PHADDD":               {446},
+	"PHADDSW":              {445},
+	"PHADDW":               {446},
+	"PHMINPOSUW":           {447},
+	"PHSUBD":               {449},
+	"PHSUBSW":              {448},
+	"PHSUBW":               {449},
+	"PINSRB":               {450},
+	"PINSRD":               {450},
+	"PINSRQ":               {450},
+	"PINSRW":               {451},
+	"PMADDUBSW":            {452},
+	"PMADDWD":              {453},
+	"PMAXSB":               {454},
+	"PMAXSD":               {454},
+	"PMAXSQ":               {454},
+	"PMAXSW":               {454},
+	"PMAXUB":               {455},
+	"PMAXUD":               {456},
+	"PMAXUQ":               {456},
+	"PMAXUW":               {455},
+	"PMINSB":               {457},
+	"PMINSD":               {458},
+	"PMINSQ":               {458},
+	"PMINSW":               {457},
+	"PMINUB":               {459},
+	"PMINUD":               {460},
+	"PMINUQ":               {460},
+	"PMINUW":               {459},
+	"PMOVMSKB":             {461},
+	"PMOVSX":               {462},
+	"PMOVSXBD":             {462},
+	"PMOVSXBQ":             {462},
+	"PMOVSXBW":             {462},
+	"PMOVSXDQ":             {462},
+	"PMOVSXWD":             {462},
+	"PMOVSXWQ":             {462},
+	"PMOVZX":               {463},
+	"PMOVZXBD":             {463},
+	"PMOVZXBQ":             {463},
+	"PMOVZXBW":             {463},
+	"PMOVZXDQ":             {463},
+	"PMOVZXWD":             {463},
+	"PMOVZXWQ":             {463},
+	"PMULDQ":               {464},
+	"PMULHRSW":             {465},
+	"PMULHUW":              {466},
+	"PMULHW":               {467},
+	"PMULLD":               {468},
+	"PMULLQ":               {468},
+	"PMULLW":               {469},
+	"PMULUDQ":              {470},
+	"POP":                  {471},
+	"POPA":                 {472},
+	"POPAD":                {472},
+	"POPCNT":               {473},
+	"POPF":                 {474},
+	"POPFD":                {474},
+	"POPFQ":                {474},
+	"POR":                  {475},
+	"PREFETCHH":            {476},
+	"PREFETCHNTA":          {476},
+	"PREFETCHT0":           {476},
+	"PREFETCHT1":           {476},
+	"PREFETCHT2":           {476},
+	"PREFETCHW":            {477},
+	"PREFETCHWT1":          {478},
+	"PSADBW":               {479},
+	"PSHUFB":               {480},
+	"PSHUFD":               {481},
+	"PSHUFHW":              {482},
+	"PSHUFLW":              {483},
+	"PSHUFW":               {484},
+	"PSIGNB":               {485},
+	"PSIGND":               {485},
+	"PSIGNW":               {485},
+	"PSLLD":                {487},
+	"PSLLDQ":               {486},
+	"PSLLQ":                {487},
+	"PSLLW":                {487},
+	"PSRAD":                {488},
+	"PSRAQ":                {488},
+	"PSRAW":                {488},
+	"PSRLD":                {490},
+	"PSRLDQ":               {489},
+	"PSRLQ":                {490},
+	"PSRLW":                {490},
+	"PSUBB":                {491},
+	"PSUBD":                {491},
+	"PSUBQ":                {492},
+	"PSUBSB":               {493},
+	"PSUBSW":               {493},
+	"PSUBUSB":              {494},
+	"PSUBUSW":              {494},
+	"PSUBW":                {491},
+	"PTEST":                {495},
+	"PTWRITE":              {496},
+	"PUNPCKHBW":            {497},
+	"PUNPCKHDQ":            {497},
+	"PUNPCKHQDQ":           {497},
+	"PUNPCKHWD":            {497},
+	"PUNPCKLBW":            {498},
+	"PUNPCKLDQ":            {498},
+	"PUNPCKLQDQ":           {498},
+	"PUNPCKLWD":            {498},
+	"PUSH":                 {499},
+	"PUSHA":                {500},
+	"PUSHAD":               {500},
+	"PUSHF":                {501},
+	"PUSHFD":               {501},
+	"PUSHFQ":               {501},
+	"PXOR":                 {502},
+	"RCL":                  {503},
+	"RCPPS":                {504},
+	"RCPSS":                {505},
+	"RCR":                  {503},
+	"RDFSBASE":             {506},
+	"RDGSBASE":             {506},
+	"RDMSR":                {507},
+	"RDPID":                {508},
+	"RDPKRU":               {509},
+	"RDPMC":                {510},
+	"RDRAND":               {511},
+	"RDSEED":               {512},
+	"RDSSPD":               {513},
+	"RDSSPQ":               {513},
+	"RDTSC":                {514},
+	"RDTSCP":               {515},
+	"REP":                  {516},
+	"REP INS":              {516},
+	"REP LODS":             {516},
+	"REP MOVS":             {516},
+	"REP OUTS":             {516},
+	"REP STOS":             {516},
+	"REPE":                 {516},
+	"REPE CMPS":            {516},
+	"REPE SCAS":            {516},
+	"REPNE":                {516},
+	"REPNE CMPS":           {516},
+	"REPNE SCAS":           {516},
+	"REPNZ":                {516},
+	"REPZ":                 {516},
+	"RET":                  {517},
+	"ROL":                  {503},
+	"ROR":                  {503},
+	"RORX":                 {518},
+	"ROUNDPD":              {519},
+	"ROUNDPS":              {520},
+	"ROUNDSD":              {521},
+	"ROUNDSS":              {522},
+	"RSM":                  {523},
+	"RSQRTPS":              {524},
+	"RSQRTSS":              {525},
+	"RSTORSSP":             {526},
+	"SAHF":                 {527},
+	"SAL":                  {528},
+	"SAR":                  {528},
+	"SARX":                 {529},
+	"SAVEPREVSSP":          {530},
+	"SBB":                  {531},
+	"SCAS":                 {532},
+	"SCASB":                {532},
+	"SCASD":                {532},
+	"SCASQ":                {532},
+	"SCASW":                {532},
+	"SENDUIPI":             {533},
+	"SERIALIZE":            {534},
+	"SETA":                 {535},
+	"SETAE":                {536},
+	"SETB":                 {537},
+	"SETBE":                {538},
+	"SETC":                 {539},
+	"SETCC":                {540},
+	"SETE":                 {541},
+	"SETG":                 {542},
+	"SETGE":                {543},
+	"SETL":                 {544},
+	"SETLE":                {545},
+	"SETNA":                {546},
+	"SETNAE":               {547},
+	"SETNB":                {548},
+	"SETNBE":               {549},
+	"SETNC":                {550},
+	"SETNE":                {551},
+	"SETNG":                {552},
+	"SETNGE":               {553},
+	"SETNL":                {554},
+	"SETNLE":               {555},
+	"SETNO":                {556},
+	"SETNP":                {557},
+	"SETNS":                {558},
+	"SETNZ":                {559},
+	"SETO":                 {560},
+	"SETP":                 {561},
+	"SETPE":                {562},
+	"SETPO":                {563},
+	"SETS":                 {564},
+	"SETSSBSY":             {565},
+	"SETZ":                 {566},
+	"SFENCE":               {567},
+	"SGDT":                 {568},
+	"SHA1MSG1":             {569},
+	"SHA1MSG2":             {570},
+	"SHA1NEXTE":            {571},
+	"SHA1RNDS4":            {572},
+	"SHA256MSG1":           {573},
+	"SHA256MSG2":           {574},
+	"SHA256RNDS2":          {575},
+	"SHL":                  {528},
+	"SHLD":                 {576},
+	"SHLX":                 {529},
+	"SHR":                  {528},
+	"SHRD":                 {577},
+	"SHRX":                 {529},
+	"SHUFPD":               {578},
+	"SHUFPS":               {579},
+	"SIDT":                 {580},
+	"SLDT":                 {581},
+	"SMSW":                 {582},
+	"SQRTPD":               {583},
+	"SQRTPS":               {584},
+	"SQRTSD":               {585},
+	"SQRTSS":               {586},
+	"STAC":                 {587},
+	"STC":                  {588},
+	"STD":                  {589},
+	"STI":                  {590},
+	"STMXCSR":              {591},
+	"STOS":                 {592},
+	"STOSB":                {592},
+	"STOSD":                {592},
+	"STOSQ":                {592},
+	"STOSW":                {592},
+	"STR":                  {593},
+	"STTILECFG":            {594},
+	"STUI":                 {595},
+	"SUB":                  {596},
+	"SUBPD":                {597},
+	"SUBPS":                {598},
+	"SUBSD":                {599},
+	"SUBSS":                {600},
+	"SWAPGS":               {601},
+	"SYSCALL":              {602},
+	"SYSENTER":             {603},
+	"SYSEXIT":              {604},
+	"SYSRET":               {605},
+	"TDPBF16PS":            {606},
+	"TDPBSSD":              {607},
+	"TDPBSUD":              {607},
+	"TDPBUSD":              {607},
+	"TDPBUUD":              {607},
+	"TEST":                 {608},
+	"TESTUI":               {609},
+	"TILELOADD":            {610},
+	"TILELOADDT1":          {610},
+	"TILERELEASE":          {611},
+	"TILESTORED":           {612},
+	"TILEZERO":             {613},
+	"TPAUSE":               {614},
+	"TZCNT":                {615},
+	"UCOMISD":              {616},
+	"UCOMISS":              {617},
+	"UD":                   {618},
+	"UD01":                 {618},
+	"UD1":                  {618},
+	"UD2":                  {618},
+	"UIRET":                {619},
+	"UMONITOR":             {620},
+	"UMWAIT":               {621},
+	"UNPCKHPD":             {622},
+	"UNPCKHPS":             {623},
+	"UNPCKLPD":             {624},
+	"UNPCKLPS":             {625},
+	"V4FMADDPS":            {626},
+	"V4FMADDSS":            {627},
+	"V4FNMADDPS":           {626},
+	"V4FNMADDSS":           {627},
+	"VADDPD":               {7},
+	"VADDPH":               {628},
+	"VADDPS":               {8},
+	"VADDSD":               {9},
+	"VADDSH":               {629},
+	"VADDSS":               {10},
+	"VADDSUBPD":            {11},
+	"VADDSUBPS":            {12},
+	"VAESDEC":              {14},
+	"VAESDECLAST":          {17},
+	"VAESENC":              {20},
+	"VAESENCLAST":          {23},
+	"VAESIMC":              {26},
+	"VAESKEYGENASSIST":     {27},
+	"VALIGND":              {630},
+	"VALIGNQ":              {630},
+	"VANDNPD":              {30},
+	"VANDNPS":              {31},
+	"VANDPD":               {32},
+	"VANDPS":               {33},
+	"VBLENDMPD":            {631},
+	"VBLENDMPS":            {631},
+	"VBLENDPD":             {36},
+	"VBLENDPS":             {37},
+	"VBLENDVPD":            {38},
+	"VBLENDVPS":            {39},
+	"VBROADCAST":           {632},
+	"VBROADCASTF128":       {632},
+	"VBROADCASTF32X2":      {632},
+	"VBROADCASTF32X4":      {632},
+	"VBROADCASTF32X8":      {632},
+	"VBROADCASTF64X2":      {632},
+	"VBROADCASTF64X4":      {632},
+	"VBROADCASTI128":       {795},
+	"VBROADCASTI32X2":      {795},
+	"VBROADCASTI32X4":      {795},
+	"VBROADCASTI32X8":      {795},
+	"VBROADCASTI64X2":      {795},
+	"VBROADCASTI64X4":      {795},
+	"VBROADCASTSD":         {632},
+	"VBROADCASTSS":         {632},
+	"VCMPPD":               {104},
+	"VCMPPH":               {633},
+	"VCMPPS":               {105},
+	"VCMPSD":               {107},
+	"VCMPSH":               {634},
+	"VCMPSS":               {108},
+	"VCOMISD":              {111},
+	"VCOMISH":              {635},
+	"VCOMISS":              {112},
+	"VCOMPRESSPD":          {636},
+	"VCOMPRESSPS":          {637},
+	"VCOMPRESSW":           {802},
+	"VCVTDQ2PD":            {115},
+	"VCVTDQ2PH":            {638},
+	"VCVTDQ2PS":            {116},
+	"VCVTNE2PS2BF16":       {639},
+	"VCVTNEPS2BF16":        {640},
+	"VCVTPD2DQ":            {117},
+	"VCVTPD2PH":            {641},
+	"VCVTPD2PS":            {119},
+	"VCVTPD2QQ":            {642},
+	"VCVTPD2UDQ":           {643},
+	"VCVTPD2UQQ":           {644},
+	"VCVTPH2DQ":            {645},
+	"VCVTPH2PD":            {646},
+	"VCVTPH2PS":            {647},
+	"VCVTPH2PSX":           {647},
+	"VCVTPH2QQ":            {648},
+	"VCVTPH2UDQ":           {649},
+	"VCVTPH2UQQ":           {650},
+	"VCVTPH2UW":            {651},
+	"VCVTPH2W":             {652},
+	"VCVTPS2DQ":            {122},
+	"VCVTPS2PD":            {123},
+	"VCVTPS2PH":            {653},
+	"VCVTPS2PHX":           {654},
+	"VCVTPS2QQ":            {655},
+	"VCVTPS2UDQ":           {656},
+	"VCVTPS2UQQ":           {657},
+	"VCVTQQ2PD":            {658},
+	"VCVTQQ2PH":            {659},
+	"VCVTQQ2PS":            {660},
+	"VCVTSD2SH":            {661},
+	"VCVTSD2SI":            {125},
+	"VCVTSD2SS":            {126},
+	"VCVTSD2USI":           {662},
+	"VCVTSH2SD":            {663},
+	"VCVTSH2SI":            {664},
+	"VCVTSH2SS":            {665},
+	"VCVTSH2USI":           {666},
+	"VCVTSI2SD":            {127},
+	"VCVTSI2SH":            {667},
+	"VCVTSI2SS":            {128},
+	"VCVTSS2SD":            {129},
+	"VCVTSS2SH":            {668},
+	"VCVTSS2SI":            {130},
+	"VCVTSS2USI":           {669},
+	"VCVTTPD2DQ":           {131},
+	"VCVTTPD2QQ":           {670},
+	"VCVTTPD2UDQ":          {671},
+	"VCVTTPD2UQQ":          {672},
+	"VCVTTPH2DQ":           {673},
+	"VCVTTPH2QQ":           {674},
+	"VCVTTPH2UDQ":          {675},
+	"VCVTTPH2UQQ":          {676},
+	"VCVTTPH2UW":           {677},
+	"VCVTTPH2W":            {678},
+	"VCVTTPS2DQ":           {133},
+	"VCVTTPS2QQ":           {679},
+	"VCVTTPS2UDQ":          {680},
+	"VCVTTPS2UQQ":          {681},
+	"VCVTTSD2SI":           {135},
+	"VCVTTSD2USI":          {682},
+	"VCVTTSH2SI":           {683},
+	"VCVTTSH2USI":          {684},
+	"VCVTTSS2SI":           {136},
+	"VCVTTSS2USI":          {685},
+	"VCVTUDQ2PD":           {686},
+	"VCVTUDQ2PH":           {687},
+	"VCVTUDQ2PS":           {688},
+	"VCVTUQQ2PD":           {689},
+	"VCVTUQQ2PH":           {690},
+	"VCVTUQQ2PS":           {691},
+	"VCVTUSI2SD":           {692},
+	"VCVTUSI2SH":           {693},
+	"VCVTUSI2SS":           {694},
+	"VCVTUW2PH":            {695},
+	"VCVTW2PH":             {696},
+	"VDBPSADBW":            {697},
+	"VDIVPD":               {142},
+	"VDIVPH":               {698},
+	"VDIVPS":               {143},
+	"VDIVSD":               {144},
+	"VDIVSH":               {699},
+	"VDIVSS":               {145},
+	"VDPBF16PS":            {700},
+	"VDPPD":                {146},
+	"VDPPS":                {147},
+	"VERR":                 {701},
+	"VERW":                 {701},
+	"VEXP2PD":              {702},
+	"VEXP2PS":              {703},
+	"VEXPANDPD":            {704},
+	"VEXPANDPS":            {705},
+	"VEXTRACTF128":         {706},
+	"VEXTRACTF32X4":        {706},
+	"VEXTRACTF32X8":        {706},
+	"VEXTRACTF64X2":        {706},
+	"VEXTRACTF64X4":        {706},
+	"VEXTRACTI128":         {707},
+	"VEXTRACTI32X4":        {707},
+	"VEXTRACTI32X8":        {707},
+	"VEXTRACTI64X2":        {707},
+	"VEXTRACTI64X4":        {707},
+	"VEXTRACTPS":           {188},
+	"VFCMADDCPH":           {708},
+	"VFCMADDCSH":           {709},
+	"VFCMULCPH":            {710},
+	"VFCMULCSH":            {711},
+	"VFIXUPIMMPD":          {712},
+	"VFIXUPIMMPS":          {713},
+	"VFIXUPIMMSD":          {714},
+	"VFIXUPIMMSS":          {715},
+	"VFMADD132PD":          {716},
+	"VFMADD132PH":          {717},
+	"VFMADD132PS":          {718},
+	"VFMADD132SD":          {719},
+	"VFMADD132SH":          {720},
+	"VFMADD132SS":          {721},
+	"VFMADD213PD":          {716},
+	"VFMADD213PH":          {717},
+	"VFMADD213PS":          {718},
+	"VFMADD213SD":          {719},
+	"VFMADD213SH":          {720},
+	"VFMADD213SS":          {721},
+	"VFMADD231PD":          {716},
+	"VFMADD231PH":          {717},
+	"VFMADD231PS":          {718},
+	"VFMADD231SD":          {719},
+	"VFMADD231SH":          {720},
+	"VFMADD231SS":          {721},
+	"VFMADDCPH":            {708},
+	"VFMADDCSH":            {709},
+	"VFMADDRND231PD":       {722},
+	"VFMADDSUB132PD":       {723},
+	"VFMADDSUB132PH":       {724},
+	"VFMADDSUB132PS":       {725},
+	"VFMADDSUB213PD":       {723},
+	"VFMADDSUB213PH":       {724},
+	"VFMADDSUB213PS":       {725},
+	"VFMADDSUB231PD":       {723},
+	"VFMADDSUB231PH":       {724},
+	"VFMADDSUB231PS":       {725},
+	"VFMSUB132PD":          {726},
+	"VFMSUB132PH":          {727},
+	"VFMSUB132PS":          {728},
+	"VFMSUB132SD":          {729},
+	"VFMSUB132SH":          {730},
+	"VFMSUB132SS":          {731},
+	"VFMSUB213PD":          {726},
+	"VFMSUB213PH":          {727},
+	"VFMSUB213PS":          {728},
+	"VFMSUB213SD":          {729},
+	"VFMSUB213SH":          {730},
+	"VFMSUB213SS":          {731},
+	"VFMSUB231PD":          {726},
+	"VFMSUB231PH":          {727},
+	"VFMSUB231PS":          {728},
+	"VFMSUB231SD":          {729},
+	"VFMSUB231SH":          {730},
+	"VFMSUB231SS":          {731},
+	"VFMSUBADD132PD":       {732},
+	"VFMSUBADD132PH":       {733},
+	"VFMSUBADD132PS":       {734},
+	"VFMSUBADD213PD":       {732},
+	"VFMSUBADD213PH":       {733},
+	"VFMSUBADD213PS":       {734},
+	"VFMSUBADD231PD":       {732},
+	"VFMSUBADD231PH":       {733},
+	"VFMSUBADD231PS":       {734},
+	"VFMULCPH":             {710},
+	"VFMULCSH":             {711},
+	"VFNMADD132PD":         {735},
+	"VFNMADD132PH":         {717},
+	"VFNMADD132PS":         {736},
+	"VFNMADD132SD":         {737},
+	"VFNMADD132SH":         {720},
+	"VFNMADD132SS":         {738},
+	"VFNMADD213PD":         {735},
+	"VFNMADD213PH":         {717},
+	"VFNMADD213PS":         {736},
+	"VFNMADD213SD":         {737},
+	"VFNMADD213SH":         {720},
+	"VFNMADD213SS":         {738},
+	"VFNMADD231PD":         {735},
+	"VFNMADD231PH":         {717},
+	"VFNMADD231PS":         {736},
+	"VFNMADD231SD":         {737},
+	"VFNMADD231SH":         {720},
+	"VFNMADD231SS":         {738},
+	"VFNMSUB132PD":         {739},
+	"VFNMSUB132PH":         {727},
+	"VFNMSUB132PS":         {740},
+	"VFNMSUB132SD":         {741},
+	"VFNMSUB132SH":         {730},
+	"VFNMSUB132SS":         {742},
+	"VFNMSUB213PD":         {739},
+	"VFNMSUB213PH":         {727},
+	"VFNMSUB213PS":         {740},
+	"VFNMSUB213SD":         {741},
+	"VFNMSUB213SH":         {730},
+	"VFNMSUB213SS":         {742},
+	"VFNMSUB231PD":         {739},
+	"VFNMSUB231PH":         {727},
+	"VFNMSUB231PS":         {740},
+	"VFNMSUB231SD":         {741},
+	"VFNMSUB231SH":         {730},
+	"VFNMSUB231SS":         {742},
+	"VFPCLASSPD":           {743},
+	"VFPCLASSPH":           {744},
+	"VFPCLASSPS":           {745},
+	"VFPCLASSSD":           {746},
+	"VFPCLASSSH":           {747},
+	"VFPCLASSSS":           {748},
+	"VGATHERDPD":           {749, 750},
+	"VGATHERDPS":           {750, 751},
+	"VGATHERPF0DPD":        {752},
+	"VGATHERPF0DPS":        {752},
+	"VGATHERPF0QPD":        {752},
+	"VGATHERPF0QPS":        {752},
+	"VGATHERPF1DPD":        {753},
+	"VGATHERPF1DPS":        {753},
+	"VGATHERPF1QPD":        {753},
+	"VGATHERPF1QPS":        {753},
+	"VGATHERQPD":           {749, 754},
+	"VGATHERQPS":           {751, 754},
+	"VGETEXPPD":            {755},
+	"VGETEXPPH":            {756},
+	"VGETEXPPS":            {757},
+	"VGETEXPSD":            {758},
+	"VGETEXPSH":            {759},
+	"VGETEXPSS":            {760},
+	"VGETMANTPD":           {761},
+	"VGETMANTPH":           {762},
+	"VGETMANTPS":           {763},
+	"VGETMANTSD":           {764},
+	"VGETMANTSH":           {765},
+	"VGETMANTSS":           {766},
+	"VGF2P8AFFINEINVQB":    {258},
+	"VGF2P8AFFINEQB":       {259},
+	"VGF2P8MULB":           {260},
+	"VHADDPD":              {261},
+	"VHADDPS":              {262},
+	"VHSUBPD":              {265},
+	"VHSUBPS":              {266},
+	"VINSERTF128":          {767},
+	"VINSERTF32X4":         {767},
+	"VINSERTF32X8":         {767},
+	"VINSERTF64X2":         {767},
+	"VINSERTF64X4":         {767},
+	"VINSERTI128":          {768},
+	"VINSERTI32X4":         {768},
+	"VINSERTI32X8":         {768},
+	"VINSERTI64X2":         {768},
+	"VINSERTI64X4":         {768},
+	"VINSERTPS":            {273},
+	"VLDDQU":               {331},
+	"VLDMXCSR":             {332},
+	"VMASKMOV":             {769},
+	"VMASKMOVDQU":          {351},
+	"VMASKMOVPD":           {769},
+	"VMASKMOVPS":           {769},
+	"VMAXPD":               {353},
+	"VMAXPH":               {770},
+	"VMAXPS":               {354},
+	"VMAXSD":               {355},
+	"VMAXSH":               {771},
+	"VMAXSS":               {356},
+	"VMCALL":               {772},
+	"VMCLEAR":              {773},
+	"VMFUNC":               {774},
+	"VMINPD":               {358},
+	"VMINPH":               {775},
+	"VMINPS":               {359},
+	"VMINSD":               {360},
+	"VMINSH":               {776},
+	"VMINSS":               {361},
+	"VMLAUNCH":             {777},
+	"VMOVAPD":              {366},
+	"VMOVAPS":              {367},
+	"VMOVD":                {369},
+	"VMOVDDUP":             {370},
+	"VMOVDQA":              {374},
+	"VMOVDQA32":            {374},
+	"VMOVDQA64":            {374},
+	"VMOVDQU":              {375},
+	"VMOVDQU16":            {375},
+	"VMOVDQU32":            {375},
+	"VMOVDQU64":            {375},
+	"VMOVDQU8":             {375},
+	"VMOVHLPS":             {376},
+	"VMOVHPD":              {377},
+	"VMOVHPS":              {378},
+	"VMOVLHPS":             {379},
+	"VMOVLPD":              {380},
+	"VMOVLPS":              {381},
+	"VMOVMSKPD":            {382},
+	"VMOVMSKPS":            {383},
+	"VMOVNTDQ":             {384},
+	"VMOVNTDQA":            {385},
+	"VMOVNTPD":             {387},
+	"VMOVNTPS":             {388},
+	"VMOVQ":                {369, 390},
+	"VMOVSD":               {393},
+	"VMOVSH":               {778},
+	"VMOVSHDUP":            {394},
+	"VMOVSLDUP":            {395},
+	"VMOVSS":               {396},
+	"VMOVUPD":              {398},
+	"VMOVUPS":              {399},
+	"VMOVW":                {779},
+	"VMPSADBW":             {401},
+	"VMPTRLD":              {780},
+	"VMPTRST":              {781},
+	"VMREAD":               {782},
+	"VMRESUME":             {777, 783},
+	"VMULPD":               {403},
+	"VMULPH":               {784},
+	"VMULPS":               {404},
+	"VMULSD":               {405},
+	"VMULSH":               {785},
+	"VMULSS":               {406},
+	"VMWRITE":              {786},
+	"VMXOFF":               {787},
+	"VMXON":                {788},
+	"VORPD":                {413},
+	"VORPS":                {414},
+	"VP2INTERSECTD":        {789},
+	"VP2INTERSECTQ":        {789},
+	"VP4DPWSSD":            {790},
+	"VP4DPWSSDS":           {791},
+	"VPABSB":               {417},
+	"VPABSD":               {417},
+	"VPABSQ":               {417},
+	"VPABSW":               {417},
+	"VPACKSSDW":            {418},
+	"VPACKSSWB":            {418},
+	"VPACKUSDW":            {419},
+	"VPACKUSWB":            {420},
+	"VPADDB":               {421},
+	"VPADDD":               {421},
+	"VPADDQ":               {421},
+	"VPADDSB":              {422},
+	"VPADDSW":              {422},
+	"VPADDUSB":             {423},
+	"VPADDUSW":             {423},
+	"VPADDW":               {421},
+	"VPALIGNR":             {424},
+	"VPAND":                {425},
+	"VPANDD":               {425},
+	"VPANDN":               {426},
+	"VPANDND":              {426},
+	"VPANDNQ":              {426},
+	"VPANDQ":               {425},
+	"VPAVGB":               {428},
+	"VPAVGW":               {428},
+	"VPBLENDD":             {792},
+	"VPBLENDMB":            {793},
+	"VPBLENDMD":            {794},
+	"VPBLENDMQ":            {794},
+	"VPBLENDMW":            {793},
+	"VPBLENDVB":            {429},
+	"VPBLENDW":             {430},
+	"VPBROADCAST":          {795},
+	"VPBROADCASTB":         {795, 796},
+	"VPBROADCASTD":         {795, 796},
+	"VPBROADCASTM":         {797},
+	"VPBROADCASTMB2Q":      {797},
+	"VPBROADCASTMW2D":      {797},
+	"VPBROADCASTQ":         {795, 796},
+	"VPBROADCASTW":         {795, 796},
+	"VPCLMULQDQ":           {431},
+	"VPCMPB":               {798},
+	"VPCMPD":               {799},
+	"VPCMPEQB":             {432},
+	"VPCMPEQD":             {432},
+	"VPCMPEQQ":             {433},
+	"VPCMPEQW":             {432},
+	"VPCMPESTRI":           {434},
+	"VPCMPESTRM":           {435},
+	"VPCMPGTB":             {436},
+	"VPCMPGTD":             {436},
+	"VPCMPGTQ":             {437},
+	"VPCMPGTW":             {436},
+	"VPCMPISTRI":           {438},
+	"VPCMPISTRM":           {439},
+	"VPCMPQ":               {800},
+	"VPCMPUB":              {798},
+	"VPCMPUD":              {799},
+	"VPCMPUQ":              {800},
+	"VPCMPUW":              {801},
+	"VPCMPW":               {801},
+	"VPCOMPRESSB":          {802},
+	"VPCOMPRESSD":          {803},
+	"VPCOMPRESSQ":          {804},
+	"VPCOMPRESSW":          {802},
+	"VPCONFLICTD":          {805},
+	"VPCONFLICTQ":          {805},
+	"VPDPBUSD":             {806},
+	"VPDPBUSDS":            {807},
+	"VPDPWSSD":             {808},
+	"VPDPWSSDS":            {809},
+	"VPERM2F128":           {810},
+	"VPERM2I128":           {811},
+	"VPERMB":               {812},
+	"VPERMD":               {813},
+	"VPERMI2B":             {814},
+	"VPERMI2D":             {815},
+	"VPERMI2PD":            {815},
+	"VPERMI2PS":            {815},
+	"VPERMI2Q":             {815},
+	"VPERMI2W":             {815},
+	"VPERMILPD":            {816},
+	"VPERMILPS":            {817},
+	"VPERMPD":              {818},
+	"VPERMPS":              {819},
+	"VPERMQ":               {820},
+	"VPERMT2B":             {821},
+	"VPERMT2D":             {822},
+	"VPERMT2PD":            {822},
+	"VPERMT2PS":            {822},
+	"VPERMT2Q":             {822},
+	"VPERMT2W":             {822},
+	"VPERMW":               {813},
+	"VPEXPANDB":            {823},
+	"VPEXPANDD":            {824},
+	"VPEXPANDQ":            {825},
+	"VPEXPANDW":            {823},
+	"VPEXTRB":              {443},
+	"VPEXTRD":              {443},
+	"VPEXTRQ":              {443},
+	"VPEXTRW":              {444},
+	"VPGATHERDD":           {826, 827},
+	"VPGATHERDQ":           {826, 828},
+	"VPGATHERQD":           {827, 829},
+	"VPGATHERQQ":           {828, 829},
+	"VPHADDD":              {446},
+	"VPHADDSW":             {445},
+	"VPHADDW":              {446},
+	"VPHMINPOSUW":          {447},
+	"VPHSUBD":              {449},
+	"VPHSUBSW":             {448},
+	"VPHSUBW":              {449},
+	"VPINSRB":              {450},
+	"VPINSRD":              {450},
+	"VPINSRQ":              {450},
+	"VPINSRW":              {451},
+	"VPLZCNTD":             {830},
+	"VPLZCNTQ":             {830},
+	"VPMADD52HUQ":          {831},
+	"VPMADD52LUQ":          {832},
+	"VPMADDUBSW":           {452},
+	"VPMADDWD":             {453},
+	"VPMASKMOV":            {833},
+	"VPMASKMOVD":           {833},
+	"VPMASKMOVQ":           {833},
+	"VPMAXSB":              {454},
+	"VPMAXSD":              {454},
+	"VPMAXSQ":              {454},
+	"VPMAXSW":              {454},
+	"VPMAXUB":              {455},
+	"VPMAXUD":              {456},
+	"VPMAXUQ":              {456},
+	"VPMAXUW":              {455},
+	"VPMINSB":              {457},
+	"VPMINSD":              {458},
+	"VPMINSQ":              {458},
+	"VPMINSW":              {457},
+	"VPMINUB":              {459},
+	"VPMINUD":              {460},
+	"VPMINUQ":              {460},
+	"VPMINUW":              {459},
+	"VPMOVB2M":             {834},
+	"VPMOVD2M":             {834},
+	"VPMOVDB":              {835},
+	"VPMOVDW":              {836},
+	"VPMOVM2B":             {837},
+	"VPMOVM2D":             {837},
+	"VPMOVM2Q":             {837},
+	"VPMOVM2W":             {837},
+	"VPMOVMSKB":            {461},
+	"VPMOVQ2M":             {834},
+	"VPMOVQB":              {838},
+	"VPMOVQD":              {839},
+	"VPMOVQW":              {840},
+	"VPMOVSDB":             {835},
+	"VPMOVSDW":             {836},
+	"VPMOVSQB":             {838},
+	"VPMOVSQD":             {839},
+	"VPMOVSQW":             {840},
+	"VPMOVSWB":             {841},
+	"VPMOVSXBD":            {462},
+	"VPMOVSXBQ":            {462},
+	"VPMOVSXBW":            {462},
+	"VPMOVSXDQ":            {462},
+	"VPMOVSXWD":            {462},
+	"VPMOVSXWQ":            {462},
+	"VPMOVUSDB":            {835},
+	"VPMOVUSDW":            {836},
+	"VPMOVUSQB":            {838},
+	"VPMOVUSQD":            {839},
+	"VPMOVUSQW":            {840},
+	"VPMOVUSWB":            {841},
+	"VPMOVW2M":             {834},
+	"VPMOVWB":              {841},
+	"VPMOVZXBD":            {463},
+	"VPMOVZXBQ":            {463},
+	"VPMOVZXBW":            {463},
+	"VPMOVZXDQ":            {463},
+	"VPMOVZXWD":            {463},
+	"VPMOVZXWQ":            {463},
+	"VPMULDQ":              {464},
+	"VPMULHRSW":            {465},
+	"VPMULHUW":             {466},
+	"VPMULHW":              {467},
+	"VPMULLD":              {468},
+	"VPMULLQ":              {468},
+	"VPMULLW":              {469},
+	"VPMULTISHIFTQB":       {842},
+	"VPMULUDQ":             {470},
+	"VPOPCNT":              {843},
+	"VPOPCNTB":             {843},
+	"VPOPCNTD":             {843},
+	"VPOPCNTQ":             {843},
+	"VPOPCNTW":             {843},
+	"VPOR":                 {475},
+	"VPORD":                {475},
+	"VPORQ":                {475},
+	"VPROLD":               {844},
+	"VPROLQ":               {844},
+	"VPROLVD":              {844},
+	"VPROLVQ":              {844},
+	"VPRORD":               {845},
+	"VPRORQ":               {845},
+	"VPRORVD":              {845},
+	"VPRORVQ":              {845},
+	"VPSADBW":              {479},
+	"VPSCATTERDD":          {846},
+	"VPSCATTERDQ":          {846},
+	"VPSCATTERQD":          {846},
+	"VPSCATTERQQ":          {846},
+	"VPSHLD":               {847},
+	"VPSHLDD":              {847},
+	"VPSHLDQ":              {847},
+	"VPSHLDV":              {848},
+	"VPSHLDVD":             {848},
+	"VPSHLDVQ":             {848},
+	"VPSHLDVW":             {848},
+	"VPSHLDW":              {847},
+	"VPSHRD":               {849},
+	"VPSHRDD":              {849},
+	"VPSHRDQ":              {849},
+	"VPSHRDV":              {850},
+	"VPSHRDVD":             {850},
+	"VPSHRDVQ":             {850},
+	"VPSHRDVW":             {850},
+	"VPSHRDW":              {849},
+	"VPSHUFB":              {480},
+	"VPSHUFBITQMB":         {851},
+	"VPSHUFD":              {481},
+	"VPSHUFHW":             {482},
+	"VPSHUFLW":             {483},
+	"VPSIGNB":              {485},
+	"VPSIGND":              {485},
+	"VPSIGNW":              {485},
+	"VPSLLD":               {487},
+	"VPSLLDQ":              {486},
+	"VPSLLQ":               {487},
+	"VPSLLVD":              {852},
+	"VPSLLVQ":              {852},
+	"VPSLLVW":              {852},
+	"VPSLLW":               {487},
+	"VPSRAD":               {488},
+	"VPSRAQ":               {488},
+	"VPSRAVD":              {853},
+	"VPSRAVQ":              {853},
+	"VPSRAVW":              {853},
+	"VPSRAW":               {488},
+	"VPSRLD":               {490},
+	"VPSRLDQ":              {489},
+	"VPSRLQ":               {490},
+	"VPSRLVD":              {854},
+	"VPSRLVQ":              {854},
+	"VPSRLVW":              {854},
+	"VPSRLW":               {490},
+	"VPSUBB":               {491},
+	"VPSUBD":               {491},
+	"VPSUBQ":               {492},
+	"VPSUBSB":              {493},
+	"VPSUBSW":              {493},
+	"VPSUBUSB":             {494},
+	"VPSUBUSW":             {494},
+	"VPSUBW":               {491},
+	"VPTERNLOGD":           {855},
+	"VPTERNLOGQ":           {855},
+	"VPTEST":               {495},
+	"VPTESTMB":             {856},
+	"VPTESTMD":             {856},
+	"VPTESTMQ":             {856},
+	"VPTESTMW":             {856},
+	"VPTESTNMB":            {857},
+	"VPTESTNMD":            {857},
+	"VPTESTNMQ":            {857},
+	"VPTESTNMW":            {857},
+	"VPUNPCKHBW":           {497},
+	"VPUNPCKHDQ":           {497},
+	"VPUNPCKHQDQ":          {497},
+	"VPUNPCKHWD":           {497},
+	"VPUNPCKLBW":           {498},
+	"VPUNPCKLDQ":           {498},
+	"VPUNPCKLQDQ":          {498},
+	"VPUNPCKLWD":           {498},
+	"VPXOR":                {502},
+	"VPXORD":               {502},
+	"VPXORQ":               {502},
+	"VRANGEPD":             {858},
+	"VRANGEPS":             {859},
+	"VRANGESD":             {860},
+	"VRANGESS":             {861},
+	"VRCP14PD":             {862},
+	"VRCP14PS":             {863},
+	"VRCP14SD":             {864},
+	"VRCP14SS":             {865},
+	"VRCP28PD":             {866},
+	"VRCP28PS":             {867},
+	"VRCP28SD":             {868},
+	"VRCP28SS":             {869},
+	"VRCPPH":               {870},
+	"VRCPPS":               {504},
+	"VRCPSH":               {871},
+	"VRCPSS":               {505},
+	"VREDUCEPD":            {872},
+	"VREDUCEPH":            {873},
+	"VREDUCEPS":            {874},
+	"VREDUCESD":            {875},
+	"VREDUCESH":            {876},
+	"VREDUCESS":            {877},
+	"VRNDSCALEPD":          {878},
+	"VRNDSCALEPH":          {879},
+	"VRNDSCALEPS":          {880},
+	"VRNDSCALESD":          {881},
+	"VRNDSCALESH":          {882},
+	"VRNDSCALESS":          {883},
+	"VROUNDPD":             {519},
+	"VROUNDPS":             {520},
+	"VROUNDSD":             {521},
+	"VROUNDSS":             {522},
+	"VRSQRT14PD":           {884},
+	"VRSQRT14PS":           {885},
+	"VRSQRT14SD":           {886},
+	"VRSQRT14SS":           {887},
+	"VRSQRT28PD":           {888},
+	"VRSQRT28PS":           {889},
+	"VRSQRT28SD":           {890},
+	"VRSQRT28SS":           {891},
+	"VRSQRTPH":             {892},
+	"VRSQRTPS":             {524},
+	"VRSQRTSH":             {893},
+	"VRSQRTSS":             {525},
+	"VSCALEFPD":            {894},
+	"VSCALEFPH":            {895},
+	"VSCALEFPS":            {896},
+	"VSCALEFSD":            {897},
+	"VSCALEFSH":            {898},
+	"VSCALEFSS":            {899},
+	"VSCATTERDPD":          {900},
+	"VSCATTERDPS":          {900},
+	"VSCATTERPF0DPD":       {901},
+	"VSCATTERPF0DPS":       {901},
+	"VSCATTERPF0QPD":       {901},
+	"VSCATTERPF0QPS":       {901},
+	"VSCATTERPF1DPD":       {902},
+	"VSCATTERPF1DPS":       {902},
+	"VSCATTERPF1QPD":       {902},
+	"VSCATTERPF1QPS":       {902},
+	"VSCATTERQPD":          {900},
+	"VSCATTERQPS":          {900},
+	"VSHUFF32X4":           {903},
+	"VSHUFF64X2":           {903},
+	"VSHUFI32X4":           {903},
+	"VSHUFI64X2":           {903},
+	"VSHUFPD":              {578},
+	"VSHUFPS":              {579},
+	"VSQRTPD":              {583},
+	"VSQRTPH":              {904},
+	"VSQRTPS":              {584},
+	"VSQRTSD":              {585},
+	"VSQRTSH":              {905},
+	"VSQRTSS":              {586},
+	"VSTMXCSR":             {591},
+	"VSUBPD":               {597},
+	"VSUBPH":               {906},
+	"VSUBPS":               {598},
+	"VSUBSD":               {599},
+	"VSUBSH":               {907},
+	"VSUBSS":               {600},
+	"VTESTPD":              {908},
+	"VTESTPS":              {908},
+	"VUCOMISD":             {616},
+	"VUCOMISH":             {909},
+	"VUCOMISS":             {617},
+	"VUNPCKHPD":            {622},
+	"VUNPCKHPS":            {623},
+	"VUNPCKLPD":            {624},
+	"VUNPCKLPS":            {625},
+	"VXORPD":               {929},
+	"VXORPS":               {930},
+	"VZEROALL":             {910},
+	"VZEROUPPER":           {911},
+	"WAIT":                 {912},
+	"WBINVD":               {913},
+	"WBNOINVD":             {914},
+	"WRFSBASE":             {915},
+	"WRGSBASE":             {915},
+	"WRMSR":                {916},
+	"WRPKRU":               {917},
+	"WRSSD":                {918},
+	"WRSSQ":                {918},
+	"WRUSSD":               {919},
+	"WRUSSQ":               {919},
+	"XABORT":               {920},
+	"XACQUIRE":             {921},
+	"XADD":                 {922},
+	"XBEGIN":               {923},
+	"XCHG":                 {924},
+	"XEND":                 {925},
+	"XGETBV":               {926},
+	"XLAT":                 {927},
+	"XLATB":                {927},
+	"XOR":                  {928},
+	"XORPD":                {929},
+	"XORPS":                {930},
+	"XRELEASE":             {921},
+	"XRESLDTRK":            {931},
+	"XRSTOR":               {932},
+	"XRSTOR64":             {932},
+	"XRSTORS":              {933},
+	"XRSTORS64":            {933},
+	"XSAVE":                {934},
+	"XSAVE64":              {934},
+	"XSAVEC":               {935},
+	"XSAVEC64":             {935},
+	"XSAVEOPT":             {936},
+	"XSAVEOPT64":           {936},
+	"XSAVES":               {937},
+	"XSAVES64":             {937},
+	"XSETBV":               {938},
+	"XSUSLDTRK":            {939},
+	"XTEST":                {940},
+}
+
+var jvmByMnemonic = map[string]int{
+	"aaload":          0,
+	"aastore":         1,
+	"aconst_null":     2,
+	"aload":           3,
+	"aload_0":         4,
+	"aload_1":         5,
+	"aload_2":         6,
+	"aload_3":         7,
+	"anewarray":       8,
+	"areturn":         9,
+	"arraylength":     10,
+	"astore":          11,
+	"astore_0":        12,
+	"astore_1":        13,
+	"astore_2":        14,
+	"astore_3":        15,
+	"athrow":          16,
+	"baload":          17,
+	"bastore":         18,
+	"bipush":          19,
+	"caload":          21,
+	"castore":         22,
+	"checkcast":       23,
+	"d2f":             24,
+	"d2i":             25,
+	"d2l":             26,
+	"dadd":            27,
+	"daload":          28,
+	"dastore":         29,
+	"dcmpg":           30,
+	"dcmpl":           31,
+	"dconst_0":        32,
+	"dconst_1":        33,
+	"ddiv":            34,
+	"dload":           35,
+	"dload_0":         36,
+	"dload_1":         37,
+	"dload_2":         38,
+	"dload_3":         39,
+	"dmul":            40,
+	"dneg":            41,
+	"drem":            42,
+	"dreturn":         43,
+	"dstore":          44,
+	"dstore_0":        45,
+	"dstore_1":        46,
+	"dstore_2":        47,
+	"dstore_3":        48,
+	"dsub":            49,
+	"dup":             50,
+	"dup2":            51,
+	"dup2_x1":         52,
+	"dup2_x2":         53,
+	"dup_x1":          54,
+	"dup_x2":          55,
+	"f2d":             56,
+	"f2i":             57,
+	"f2l":             58,
+	"fadd":            59,
+	"faload":          60,
+	"fastore":         61,
+	"fcmpg":           62,
+	"fcmpl":           63,
+	"fconst_0":        64,
+	"fconst_1":        65,
+	"fconst_2":        66,
+	"fdiv":            67,
+	"fload":           68,
+	"fload_0":         69,
+	"fload_1":         70,
+	"fload_2":         71,
+	"fload_3":         72,
+	"fmul":            73,
+	"fneg":            74,
+	"frem":            75,
+	"freturn":         76,
+	"fstore":          77,
+	"fstore_0":        78,
+	"fstore_1":        79,
+	"fstore_2":        80,
+	"fstore_3":        81,
+	"fsub":            82,
+	"getfield":        83,
+	"getstatic":       84,
+	"goto":            85,
+	"goto_w":          86,
+	"i2b":             87,
+	"i2c":             88,
+	"i2d":             89,
+	"i2f":             90,
+	"i2l":             91,
+	"i2s":             92,
+	"iadd":            93,
+	"iaload":          94,
+	"iand":            95,
+	"iastore":         96,
+	"iconst_0":        97,
+	"iconst_1":        98,
+	"iconst_2":        99,
+	"iconst_3":        100,
+	"iconst_4":        101,
+	"iconst_5":        102,
+	"iconst_m1":       103,
+	"idiv":            104,
+	"if_acmpeq":       105,
+	"if_acmpne":       106,
+	"if_icmpeq":       107,
+	"if_icmpge":       108,
+	"if_icmpgt":       109,
+	"if_icmple":       110,
+	"if_icmplt":       111,
+	"if_icmpne":       112,
+	"ifeq":            113,
+	"ifge":            114,
+	"ifgt":            115,
+	"ifle":            116,
+	"iflt":            117,
+	"ifne":            118,
+	"ifnonnull":       119,
+	"ifnull":          120,
+	"iinc":            121,
+	"iload":           122,
+	"iload_0":         123,
+	"iload_1":         124,
+	"iload_2":         125,
+	"iload_3":         126,
+	"imul":            129,
+	"ineg":            130,
+	"instanceof":      131,
+	"invokedynamic":   132,
+	"invokeinterface": 133,
+	"invokespecial":   134,
+	"invokestatic":    135,
+	"invokevirtual":   136,
+	"ior":             137,
+	"irem":            138,
+	"ireturn":         139,
+	"ishl":            140,
+	"ishr":            141,
+	"istore":          142,
+	"istore_0":        143,
+	"istore_1":        144,
+	"istore_2":        145,
+	"istore_3":        146,
+	"isub":            147,
+	"iushr":           148,
+	"ixor":            149,
+	"jsr":             150,
+	"jsr_w":           151,
+	"l2d":             152,
+	"l2f":             153,
+	"l2i":             154,
+	"ladd":            155,
+	"laload":          156,
+	"land":            157,
+	"lastore":         158,
+	"lcmp":            159,
+	"lconst_0":        160,
+	"lconst_1":        161,
+	"ldc":             162,
+	"ldc2_w":          163,
+	"ldc_w":           164,
+	"ldiv":            165,
+	"lload":           166,
+	"lload_0":         167,
+	"lload_1":         168,
+	"lload_2":         169,
+	"lload_3":         170,
+	"lmul":            171,
+	"lneg":            172,
+	"lookupswitch":    173,
+	"lor":             174,
+	"lrem":            175,
+	"lreturn":         176,
+	"lshl":            177,
+	"lshr":            178,
+	"lstore":          179,
+	"lstore_0":        180,
+	"lstore_1":        181,
+	"lstore_2":        182,
+	"lstore_3":        183,
+	"lsub":            184,
+	"lushr":           185,
+	"lxor":            186,
+	"monitorenter":    187,
+	"monitorexit":     188,
+	"multianewarray":  189,
+	"new":             190,
+	"newarray":        191,
+	"nop":             192,
+	"pop":             193,
+	"pop2":            194,
+	"putfield":        195,
+	"putstatic":       196,
+	"ret":             197,
+	"return":          198,
+	"saload":          199,
+	"sastore":         200,
+	"sipush":          201,
+	"swap":            202,
+	"tableswitch":     203,
+	"wide":            204,
+	"wide aload":      205,
+	"wide astore":     206,
+	"wide dload":      207,
+	"wide dstore":     208,
+	"wide fload":      209,
+	"wide fstore":     210,
+	"wide iinc":       211,
+	"wide iload":      212,
+	"wide istore":     213,
+	"wide lload":      214,
+	"wide lstore":     215,
+	"wide ret":        216,
+}
+
+var jvmByOpcode = map[uint8]int{
+	0x00: 192,
+	0x01: 2,
+	0x02: 103,
+	0x03: 97,
+	0x04: 98,
+	0x05: 99,
+	0x06: 100,
+	0x07: 101,
+	0x08: 102,
+	0x09: 160,
+	0x0a: 161,
+	0x0b: 64,
+	0x0c: 65,
+	0x0d: 66,
+	0x0e: 32,
+	0x0f: 33,
+	0x10: 19,
+	0x11: 201,
+	0x12: 162,
+	0x13: 164,
+	0x14: 163,
+	0x15: 122,
+	0x16: 166,
+	0x17: 68,
+	0x18: 35,
+	0x19: 3,
+	0x1a: 123,
+	0x1b: 124,
+	0x1c: 125,
+	0x1d: 126,
+	0x1e: 167,
+	0x1f: 168,
+	0x20: 169,
+	0x21: 170,
+	0x22: 69,
+	0x23: 70,
+	0x24: 71,
+	0x25: 72,
+	0x26: 36,
+	0x27: 37,
+	0x28: 38,
+	0x29: 39,
+	0x2a: 4,
+	0x2b: 5,
+	0x2c: 6,
+	0x2d: 7,
+	0x2e: 94,
+	0x2f: 156,
+	0x30: 60,
+	0x31: 28,
+	0x32: 0,
+	0x33: 17,
+	0x34: 21,
+	0x35: 199,
+	0x36: 142,
+	0x37: 179,
+	0x38: 77,
+	0x39: 44,
+	0x3a: 11,
+	0x3b: 143,
+	0x3c: 144,
+	0x3d: 145,
+	0x3e: 146,
+	0x3f: 180,
+	0x40: 181,
+	0x41: 182,
+	0x42: 183,
+	0x43: 78,
+	0x44: 79,
+	0x45: 80,
+	0x46: 81,
+	0x47: 45,
+	0x48: 46,
+	0x49: 47,
+	0x4a: 48,
+	0x4b: 12,
+	0x4c: 13,
+	0x4d: 14,
+	0x4e: 15,
+	0x4f: 96,
+	0x50: 158,
+	0x51: 61,
+	0x52: 29,
+	0x53: 1,
+	0x54: 18,
+	0x55: 22,
+	0x56: 200,
+	0x57: 193,
+	0x58: 194,
+	0x59: 50,
+	0x5a: 54,
+	0x5b: 55,
+	0x5c: 51,
+	0x5d: 52,
+	0x5e: 53,
+	0x5f: 202,
+	0x60: 93,
+	0x61: 155,
+	0x62: 59,
+	0x63: 27,
+	0x64: 147,
+	0x65: 184,
+	0x66: 82,
+	0x67: 49,
+	0x68: 129,
+	0x69: 171,
+	0x6a: 73,
+	0x6b: 40,
+	0x6c: 104,
+	0x6d: 165,
+	0x6e: 67,
+	0x6f: 34,
+	0x70: 138,
+	0x71: 175,
+	0x72: 75,
+	0x73: 42,
+	0x74: 130,
+	0x75: 172,
+	0x76: 74,
+	0x77: 41,
+	0x78: 140,
+	0x79: 177,
+	0x7a: 141,
+	0x7b: 178,
+	0x7c: 148,
+	0x7d: 185,
+	0x7e: 95,
+	0x7f: 157,
+	0x80: 137,
+	0x81: 174,
+	0x82: 149,
+	0x83: 186,
+	0x84: 121,
+	0x85: 91,
+	0x86: 90,
+	0x87: 89,
+	0x88: 154,
+	0x89: 153,
+	0x8a: 152,
+	0x8b: 57,
+	0x8c: 58,
+	0x8d: 56,
+	0x8e: 25,
+	0x8f: 26,
+	0x90: 24,
+	0x91: 87,
+	0x92: 88,
+	0x93: 92,
+	0x94: 159,
+	0x95: 63,
+	0x96: 62,
+	0x97: 31,
+	0x98: 30,
+	0x99: 113,
+	0x9a: 118,
+	0x9b: 117,
+	0x9c: 114,
+	0x9d: 115,
+	0x9e: 116,
+	0x9f: 107,
+	0xa0: 112,
+	0xa1: 111,
+	0xa2: 108,
+	0xa3: 109,
+	0xa4: 110,
+	0xa5: 105,
+	0xa6: 106,
+	0xa7: 85,
+	0xa8: 150,
+	0xa9: 197,
+	0xaa: 203,
+	0xab: 173,
+	0xac: 139,
+	0xad: 176,
+	0xae: 76,
+	0xaf: 43,
+	0xb0: 9,
+	0xb1: 198,
+	0xb2: 84,
+	0xb3: 196,
+	0xb4: 83,
+	0xb5: 195,
+	0xb6: 136,
+	0xb7: 134,
+	0xb8: 135,
+	0xb9: 133,
+	0xba: 132,
+	0xbb: 190,
+	0xbc: 191,
+	0xbd: 8,
+	0xbe: 10,
+	0xbf: 16,
+	0xc0: 23,
+	0xc1: 131,
+	0xc2: 187,
+	0xc3: 188,
+	0xc4: 204,
+	0xc5: 189,
+	0xc6: 120,
+	0xc7: 119,
+	0xc8: 86,
+	0xc9: 151,
+}
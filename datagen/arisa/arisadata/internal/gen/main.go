@@ -0,0 +1,210 @@
+// Command gen turns the x86 and JVM datasets into the embedded blobs and
+// lookup tables of package arisadata. It runs from go generate:
+//
+//	go generate ./arisadata
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+	"strings"
+
+	"arisa/dataset"
+	"arisa/schema"
+)
+
+func main() {
+	x86File := flag.String("x86", "../../x86/x86.json", "x86 dataset to embed")
+	jvmFile := flag.String("jvm", "../../java/jvm_instructions.json", "JVM dataset to embed")
+	flag.Parse()
+
+	if err := generate(*x86File, *jvmFile); err != nil {
+		fmt.Fprintln(os.Stderr, "gen:", err)
+		os.Exit(1)
+	}
+}
+
+func generate(x86File, jvmFile string) error {
+	x86Records, err := readRecords(x86File)
+	if err != nil {
+		return err
+	}
+	jvmRecords, err := readRecords(jvmFile)
+	if err != nil {
+		return err
+	}
+
+	x86Index, err := indexX86(x86Records)
+	if err != nil {
+		return err
+	}
+	jvmByMnemonic, jvmByOpcode, err := indexJVM(jvmRecords)
+	if err != nil {
+		return err
+	}
+
+	if err := writeBlob("x86.json.zst", x86Records); err != nil {
+		return err
+	}
+	if err := writeBlob("jvm_instructions.json.zst", jvmRecords); err != nil {
+		return err
+	}
+	return writeIndex("index_gen.go", x86Index, jvmByMnemonic, jvmByOpcode)
+}
+
+// readRecords splits a dataset into its records, compacted, keeping their
+// order so positions in the index line up with the embedded array.
+func readRecords(path string) ([]json.RawMessage, error) {
+	content, err := dataset.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	content, _, err = dataset.Unwrap(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	var records []json.RawMessage
+	if err := json.Unmarshal(content, &records); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	for i, record := range records {
+		compacted := new(bytes.Buffer)
+		if err := json.Compact(compacted, record); err != nil {
+			return nil, fmt.Errorf("failed to compact record %d of %s: %w", i, path, err)
+		}
+		records[i] = compacted.Bytes()
+	}
+	return records, nil
+}
+
+// indexX86 maps each upper-case mnemonic to the records documenting it:
+// every mnemonic in a record's forms, plus the ones named in its title,
+// such as both ADDPD and VADDPD for "ADDPD/VADDPD—Add Packed ...".
+func indexX86(records []json.RawMessage) (map[string][]int, error) {
+	index := make(map[string][]int)
+	for i, raw := range records {
+		var record struct {
+			InstructionName string `json:"instructionName"`
+			Forms           []struct {
+				Mnemonic string `json:"mnemonic"`
+			} `json:"forms"`
+		}
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal x86 record %d: %w", i, err)
+		}
+
+		seen := make(map[string]bool)
+		title, _, _ := strings.Cut(record.InstructionName, "—")
+		title = strings.Join(strings.Fields(title), "")
+		names := strings.Split(title, "/")
+		for _, form := range record.Forms {
+			names = append(names, form.Mnemonic)
+		}
+		for _, name := range names {
+			name = strings.ToUpper(strings.TrimSpace(name))
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			index[name] = append(index[name], i)
+		}
+	}
+	return index, nil
+}
+
+// indexJVM maps mnemonics and opcode bytes to record positions. Datasets
+// written before opcodeByte existed only carry it in the "name = dec
+// (0xhex)" opcode string, so that is read when the field is missing.
+func indexJVM(records []json.RawMessage) (map[string]int, map[uint8]int, error) {
+	byMnemonic := make(map[string]int)
+	byOpcode := make(map[uint8]int)
+	for i, raw := range records {
+		var record schema.JVMInstruction
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal JVM record %d: %w", i, err)
+		}
+		if record.Reserved {
+			continue
+		}
+		byMnemonic[record.Mnemonic] = i
+		// The wide-modified forms share wide's opcode byte.
+		if record.Modifies != "" {
+			continue
+		}
+
+		opcode := record.OpcodeByte
+		if !bytes.Contains(raw, []byte(`"opcodeByte"`)) {
+			var name string
+			if _, err := fmt.Sscanf(record.Opcode, "%s = %d", &name, &opcode); err != nil {
+				continue
+			}
+		}
+		byOpcode[opcode] = i
+	}
+	return byMnemonic, byOpcode, nil
+}
+
+func writeBlob(path string, records []json.RawMessage) error {
+	content := new(bytes.Buffer)
+	content.WriteByte('[')
+	for i, record := range records {
+		if i > 0 {
+			content.WriteByte(',')
+		}
+		content.Write(record)
+	}
+	content.WriteByte(']')
+
+	compressed, err := dataset.Compress(content.Bytes(), "zst")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, compressed, 0644)
+}
+
+func writeIndex(path string, x86Index map[string][]int, jvmByMnemonic map[string]int, jvmByOpcode map[uint8]int) error {
+	source := new(bytes.Buffer)
+	source.WriteString("// Code generated by internal/gen. DO NOT EDIT.\n\npackage arisadata\n\n")
+
+	source.WriteString("var x86Index = map[string][]int{\n")
+	for _, name := range sortedKeys(x86Index) {
+		positions := make([]string, len(x86Index[name]))
+		for i, position := range x86Index[name] {
+			positions[i] = fmt.Sprint(position)
+		}
+		fmt.Fprintf(source, "%q: {%s},\n", name, strings.Join(positions, ", "))
+	}
+	source.WriteString("}\n\n")
+
+	source.WriteString("var jvmByMnemonic = map[string]int{\n")
+	for _, name := range sortedKeys(jvmByMnemonic) {
+		fmt.Fprintf(source, "%q: %d,\n", name, jvmByMnemonic[name])
+	}
+	source.WriteString("}\n\n")
+
+	source.WriteString("var jvmByOpcode = map[uint8]int{\n")
+	for _, opcode := range sortedKeys(jvmByOpcode) {
+		fmt.Fprintf(source, "0x%02x: %d,\n", opcode, jvmByOpcode[opcode])
+	}
+	source.WriteString("}\n")
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", path, err)
+	}
+	return os.WriteFile(path, formatted, 0644)
+}
+
+func sortedKeys[K string | uint8, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
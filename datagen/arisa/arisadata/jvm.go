@@ -0,0 +1,41 @@
+package arisadata
+
+import (
+	"strings"
+
+	"arisa/schema"
+)
+
+// JVMInstruction is a record of the JVM dataset.
+type JVMInstruction = schema.JVMInstruction
+
+// JVM returns every record in the JVM dataset, including the reserved
+// opcodes. The slice is shared; don't modify it.
+func JVM() ([]JVMInstruction, error) {
+	return loadJVM()
+}
+
+// JVMLookup returns the instruction with the given mnemonic, matched without
+// regard to case.
+func JVMLookup(mnemonic string) (JVMInstruction, bool, error) {
+	position, ok := jvmByMnemonic[strings.ToLower(mnemonic)]
+	return jvmAt(position, ok)
+}
+
+// JVMOpcode returns the instruction encoded by an opcode byte. Unassigned
+// opcodes are not found.
+func JVMOpcode(opcode uint8) (JVMInstruction, bool, error) {
+	position, ok := jvmByOpcode[opcode]
+	return jvmAt(position, ok)
+}
+
+func jvmAt(position int, ok bool) (JVMInstruction, bool, error) {
+	if !ok {
+		return JVMInstruction{}, false, nil
+	}
+	instructions, err := loadJVM()
+	if err != nil {
+		return JVMInstruction{}, false, err
+	}
+	return instructions[position], true, nil
+}
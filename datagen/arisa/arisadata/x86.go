@@ -0,0 +1,100 @@
+package arisadata
+
+import "strings"
+
+// X86Instruction is one felixcloutier.com page from the x86 dataset. It
+// carries the fields Go consumers look up; the raw tables in x86.json are
+// left out.
+type X86Instruction struct {
+	URL               string                          `json:"url"`
+	Category          string                          `json:"category"`
+	Taxonomy          string                          `json:"taxonomy,omitempty"`
+	InstructionName   string                          `json:"instructionName"`
+	Parent            string                          `json:"parent,omitempty"`
+	DescriptionText   string                          `json:"descriptionText"`
+	OperationText     string                          `json:"operationText"`
+	FlagsAffectedText string                          `json:"flagsAffectedText"`
+	Intrinsics        []string                        `json:"intrinsics,omitempty"`
+	FlagsAffected     map[string]string               `json:"flagsAffected,omitempty"`
+	Exceptions        map[string][]string             `json:"exceptions"`
+	ExceptionRecords  map[string][]X86ExceptionRecord `json:"exceptionRecords,omitempty"`
+	Forms             []X86Form                       `json:"forms,omitempty"`
+	FeatureFlags      []string                        `json:"featureFlags,omitempty"`
+	ContentHash       string                          `json:"contentHash,omitempty"`
+	Aliases           []string                        `json:"aliases,omitempty"`
+	Error             string                          `json:"error,omitempty"`
+}
+
+// X86Form is one row of an instruction's opcode table.
+type X86Form struct {
+	Opcode         string       `json:"opcode"`
+	Instruction    string       `json:"instruction"`
+	Mnemonic       string       `json:"mnemonic"`
+	Operands       []string     `json:"operands,omitempty"`
+	OpEn           string       `json:"opEn,omitempty"`
+	Valid64        string       `json:"valid64,omitempty"`
+	ValidCompat    string       `json:"validCompat,omitempty"`
+	CPUID          string       `json:"cpuid,omitempty"`
+	FeatureFlags   []string     `json:"featureFlags,omitempty"`
+	Description    string       `json:"description,omitempty"`
+	Encoding       *X86Encoding `json:"encoding,omitempty"`
+	OperandDetails []X86Operand `json:"operandDetails,omitempty"`
+}
+
+// X86Encoding is the decoded opcode of a form.
+type X86Encoding struct {
+	PrefixClass     string `json:"prefixClass"`
+	VectorLength    string `json:"vectorLength,omitempty"`
+	MandatoryPrefix string `json:"mandatoryPrefix,omitempty"`
+	Map             string `json:"map"`
+	W               string `json:"w,omitempty"`
+	OpcodeByte      string `json:"opcodeByte,omitempty"`
+	ModRM           string `json:"modrm,omitempty"`
+	Immediate       string `json:"immediate,omitempty"`
+}
+
+type X86Operand struct {
+	Syntax   string `json:"syntax"`
+	Type     string `json:"type"`
+	Kind     string `json:"kind"`
+	Width    int    `json:"width,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	Access   string `json:"access,omitempty"`
+}
+
+// X86ExceptionRecord is one condition of an exceptions section.
+type X86ExceptionRecord struct {
+	Vector    string `json:"vector,omitempty"`
+	Condition string `json:"condition,omitempty"`
+}
+
+// X86 returns every record in the x86 dataset. The slice is shared; don't
+// modify it.
+func X86() ([]X86Instruction, error) {
+	return loadX86()
+}
+
+// X86Lookup returns the records documenting a mnemonic, matched without
+// regard to case. A mnemonic can appear on more than one page, such as MOV.
+func X86Lookup(mnemonic string) ([]X86Instruction, error) {
+	instructions, err := loadX86()
+	if err != nil {
+		return nil, err
+	}
+
+	positions := x86Index[strings.ToUpper(mnemonic)]
+	matches := make([]X86Instruction, 0, len(positions))
+	for _, position := range positions {
+		matches = append(matches, instructions[position])
+	}
+	return matches, nil
+}
+
+// X86Mnemonics lists every mnemonic X86Lookup knows, upper-case.
+func X86Mnemonics() []string {
+	mnemonics := make([]string, 0, len(x86Index))
+	for mnemonic := range x86Index {
+		mnemonics = append(mnemonics, mnemonic)
+	}
+	return mnemonics
+}
@@ -0,0 +1,71 @@
+// Package cheader helps the generators write their opcode constants as C
+// headers, for emulators and OS code that want them without a JSON parser.
+package cheader
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const maxCommentLength = 100
+
+// Header accumulates a header file between its include guard.
+type Header struct {
+	bytes.Buffer
+	guard string
+}
+
+// New starts a header whose include guard is derived from name, with a
+// banner saying where it was generated from.
+func New(name, source string) *Header {
+	header := &Header{guard: "ARISA_" + Identifier(name)}
+	fmt.Fprintf(header, "/* %s: generated by Arisa from %s. Do not edit. */\n\n", name, Comment(source))
+	fmt.Fprintf(header, "#ifndef %s\n#define %s\n\n", header.guard, header.guard)
+	return header
+}
+
+// Finish closes the include guard and returns the finished header.
+func (h *Header) Finish() []byte {
+	fmt.Fprintf(&h.Buffer, "\n#endif /* %s */\n", h.guard)
+	return h.Buffer.Bytes()
+}
+
+// Identifier upper-cases text and joins its runs of letters and digits with
+// underscores, so "ADD r/m64, imm8" becomes "ADD_R_M64_IMM8".
+func Identifier(text string) string {
+	var builder strings.Builder
+	pending := false
+	for _, r := range text {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if pending && builder.Len() > 0 {
+				builder.WriteByte('_')
+			}
+			builder.WriteRune(unicode.ToUpper(r))
+			pending = false
+			continue
+		}
+		pending = true
+	}
+	return builder.String()
+}
+
+// Comment reduces text to one short line that is safe inside /* */: its
+// first sentence, with whitespace collapsed, cut at a word boundary if it
+// is still long.
+func Comment(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	text = strings.ReplaceAll(text, "*/", "* /")
+	if end := strings.Index(text, ". "); end >= 0 {
+		text = text[:end+1]
+	}
+	if len(text) <= maxCommentLength {
+		return text
+	}
+	cut := strings.LastIndex(text[:maxCommentLength], " ")
+	if cut <= 0 {
+		cut = maxCommentLength
+	}
+	return text[:cut] + "..."
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"arisa/instructions"
+)
+
+// cardWidth is the column reference cards are wrapped at on the command
+// line.
+const cardWidth = 78
+
+func x86Card(args []string) error {
+	flags := flag.NewFlagSet("x86", flag.ContinueOnError)
+	x86File := flags.String("x86", "", "x86 dataset file (default: the embedded snapshot)")
+	format := flags.String("format", "text", "output format: text or json")
+	operation := flags.Bool("operation", false, "include the Operation pseudocode")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: arisa x86 [flags] mnemonic")
+	}
+
+	set, err := loadX86(*x86File)
+	if err != nil {
+		return err
+	}
+	records := set.LookupMnemonic(flags.Arg(0))
+	if len(records) == 0 {
+		var suggestions []string
+		for _, record := range truncate(set.FuzzySearch(flags.Arg(0)), 3) {
+			suggestions = append(suggestions, instructions.X86Mnemonics(record)[0])
+		}
+		return notFound("x86", flags.Arg(0), suggestions)
+	}
+
+	switch *format {
+	case "json":
+		return writeJSON(records)
+	case "text":
+		for i, record := range records {
+			if i > 0 {
+				fmt.Println()
+			}
+			if err := writeX86Card(os.Stdout, cardWidth, record, *operation); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+}
+
+func jvmCard(args []string) error {
+	flags := flag.NewFlagSet("jvm", flag.ContinueOnError)
+	jvmFile := flags.String("jvm", "", "JVM dataset file (default: the embedded snapshot)")
+	format := flags.String("format", "text", "output format: text or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: arisa jvm [flags] mnemonic")
+	}
+
+	set, err := loadJVM(*jvmFile)
+	if err != nil {
+		return err
+	}
+	record, ok := set.Lookup(flags.Arg(0))
+	if !ok {
+		var suggestions []string
+		for _, record := range truncate(set.FuzzySearch(flags.Arg(0)), 3) {
+			suggestions = append(suggestions, record.Mnemonic)
+		}
+		return notFound("JVM", flags.Arg(0), suggestions)
+	}
+
+	switch *format {
+	case "json":
+		return writeJSON(record)
+	case "text":
+		return writeJVMCard(os.Stdout, cardWidth, record)
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+}
+
+func notFound(isa, mnemonic string, suggestions []string) error {
+	if len(suggestions) == 0 {
+		return fmt.Errorf("no %s instruction %q", isa, mnemonic)
+	}
+	return fmt.Errorf("no %s instruction %q; did you mean %s?", isa, mnemonic, strings.Join(suggestions, ", "))
+}
+
+func writeJSON(value any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// writeX86Card prints a page's title, opcode table, description, flags and
+// exceptions by mode. Datasets from before forms were parsed have no opcode
+// table, so the card says so instead.
+func writeX86Card(w io.Writer, width int, record instructions.X86Instruction, operation bool) error {
+	paragraphs(w, strings.Join(strings.Fields(record.InstructionName), " "), "", width)
+	fmt.Fprintln(w, record.URL)
+	var facts []string
+	if record.Taxonomy != "" {
+		facts = append(facts, "Category: "+record.Taxonomy)
+	} else if record.Category != "" {
+		facts = append(facts, "Category: "+record.Category)
+	}
+	if len(record.FeatureFlags) > 0 {
+		facts = append(facts, "CPUID: "+strings.Join(record.FeatureFlags, ", "))
+	}
+	if len(facts) > 0 {
+		fmt.Fprintln(w, strings.Join(facts, ". "))
+	}
+
+	section(w, "Encodings")
+	if len(record.Forms) == 0 {
+		fmt.Fprintln(w, "  The x86 dataset has no forms; regenerate it, or pass a newer one with -x86.")
+	} else {
+		table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(table, "  Opcode\tInstruction\tOp/En\t64-Bit\tCompat\tCPUID")
+		for _, form := range record.Forms {
+			fmt.Fprintf(table, "  %s\t%s\t%s\t%s\t%s\t%s\n", form.Opcode, form.Instruction, form.OpEn, form.Valid64, form.ValidCompat, form.CPUID)
+		}
+		if err := table.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if strings.TrimSpace(record.DescriptionText) != "" {
+		section(w, "Description")
+		paragraphs(w, record.DescriptionText, "  ", width)
+	}
+
+	if operation && strings.TrimSpace(record.OperationText) != "" {
+		section(w, "Operation")
+		for _, line := range strings.Split(strings.Trim(record.OperationText, "\n"), "\n") {
+			fmt.Fprintln(w, strings.TrimRight("  "+line, " "))
+		}
+	}
+
+	if len(record.FlagsAffected) > 0 || strings.TrimSpace(record.FlagsAffectedText) != "" {
+		section(w, "Flags Affected")
+		if len(record.FlagsAffected) > 0 {
+			table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+			for _, flag := range sortedFlags(record.FlagsAffected) {
+				fmt.Fprintf(table, "  %s\t%s\n", flag, record.FlagsAffected[flag])
+			}
+			if err := table.Flush(); err != nil {
+				return err
+			}
+		} else {
+			paragraphs(w, record.FlagsAffectedText, "  ", width)
+		}
+	}
+
+	for _, mode := range instructions.X86ExceptionModes(record) {
+		section(w, mode.Title)
+		exceptions := instructions.X86Exceptions(record, mode.Key)
+		vectorWidth := 0
+		for _, exception := range exceptions {
+			vectorWidth = max(vectorWidth, len(exception.Vector))
+		}
+		indent := "  "
+		if vectorWidth > 0 {
+			indent += strings.Repeat(" ", vectorWidth+2)
+		}
+		for _, exception := range exceptions {
+			for i, line := range wrap(exception.Condition, width-len(indent)) {
+				if i == 0 && vectorWidth > 0 {
+					fmt.Fprintf(w, "  %-*s  %s\n", vectorWidth, exception.Vector, line)
+				} else {
+					fmt.Fprintln(w, indent+line)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// writeJVMCard prints an instruction's opcode, format, operand stack,
+// description, exceptions and notes.
+func writeJVMCard(w io.Writer, width int, record instructions.JVMInstruction) error {
+	paragraphs(w, record.Mnemonic+" — "+record.Operation, "", width)
+	if record.SpecURL != "" {
+		fmt.Fprintln(w, record.SpecURL)
+	}
+
+	section(w, "Encoding")
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(table, "  Opcode\t0x%02x (%d)\n", record.OpcodeByte, record.OpcodeByte)
+	if record.Format != "" {
+		fmt.Fprintf(table, "  Format\t%s\n", record.Format)
+	}
+	for _, operand := range record.OperandLayout {
+		size := "variable"
+		if operand.Size > 0 {
+			size = byteCount(operand.Size)
+		}
+		fmt.Fprintf(table, "  Operand\t%s\t%s\t%s\n", operand.Name, operand.Type, size)
+	}
+	if record.Modifies != "" {
+		fmt.Fprintf(table, "  Modifies\t%s\n", record.Modifies)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	section(w, "Operand Stack")
+	fmt.Fprintf(w, "  %s → %s\n", record.OperandStackBefore, record.OperandStackAfter)
+
+	if record.Description != "" && record.Description != record.Operation {
+		section(w, "Description")
+		paragraphs(w, record.Description, "  ", width)
+	}
+	if record.LinkingExceptions != "" {
+		section(w, "Linking Exceptions")
+		paragraphs(w, record.LinkingExceptions, "  ", width)
+	}
+	if record.RuntimeExceptions != "" {
+		section(w, "Run-time Exceptions")
+		paragraphs(w, record.RuntimeExceptions, "  ", width)
+	}
+	if record.Notes != "" {
+		section(w, "Notes")
+		paragraphs(w, record.Notes, "  ", width)
+	}
+	return nil
+}
+
+func section(w io.Writer, heading string) {
+	fmt.Fprintf(w, "\n%s\n", strings.ToUpper(heading))
+}
+
+// paragraphs prints each line of text as a paragraph wrapped at width,
+// indented by indent.
+func paragraphs(w io.Writer, text, indent string, width int) {
+	for _, paragraph := range strings.Split(text, "\n") {
+		for _, line := range wrap(paragraph, width-len(indent)) {
+			fmt.Fprintln(w, indent+line)
+		}
+	}
+}
+
+// wrap breaks text into lines of at most width bytes, except for words
+// longer than that.
+func wrap(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func sortedFlags(flags map[string]string) []string {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"arisa/instructions"
+)
+
+// decodeResult is one matching form in decode output.
+type decodeResult struct {
+	Instruction string `json:"instruction"`
+	Opcode      string `json:"opcode"`
+	Class       string `json:"class"`
+	Prefixes    string `json:"prefixes,omitempty"`
+	Length      int    `json:"length"`
+	URL         string `json:"url"`
+}
+
+func decode(args []string) error {
+	flags := flag.NewFlagSet("decode", flag.ContinueOnError)
+	x86File := flags.String("x86", "", "x86 dataset file (default: the embedded snapshot)")
+	format := flags.String("format", "text", "output format: text or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	code, err := instructions.ParseHex(strings.Join(flags.Args(), " "))
+	if err != nil {
+		return err
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("decode needs the instruction bytes in hex")
+	}
+
+	set, err := loadX86(*x86File)
+	if err != nil {
+		return err
+	}
+	results := []decodeResult{}
+	for _, match := range set.Decode(code) {
+		results = append(results, decodeResult{
+			Instruction: match.Form.Instruction,
+			Opcode:      match.Form.Opcode,
+			Class:       match.Class,
+			Prefixes:    fmt.Sprintf("% X", match.Prefixes),
+			Length:      match.Length,
+			URL:         match.Instruction.URL,
+		})
+	}
+
+	switch *format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	case "text":
+		if len(results) == 0 {
+			fmt.Printf("No instruction forms match % X.\n", code)
+			if !hasForms(set) {
+				fmt.Println("The x86 dataset has no forms; regenerate it, or pass a newer one with -x86.")
+			}
+			return nil
+		}
+		writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for _, result := range results {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", result.Instruction, result.Opcode, byteCount(result.Length), result.URL)
+		}
+		return writer.Flush()
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+}
+
+// hasForms reports whether a dataset has the parsed opcode tables Decode
+// needs; older ones don't.
+func hasForms(set *instructions.X86) bool {
+	for _, record := range set.All() {
+		if len(record.Forms) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// byteCount formats a length in bytes, as "1 byte" or "3 bytes".
+func byteCount(n int) string {
+	if n == 1 {
+		return "1 byte"
+	}
+	return fmt.Sprintf("%d bytes", n)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// encodeResult is one candidate encoding in encode output.
+type encodeResult struct {
+	Bytes       string `json:"bytes"`
+	Instruction string `json:"instruction"`
+	Opcode      string `json:"opcode"`
+	URL         string `json:"url"`
+}
+
+func encode(args []string) error {
+	flags := flag.NewFlagSet("encode", flag.ContinueOnError)
+	x86File := flags.String("x86", "", "x86 dataset file (default: the embedded snapshot)")
+	format := flags.String("format", "text", "output format: text or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	mnemonic, list, _ := strings.Cut(strings.TrimSpace(strings.Join(flags.Args(), " ")), " ")
+	if mnemonic == "" {
+		return fmt.Errorf("encode needs an instruction, such as \"vpshufb ymm1, ymm2, [rax+8]\"")
+	}
+	var operands []string
+	if strings.TrimSpace(list) != "" {
+		operands = strings.Split(list, ",")
+	}
+
+	set, err := loadX86(*x86File)
+	if err != nil {
+		return err
+	}
+	encoded, err := set.Encode(mnemonic, operands...)
+	if err != nil {
+		return err
+	}
+	results := []encodeResult{}
+	for _, candidate := range encoded {
+		results = append(results, encodeResult{
+			Bytes:       fmt.Sprintf("% X", candidate.Bytes),
+			Instruction: candidate.Form.Instruction,
+			Opcode:      candidate.Form.Opcode,
+			URL:         candidate.Instruction.URL,
+		})
+	}
+
+	switch *format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	case "text":
+		if len(results) == 0 {
+			fmt.Println("No instruction forms take these operands.")
+			if !hasForms(set) {
+				fmt.Println("The x86 dataset has no forms; regenerate it, or pass a newer one with -x86.")
+			}
+			return nil
+		}
+		writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for _, result := range results {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", result.Bytes, result.Instruction, result.Opcode)
+		}
+		return writer.Flush()
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+}
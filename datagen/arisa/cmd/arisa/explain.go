@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"arisa/dataset"
+)
+
+// explain prints the passes behind an x86 record's fields, from the
+// provenance file the generator writes beside the dataset. Flags may follow
+// the mnemonic, as in "arisa explain x86 ADD --field flagsAffected".
+func explain(args []string) error {
+	flags := flag.NewFlagSet("explain", flag.ContinueOnError)
+	x86File := flags.String("x86", "", "x86 dataset file (default: the embedded snapshot)")
+	provenanceFile := flags.String("provenance", "", "provenance file (default: the one beside -x86)")
+	field := flags.String("field", "", "only explain the given field")
+
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if flags.NArg() == 0 {
+			break
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("usage: arisa explain [flags] isa mnemonic")
+	}
+	isa, mnemonic := positional[0], positional[1]
+	if isa != "x86" {
+		return fmt.Errorf("no provenance is recorded for %s", isa)
+	}
+
+	path := *provenanceFile
+	if path == "" {
+		if *x86File == "" {
+			return fmt.Errorf("the embedded snapshot has no provenance; pass -x86 or -provenance")
+		}
+		path = dataset.ProvenancePath(*x86File)
+	}
+	provenance, err := dataset.ReadProvenance(path)
+	if err != nil {
+		return err
+	}
+
+	set, err := loadX86(*x86File)
+	if err != nil {
+		return err
+	}
+	records := set.LookupMnemonic(mnemonic)
+	if len(records) == 0 {
+		return notFound("x86", mnemonic, nil)
+	}
+
+	for _, record := range records {
+		fields := provenance[record.URL]
+		fmt.Printf("%s %s (%s)\n", isa, strings.ToUpper(mnemonic), record.URL)
+
+		var names []string
+		if *field != "" {
+			name, ok := provenanceField(fields, *field)
+			if !ok {
+				return fmt.Errorf("no provenance recorded for field %q of %s", *field, record.URL)
+			}
+			names = []string{name}
+		} else {
+			for name := range fields {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+		}
+
+		for _, name := range names {
+			fmt.Printf("  %s:\n", name)
+			for i, step := range fields[name] {
+				line := fmt.Sprintf("    %d. %s", i+1, step.Pass)
+				if step.Source != "" {
+					line += " source=" + step.Source
+				}
+				if step.Parser != "" {
+					line += " parser=" + step.Parser
+				}
+				if len(step.Inputs) > 0 {
+					line += " inputs=" + strings.Join(step.Inputs, ",")
+				}
+				fmt.Println(line)
+			}
+		}
+	}
+	return nil
+}
+
+// provenanceField finds a field by the name it was asked for, or with the
+// "Text" suffix the scraped prose fields carry, so "operation" finds
+// operationText.
+func provenanceField(fields map[string][]dataset.ProvenanceStep, name string) (string, bool) {
+	for _, candidate := range []string{name, name + "Text"} {
+		if _, ok := fields[candidate]; ok {
+			return candidate, true
+		}
+	}
+	return "", false
+}
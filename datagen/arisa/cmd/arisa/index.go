@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"arisa/instructions"
+)
+
+// index writes the full-text index beside each dataset file, where Load
+// picks it up so text search doesn't rebuild it on every run.
+func index(args []string) error {
+	flags := flag.NewFlagSet("index", flag.ContinueOnError)
+	x86File := flags.String("x86", "", "x86 dataset file to index")
+	jvmFile := flags.String("jvm", "", "JVM dataset file to index")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *x86File == "" && *jvmFile == "" {
+		return fmt.Errorf("index needs -x86 or -jvm")
+	}
+
+	if *x86File != "" {
+		set, err := instructions.LoadX86(*x86File)
+		if err != nil {
+			return err
+		}
+		if err := writeIndex(*x86File, set.BuildIndex()); err != nil {
+			return err
+		}
+	}
+	if *jvmFile != "" {
+		set, err := instructions.LoadJVM(*jvmFile)
+		if err != nil {
+			return err
+		}
+		if err := writeIndex(*jvmFile, set.BuildIndex()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeIndex(datasetFile string, index *instructions.Index) error {
+	path := instructions.IndexPath(datasetFile)
+	if err := instructions.WriteIndex(path, index); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s (%d records, %d terms)\n", path, index.Records, index.Terms())
+	return nil
+}
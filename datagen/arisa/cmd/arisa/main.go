@@ -0,0 +1,65 @@
+// Command arisa looks instructions up in the x86 and JVM datasets. It
+// reads the snapshot compiled into the binary unless pointed at a dataset
+// file:
+//
+//	arisa x86 vpshufb
+//	arisa jvm invokedynamic
+//	arisa search popcont
+//	arisa search -isa jvm -jvm ../java/jvm_instructions.json invokedynamc
+//	arisa decode 66 0f 38 17 c1
+//	arisa encode vpshufb ymm1, ymm2, [rax+8]
+//	arisa explain -x86 ../x86/x86.json x86 ADD --field flagsAffected
+//	arisa tui
+//	arisa serve -addr localhost:8080
+//
+// Full-text search with -text builds an index of the descriptions on each
+// run; `arisa index` saves one beside a dataset file to skip that.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"arisa/instructions"
+)
+
+var commands = map[string]func(args []string) error{
+	"x86":     x86Card,
+	"jvm":     jvmCard,
+	"search":  search,
+	"index":   index,
+	"decode":  decode,
+	"encode":  encode,
+	"explain": explain,
+	"tui":     tui,
+	"serve":   serve,
+}
+
+func main() {
+	if len(os.Args) < 2 || commands[os.Args[1]] == nil {
+		fmt.Fprintln(os.Stderr, "usage: arisa x86 [flags] mnemonic | jvm [flags] mnemonic | search [flags] query | decode [flags] hex | encode [flags] instruction | explain [flags] isa mnemonic | index [-x86 file] [-jvm file] | tui [flags] | serve [flags]")
+		os.Exit(2)
+	}
+	if err := commands[os.Args[1]](os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "arisa:", err)
+		os.Exit(1)
+	}
+}
+
+// loadX86 reads the x86 dataset at path, or the embedded one when path is
+// empty.
+func loadX86(path string) (*instructions.X86, error) {
+	if path == "" {
+		return instructions.EmbeddedX86()
+	}
+	return instructions.LoadX86(path)
+}
+
+// loadJVM reads the JVM dataset at path, or the embedded one when path is
+// empty.
+func loadJVM(path string) (*instructions.JVM, error) {
+	if path == "" {
+		return instructions.EmbeddedJVM()
+	}
+	return instructions.LoadJVM(path)
+}
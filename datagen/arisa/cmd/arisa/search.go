@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"arisa/instructions"
+)
+
+// searchResult is one line of search output.
+type searchResult struct {
+	ISA      string `json:"isa"`
+	Mnemonic string `json:"mnemonic"`
+	Summary  string `json:"summary"`
+	URL      string `json:"url,omitempty"`
+	Opcode   string `json:"opcode,omitempty"`
+}
+
+func search(args []string) error {
+	flags := flag.NewFlagSet("search", flag.ContinueOnError)
+	isa := flags.String("isa", "all", "dataset to search: x86, jvm or all")
+	x86File := flags.String("x86", "", "x86 dataset file (default: the embedded snapshot)")
+	jvmFile := flags.String("jvm", "", "JVM dataset file (default: the embedded snapshot)")
+	limit := flags.Int("limit", 10, "maximum results per dataset, or 0 for all")
+	format := flags.String("format", "text", "output format: text or json")
+	text := flags.Bool("text", false, "match every word against the description text instead of fuzzy-matching mnemonics")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	query := strings.Join(flags.Args(), " ")
+	if query == "" {
+		return fmt.Errorf("search needs a query")
+	}
+	if *isa != "all" && *isa != "x86" && *isa != "jvm" {
+		return fmt.Errorf("unknown -isa %q", *isa)
+	}
+
+	results := []searchResult{}
+	if *isa != "jvm" {
+		set, err := loadX86(*x86File)
+		if err != nil {
+			return err
+		}
+		find := set.FuzzySearch
+		if *text {
+			find = set.TextSearch
+		}
+		for _, record := range truncate(find(query), *limit) {
+			results = append(results, x86SearchResult(record))
+		}
+	}
+	if *isa != "x86" {
+		set, err := loadJVM(*jvmFile)
+		if err != nil {
+			return err
+		}
+		find := set.FuzzySearch
+		if *text {
+			find = set.TextSearch
+		}
+		for _, record := range truncate(find(query), *limit) {
+			results = append(results, jvmSearchResult(record))
+		}
+	}
+
+	switch *format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	case "text":
+		if len(results) == 0 {
+			fmt.Printf("No instructions match %q.\n", query)
+			return nil
+		}
+		writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for _, result := range results {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", result.ISA, result.Mnemonic, result.Summary)
+		}
+		return writer.Flush()
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+}
+
+func x86SearchResult(record instructions.X86Instruction) searchResult {
+	title := strings.Join(strings.Fields(record.InstructionName), " ")
+	mnemonic, summary, _ := strings.Cut(title, "—")
+	return searchResult{
+		ISA:      "x86",
+		Mnemonic: strings.TrimSpace(mnemonic),
+		Summary:  strings.TrimSpace(summary),
+		URL:      record.URL,
+	}
+}
+
+func jvmSearchResult(record instructions.JVMInstruction) searchResult {
+	return searchResult{
+		ISA:      "jvm",
+		Mnemonic: record.Mnemonic,
+		Summary:  record.Operation,
+		Opcode:   fmt.Sprintf("0x%02x", record.OpcodeByte),
+	}
+}
+
+func truncate[T any](records []T, limit int) []T {
+	if limit > 0 && len(records) > limit {
+		return records[:limit]
+	}
+	return records
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"arisa/instructions"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// apiServer answers the /v1 HTTP API from datasets loaded at startup.
+// They never change while it runs, so a response's ETag is a hash of its
+// body.
+type apiServer struct {
+	x86 *instructions.X86
+	jvm *instructions.JVM
+}
+
+// page is a paginated list response. Next is the URL of the following
+// page, if there is one.
+type page[T any] struct {
+	Total   int    `json:"total"`
+	Offset  int    `json:"offset"`
+	Limit   int    `json:"limit"`
+	Next    string `json:"next,omitempty"`
+	Records []T    `json:"records"`
+}
+
+type apiError struct {
+	Error string `json:"error"`
+}
+
+func serve(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	x86File := flags.String("x86", "", "x86 dataset file (default: the embedded snapshot)")
+	jvmFile := flags.String("jvm", "", "JVM dataset file (default: the embedded snapshot)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("usage: arisa serve [-addr host:port] [-x86 file] [-jvm file]")
+	}
+
+	x86, err := loadX86(*x86File)
+	if err != nil {
+		return err
+	}
+	jvm, err := loadJVM(*jvmFile)
+	if err != nil {
+		return err
+	}
+	api := &apiServer{x86: x86, jvm: jvm}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           api.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdown)
+	}()
+
+	fmt.Fprintf(os.Stderr, "arisa: serving on http://%s/v1/\n", *addr)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (a *apiServer) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/x86/instructions", a.x86Instructions)
+	mux.HandleFunc("GET /v1/x86/instructions/{mnemonic}", a.x86Instruction)
+	mux.HandleFunc("GET /v1/jvm/instructions", a.jvmInstructions)
+	mux.HandleFunc("GET /v1/jvm/instructions/{mnemonic}", a.jvmInstruction)
+	mux.HandleFunc("GET /v1/search", a.search)
+	return mux
+}
+
+func (a *apiServer) x86Instructions(w http.ResponseWriter, r *http.Request) {
+	records := a.x86.All()
+	if category := r.URL.Query().Get("category"); category != "" {
+		records = a.x86.Filter(func(record instructions.X86Instruction) bool {
+			return strings.EqualFold(record.Category, category) || strings.EqualFold(record.Taxonomy, category)
+		})
+	}
+	writePage(w, r, records)
+}
+
+// x86Instruction returns every page documenting a mnemonic, resolved as
+// LookupMnemonic does, so /v1/x86/instructions/jne answers with Jcc.
+func (a *apiServer) x86Instruction(w http.ResponseWriter, r *http.Request) {
+	mnemonic := r.PathValue("mnemonic")
+	records := a.x86.LookupMnemonic(mnemonic)
+	if len(records) == 0 {
+		writeAPIError(w, r, http.StatusNotFound, fmt.Sprintf("no x86 instruction %q", mnemonic))
+		return
+	}
+	writeJSONResponse(w, r, http.StatusOK, records)
+}
+
+func (a *apiServer) jvmInstructions(w http.ResponseWriter, r *http.Request) {
+	writePage(w, r, a.jvm.All())
+}
+
+func (a *apiServer) jvmInstruction(w http.ResponseWriter, r *http.Request) {
+	mnemonic := r.PathValue("mnemonic")
+	record, ok := a.jvm.Lookup(mnemonic)
+	if !ok {
+		writeAPIError(w, r, http.StatusNotFound, fmt.Sprintf("no JVM instruction %q", mnemonic))
+		return
+	}
+	writeJSONResponse(w, r, http.StatusOK, record)
+}
+
+// search answers /v1/search?q=...&isa=x86|jvm|all&text=true with the rows
+// arisa search prints, x86 first.
+func (a *apiServer) search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := strings.TrimSpace(query.Get("q"))
+	if q == "" {
+		writeAPIError(w, r, http.StatusBadRequest, "search needs a q parameter")
+		return
+	}
+	isa := query.Get("isa")
+	if isa == "" {
+		isa = "all"
+	}
+	if isa != "all" && isa != "x86" && isa != "jvm" {
+		writeAPIError(w, r, http.StatusBadRequest, fmt.Sprintf("unknown isa %q", isa))
+		return
+	}
+	text, _ := strconv.ParseBool(query.Get("text"))
+
+	results := []searchResult{}
+	if isa != "jvm" {
+		find := a.x86.FuzzySearch
+		if text {
+			find = a.x86.TextSearch
+		}
+		for _, record := range find(q) {
+			results = append(results, x86SearchResult(record))
+		}
+	}
+	if isa != "x86" {
+		find := a.jvm.FuzzySearch
+		if text {
+			find = a.jvm.TextSearch
+		}
+		for _, record := range find(q) {
+			results = append(results, jvmSearchResult(record))
+		}
+	}
+	writePage(w, r, results)
+}
+
+// writePage writes the slice of records the offset and limit parameters
+// select.
+func writePage[T any](w http.ResponseWriter, r *http.Request, records []T) {
+	query := r.URL.Query()
+	offset, limit := 0, defaultPageSize
+	if value := query.Get("offset"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			writeAPIError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid offset %q", value))
+			return
+		}
+		offset = n
+	}
+	if value := query.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxPageSize {
+			writeAPIError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid limit %q: want 1 to %d", value, maxPageSize))
+			return
+		}
+		limit = n
+	}
+
+	// Past the end every page is empty; clamping there also keeps
+	// offset+limit from overflowing.
+	offset = min(offset, len(records))
+	result := page[T]{Total: len(records), Offset: offset, Limit: limit, Records: records[offset:min(offset+limit, len(records))]}
+	if len(result.Records) == 0 {
+		result.Records = []T{}
+	}
+	if offset+limit < len(records) {
+		next := url.Values{}
+		for key, values := range query {
+			next[key] = values
+		}
+		next.Set("offset", strconv.Itoa(offset+limit))
+		next.Set("limit", strconv.Itoa(limit))
+		result.Next = r.URL.Path + "?" + next.Encode()
+	}
+	writeJSONResponse(w, r, http.StatusOK, result)
+}
+
+func writeAPIError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	writeJSONResponse(w, r, status, apiError{Error: message})
+}
+
+// writeJSONResponse encodes value and writes it with an ETag, or answers
+// 304 Not Modified when the request's If-None-Match already has it.
+func writeJSONResponse(w http.ResponseWriter, r *http.Request, status int, value any) {
+	body := new(bytes.Buffer)
+	encoder := json.NewEncoder(body)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "application/json; charset=utf-8")
+	if status == http.StatusOK {
+		sum := sha256.Sum256(body.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		header.Set("ETag", etag)
+		header.Set("Cache-Control", "no-cache")
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	w.WriteHeader(status)
+	w.Write(body.Bytes())
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, by the
+// weak comparison RFC 9110 prescribes for it.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
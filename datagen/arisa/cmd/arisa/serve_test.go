@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"arisa/instructions"
+)
+
+// testServer serves the API over a few x86 pages and JVM instructions.
+func testServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var x86 []instructions.X86Instruction
+	for _, mnemonic := range []string{"ADC", "ADD", "AND", "OR", "SUB"} {
+		x86 = append(x86, instructions.X86Instruction{
+			URL:             "https://www.felixcloutier.com/x86/" + mnemonic,
+			Category:        "General Purpose",
+			InstructionName: mnemonic + "— Test",
+		})
+	}
+	jvm := []instructions.JVMInstruction{
+		{Mnemonic: "nop", Opcode: "nop = 0 (0x00)", OpcodeByte: 0x00},
+		{Mnemonic: "iadd", Opcode: "iadd = 96 (0x60)", OpcodeByte: 0x60},
+	}
+
+	api := &apiServer{x86: instructions.NewX86(x86), jvm: instructions.NewJVM(jvm)}
+	server := httptest.NewServer(api.routes())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, target string, header http.Header) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest("GET", target, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestServePagination(t *testing.T) {
+	server := testServer(t)
+	tests := []struct {
+		query  string
+		status int
+		offset int
+		count  int
+		next   bool
+	}{
+		{"", http.StatusOK, 0, 5, false},
+		{"?limit=2", http.StatusOK, 0, 2, true},
+		{"?offset=4&limit=2", http.StatusOK, 4, 1, false},
+		{"?offset=100", http.StatusOK, 5, 0, false},
+		{fmt.Sprintf("?offset=%d&limit=%d", math.MaxInt, maxPageSize), http.StatusOK, 5, 0, false},
+		{"?offset=-1", http.StatusBadRequest, 0, 0, false},
+		{"?offset=x", http.StatusBadRequest, 0, 0, false},
+		{"?limit=0", http.StatusBadRequest, 0, 0, false},
+		{fmt.Sprintf("?limit=%d", maxPageSize+1), http.StatusBadRequest, 0, 0, false},
+	}
+	for _, test := range tests {
+		resp := get(t, server.URL+"/v1/x86/instructions"+test.query, nil)
+		if resp.StatusCode != test.status {
+			t.Errorf("%s: status %d, want %d", test.query, resp.StatusCode, test.status)
+			continue
+		}
+		if test.status != http.StatusOK {
+			var body apiError
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Error == "" {
+				t.Errorf("%s: error body %+v, %v", test.query, body, err)
+			}
+			continue
+		}
+
+		var body page[json.RawMessage]
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("%s: %v", test.query, err)
+		}
+		if body.Total != 5 || body.Offset != test.offset || len(body.Records) != test.count || (body.Next != "") != test.next {
+			t.Errorf("%s: total %d, offset %d, %d records, next %q", test.query, body.Total, body.Offset, len(body.Records), body.Next)
+		}
+	}
+}
+
+func TestServeNotModified(t *testing.T) {
+	server := testServer(t)
+	target := server.URL + "/v1/x86/instructions/add"
+
+	first := get(t, target, nil)
+	etag := first.Header.Get("ETag")
+	if first.StatusCode != http.StatusOK || etag == "" {
+		t.Fatalf("status %d, ETag %q", first.StatusCode, etag)
+	}
+
+	for _, match := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		resp := get(t, target, http.Header{"If-None-Match": {match}})
+		if resp.StatusCode != http.StatusNotModified {
+			t.Errorf("If-None-Match: %s: status %d, want 304", match, resp.StatusCode)
+		}
+	}
+	if resp := get(t, target, http.Header{"If-None-Match": {`"other"`}}); resp.StatusCode != http.StatusOK {
+		t.Errorf("stale If-None-Match: status %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestServeUnknownMnemonic(t *testing.T) {
+	server := testServer(t)
+	for _, path := range []string{"/v1/x86/instructions/nosuch", "/v1/jvm/instructions/nosuch"} {
+		resp := get(t, server.URL+path, nil)
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("%s: status %d, want 404", path, resp.StatusCode)
+			continue
+		}
+		var body apiError
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Error == "" {
+			t.Errorf("%s: error body %+v, %v", path, body, err)
+		}
+		if resp.Header.Get("ETag") != "" {
+			t.Errorf("%s: a 404 has an ETag", path)
+		}
+	}
+
+	if resp := get(t, server.URL+"/v1/jvm/instructions/iadd", nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("known JVM mnemonic: status %d, want 200", resp.StatusCode)
+	}
+}
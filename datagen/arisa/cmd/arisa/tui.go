@@ -0,0 +1,355 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"arisa/instructions"
+)
+
+// browserEntry is one instruction in the browser's list. Exactly one of
+// x86 and jvm is set.
+type browserEntry struct {
+	isa      string
+	mnemonic string
+	summary  string
+	category string
+	x86      *instructions.X86Instruction
+	jvm      *instructions.JVMInstruction
+}
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	dimStyle      = lipgloss.NewStyle().Faint(true)
+	paneStyle     = lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, false, true).PaddingLeft(1)
+)
+
+// browser is the bubbletea model behind arisa tui: the instruction list
+// on the left, filtered by the search box and the category, and the
+// selected instruction's card on the right.
+type browser struct {
+	x86     *instructions.X86
+	jvm     *instructions.JVM
+	entries []browserEntry
+
+	// categories are the filters tab cycles through; the first, "", is
+	// every category.
+	categories []string
+	category   int
+
+	search  textinput.Model
+	visible []int
+	cursor  int
+	offset  int
+
+	detail        viewport.Model
+	width, height int
+}
+
+func tui(args []string) error {
+	flags := flag.NewFlagSet("tui", flag.ContinueOnError)
+	isa := flags.String("isa", "all", "dataset to browse: x86, jvm or all")
+	x86File := flags.String("x86", "", "x86 dataset file (default: the embedded snapshot)")
+	jvmFile := flags.String("jvm", "", "JVM dataset file (default: the embedded snapshot)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("usage: arisa tui [-isa x86|jvm|all] [-x86 file] [-jvm file]")
+	}
+	if *isa != "all" && *isa != "x86" && *isa != "jvm" {
+		return fmt.Errorf("unknown -isa %q", *isa)
+	}
+
+	b := &browser{search: textinput.New()}
+	b.search.Prompt = "/"
+	b.search.Placeholder = "search"
+	if *isa != "jvm" {
+		set, err := loadX86(*x86File)
+		if err != nil {
+			return err
+		}
+		b.x86 = set
+		for _, record := range set.All() {
+			b.entries = append(b.entries, x86Entry(record))
+		}
+	}
+	if *isa != "x86" {
+		set, err := loadJVM(*jvmFile)
+		if err != nil {
+			return err
+		}
+		b.jvm = set
+		for _, record := range set.All() {
+			b.entries = append(b.entries, jvmEntry(record))
+		}
+	}
+
+	sort.SliceStable(b.entries, func(i, j int) bool {
+		if b.entries[i].isa != b.entries[j].isa {
+			return b.entries[i].isa < b.entries[j].isa
+		}
+		return b.entries[i].mnemonic < b.entries[j].mnemonic
+	})
+
+	seen := make(map[string]bool)
+	for _, entry := range b.entries {
+		seen[entry.category] = true
+	}
+	for category := range seen {
+		b.categories = append(b.categories, category)
+	}
+	sort.Strings(b.categories)
+	b.categories = append([]string{""}, b.categories...)
+	b.filter()
+
+	_, err := tea.NewProgram(b, tea.WithAltScreen()).Run()
+	return err
+}
+
+func x86Entry(record instructions.X86Instruction) browserEntry {
+	title := strings.Join(strings.Fields(record.InstructionName), " ")
+	mnemonic, summary, _ := strings.Cut(title, "—")
+	category := record.Taxonomy
+	if category == "" {
+		category = record.Category
+	}
+	return browserEntry{
+		isa:      "x86",
+		mnemonic: strings.TrimSpace(mnemonic),
+		summary:  strings.TrimSpace(summary),
+		category: "x86: " + category,
+		x86:      &record,
+	}
+}
+
+func jvmEntry(record instructions.JVMInstruction) browserEntry {
+	return browserEntry{
+		isa:      "jvm",
+		mnemonic: record.Mnemonic,
+		summary:  record.Operation,
+		category: "jvm",
+		jvm:      &record,
+	}
+}
+
+// key identifies a record across the copies search returns.
+func (e browserEntry) key() string {
+	if e.x86 != nil {
+		return "x86\x00" + e.x86.URL + "\x00" + e.x86.InstructionName
+	}
+	return "jvm\x00" + e.mnemonic
+}
+
+// filter recomputes the visible entries: all of them in mnemonic order
+// with no query, or the search results best first, and in either case
+// only those in the selected category.
+func (b *browser) filter() {
+	query := strings.TrimSpace(b.search.Value())
+	b.visible = b.visible[:0]
+	keep := func(i int) {
+		if category := b.categories[b.category]; category == "" || b.entries[i].category == category {
+			b.visible = append(b.visible, i)
+		}
+	}
+
+	if query == "" {
+		for i := range b.entries {
+			keep(i)
+		}
+	} else {
+		positions := make(map[string]int, len(b.entries))
+		for i, entry := range b.entries {
+			positions[entry.key()] = i
+		}
+		var results []browserEntry
+		if b.x86 != nil {
+			for _, record := range b.x86.FuzzySearch(query) {
+				results = append(results, x86Entry(record))
+			}
+		}
+		if b.jvm != nil {
+			for _, record := range b.jvm.FuzzySearch(query) {
+				results = append(results, jvmEntry(record))
+			}
+		}
+		for _, result := range results {
+			if i, ok := positions[result.key()]; ok {
+				keep(i)
+			}
+		}
+	}
+
+	b.cursor, b.offset = 0, 0
+	b.showSelected()
+}
+
+func (b *browser) listHeight() int {
+	return max(b.height-3, 1)
+}
+
+func (b *browser) listWidth() int {
+	return min(max(b.width*2/5, 24), 60)
+}
+
+// showSelected renders the selected entry's card into the detail pane.
+func (b *browser) showSelected() {
+	if b.cursor < 0 || b.cursor >= len(b.visible) {
+		b.detail.SetContent("")
+		return
+	}
+	entry := b.entries[b.visible[b.cursor]]
+	width := max(b.detail.Width, 20)
+
+	buffer := new(bytes.Buffer)
+	if entry.x86 != nil {
+		writeX86Card(buffer, width, *entry.x86, true)
+	} else {
+		writeJVMCard(buffer, width, *entry.jvm)
+	}
+	lines := strings.Split(strings.TrimRight(buffer.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, width, "…")
+	}
+	b.detail.SetContent(strings.Join(lines, "\n"))
+	b.detail.GotoTop()
+}
+
+func (b *browser) move(delta int) {
+	if len(b.visible) == 0 {
+		return
+	}
+	cursor := min(max(b.cursor+delta, 0), len(b.visible)-1)
+	if cursor == b.cursor {
+		return
+	}
+	b.cursor = cursor
+	if b.cursor < b.offset {
+		b.offset = b.cursor
+	} else if b.cursor >= b.offset+b.listHeight() {
+		b.offset = b.cursor - b.listHeight() + 1
+	}
+	b.showSelected()
+}
+
+func (b *browser) Init() tea.Cmd {
+	return nil
+}
+
+func (b *browser) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		b.width, b.height = msg.Width, msg.Height
+		b.detail.Width = max(b.width-b.listWidth()-3, 1)
+		b.detail.Height = b.listHeight() + 1
+		b.search.Width = b.listWidth() - 2
+		b.move(0)
+		b.showSelected()
+		return b, nil
+
+	case tea.KeyMsg:
+		if b.search.Focused() {
+			switch msg.String() {
+			case "ctrl+c":
+				return b, tea.Quit
+			case "enter", "up", "down":
+				b.search.Blur()
+			case "esc":
+				b.search.Blur()
+				b.search.SetValue("")
+				b.filter()
+				return b, nil
+			default:
+				var cmd tea.Cmd
+				before := b.search.Value()
+				b.search, cmd = b.search.Update(msg)
+				if b.search.Value() != before {
+					b.filter()
+				}
+				return b, cmd
+			}
+		}
+
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return b, tea.Quit
+		case "/":
+			return b, b.search.Focus()
+		case "esc":
+			if b.search.Value() != "" {
+				b.search.SetValue("")
+				b.filter()
+			}
+		case "up", "k":
+			b.move(-1)
+		case "down", "j":
+			b.move(1)
+		case "pgup":
+			b.move(-b.listHeight())
+		case "pgdown":
+			b.move(b.listHeight())
+		case "home", "g":
+			b.move(-len(b.visible))
+		case "end", "G":
+			b.move(len(b.visible))
+		case "tab":
+			b.category = (b.category + 1) % len(b.categories)
+			b.filter()
+		case "shift+tab":
+			b.category = (b.category + len(b.categories) - 1) % len(b.categories)
+			b.filter()
+		case "ctrl+d", "right", "l":
+			b.detail.HalfPageDown()
+		case "ctrl+u", "left", "h":
+			b.detail.HalfPageUp()
+		}
+	}
+	return b, nil
+}
+
+func (b *browser) View() string {
+	if b.width == 0 {
+		return ""
+	}
+	listWidth := b.listWidth()
+
+	var list []string
+	list = append(list, b.search.View())
+	for row := range b.listHeight() {
+		i := b.offset + row
+		if i >= len(b.visible) {
+			list = append(list, "")
+			continue
+		}
+		entry := b.entries[b.visible[i]]
+		line := entry.mnemonic
+		if b.x86 != nil && b.jvm != nil {
+			line = entry.isa + " " + line
+		}
+		line = ansi.Truncate(line+" "+dimStyle.Render(entry.summary), listWidth, "…")
+		if i == b.cursor {
+			line = selectedStyle.Render(ansi.Strip(line))
+		}
+		list = append(list, line)
+	}
+	left := lipgloss.NewStyle().Width(listWidth).Render(strings.Join(list, "\n"))
+
+	category := b.categories[b.category]
+	if category == "" {
+		category = "all categories"
+	}
+	header := titleStyle.Render("arisa") + dimStyle.Render(fmt.Sprintf("  %d of %d  %s", len(b.visible), len(b.entries), category))
+	footer := dimStyle.Render("/ search  tab category  ↑↓ select  ←→ scroll  q quit")
+	right := paneStyle.Render(b.detail.View())
+	return header + "\n" + lipgloss.JoinHorizontal(lipgloss.Top, left, right) + "\n" + footer
+}
@@ -0,0 +1,151 @@
+// Command release writes a SHA256SUMS file for published datasets and
+// optionally signs them with minisign or cosign, so consumers embedding the
+// data in security tooling can check it with the dataset package's
+// verification functions or the usual command-line tools:
+//
+//	go run ./cmd/release -sign minisign -key arisa.key ../x86/x86.json ../java/jvm_instructions.json
+//
+// Signing runs the minisign or cosign binary, which prompts for the key's
+// password as usual. With -verify, the files are checked instead.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"arisa/dataset"
+)
+
+func main() {
+	sumsFile := flag.String("sums", "", "checksum file to write or check (default: "+dataset.ChecksumsFilename+" beside the first file)")
+	signer := flag.String("sign", "", "also sign each file and the checksum file: minisign or cosign")
+	key := flag.String("key", "", "secret key to sign with, or public key to verify with")
+	verify := flag.Bool("verify", false, "check the files against the checksum file, and their signatures when -sign is given, instead of writing them")
+	flag.Parse()
+
+	files := flag.Args()
+	if len(files) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: release [-sums file] [-sign minisign|cosign -key file] [-verify] file...")
+		os.Exit(2)
+	}
+	if *sumsFile == "" {
+		*sumsFile = filepath.Join(filepath.Dir(files[0]), dataset.ChecksumsFilename)
+	}
+
+	var err error
+	if *verify {
+		err = verifyRelease(files, *sumsFile, *signer, *key)
+	} else {
+		err = release(files, *sumsFile, *signer, *key)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "release:", err)
+		os.Exit(1)
+	}
+}
+
+func release(files []string, sumsFile, signer, key string) error {
+	sums, err := dataset.Checksums(files)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(sumsFile, sums, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", sumsFile, err)
+	}
+	fmt.Printf("wrote %s\n", sumsFile)
+
+	if signer == "" {
+		return nil
+	}
+	if key == "" {
+		return fmt.Errorf("-sign needs -key")
+	}
+	for _, file := range append(files, sumsFile) {
+		if err := sign(signer, key, file); err != nil {
+			return fmt.Errorf("failed to sign %s: %w", file, err)
+		}
+		fmt.Printf("signed %s\n", signatureFile(signer, file))
+	}
+	return nil
+}
+
+func sign(signer, key, file string) error {
+	var cmd *exec.Cmd
+	switch signer {
+	case "minisign":
+		cmd = exec.Command("minisign", "-S", "-s", key, "-m", file, "-x", signatureFile(signer, file))
+	case "cosign":
+		cmd = exec.Command("cosign", "sign-blob", "--yes", "--key", key, "--output-signature", signatureFile(signer, file), file)
+	default:
+		return fmt.Errorf("unknown signer %q", signer)
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stderr, os.Stderr
+	return cmd.Run()
+}
+
+// signatureFile is where each tool puts a file's signature by default.
+func signatureFile(signer, file string) string {
+	if signer == "minisign" {
+		return file + ".minisig"
+	}
+	return file + ".sig"
+}
+
+func verifyRelease(files []string, sumsFile, signer, key string) error {
+	sums, err := os.ReadFile(sumsFile)
+	if err != nil {
+		return err
+	}
+	if signer != "" {
+		if err := verifySignature(signer, key, sumsFile); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range files {
+		if err := dataset.VerifyChecksum(file, sums); err != nil {
+			return err
+		}
+		if signer != "" {
+			if err := verifySignature(signer, key, file); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("%s: ok\n", file)
+	}
+	return nil
+}
+
+func verifySignature(signer, key, file string) error {
+	if key == "" {
+		return fmt.Errorf("-verify with -sign needs the public key in -key")
+	}
+	publicKey, err := os.ReadFile(key)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	signature, err := os.ReadFile(signatureFile(signer, file))
+	if err != nil {
+		return err
+	}
+
+	switch signer {
+	case "minisign":
+		err = dataset.VerifyMinisign(content, signature, string(publicKey))
+	case "cosign":
+		err = dataset.VerifyCosign(content, signature, publicKey)
+	default:
+		return fmt.Errorf("unknown signer %q", signer)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	return nil
+}
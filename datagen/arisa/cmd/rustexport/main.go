@@ -0,0 +1,394 @@
+// Command rustexport writes the x86 and JVM datasets as a Rust module, so
+// Rust disassemblers and emulators can vendor Arisa's data at build time.
+// The module has serde structs mirroring the JSON records, for loading the
+// full datasets, and const tables of the opcodes for code that only needs
+// those:
+//
+//	go run ./cmd/rustexport -x86 ../x86/x86.json -jvm ../java/jvm_instructions.json -out arisa_data.rs
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"arisa/arisadata"
+	"arisa/dataset"
+	"arisa/schema"
+)
+
+// rustKeywords can't be used as field names without the r# prefix.
+var rustKeywords = map[string]bool{
+	"as": true, "break": true, "const": true, "continue": true, "crate": true, "else": true, "enum": true,
+	"extern": true, "false": true, "fn": true, "for": true, "if": true, "impl": true, "in": true, "let": true,
+	"loop": true, "match": true, "mod": true, "move": true, "mut": true, "pub": true, "ref": true,
+	"return": true, "self": true, "static": true, "struct": true, "super": true, "trait": true, "true": true,
+	"type": true, "unsafe": true, "use": true, "where": true, "while": true, "async": true, "await": true,
+	"dyn": true, "abstract": true, "become": true, "box": true, "do": true, "final": true, "macro": true,
+	"override": true, "priv": true, "typeof": true, "unsized": true, "virtual": true, "yield": true, "try": true,
+}
+
+func main() {
+	x86File := flag.String("x86", "../x86/x86.json", "x86 dataset to export")
+	jvmFile := flag.String("jvm", "../java/jvm_instructions.json", "JVM dataset to export")
+	output := flag.String("out", "arisa_data.rs", "Rust module to write")
+	flag.Parse()
+
+	if err := export(*x86File, *jvmFile, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "rustexport:", err)
+		os.Exit(1)
+	}
+}
+
+func export(x86File, jvmFile, output string) error {
+	var x86 []arisadata.X86Instruction
+	if err := readDataset(x86File, &x86); err != nil {
+		return err
+	}
+	var jvm []schema.JVMInstruction
+	if err := readDataset(jvmFile, &jvm); err != nil {
+		return err
+	}
+
+	module := new(bytes.Buffer)
+	module.WriteString("// Code generated by rustexport. DO NOT EDIT.\n\n")
+	module.WriteString("// Arisa's x86 and JVM instruction data. Load the full datasets with the\n")
+	module.WriteString("// serde structs, or use the opcode tables directly.\n\n")
+	module.WriteString("use serde::{Deserialize, Deserializer, Serialize};\n")
+	module.WriteString("use std::collections::HashMap;\n\n")
+	module.WriteString(nullDefault)
+	module.WriteString(datasetEnvelope)
+
+	structs := &structWriter{written: make(map[reflect.Type]bool), out: module}
+	structs.write(reflect.TypeOf(arisadata.X86Instruction{}))
+	structs.write(reflect.TypeOf(schema.JVMInstruction{}))
+
+	writeX86Table(module, x86)
+	writeJVMTable(module, jvm)
+
+	if err := os.WriteFile(output, module.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	return nil
+}
+
+func readDataset(path string, records any) error {
+	content, err := dataset.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	content, _, err = dataset.Unwrap(content)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	if err := json.Unmarshal(content, records); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	return nil
+}
+
+// nullDefault lets list and map fields accept the null that Go writes for
+// an empty slice or map.
+const nullDefault = `fn null_default<'de, D, T>(deserializer: D) -> Result<T, D::Error>
+where
+    D: Deserializer<'de>,
+    T: Default + Deserialize<'de>,
+{
+    Ok(Option::<T>::deserialize(deserializer)?.unwrap_or_default())
+}
+
+`
+
+// datasetEnvelope reads a dataset file whether it is wrapped in its
+// metadata or, as datasets were before the envelope, a bare array.
+const datasetEnvelope = `/// A dataset file. Parse it with serde_json and take the records with
+/// into_records; older files are a bare array and have no metadata.
+#[derive(Debug, Clone, Deserialize)]
+#[serde(untagged)]
+pub enum Dataset<T> {
+    Envelope(Envelope<T>),
+    Records(Vec<T>),
+}
+
+impl<T> Dataset<T> {
+    pub fn into_records(self) -> Vec<T> {
+        match self {
+            Dataset::Envelope(envelope) => envelope.records,
+            Dataset::Records(records) => records,
+        }
+    }
+}
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+#[serde(rename_all = "camelCase")]
+pub struct Envelope<T> {
+    #[serde(default)]
+    pub schema_version: i64,
+    #[serde(default)]
+    pub generator: String,
+    #[serde(default)]
+    pub generator_version: String,
+    #[serde(default, deserialize_with = "null_default")]
+    pub sources: Vec<String>,
+    #[serde(default)]
+    pub scraped_at: String,
+    #[serde(default)]
+    pub count: i64,
+    pub records: Vec<T>,
+}
+
+`
+
+// structWriter emits a serde struct for a Go record type and, after it, for
+// every struct type its fields use. Missing fields take their defaults, so
+// datasets written before a field was added still load.
+type structWriter struct {
+	written map[reflect.Type]bool
+	out     *bytes.Buffer
+}
+
+func (w *structWriter) write(t reflect.Type) {
+	if w.written[t] {
+		return
+	}
+	w.written[t] = true
+
+	var fields strings.Builder
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		for _, nested := range w.structsIn(field.Type) {
+			defer w.write(nested)
+		}
+
+		rustType := w.rustType(field.Type)
+		var attributes []string
+		fieldName := snakeCase(field.Name)
+		if rustKeywords[fieldName] {
+			fieldName = "r#" + fieldName
+		}
+		if strings.TrimPrefix(fieldName, "r#") != name {
+			attributes = append(attributes, fmt.Sprintf("rename = %s", rustString(name)))
+		}
+		if kind := field.Type.Kind(); kind == reflect.Slice || kind == reflect.Map {
+			attributes = append(attributes, `deserialize_with = "null_default"`)
+		}
+		if len(attributes) > 0 {
+			fmt.Fprintf(&fields, "    #[serde(%s)]\n", strings.Join(attributes, ", "))
+		}
+		fmt.Fprintf(&fields, "    pub %s: %s,\n", fieldName, rustType)
+	}
+
+	w.out.WriteString("#[derive(Debug, Clone, Default, PartialEq, Serialize, Deserialize)]\n")
+	w.out.WriteString("#[serde(default)]\n")
+	fmt.Fprintf(w.out, "pub struct %s {\n%s}\n\n", rustTypeName(t.Name()), fields.String())
+}
+
+func (w *structWriter) structsIn(t reflect.Type) []reflect.Type {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		return w.structsIn(t.Elem())
+	case reflect.Struct:
+		return []reflect.Type{t}
+	}
+	return nil
+}
+
+func (w *structWriter) rustType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "Option<" + w.rustType(t.Elem()) + ">"
+	case reflect.Slice:
+		return "Vec<" + w.elementType(t.Elem()) + ">"
+	case reflect.Map:
+		return "HashMap<" + w.rustType(t.Key()) + ", " + w.elementType(t.Elem()) + ">"
+	case reflect.Struct:
+		return rustTypeName(t.Name())
+	case reflect.String:
+		return "String"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Uint8:
+		return "u8"
+	case reflect.Int:
+		return "i64"
+	}
+	return "serde_json::Value"
+}
+
+// elementType is rustType for the elements of a slice or map. Go writes a
+// nil slice or map there as null, and null_default only covers fields, so
+// nested ones are optional.
+func (w *structWriter) elementType(t reflect.Type) string {
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		return "Option<" + w.rustType(t) + ">"
+	}
+	return w.rustType(t)
+}
+
+func writeX86Table(out *bytes.Buffer, instructions []arisadata.X86Instruction) {
+	out.WriteString(`/// One opcode table row of an x86 instruction page.
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub struct X86FormEntry {
+    pub instruction: &'static str,
+    pub opcode: &'static str,
+    pub mnemonic: &'static str,
+}
+
+/// An x86 instruction page, by the mnemonic in its title.
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub struct X86Entry {
+    pub mnemonic: &'static str,
+    pub title: &'static str,
+    pub category: &'static str,
+    pub url: &'static str,
+    pub forms: &'static [X86FormEntry],
+}
+
+`)
+	sorted := make([]arisadata.X86Instruction, 0, len(instructions))
+	for _, inst := range instructions {
+		if inst.Error == "" && inst.Parent == "" {
+			sorted = append(sorted, inst)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].URL < sorted[j].URL })
+
+	out.WriteString("pub const X86_INSTRUCTIONS: &[X86Entry] = &[\n")
+	for _, inst := range sorted {
+		mnemonic, title, _ := strings.Cut(strings.Join(strings.Fields(inst.InstructionName), " "), "—")
+		fmt.Fprintf(out, "    X86Entry {\n        mnemonic: %s,\n        title: %s,\n        category: %s,\n        url: %s,\n        forms: &[",
+			rustString(strings.TrimSpace(mnemonic)), rustString(strings.TrimSpace(title)), rustString(inst.Category), rustString(inst.URL))
+		for i, form := range inst.Forms {
+			if i == 0 {
+				out.WriteString("\n")
+			}
+			fmt.Fprintf(out, "            X86FormEntry { instruction: %s, opcode: %s, mnemonic: %s },\n",
+				rustString(form.Instruction), rustString(form.Opcode), rustString(form.Mnemonic))
+			if i == len(inst.Forms)-1 {
+				out.WriteString("        ")
+			}
+		}
+		out.WriteString("],\n    },\n")
+	}
+	out.WriteString("];\n\n")
+	out.WriteString(`/// Returns the x86 pages whose title names the mnemonic, ignoring case.
+pub fn x86_lookup(mnemonic: &str) -> impl Iterator<Item = &'static X86Entry> + '_ {
+    X86_INSTRUCTIONS
+        .iter()
+        .filter(move |entry| entry.mnemonic.split('/').any(|name| name.eq_ignore_ascii_case(mnemonic)))
+}
+
+`)
+}
+
+func writeJVMTable(out *bytes.Buffer, instructions []schema.JVMInstruction) {
+	out.WriteString(`/// A JVM opcode and its one-line description.
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub struct JvmOpcode {
+    pub mnemonic: &'static str,
+    pub opcode: u8,
+    pub description: &'static str,
+    pub reserved: bool,
+}
+
+`)
+	sorted := make([]schema.JVMInstruction, 0, len(instructions))
+	for _, inst := range instructions {
+		if inst.Opcode == "" {
+			continue
+		}
+		// Datasets from before opcodeByte was added only have the
+		// "name = 180 (0xb4)" text.
+		if inst.OpcodeByte == 0 {
+			var name string
+			fmt.Sscanf(inst.Opcode, "%s = %d", &name, &inst.OpcodeByte)
+		}
+		sorted = append(sorted, inst)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].OpcodeByte < sorted[j].OpcodeByte })
+
+	out.WriteString("/// Every assigned JVM opcode, in opcode order.\npub const JVM_OPCODES: &[JvmOpcode] = &[\n")
+	for _, inst := range sorted {
+		fmt.Fprintf(out, "    JvmOpcode { mnemonic: %s, opcode: 0x%02x, description: %s, reserved: %t },\n",
+			rustString(inst.Mnemonic), inst.OpcodeByte, rustString(inst.Description), inst.Reserved)
+	}
+	out.WriteString("];\n\n")
+	out.WriteString(`/// Returns the JVM instruction an opcode byte encodes.
+pub fn jvm_opcode(opcode: u8) -> Option<&'static JvmOpcode> {
+    JVM_OPCODES
+        .binary_search_by_key(&opcode, |entry| entry.opcode)
+        .ok()
+        .map(|index| &JVM_OPCODES[index])
+}
+`)
+}
+
+// rustTypeName turns Go's upper-case acronyms into Rust's camel case, so
+// JVMOperand becomes JvmOperand.
+func rustTypeName(name string) string {
+	original := []rune(name)
+	runes := []rune(name)
+	for i := 1; i < len(runes); i++ {
+		next := i+1 < len(runes) && unicode.IsLower(original[i+1])
+		if unicode.IsUpper(original[i]) && unicode.IsUpper(original[i-1]) && !next {
+			runes[i] = unicode.ToLower(runes[i])
+		}
+	}
+	return string(runes)
+}
+
+// snakeCase converts a Go field name, so InstructionName becomes
+// instruction_name and SpecURL becomes spec_url.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			previous := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextLower) {
+				builder.WriteByte('_')
+			}
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+	return builder.String()
+}
+
+// rustString quotes text as a Rust string literal.
+func rustString(text string) string {
+	var builder strings.Builder
+	builder.WriteByte('"')
+	for _, r := range text {
+		switch {
+		case r == '"' || r == '\\':
+			builder.WriteByte('\\')
+			builder.WriteRune(r)
+		case r == '\n':
+			builder.WriteString(`\n`)
+		case r == '\r':
+			builder.WriteString(`\r`)
+		case r == '\t':
+			builder.WriteString(`\t`)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&builder, `\u{%x}`, r)
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
@@ -0,0 +1,154 @@
+// Package config holds the settings the scrapers used to hard-code. Each
+// scraper starts from its own defaults, which an optional TOML file and then
+// command-line flags override, so a run can change concurrency or where the
+// dataset goes without recompiling.
+//
+// A config file can set keys at the top level, which apply to every
+// scraper, and under a table named after one scraper:
+//
+//	request_timeout = "30s"
+//
+//	[x86]
+//	workers = 8
+//	output = "out/x86.json"
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+type Scraper struct {
+	Workers            int           `toml:"workers"`
+	RequestTimeout     time.Duration `toml:"request_timeout"`
+	Output             string        `toml:"output"`
+	BaseURL            string        `toml:"base_url"`
+	UserAgent          string        `toml:"user_agent"`
+	Proxy              string        `toml:"proxy"`
+	InsecureSkipVerify bool          `toml:"insecure_skip_verify"`
+	CAFile             string        `toml:"ca_file"`
+	TLSMinVersion      string        `toml:"tls_min_version"`
+	MaxResponseBytes   int64         `toml:"max_response_bytes"`
+}
+
+// Flags binds the shared flags to a flag set and resolves them against the
+// defaults and config file once the set has been parsed.
+type Flags struct {
+	fs       *flag.FlagSet
+	section  string
+	defaults Scraper
+	path     string
+	values   Scraper
+}
+
+// Bind registers -config, -timeout, -output, -base-url and the HTTP client
+// flags on fs, plus -workers when defaults.Workers is set; scrapers that
+// fetch a single page leave it zero. section names the scraper's table in
+// the config file.
+func Bind(fs *flag.FlagSet, section string, defaults Scraper) *Flags {
+	f := &Flags{fs: fs, section: section, defaults: defaults}
+	fs.StringVar(&f.path, "config", "", "read scraper settings from this TOML file")
+	if defaults.Workers > 0 {
+		fs.IntVar(&f.values.Workers, "workers", defaults.Workers, "number of pages to fetch concurrently")
+	}
+	fs.DurationVar(&f.values.RequestTimeout, "timeout", defaults.RequestTimeout, "timeout for each HTTP request")
+	fs.StringVar(&f.values.Output, "output", defaults.Output, "path of the generated dataset")
+	fs.StringVar(&f.values.BaseURL, "base-url", defaults.BaseURL, "URL to scrape from")
+	fs.StringVar(&f.values.UserAgent, "user-agent", defaults.UserAgent, "User-Agent header sent with every request")
+	fs.StringVar(&f.values.Proxy, "proxy", defaults.Proxy, "http, https or socks5 proxy URL (default: HTTP_PROXY/HTTPS_PROXY)")
+	fs.BoolVar(&f.values.InsecureSkipVerify, "insecure-skip-verify", defaults.InsecureSkipVerify, "don't verify server TLS certificates")
+	fs.StringVar(&f.values.CAFile, "ca-file", defaults.CAFile, "PEM file of extra CA certificates to trust")
+	fs.StringVar(&f.values.TLSMinVersion, "tls-min-version", defaults.TLSMinVersion, "lowest TLS version to accept: 1.0, 1.1, 1.2 or 1.3")
+	fs.Int64Var(&f.values.MaxResponseBytes, "max-response-size", defaults.MaxResponseBytes, "largest response body to accept after decompression, in bytes (0 for no limit)")
+	return f
+}
+
+// Resolve returns the effective settings: the defaults, overridden by the
+// config file when -config was given, overridden in turn by any flag set
+// explicitly on the command line.
+func (f *Flags) Resolve() (Scraper, error) {
+	cfg := f.defaults
+	if f.path != "" {
+		if err := cfg.load(f.path, f.section); err != nil {
+			return Scraper{}, err
+		}
+	}
+
+	f.fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "workers":
+			cfg.Workers = f.values.Workers
+		case "timeout":
+			cfg.RequestTimeout = f.values.RequestTimeout
+		case "output":
+			cfg.Output = f.values.Output
+		case "base-url":
+			cfg.BaseURL = f.values.BaseURL
+		case "user-agent":
+			cfg.UserAgent = f.values.UserAgent
+		case "proxy":
+			cfg.Proxy = f.values.Proxy
+		case "insecure-skip-verify":
+			cfg.InsecureSkipVerify = f.values.InsecureSkipVerify
+		case "ca-file":
+			cfg.CAFile = f.values.CAFile
+		case "tls-min-version":
+			cfg.TLSMinVersion = f.values.TLSMinVersion
+		case "max-response-size":
+			cfg.MaxResponseBytes = f.values.MaxResponseBytes
+		}
+	})
+
+	if err := cfg.validate(f.defaults.Workers > 0); err != nil {
+		return Scraper{}, err
+	}
+	return cfg, nil
+}
+
+// load overlays the file's top-level keys and then its section onto cfg.
+// Keys the file leaves out keep their current values.
+func (cfg *Scraper) load(path, section string) error {
+	var tables map[string]toml.Primitive
+	md, err := toml.DecodeFile(path, &tables)
+	if err != nil {
+		return fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	if _, err := toml.DecodeFile(path, cfg); err != nil {
+		return fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+	if table, ok := tables[section]; ok {
+		if err := md.PrimitiveDecode(table, cfg); err != nil {
+			return fmt.Errorf("failed to read [%s] in config %s: %w", section, path, err)
+		}
+	}
+	return nil
+}
+
+func (cfg Scraper) validate(needsWorkers bool) error {
+	if needsWorkers && cfg.Workers < 1 {
+		return fmt.Errorf("workers must be at least 1, got %d", cfg.Workers)
+	}
+	if cfg.RequestTimeout <= 0 {
+		return fmt.Errorf("request timeout must be positive, got %s", cfg.RequestTimeout)
+	}
+	if cfg.Output == "" {
+		return errors.New("output path must not be empty")
+	}
+	u, err := url.Parse(cfg.BaseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("base URL %q is not an absolute URL", cfg.BaseURL)
+	}
+	if cfg.MaxResponseBytes < 0 {
+		return fmt.Errorf("max response size must not be negative, got %d", cfg.MaxResponseBytes)
+	}
+	if cfg.UserAgent == "" {
+		return errors.New("user agent must not be empty")
+	}
+	return nil
+}
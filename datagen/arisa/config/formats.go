@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ParseFormats reads a --format value, a comma-separated list of dataset
+// formats, into a set. Every entry must be one of supported. JSON is always
+// in the set, listed or not, since the scrapers resume from it.
+func ParseFormats(value string, supported ...string) (map[string]bool, error) {
+	formats := map[string]bool{"json": true}
+	for _, format := range strings.Split(value, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format == "" || format == "json" {
+			continue
+		}
+		known := false
+		for _, name := range supported {
+			known = known || name == format
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown format %q (want one of json, %s)", format, strings.Join(supported, ", "))
+		}
+		formats[format] = true
+	}
+	return formats, nil
+}
+
+// FormatPath swaps the output's extension for the format's, so x86.json is
+// joined by x86.yaml or x86.msgpack.
+func FormatPath(output, format string) string {
+	return strings.TrimSuffix(output, filepath.Ext(output)) + "." + format
+}
@@ -0,0 +1,159 @@
+// Package dataset reads and writes the generators' dataset files, which may
+// be shipped plain or compressed with gzip or zstd. Readers don't need to
+// know which: Open finds whichever copy exists and decompresses it.
+package dataset
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compressions are the supported compressed copies, by file extension, in
+// the order Locate prefers them.
+var Compressions = []string{"zst", "gz"}
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// Locate returns path if it exists, or else the first compressed copy of it
+// (path.zst, path.gz) that does. The error is path's own when none exist.
+func Locate(path string) (string, error) {
+	_, err := os.Stat(path)
+	if err == nil || !os.IsNotExist(err) {
+		return path, err
+	}
+	for _, extension := range Compressions {
+		if _, statErr := os.Stat(path + "." + extension); statErr == nil {
+			return path + "." + extension, nil
+		}
+	}
+	return path, err
+}
+
+// Open opens the dataset at path, or its compressed copy, and returns a
+// reader of the decompressed content. The compression is recognized from
+// the content, not the name.
+func Open(path string) (io.ReadCloser, error) {
+	path, err := Locate(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	return &decompressor{Reader: reader, closers: []io.Closer{reader, file}}, nil
+}
+
+// NewReader decompresses r if it starts with a gzip or zstd header and
+// passes it through otherwise. Closing the result doesn't close r.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(r)
+	header, _ := buffered.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		reader, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzip header: %w", err)
+		}
+		return reader, nil
+	case bytes.HasPrefix(header, zstdMagic):
+		decoder, err := zstd.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read zstd header: %w", err)
+		}
+		return decoder.IOReadCloser(), nil
+	}
+	return io.NopCloser(buffered), nil
+}
+
+// ReadFile reads the whole decompressed dataset at path, like os.ReadFile.
+func ReadFile(path string) ([]byte, error) {
+	reader, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	content, err := io.ReadAll(reader)
+	if closeErr := reader.Close(); err == nil {
+		err = closeErr
+	}
+	return content, err
+}
+
+// Compress encodes content for the compressed copy with the given
+// extension, one of Compressions.
+func Compress(content []byte, extension string) ([]byte, error) {
+	var buffer bytes.Buffer
+	switch strings.ToLower(extension) {
+	case "gz":
+		writer, err := gzip.NewWriterLevel(&buffer, gzip.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		writer.Write(content)
+		if err := writer.Close(); err != nil {
+			return nil, fmt.Errorf("failed to gzip: %w", err)
+		}
+	case "zst":
+		encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(encoder.EncodeAll(content, nil))
+		encoder.Close()
+	default:
+		return nil, fmt.Errorf("unknown compression %q (want one of %s)", extension, strings.Join(Compressions, ", "))
+	}
+	return buffer.Bytes(), nil
+}
+
+// ParseCompressions reads a comma-separated list of extensions, such as a
+// --compress value, rejecting any that Compress doesn't support.
+func ParseCompressions(value string) ([]string, error) {
+	var extensions []string
+	for _, extension := range strings.Split(value, ",") {
+		extension = strings.ToLower(strings.TrimSpace(extension))
+		if extension == "" {
+			continue
+		}
+		known := false
+		for _, name := range Compressions {
+			known = known || name == extension
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown compression %q (want one of %s)", extension, strings.Join(Compressions, ", "))
+		}
+		extensions = append(extensions, extension)
+	}
+	return extensions, nil
+}
+
+// decompressor closes the decoder and then the file under it.
+type decompressor struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (d *decompressor) Close() error {
+	var errs []error
+	for _, closer := range d.closers {
+		errs = append(errs, closer.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,193 @@
+package dataset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// FieldChange is one top-level field that differs between two versions of
+// a record. Object fields, such as exceptions by mode, and fields compared
+// with DiffKeyed list the keys that were added, removed or changed; other
+// fields carry their encoded value before and after.
+type FieldChange struct {
+	Field   string          `json:"field"`
+	Before  json.RawMessage `json:"before,omitempty"`
+	After   json.RawMessage `json:"after,omitempty"`
+	Added   []string        `json:"added,omitempty"`
+	Removed []string        `json:"removed,omitempty"`
+	Changed []string        `json:"changed,omitempty"`
+}
+
+// DiffFields compares two records field by field as they encode to JSON,
+// skipping the fields named in ignore. Changes come back in field name
+// order.
+func DiffFields(before, after any, ignore ...string) ([]FieldChange, error) {
+	old, err := encodeFields(before)
+	if err != nil {
+		return nil, err
+	}
+	current, err := encodeFields(after)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range ignore {
+		delete(old, field)
+		delete(current, field)
+	}
+
+	names := make(map[string]bool)
+	for name := range old {
+		names[name] = true
+	}
+	for name := range current {
+		names[name] = true
+	}
+
+	var changes []FieldChange
+	for _, name := range sortedKeys(names) {
+		if change, ok := diffValue(name, old[name], current[name]); ok {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// DiffKeyed compares two lists whose elements are identified by key, such
+// as instruction forms by opcode and syntax, reporting which keys appear on
+// only one side and which elements under a shared key differ.
+func DiffKeyed[T any](field string, before, after []T, key func(T) string) (FieldChange, bool) {
+	index := func(elements []T) map[string]string {
+		encoded := make(map[string]string)
+		for _, element := range elements {
+			content, _ := json.Marshal(element)
+			encoded[key(element)] = string(content)
+		}
+		return encoded
+	}
+	return diffKeys(field, index(before), index(after))
+}
+
+// Summary describes the change in a few words for human-readable diffs.
+func (c FieldChange) Summary() string {
+	var parts []string
+	for _, key := range c.Added {
+		parts = append(parts, "+"+key)
+	}
+	for _, key := range c.Removed {
+		parts = append(parts, "-"+key)
+	}
+	for _, key := range c.Changed {
+		parts = append(parts, "~"+key)
+	}
+	if len(parts) > 0 {
+		return strings.Join(parts, ", ")
+	}
+
+	switch {
+	case isEmpty(c.Before):
+		return "set"
+	case isEmpty(c.After):
+		return "cleared"
+	}
+	var before, after string
+	if json.Unmarshal(c.Before, &before) == nil && json.Unmarshal(c.After, &after) == nil {
+		return fmt.Sprintf("edited (%d → %d characters)", len([]rune(before)), len([]rune(after)))
+	}
+	var beforeList, afterList []json.RawMessage
+	if json.Unmarshal(c.Before, &beforeList) == nil && json.Unmarshal(c.After, &afterList) == nil {
+		return fmt.Sprintf("changed (%d → %d entries)", len(beforeList), len(afterList))
+	}
+	return fmt.Sprintf("%s → %s", c.Before, c.After)
+}
+
+// WriteFieldChanges lists changes one per line under a changed record in a
+// human-readable diff.
+func WriteFieldChanges(w io.Writer, indent string, changes []FieldChange) {
+	for _, change := range changes {
+		fmt.Fprintf(w, "%s%s: %s\n", indent, change.Field, change.Summary())
+	}
+}
+
+func encodeFields(record any) (map[string]json.RawMessage, error) {
+	content, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func diffValue(name string, before, after json.RawMessage) (FieldChange, bool) {
+	if bytes.Equal(before, after) || (isEmpty(before) && isEmpty(after)) {
+		return FieldChange{}, false
+	}
+
+	var old, current map[string]json.RawMessage
+	if json.Unmarshal(orNull(before), &old) == nil && json.Unmarshal(orNull(after), &current) == nil && (old != nil || current != nil) {
+		encoded := func(fields map[string]json.RawMessage) map[string]string {
+			values := make(map[string]string, len(fields))
+			for key, value := range fields {
+				values[key] = string(value)
+			}
+			return values
+		}
+		return diffKeys(name, encoded(old), encoded(current))
+	}
+
+	return FieldChange{Field: name, Before: before, After: after}, true
+}
+
+func diffKeys(field string, before, after map[string]string) (FieldChange, bool) {
+	change := FieldChange{Field: field}
+	for key, value := range after {
+		previous, ok := before[key]
+		switch {
+		case !ok:
+			change.Added = append(change.Added, key)
+		case previous != value:
+			change.Changed = append(change.Changed, key)
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			change.Removed = append(change.Removed, key)
+		}
+	}
+	sort.Strings(change.Added)
+	sort.Strings(change.Removed)
+	sort.Strings(change.Changed)
+	return change, len(change.Added)+len(change.Removed)+len(change.Changed) > 0
+}
+
+// isEmpty treats a missing field like the null, "", [] or {} that
+// encoding/json writes for an empty one.
+func isEmpty(value json.RawMessage) bool {
+	switch strings.TrimSpace(string(value)) {
+	case "", "null", `""`, "[]", "{}", "false", "0":
+		return true
+	}
+	return false
+}
+
+func orNull(value json.RawMessage) json.RawMessage {
+	if len(value) == 0 {
+		return json.RawMessage("null")
+	}
+	return value
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
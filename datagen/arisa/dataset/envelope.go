@@ -0,0 +1,141 @@
+package dataset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metadata says where a dataset came from and which schema its records
+// follow, so consumers can tell how old a given file is.
+type Metadata struct {
+	SchemaVersion    int      `json:"schemaVersion"`
+	Generator        string   `json:"generator"`
+	GeneratorVersion string   `json:"generatorVersion"`
+	Sources          []string `json:"sources"`
+	ScrapedAt        string   `json:"scrapedAt"`
+	Count            int      `json:"count"`
+}
+
+// Envelope is a dataset as the generators write it: an object holding the
+// metadata and, under "records", the array that older datasets consisted
+// of on their own.
+type Envelope struct {
+	Metadata
+	Records any `json:"records"`
+}
+
+// NewMetadata describes count records generated now by this binary. For
+// reproducible builds, SOURCE_DATE_EPOCH overrides the time.
+func NewMetadata(generator string, schemaVersion int, sources []string, count int) Metadata {
+	scrapedAt := time.Now()
+	if epoch, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64); err == nil {
+		scrapedAt = time.Unix(epoch, 0)
+	}
+	return Metadata{
+		SchemaVersion:    schemaVersion,
+		Generator:        generator,
+		GeneratorVersion: GeneratorVersion(),
+		Sources:          sources,
+		ScrapedAt:        scrapedAt.UTC().Format(time.RFC3339),
+		Count:            count,
+	}
+}
+
+// StableMetadata returns metadata for records about to replace the dataset
+// in previousFile. When they encode the same as the records already there,
+// the previous scrapedAt and generatorVersion are kept, so re-running a
+// generator over unchanged pages rewrites the file byte for byte. A
+// previous version from a modified tree is replaced all the same, since no
+// commit can reproduce it.
+func StableMetadata(metadata Metadata, records any, previousFile string) Metadata {
+	content, err := os.ReadFile(previousFile)
+	if err != nil {
+		return metadata
+	}
+	previousRecords, previous, err := Unwrap(content)
+	if err != nil || previous == nil {
+		return metadata
+	}
+
+	// Both sides are compared decoded, so formatting and escaping don't
+	// count as changes.
+	encoded, err := json.Marshal(records)
+	if err != nil {
+		return metadata
+	}
+	var current, old any
+	if json.Unmarshal(encoded, &current) != nil || json.Unmarshal(previousRecords, &old) != nil {
+		return metadata
+	}
+	if reflect.DeepEqual(current, old) {
+		metadata.ScrapedAt = previous.ScrapedAt
+		if !strings.HasSuffix(previous.GeneratorVersion, dirtySuffix) {
+			metadata.GeneratorVersion = previous.GeneratorVersion
+		}
+	}
+	return metadata
+}
+
+// dirtySuffix marks a GeneratorVersion built from a tree with uncommitted
+// changes.
+const dirtySuffix = "-dirty"
+
+// GeneratorVersion is the running binary's module version, or the VCS
+// revision it was built from for development builds.
+func GeneratorVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	if info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+
+	var revision, modified string
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value
+		}
+	}
+	if revision == "" {
+		return "devel"
+	}
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	if modified == "true" {
+		revision += dirtySuffix
+	}
+	return revision
+}
+
+// Unwrap returns the records array of an encoded dataset and its metadata.
+// Legacy datasets are a bare array and have no metadata, so it is nil for
+// them.
+func Unwrap(content []byte) (json.RawMessage, *Metadata, error) {
+	if trimmed := bytes.TrimLeft(content, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '[' {
+		return content, nil, nil
+	}
+
+	var envelope struct {
+		Metadata
+		Records json.RawMessage `json:"records"`
+	}
+	if err := json.Unmarshal(content, &envelope); err != nil {
+		return nil, nil, err
+	}
+	if envelope.Records == nil {
+		return nil, nil, fmt.Errorf("dataset has no records")
+	}
+	return envelope.Records, &envelope.Metadata, nil
+}
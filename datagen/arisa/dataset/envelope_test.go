@@ -0,0 +1,62 @@
+package dataset
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewMetadataHonorsSourceDateEpoch(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	if got := NewMetadata("test", 1, nil, 0).ScrapedAt; got != "2023-11-14T22:13:20Z" {
+		t.Errorf("ScrapedAt = %s, want 2023-11-14T22:13:20Z", got)
+	}
+}
+
+func TestStableMetadata(t *testing.T) {
+	type record struct {
+		Name string `json:"name"`
+	}
+	records := []record{{"a <b>"}, {"c"}}
+	previous := Envelope{
+		Metadata: Metadata{SchemaVersion: 1, Generator: "test", GeneratorVersion: "v1", ScrapedAt: "2020-01-01T00:00:00Z", Count: 2},
+		Records:  records,
+	}
+	content, err := json.MarshalIndent(previous, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "dataset.json")
+	if err := os.WriteFile(file, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fresh := Metadata{SchemaVersion: 1, Generator: "test", GeneratorVersion: "v2-dirty", ScrapedAt: "2024-01-01T00:00:00Z", Count: 2}
+	kept := StableMetadata(fresh, records, file)
+	if kept.ScrapedAt != previous.ScrapedAt || kept.GeneratorVersion != previous.GeneratorVersion {
+		t.Errorf("unchanged records got %s %s, want the previous %s %s", kept.GeneratorVersion, kept.ScrapedAt, previous.GeneratorVersion, previous.ScrapedAt)
+	}
+
+	previous.GeneratorVersion = "v1-dirty"
+	content, err = json.MarshalIndent(previous, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if rebuilt := StableMetadata(fresh, records, file); rebuilt.GeneratorVersion != fresh.GeneratorVersion || rebuilt.ScrapedAt != previous.ScrapedAt {
+		t.Errorf("unchanged records from a dirty tree got %s %s, want %s %s", rebuilt.GeneratorVersion, rebuilt.ScrapedAt, fresh.GeneratorVersion, previous.ScrapedAt)
+	}
+
+	changed := StableMetadata(fresh, []record{{"a <b>"}, {"d"}}, file)
+	if !reflect.DeepEqual(changed, fresh) {
+		t.Errorf("changed records got %+v, want %+v", changed, fresh)
+	}
+
+	if missing := StableMetadata(fresh, records, filepath.Join(t.TempDir(), "missing.json")); !reflect.DeepEqual(missing, fresh) {
+		t.Errorf("no previous file got %+v, want %+v", missing, fresh)
+	}
+}
@@ -0,0 +1,212 @@
+package dataset
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// ChecksumsFilename is the sha256sum-format list a release writes beside
+// its datasets.
+const ChecksumsFilename = "SHA256SUMS"
+
+// Checksums lists the SHA-256 of each file by its base name, in the format
+// sha256sum writes and `sha256sum -c` checks.
+func Checksums(paths []string) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	for _, path := range paths {
+		sum, err := fileSHA256(path)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(buffer, "%s  %s\n", sum, filepath.Base(path))
+	}
+	return buffer.Bytes(), nil
+}
+
+// VerifyChecksum checks the file at path against its entry in a checksum
+// list from Checksums. A file the list doesn't mention fails.
+func VerifyChecksum(path string, sums []byte) error {
+	want, err := lookupChecksum(sums, filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	got, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("%s: SHA-256 mismatch: got %s, want %s", path, got, want)
+	}
+	return nil
+}
+
+// ReadFileVerified is ReadFile for a dataset that must match the checksum
+// list at sumsPath. The check covers the file exactly as published, before
+// any decompression, so path must name the copy that was checksummed.
+func ReadFileVerified(path, sumsPath string) ([]byte, error) {
+	sums, err := os.ReadFile(sumsPath)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	want, err := lookupChecksum(sums, filepath.Base(path))
+	if err != nil {
+		return nil, err
+	}
+	if got := sha256Hex(content); got != want {
+		return nil, fmt.Errorf("%s: SHA-256 mismatch: got %s, want %s", path, got, want)
+	}
+
+	reader, err := NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// VerifyMinisign checks a minisign signature file against content. The
+// public key is the base64 line of a minisign .pub file, or the whole file.
+// Both prehashed and legacy signatures are accepted, and the trusted
+// comment must carry a valid global signature.
+func VerifyMinisign(content, signature []byte, publicKey string) error {
+	keyLine := lastLine(publicKey)
+	key, err := base64.StdEncoding.DecodeString(keyLine)
+	if err != nil || len(key) != 42 || string(key[:2]) != "Ed" {
+		return errors.New("invalid minisign public key")
+	}
+	keyID, pub := key[2:10], ed25519.PublicKey(key[10:])
+
+	lines := strings.Split(strings.ReplaceAll(string(signature), "\r\n", "\n"), "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return errors.New("invalid minisign signature file")
+	}
+	sig, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(sig) != 74 {
+		return errors.New("invalid minisign signature")
+	}
+	algorithm, sigKeyID, sigBytes := string(sig[:2]), sig[2:10], sig[10:]
+	if !bytes.Equal(keyID, sigKeyID) {
+		return errors.New("minisign signature was made with a different key")
+	}
+
+	message := content
+	switch algorithm {
+	case "ED":
+		digest := blake2b.Sum512(content)
+		message = digest[:]
+	case "Ed":
+	default:
+		return fmt.Errorf("unsupported minisign algorithm %q", algorithm)
+	}
+	if !ed25519.Verify(pub, message, sigBytes) {
+		return errors.New("minisign signature does not match")
+	}
+
+	trustedComment := strings.TrimPrefix(lines[2], "trusted comment: ")
+	globalSig, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(globalSig) != ed25519.SignatureSize {
+		return errors.New("invalid minisign trusted comment signature")
+	}
+	if !ed25519.Verify(pub, append(append([]byte{}, sigBytes...), trustedComment...), globalSig) {
+		return errors.New("minisign trusted comment signature does not match")
+	}
+	return nil
+}
+
+// VerifyCosign checks a signature written by `cosign sign-blob --key`,
+// which is the base64 signature of the content's SHA-256, against the PEM
+// public key from `cosign generate-key-pair`.
+func VerifyCosign(content, signature, publicKeyPEM []byte) error {
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return errors.New("invalid cosign public key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("invalid cosign public key: %w", err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return errors.New("invalid cosign signature")
+	}
+
+	digest := sha256.Sum256(content)
+	var valid bool
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(key, digest[:], sig)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, content, sig)
+	default:
+		return fmt.Errorf("unsupported cosign key type %T", key)
+	}
+	if !valid {
+		return errors.New("cosign signature does not match")
+	}
+	return nil
+}
+
+func lookupChecksum(sums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		sum, file, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		// sha256sum marks binary-mode entries with a '*' before the name.
+		file = strings.TrimPrefix(strings.TrimLeft(file, " "), "*")
+		if file == name {
+			return strings.ToLower(sum), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no checksum listed for %s", name)
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func lastLine(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,45 @@
+package dataset
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ProvenanceStep is one pass in the chain that produced a field: the page
+// it was scraped from, or the pass that derived it and the fields it read.
+type ProvenanceStep struct {
+	Pass   string   `json:"pass"`
+	Source string   `json:"source,omitempty"`
+	Parser string   `json:"parser,omitempty"`
+	Inputs []string `json:"inputs,omitempty"`
+}
+
+// Provenance holds the steps behind every field of a dataset, by record URL
+// and then field name. It is kept in a file beside the dataset rather than
+// in each record, which only consumers tracing a field need to read.
+type Provenance map[string]map[string][]ProvenanceStep
+
+// ProvenancePath is where the provenance of the dataset at path is kept:
+// x86_provenance.json beside x86.json or its compressed copies.
+func ProvenancePath(path string) string {
+	for _, extension := range Compressions {
+		path = strings.TrimSuffix(path, "."+extension)
+	}
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return filepath.Join(filepath.Dir(path), base+"_provenance.json")
+}
+
+// ReadProvenance reads a provenance file, or its compressed copy.
+func ReadProvenance(path string) (Provenance, error) {
+	content, err := ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var provenance Provenance
+	if err := json.Unmarshal(content, &provenance); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	return provenance, nil
+}
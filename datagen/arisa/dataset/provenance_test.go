@@ -0,0 +1,20 @@
+package dataset
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestProvenancePath(t *testing.T) {
+	tests := map[string]string{
+		"x86.json":                   "x86_provenance.json",
+		"x86.json.zst":               "x86_provenance.json",
+		filepath.Join("out", "x.gz"): filepath.Join("out", "x_provenance.json"),
+		filepath.Join("data", "jvm_instructions.json"): filepath.Join("data", "jvm_instructions_provenance.json"),
+	}
+	for path, want := range tests {
+		if got := ProvenancePath(path); got != want {
+			t.Errorf("ProvenancePath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
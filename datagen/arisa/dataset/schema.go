@@ -0,0 +1,197 @@
+package dataset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+const schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema generates a JSON Schema describing a dataset of record's type, as
+// encoding/json writes it: an Envelope whose records are an array of them,
+// or that array on its own for legacy datasets. Fields without omitempty
+// are required, nil slices, maps and pointers may be null, and no other
+// properties are allowed, so a field added to the structs but not to a
+// consumer's copy of the schema shows up as a validation failure.
+func Schema(record any, id, title string) ([]byte, error) {
+	generator := &schemaGenerator{defs: make(map[string]any)}
+	records := map[string]any{
+		"type":  "array",
+		"items": generator.schemaFor(reflect.TypeOf(record)),
+	}
+	envelope := generator.structSchema(reflect.TypeOf(Envelope{}))
+	envelope["properties"].(map[string]any)["records"] = records
+	root := map[string]any{
+		"$schema": schemaDraft,
+		"$id":     id,
+		"title":   title,
+		"oneOf":   []any{envelope, records},
+	}
+	if len(generator.defs) > 0 {
+		root["$defs"] = generator.defs
+	}
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(root); err != nil {
+		return nil, fmt.Errorf("failed to encode schema: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+type schemaGenerator struct {
+	defs map[string]any
+}
+
+func (g *schemaGenerator) schemaFor(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(g.schemaFor(t.Elem()))
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return nullable(map[string]any{"type": "array", "items": g.schemaFor(t.Elem())})
+	case reflect.Map:
+		return nullable(map[string]any{"type": "object", "additionalProperties": g.schemaFor(t.Elem())})
+	case reflect.Struct:
+		return g.structRef(t)
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Uint8:
+		return map[string]any{"type": "integer", "minimum": 0, "maximum": 255}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	}
+	return map[string]any{}
+}
+
+// structRef describes each named struct once under $defs and refers to it
+// from everywhere else, which also handles self-referencing types.
+func (g *schemaGenerator) structRef(t reflect.Type) map[string]any {
+	name := t.Name()
+	if name == "" {
+		return g.structSchema(t)
+	}
+	if _, ok := g.defs[name]; !ok {
+		// Claim the name first so a field of the same type refers back
+		// to it instead of recursing forever.
+		g.defs[name] = nil
+		g.defs[name] = g.structSchema(t)
+	}
+	return map[string]any{"$ref": "#/$defs/" + name}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	required := []string{}
+	g.addFields(t, properties, &required)
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func (g *schemaGenerator) addFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.addFields(field.Type, properties, required)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = g.schemaFor(field.Type)
+		if !strings.Contains(","+options+",", ",omitempty,") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func nullable(schema map[string]any) map[string]any {
+	return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+}
+
+// Validator checks dataset files against a schema from Schema.
+type Validator struct {
+	schema *jsonschema.Schema
+}
+
+// NewValidator compiles a schema produced by Schema or read from a
+// published schema file.
+func NewValidator(schema []byte) (*Validator, error) {
+	document, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("urn:arisa:dataset.schema.json", document); err != nil {
+		return nil, fmt.Errorf("failed to load schema: %w", err)
+	}
+	compiled, err := compiler.Compile("urn:arisa:dataset.schema.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema: %w", err)
+	}
+	return &Validator{schema: compiled}, nil
+}
+
+// Validate checks an encoded dataset. The error lists every violation with
+// its location in the document.
+func (v *Validator) Validate(content []byte) error {
+	document, err := jsonschema.UnmarshalJSON(bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("failed to parse dataset: %w", err)
+	}
+	return v.schema.Validate(document)
+}
+
+// ValidateFile validates the dataset at path, or its compressed copy.
+func (v *Validator) ValidateFile(path string) error {
+	content, err := ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return v.Validate(content)
+}
+
+// ValidateFiles validates each file in turn, printing whether it passed and
+// every violation when it didn't, and fails if any file did.
+func (v *Validator) ValidateFiles(w io.Writer, files []string) error {
+	failed := 0
+	for _, file := range files {
+		if err := v.ValidateFile(file); err != nil {
+			fmt.Fprintf(w, "%s: invalid\n%v\n", file, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(w, "%s: ok\n", file)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed validation", failed, len(files))
+	}
+	return nil
+}
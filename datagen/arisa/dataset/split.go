@@ -0,0 +1,71 @@
+package dataset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// ManifestFilename is the index written beside a split dataset's files.
+const ManifestFilename = "manifest.json"
+
+// Manifest lists the files a dataset was split into, so consumers can load
+// just the groups they need.
+type Manifest struct {
+	Source string         `json:"source"`
+	Files  []ManifestFile `json:"files"`
+}
+
+// ManifestFile is one group's file, named relative to the manifest.
+type ManifestFile struct {
+	Group   string `json:"group"`
+	File    string `json:"file"`
+	Records int    `json:"records"`
+}
+
+// WriteSplit writes each group's records as a JSON array in dir/<group>.json
+// and a manifest indexing them, passing every file to write. Groups are
+// listed in name order; a record may belong to several groups.
+func WriteSplit[T any](dir, source string, groups map[string][]T, write func(path string, content []byte) error) (*Manifest, error) {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	manifest := &Manifest{Source: source, Files: []ManifestFile{}}
+	for _, name := range names {
+		content, err := encodeJSON(groups[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+		file := name + ".json"
+		if err := write(filepath.Join(dir, file), content); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file, err)
+		}
+		manifest.Files = append(manifest.Files, ManifestFile{Group: name, File: file, Records: len(groups[name])})
+	}
+
+	content, err := encodeJSON(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := write(filepath.Join(dir, ManifestFilename), content); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// encodeJSON indents like the generators' own datasets.
+func encodeJSON(value any) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,231 @@
+// Package docset writes datasets as Dash docsets: a bundle of HTML pages
+// with a SQLite search index, which Dash, Zeal, and Velocity all read for
+// offline lookup.
+package docset
+
+import (
+	"database/sql"
+	"fmt"
+	"html"
+	"html/template"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Info describes the docset to the documentation browser.
+type Info struct {
+	// Identifier names the bundle, and Family is the keyword that
+	// restricts a search to it, such as "x86:".
+	Identifier string
+	Name       string
+	Family     string
+}
+
+// Entry is a row of the search index. Type is one of the browser's entry
+// types, such as "Instruction", and Path is relative to the Documents
+// directory, optionally with an anchor.
+type Entry struct {
+	Name string
+	Type string
+	Path string
+}
+
+// Page is an HTML page in the bundle, laid out from sections so the
+// generators don't each need their own templates.
+type Page struct {
+	Path     string
+	Title    string
+	Subtitle string
+	Source   string
+	Sections []Section
+}
+
+// Section is a heading followed by whichever of its parts are set, in
+// field order. A table's first row is its header.
+type Section struct {
+	Heading    string
+	Paragraphs []string
+	Table      [][]string
+	List       []string
+	Code       string
+}
+
+const stylesheet = `body { font: 14px/1.5 -apple-system, "Segoe UI", sans-serif; margin: 1.5em; max-width: 60em; }
+table { border-collapse: collapse; margin: 0.5em 0; }
+th, td { border: 1px solid #ccc; padding: 0.2em 0.5em; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+pre { background: #f7f7f7; padding: 0.75em; overflow-x: auto; }
+.subtitle { color: #555; }
+`
+
+// pageTemplate marks each section with a dashAnchor, which the browsers
+// list as the page's table of contents.
+var pageTemplate = template.Must(template.New("page").Funcs(template.FuncMap{"anchor": url.PathEscape}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<link rel="stylesheet" href="style.css">
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{with .Subtitle}}<p class="subtitle">{{.}}</p>
+{{end}}{{with .Source}}<p>Source: <a href="{{.}}">{{.}}</a></p>
+{{end}}{{range .Sections}}
+<a name="//apple_ref/cpp/Section/{{anchor .Heading}}" class="dashAnchor"></a>
+<h2>{{.Heading}}</h2>
+{{range .Paragraphs}}<p>{{.}}</p>
+{{end}}{{with .Table}}<table>
+{{range $i, $row := .}}<tr>{{range $row}}{{if eq $i 0}}<th>{{.}}</th>{{else}}<td>{{.}}</td>{{end}}{{end}}</tr>
+{{end}}</table>
+{{end}}{{with .List}}<ul>
+{{range .}}<li>{{.}}</li>
+{{end}}</ul>
+{{end}}{{with .Code}}<pre><code>{{.}}</code></pre>
+{{end}}{{end}}
+</body>
+</html>
+`))
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Name}}</title>
+<link rel="stylesheet" href="style.css">
+</head>
+<body>
+<h1>{{.Name}}</h1>
+<ul>
+{{range .Pages}}<li><a href="{{.Path}}">{{.Title}}</a></li>
+{{end}}</ul>
+</body>
+</html>
+`))
+
+// Write builds the docset at target, which conventionally ends in
+// ".docset", replacing any previous build. An index.html listing the
+// pages in the given order is added as the docset's start page.
+func Write(target string, info Info, pages []Page, entries []Entry) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	// The bundle is built beside the target so a failed build leaves the
+	// previous one in place.
+	build, err := os.MkdirTemp(filepath.Dir(target), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(build)
+
+	resources := filepath.Join(build, "Contents", "Resources")
+	documents := filepath.Join(resources, "Documents")
+	if err := os.MkdirAll(documents, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(build, "Contents", "Info.plist"), infoPlist(info), 0644); err != nil {
+		return fmt.Errorf("failed to write Info.plist: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(documents, "style.css"), []byte(stylesheet), 0644); err != nil {
+		return fmt.Errorf("failed to write stylesheet: %w", err)
+	}
+	if err := writeTemplate(filepath.Join(documents, "index.html"), indexTemplate, struct {
+		Name  string
+		Pages []Page
+	}{info.Name, pages}); err != nil {
+		return err
+	}
+	for _, page := range pages {
+		if err := writeTemplate(filepath.Join(documents, filepath.FromSlash(page.Path)), pageTemplate, page); err != nil {
+			return err
+		}
+	}
+	if err := writeIndex(filepath.Join(resources, "docSet.dsidx"), entries); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(target); err != nil {
+		return fmt.Errorf("failed to remove previous docset: %w", err)
+	}
+	if err := os.Rename(build, target); err != nil {
+		return fmt.Errorf("failed to move docset into place: %w", err)
+	}
+	return nil
+}
+
+func writeTemplate(target string, tmpl *template.Template, data any) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	file, err := os.Create(target)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Base(target), err)
+	}
+	if err := tmpl.Execute(file, data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to render %s: %w", filepath.Base(target), err)
+	}
+	return file.Close()
+}
+
+// writeIndex creates the search index in the schema the browsers expect.
+func writeIndex(target string, entries []Entry) error {
+	db, err := sql.Open("sqlite3", target)
+	if err != nil {
+		return fmt.Errorf("failed to open search index: %w", err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`CREATE TABLE searchIndex(id INTEGER PRIMARY KEY, name TEXT, type TEXT, path TEXT);
+		CREATE UNIQUE INDEX anchor ON searchIndex (name, type, path);`); err != nil {
+		return fmt.Errorf("failed to create search index: %w", err)
+	}
+	insert, err := tx.Prepare(`INSERT OR IGNORE INTO searchIndex(name, type, path) VALUES (?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare insert: %w", err)
+	}
+	defer insert.Close()
+	for _, entry := range entries {
+		if _, err := insert.Exec(entry.Name, entry.Type, entry.Path); err != nil {
+			return fmt.Errorf("failed to index %s: %w", entry.Name, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit search index: %w", err)
+	}
+	return nil
+}
+
+func infoPlist(info Info) []byte {
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>%s</string>
+	<key>CFBundleName</key>
+	<string>%s</string>
+	<key>DocSetPlatformFamily</key>
+	<string>%s</string>
+	<key>isDashDocset</key>
+	<true/>
+	<key>dashIndexFilePath</key>
+	<string>index.html</string>
+	<key>DashDocSetFamily</key>
+	<string>dashtoc</string>
+	<key>isJavaScriptEnabled</key>
+	<false/>
+</dict>
+</plist>
+`, html.EscapeString(info.Identifier), html.EscapeString(info.Name), html.EscapeString(info.Family)))
+}
@@ -0,0 +1,102 @@
+package httpclient
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// ErrResponseTooLarge is returned while reading a body that grows past the
+// configured limit after decompression.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// decodingTransport asks for gzip or brotli and hands callers the decoded
+// body, capped at maxBytes. net/http only does this transparently for gzip,
+// and without a limit.
+type decodingTransport struct {
+	base     http.RoundTripper
+	maxBytes int64
+}
+
+func (t *decodingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept-Encoding", "gzip, br")
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body := resp.Body
+	switch encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))); encoding {
+	case "", "identity":
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(body)
+		if err != nil && !errors.Is(err, io.EOF) {
+			body.Close()
+			return nil, fmt.Errorf("failed to decode gzip response: %w", err)
+		}
+		if err == nil {
+			body = readCloser{gz, body}
+		}
+		t.markDecoded(resp)
+	case "br":
+		body = readCloser{brotli.NewReader(body), body}
+		t.markDecoded(resp)
+	default:
+		body.Close()
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+
+	if t.maxBytes > 0 {
+		body = &limitedBody{ReadCloser: body, remaining: t.maxBytes}
+	}
+	resp.Body = body
+	return resp, nil
+}
+
+func (t *decodingTransport) markDecoded(resp *http.Response) {
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// limitedBody fails rather than truncating, so an oversized page is an
+// error and not a silently partial parse.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// Probe for one more byte to tell a body that ends exactly at the
+		// limit from one that goes past it.
+		var probe [1]byte
+		n, err := b.ReadCloser.Read(probe[:])
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,95 @@
+// Package httpclient builds the http.Client the scrapers share, applying the
+// proxy, TLS and response size settings from their config. Clients speak
+// HTTP/2 where the server does and accept gzip and brotli bodies.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"arisa/config"
+)
+
+// New returns a client for cfg. Without an explicit proxy it honors
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY; http, https, socks5 and socks5h
+// proxy URLs are all supported. maxIdleConns should roughly match the
+// scraper's concurrency.
+func New(cfg config.Scraper, maxIdleConns int) (*http.Client, error) {
+	tlsConfig, err := tlsConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if cfg.Proxy != "" {
+		proxyURL, err := url.Parse(cfg.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse proxy URL: %w", err)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("unsupported proxy scheme %q", proxyURL.Scheme)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	// A custom TLSClientConfig turns off HTTP/2 unless it is asked for, and
+	// compression is left to decodingTransport so brotli works too.
+	transport := &http.Transport{
+		Proxy:              proxy,
+		TLSClientConfig:    tlsConfig,
+		ForceAttemptHTTP2:  true,
+		DisableCompression: true,
+		DisableKeepAlives:  false,
+		MaxIdleConns:       maxIdleConns,
+		IdleConnTimeout:    90 * time.Second,
+	}
+
+	return &http.Client{
+		Timeout:   cfg.RequestTimeout,
+		Transport: &decodingTransport{base: transport, maxBytes: cfg.MaxResponseBytes},
+	}, nil
+}
+
+func tlsConfig(cfg config.Scraper) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+
+	switch cfg.TLSMinVersion {
+	case "":
+	case "1.0":
+		tlsConfig.MinVersion = tls.VersionTLS10
+	case "1.1":
+		tlsConfig.MinVersion = tls.VersionTLS11
+	case "1.2":
+		tlsConfig.MinVersion = tls.VersionTLS12
+	case "1.3":
+		tlsConfig.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("unknown TLS version %q", cfg.TLSMinVersion)
+	}
+
+	// Extra CAs are added to the system pool, for proxies that intercept
+	// TLS with their own certificate.
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,139 @@
+package instructions
+
+import (
+	"path"
+	"strings"
+)
+
+// conditionCodes are the condition suffixes each cc family page documents
+// under one "xxxcc" title, such as JNE and JNZ on the Jcc page.
+var conditionCodes = map[string][]string{
+	"J":     {"O", "NO", "B", "C", "NAE", "AE", "NB", "NC", "E", "Z", "NE", "NZ", "BE", "NA", "A", "NBE", "S", "NS", "P", "PE", "NP", "PO", "L", "NGE", "GE", "NL", "LE", "NG", "G", "NLE", "CXZ", "ECXZ", "RCXZ"},
+	"SET":   {"O", "NO", "B", "C", "NAE", "AE", "NB", "NC", "E", "Z", "NE", "NZ", "BE", "NA", "A", "NBE", "S", "NS", "P", "PE", "NP", "PO", "L", "NGE", "GE", "NL", "LE", "NG", "G", "NLE"},
+	"CMOV":  {"O", "NO", "B", "C", "NAE", "AE", "NB", "NC", "E", "Z", "NE", "NZ", "BE", "NA", "A", "NBE", "S", "NS", "P", "PE", "NP", "PO", "L", "NGE", "GE", "NL", "LE", "NG", "G", "NLE"},
+	"FCMOV": {"B", "E", "BE", "U", "NB", "NE", "NBE", "NU"},
+	"LOOP":  {"E", "Z", "NE", "NZ"},
+}
+
+// attMnemonics are the AT&T names that aren't just the Intel mnemonic
+// with a size suffix.
+var attMnemonics = map[string]string{
+	"CBTW":   "CBW",
+	"CWTL":   "CWDE",
+	"CLTQ":   "CDQE",
+	"CWTD":   "CWD",
+	"CLTD":   "CDQ",
+	"CQTO":   "CQO",
+	"MOVABS": "MOV",
+	"MOVSLQ": "MOVSXD",
+	"LJMP":   "JMP",
+	"LCALL":  "CALL",
+	"LRET":   "RET",
+}
+
+// LookupMnemonic is Lookup for a name as it appears in real code rather
+// than as the page titles spell it. After an exact match it tries, in order,
+// the cc family page for a condition-code mnemonic such as "jne" or
+// "cmovz", the Intel name for an AT&T one such as "movl", "movzbl" or
+// "cltq", the names of alias pages merged into another record, and the
+// legacy page for a VEX mnemonic such as "vpshufb" when no form names it.
+func (s *X86) LookupMnemonic(name string) []X86Instruction {
+	return s.at(s.resolve(name))
+}
+
+// resolve finds the positions of the records LookupMnemonic returns.
+func (s *X86) resolve(name string) []int {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	for _, candidate := range append([]string{name}, attCandidates(name)...) {
+		if positions, ok := s.byMnemonic[candidate]; ok {
+			return positions
+		}
+		if family := conditionFamily(candidate); family != "" {
+			if positions, ok := s.byMnemonic[family]; ok {
+				return positions
+			}
+		}
+		if positions, ok := s.byAlias[candidate]; ok {
+			return positions
+		}
+	}
+	// Datasets without forms only know a page's title mnemonics, and
+	// PSHUFB's page documents VPSHUFB too.
+	if legacy, ok := strings.CutPrefix(name, "V"); ok && len(legacy) > 2 {
+		return s.byMnemonic[legacy]
+	}
+	return nil
+}
+
+// conditionFamily names the cc page documenting an upper-case
+// condition-code mnemonic, such as "JCC" for JNE, or "" for any other.
+func conditionFamily(mnemonic string) string {
+	// Longest prefix first, so FCMOVB isn't read as F plus CMOVB.
+	for _, prefix := range []string{"FCMOV", "CMOV", "LOOP", "SET", "J"} {
+		condition, ok := strings.CutPrefix(mnemonic, prefix)
+		if !ok {
+			continue
+		}
+		for _, code := range conditionCodes[prefix] {
+			if condition == code {
+				return prefix + "CC"
+			}
+		}
+		return ""
+	}
+	return ""
+}
+
+// attCandidates lists the Intel names an upper-case AT&T mnemonic may
+// stand for, most specific first: a renamed instruction, a sign or zero
+// extension with both operand sizes in its suffix, then the mnemonic
+// without its operand size suffix.
+func attCandidates(mnemonic string) []string {
+	if intel, ok := attMnemonics[mnemonic]; ok {
+		return []string{intel}
+	}
+	// movabsq and lretq carry a size suffix on top of the rename.
+	if sized := strings.TrimRight(mnemonic, "BWLQ"); len(sized) == len(mnemonic)-1 {
+		if intel, ok := attMnemonics[sized]; ok {
+			return []string{intel}
+		}
+	}
+
+	var candidates []string
+	for _, extend := range []string{"MOVZ", "MOVS"} {
+		sizes, ok := strings.CutPrefix(mnemonic, extend)
+		if ok && len(sizes) == 2 && strings.ContainsRune("BW", rune(sizes[0])) && strings.ContainsRune("WLQ", rune(sizes[1])) {
+			candidates = append(candidates, extend+"X")
+		}
+	}
+
+	if len(mnemonic) < 3 {
+		return candidates
+	}
+	// x87 memory operands are sized s, l, t or ll, as in fadds, fildll
+	// and fstpt.
+	if strings.HasPrefix(mnemonic, "F") {
+		if trimmed, ok := strings.CutSuffix(mnemonic, "LL"); ok {
+			return append(candidates, trimmed)
+		}
+		if strings.ContainsRune("SLT", rune(mnemonic[len(mnemonic)-1])) {
+			candidates = append(candidates, mnemonic[:len(mnemonic)-1])
+		}
+	}
+	if strings.ContainsRune("BWLQ", rune(mnemonic[len(mnemonic)-1])) {
+		candidates = append(candidates, mnemonic[:len(mnemonic)-1])
+	}
+	return candidates
+}
+
+// aliasNames lists the upper-case mnemonics in an alias page URL, such as
+// XLAT and XLATB for ".../x86/xlat:xlatb".
+func aliasNames(url string) []string {
+	var names []string
+	for _, name := range strings.Split(path.Base(url), ":") {
+		if name != "" {
+			names = append(names, strings.ToUpper(name))
+		}
+	}
+	return names
+}
@@ -0,0 +1,658 @@
+package instructions
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// X86Match is a form whose encoding matches the start of some machine
+// code.
+type X86Match struct {
+	Instruction X86Instruction
+	Form        X86Form
+	// Class is how the instruction was encoded: "legacy", "REX2", "VEX",
+	// "EVEX" or "XOP".
+	Class string
+	// Prefixes are the legacy and REX prefix bytes before the opcode, in
+	// order.
+	Prefixes []byte
+	// Length is the instruction's length in bytes, which can be more
+	// than the code given if it was cut short.
+	Length int
+}
+
+var (
+	opcodeTokenPattern  = regexp.MustCompile(`^([0-9A-F]{2})(\+(rb|rw|rd|ro|i))?$`)
+	splitRegisterSuffix = regexp.MustCompile(`\+\s+(rb|rw|rd|ro|i)\b`)
+	gluedModRMPattern   = regexp.MustCompile(`\b([0-9A-F]{2})/([0-7r])\b`)
+	operandSizePattern  = regexp.MustCompile(`^(?:r|r/m|reg)(16|32|64)$`)
+	memoryOnlyPattern   = regexp.MustCompile(`^(?:m|mem|m\d+\S*|vm\d+\S*)$`)
+	memoryPattern       = regexp.MustCompile(`(?:^|/)(?:m|mem|m\d+\S*|vm\d+\S*)$`)
+	memorySizePattern   = regexp.MustCompile(`^m(16|32|64)$`)
+)
+
+// immediateSizes is the width in bytes of each immediate and code-offset
+// token of the opcode column.
+var immediateSizes = map[string]int{
+	"ib": 1, "iw": 2, "id": 4, "io": 8,
+	"cb": 1, "cw": 2, "cd": 4, "cp": 6, "co": 8, "ct": 10,
+	"is4": 1,
+}
+
+// sizedMnemonics are operandless legacy forms whose mnemonic names the
+// operand size, such as MOVSW and MOVSD, which share opcode A5.
+var sizedMnemonics = map[string]int{
+	"MOVSW": 16, "MOVSD": 32, "MOVSQ": 64,
+	"CMPSW": 16, "CMPSD": 32, "CMPSQ": 64,
+	"SCASW": 16, "SCASD": 32, "SCASQ": 64,
+	"LODSW": 16, "LODSD": 32, "LODSQ": 64,
+	"STOSW": 16, "STOSD": 32, "STOSQ": 64,
+	"INSW": 16, "INSD": 32,
+	"OUTSW": 16, "OUTSD": 32,
+	"CBW": 16, "CWDE": 32, "CDQE": 64,
+	"CWD": 16, "CDQ": 32, "CQO": 64,
+	"IRET": 16, "IRETD": 32, "IRETQ": 64,
+	"PUSHF": 16, "PUSHFD": 32, "PUSHFQ": 64,
+	"POPF": 16, "POPFD": 32, "POPFQ": 64,
+}
+
+// legacyPrefixes are the legacy prefix bytes, which may come in any order
+// before the opcode.
+var legacyPrefixes = map[byte]bool{
+	0xF0: true, 0xF2: true, 0xF3: true,
+	0x2E: true, 0x36: true, 0x3E: true, 0x26: true, 0x64: true, 0x65: true,
+	0x66: true, 0x67: true,
+}
+
+// vectorMaps are the opcode maps selected by the map field of VEX, EVEX
+// and XOP prefixes.
+var vectorMaps = map[byte]string{
+	1: "0F", 2: "0F38", 3: "0F3A", 4: "MAP4", 5: "MAP5", 6: "MAP6", 7: "MAP7",
+	8: "XOP08", 9: "XOP09", 10: "XOP0A",
+}
+
+// ParseHex reads machine code written as hex, the way it's pasted from
+// disassemblers and crash dumps: "66 0f 38 17 c1", "660F3817C1",
+// "0x66, 0x0f" and "\x66\x0f" all work.
+func ParseHex(text string) ([]byte, error) {
+	text = strings.NewReplacer("0x", " ", "0X", " ", `\x`, " ", ",", " ").Replace(text)
+	code, err := hex.DecodeString(strings.Join(strings.Fields(text), ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex: %w", err)
+	}
+	return code, nil
+}
+
+// Decode returns the forms whose encoding matches the instruction at the
+// start of code, decoded as 64-bit code. When the operand size prefixes
+// settle which of several forms it is, such as ADD r/m16 or ADD r/m32,
+// only that form is returned.
+func (s *X86) Decode(code []byte) []X86Match {
+	header, ok := decodeHeader(code)
+	if !ok {
+		return nil
+	}
+
+	s.patternsOnce.Do(s.parsePatterns)
+	var matches []X86Match
+	var sizes []int
+	var rex []bool
+	for i, record := range s.records {
+		for j, form := range record.Forms {
+			pattern := s.patterns[i][j]
+			if pattern == nil || !validIn64(form.Valid64) {
+				continue
+			}
+			length, ok := pattern.match(header, formOperands(form))
+			if !ok {
+				continue
+			}
+			matches = append(matches, X86Match{
+				Instruction: record,
+				Form:        form,
+				Class:       header.class,
+				Prefixes:    header.prefixes,
+				Length:      length,
+			})
+			sizes = append(sizes, decodedOperandSize(*pattern, form))
+			rex = append(rex, pattern.rex)
+		}
+	}
+	matches, sizes = preferREXForms(matches, sizes, rex)
+	return preferOperandSize(matches, sizes, header.operandSize())
+}
+
+// preferREXForms drops a form that matched alongside its REX twin, such
+// as "86 /r" beside "REX + 86 /r": both are XCHG r/m8, r8, and only the
+// REX one describes the registers a REX prefix selects.
+func preferREXForms(matches []X86Match, sizes []int, rex []bool) ([]X86Match, []int) {
+	twins := make(map[[2]string]bool)
+	for i, match := range matches {
+		if rex[i] {
+			twins[[2]string{match.Instruction.URL, match.Form.Instruction}] = true
+		}
+	}
+	if len(twins) == 0 {
+		return matches, sizes
+	}
+
+	var keptMatches []X86Match
+	var keptSizes []int
+	for i, match := range matches {
+		if !rex[i] && twins[[2]string{match.Instruction.URL, match.Form.Instruction}] {
+			continue
+		}
+		keptMatches = append(keptMatches, match)
+		keptSizes = append(keptSizes, sizes[i])
+	}
+	return keptMatches, keptSizes
+}
+
+// parsePatterns parses every form's opcode column once, for Decode. Forms
+// it can't parse are left nil.
+func (s *X86) parsePatterns() {
+	s.patterns = make([][]*x86Pattern, len(s.records))
+	for i, record := range s.records {
+		s.patterns[i] = make([]*x86Pattern, len(record.Forms))
+		for j, form := range record.Forms {
+			if pattern, ok := parseX86Pattern(form.Opcode); ok {
+				s.patterns[i][j] = &pattern
+			}
+		}
+	}
+}
+
+// x86Header is what comes before the opcode byte: prefixes, and the
+// opcode map and fields they select.
+type x86Header struct {
+	class    string
+	prefixes []byte
+	// prefix is the mandatory prefix the VEX-style pp field selects:
+	// "NP", "66", "F3" or "F2". Legacy instructions carry theirs among
+	// the prefixes.
+	prefix string
+	opMap  string
+	// rex is set when a REX or REX2 prefix is present, and w is its W bit
+	// or the VEX-style one.
+	rex bool
+	w   bool
+	// vectorLength is L, or L'L for EVEX, and broadcast is EVEX.b, which
+	// repurposes L'L for rounding on register operands.
+	vectorLength int
+	broadcast    bool
+	// opcode is the code from the opcode byte on, and offset is where
+	// it starts.
+	opcode []byte
+	offset int
+}
+
+func decodeHeader(code []byte) (x86Header, bool) {
+	header, ok := decodePrefixes(code)
+	header.offset = len(code) - len(header.opcode)
+	return header, ok && len(header.opcode) > 0
+}
+
+func decodePrefixes(code []byte) (x86Header, bool) {
+	header := x86Header{class: "legacy", opMap: "legacy"}
+	i := 0
+	for i < len(code) && legacyPrefixes[code[i]] {
+		i++
+	}
+	header.prefixes = code[:i]
+	if i >= len(code) {
+		return header, false
+	}
+
+	ppPrefixes := [4]string{"NP", "66", "F3", "F2"}
+	rest := code[i:]
+	switch {
+	case rest[0]&0xF0 == 0x40:
+		header.prefixes = code[:i+1]
+		header.rex = true
+		header.w = rest[0]&0x08 != 0
+		rest = rest[1:]
+	case rest[0] == 0xD5 && len(rest) > 1:
+		header.class = "REX2"
+		header.prefixes = code[:i+2]
+		header.rex = true
+		header.w = rest[1]&0x08 != 0
+		if rest[1]&0x80 != 0 {
+			header.opMap = "0F"
+		}
+		header.opcode = rest[2:]
+		return header, true
+	case rest[0] == 0xC5 && len(rest) > 2:
+		header.class = "VEX"
+		header.prefix = ppPrefixes[rest[1]&0x03]
+		header.opMap = "0F"
+		header.vectorLength = int(rest[1]>>2) & 1
+		header.opcode = rest[2:]
+		return header, true
+	case len(rest) > 3 && (rest[0] == 0xC4 || rest[0] == 0x8F && rest[1]&0x1F >= 8):
+		header.class = "VEX"
+		if rest[0] == 0x8F {
+			header.class = "XOP"
+		}
+		header.opMap = vectorMaps[rest[1]&0x1F]
+		header.w = rest[2]&0x80 != 0
+		header.prefix = ppPrefixes[rest[2]&0x03]
+		header.vectorLength = int(rest[2]>>2) & 1
+		header.opcode = rest[3:]
+		return header, header.opMap != ""
+	case rest[0] == 0x62 && len(rest) > 4:
+		header.class = "EVEX"
+		header.opMap = vectorMaps[rest[1]&0x07]
+		header.w = rest[2]&0x80 != 0
+		header.prefix = ppPrefixes[rest[2]&0x03]
+		header.vectorLength = int(rest[3]>>5) & 3
+		header.broadcast = rest[3]&0x10 != 0
+		header.opcode = rest[4:]
+		return header, header.opMap != ""
+	}
+
+	if len(rest) > 1 && rest[0] == 0x0F {
+		header.opMap = "0F"
+		rest = rest[1:]
+		if len(rest) > 1 && (rest[0] == 0x38 || rest[0] == 0x3A) {
+			header.opMap = fmt.Sprintf("0F%02X", rest[0])
+			rest = rest[1:]
+		}
+	}
+	header.opcode = rest
+	return header, true
+}
+
+// hasPrefix reports whether a legacy prefix byte was given.
+func (h x86Header) hasPrefix(prefix byte) bool {
+	for _, b := range h.prefixes {
+		if b == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// operandSize is the operand size the prefixes select in 64-bit mode.
+func (h x86Header) operandSize() int {
+	switch {
+	case h.w:
+		return 64
+	case h.hasPrefix(0x66):
+		return 16
+	}
+	return 32
+}
+
+// x86Pattern is a form's opcode column, such as "66 0F 38 17 /r" or
+// "VEX.128.66.0F38.WIG 00 /r", parsed for matching.
+type x86Pattern struct {
+	class  string
+	prefix string
+	opMap  string
+	// rex is set for legacy forms that need a REX prefix, such as
+	// "REX + 86 /r", which reaches SPL rather than AH.
+	rex          bool
+	w            string
+	vectorLength string
+	opcode       []byte
+	// register is set when the last opcode byte has a register added to
+	// it, as in "B8+rd".
+	register   bool
+	modrm      bool
+	reg        int
+	immediates []int
+	// is4 is set when the last immediate byte holds a register in its
+	// top four bits.
+	is4 bool
+}
+
+func parseX86Pattern(opcode string) (x86Pattern, bool) {
+	opcode = splitRegisterSuffix.ReplaceAllString(strings.ReplaceAll(opcode, "*", ""), "+$1")
+	// Some pages glue the ModRM token onto the opcode byte, as in "0F B0/r".
+	opcode = gluedModRMPattern.ReplaceAllString(opcode, "$1 /$2")
+	tokens := strings.Fields(opcode)
+	pattern := x86Pattern{class: "legacy", opMap: "legacy", reg: -1}
+	if len(tokens) == 0 {
+		return pattern, false
+	}
+
+	if class, _, _ := strings.Cut(tokens[0], "."); class == "VEX" || class == "EVEX" || class == "XOP" || class == "REX2" {
+		pattern.parseVectorPrefix(tokens[0])
+		tokens = tokens[1:]
+	}
+
+	for i, token := range tokens {
+		switch {
+		case pattern.class == "legacy" && token == "REX.W":
+			pattern.w = "W1"
+		case pattern.class == "legacy" && (token == "REX" || token == "REX.R"):
+			pattern.rex = true
+		case token == "REX" || token == "REX.R" || token == "+":
+		case pattern.class == "legacy" && (token == "NP" || token == "NFx"):
+			pattern.prefix = token
+		case pattern.class == "legacy" && len(pattern.opcode) == 0 && (token == "66" || token == "F2" || token == "F3") && i+1 < len(tokens):
+			pattern.prefix = token
+		case pattern.class == "legacy" && len(pattern.opcode) == 0 && pattern.opMap == "legacy" && token == "0F":
+			pattern.opMap = "0F"
+		case pattern.class == "legacy" && len(pattern.opcode) == 0 && pattern.opMap == "0F" && (token == "38" || token == "3A"):
+			pattern.opMap += token
+		case opcodeTokenPattern.MatchString(token) && !pattern.modrm && len(pattern.immediates) == 0:
+			match := opcodeTokenPattern.FindStringSubmatch(token)
+			b, _ := hex.DecodeString(match[1])
+			pattern.opcode = append(pattern.opcode, b[0])
+			pattern.register = match[2] != ""
+		case strings.HasPrefix(token, "+") && len(pattern.opcode) > 0:
+			pattern.register = true
+		case token == "/r":
+			pattern.modrm = true
+		case len(token) == 2 && token[0] == '/' && token[1] >= '0' && token[1] <= '7':
+			pattern.modrm = true
+			pattern.reg = int(token[1] - '0')
+		case immediateSizes[strings.TrimPrefix(token, "/")] > 0:
+			pattern.immediates = append(pattern.immediates, immediateSizes[strings.TrimPrefix(token, "/")])
+			pattern.is4 = strings.TrimPrefix(token, "/") == "is4"
+		}
+	}
+	return pattern, len(pattern.opcode) > 0
+}
+
+func (p *x86Pattern) parseVectorPrefix(token string) {
+	parts := strings.Split(token, ".")
+	p.class = parts[0]
+	p.opMap = "0F"
+	if p.class == "REX2" {
+		p.opMap = "legacy"
+	}
+	normalizeMap := func(part string) string {
+		switch part {
+		case "M5", "M6":
+			return "MAP" + part[1:]
+		case "08", "09", "0A":
+			return "XOP" + part
+		}
+		return part
+	}
+
+	for _, part := range parts[1:] {
+		switch {
+		case part == "66" || part == "F2" || part == "F3" || part == "NP":
+			p.prefix = part
+		case len(part) > 2 && (strings.HasPrefix(part, "66") || strings.HasPrefix(part, "F2") || strings.HasPrefix(part, "F3")):
+			p.prefix = part[:2]
+			p.opMap = normalizeMap(part[2:])
+		case p.class == "REX2" && (part == "M0" || part == "M1"):
+			p.opMap = map[string]string{"M0": "legacy", "M1": "0F"}[part]
+		case part == "0F" || part == "0F38" || part == "0F3A" || strings.HasPrefix(part, "MAP") || part == "M5" || part == "M6" ||
+			part == "08" || part == "09" || part == "0A":
+			p.opMap = normalizeMap(part)
+		case part == "W0" || part == "W1" || part == "WIG":
+			p.w = part
+		case part == "128" || part == "256" || part == "512" || strings.HasPrefix(part, "L"):
+			p.vectorLength = strings.TrimPrefix(part, "L")
+		}
+	}
+}
+
+// match reports whether the instruction after header is encoded by the
+// pattern, and its length.
+func (p x86Pattern) match(header x86Header, operands []string) (int, bool) {
+	if !p.matchPrefixes(header) {
+		return 0, false
+	}
+
+	code := header.opcode
+	if len(code) < len(p.opcode) {
+		return 0, false
+	}
+	for i, b := range p.opcode {
+		got := code[i]
+		if p.register && i == len(p.opcode)-1 {
+			got &^= 0x07
+		}
+		if got != b {
+			return 0, false
+		}
+	}
+
+	length := header.offset + len(p.opcode)
+	if p.modrm {
+		if len(code) <= len(p.opcode) {
+			return 0, false
+		}
+		modrm := code[len(p.opcode)]
+		if p.reg >= 0 && int(modrm>>3&7) != p.reg {
+			return 0, false
+		}
+		register := modrm>>6 == 3
+		if register && memoryOnly(operands) || !register && registerOnly(operands) {
+			return 0, false
+		}
+		if register && header.class == "EVEX" && header.broadcast {
+			// L'L selects rounding here, not a vector length.
+			header.vectorLength = -1
+		}
+		length += modrmLength(code[len(p.opcode):])
+	}
+	if !p.matchVectorLength(header.vectorLength) {
+		return 0, false
+	}
+	for _, size := range p.immediates {
+		length += size
+	}
+	return length, true
+}
+
+func (p x86Pattern) matchPrefixes(header x86Header) bool {
+	switch {
+	case p.class == "REX2":
+		if header.class != "REX2" {
+			return false
+		}
+	case p.class == "legacy":
+		// REX2 can be added to legacy instructions to reach the extended
+		// registers.
+		if header.class != "legacy" && header.class != "REX2" {
+			return false
+		}
+	case p.class != header.class:
+		return false
+	}
+	if p.opMap != header.opMap || p.rex && !header.rex {
+		return false
+	}
+
+	switch p.w {
+	case "W0":
+		if header.w {
+			return false
+		}
+	case "W1":
+		if !header.w {
+			return false
+		}
+	}
+
+	if header.class == "legacy" || header.class == "REX2" {
+		switch p.prefix {
+		case "66", "F2", "F3":
+			b, _ := hex.DecodeString(p.prefix)
+			return header.hasPrefix(b[0])
+		case "NP":
+			return !header.hasPrefix(0x66) && !header.hasPrefix(0xF2) && !header.hasPrefix(0xF3)
+		case "NFx":
+			return !header.hasPrefix(0xF2) && !header.hasPrefix(0xF3)
+		}
+		return true
+	}
+	prefix := p.prefix
+	if prefix == "" {
+		prefix = "NP"
+	}
+	return prefix == header.prefix
+}
+
+func (p x86Pattern) matchVectorLength(length int) bool {
+	if length < 0 {
+		return true
+	}
+	switch p.vectorLength {
+	case "128", "0", "Z", "LZ":
+		return length == 0
+	case "256", "1":
+		return length == 1
+	case "512":
+		return length == 2
+	}
+	return true
+}
+
+// modrmLength is the length of a ModRM byte and the SIB byte and
+// displacement it calls for, with 64-bit addressing.
+func modrmLength(code []byte) int {
+	modrm := code[0]
+	mod, rm := modrm>>6, modrm&7
+	length := 1
+	if mod != 3 && rm == 4 {
+		length++
+		if mod == 0 && len(code) > 1 && code[1]&7 == 5 {
+			length += 4
+		}
+	}
+	switch {
+	case mod == 0 && rm == 5:
+		length += 4
+	case mod == 1:
+		length++
+	case mod == 2:
+		length += 4
+	}
+	return length
+}
+
+// formOperands lists a form's operands without their decorations, such
+// as the {k1}{z} of "xmm1 {k1}{z}".
+func formOperands(form X86Form) []string {
+	operands := form.Operands
+	if len(operands) == 0 {
+		_, list, _ := strings.Cut(strings.TrimSpace(form.Instruction), " ")
+		operands = strings.Split(list, ",")
+	}
+	var cleaned []string
+	for _, operand := range operands {
+		if fields := strings.Fields(operand); len(fields) > 0 {
+			cleaned = append(cleaned, fields[0])
+		}
+	}
+	return cleaned
+}
+
+// memoryOnly reports whether a form's ModRM operand must be in memory, as
+// for MOVLPS xmm1, m64.
+func memoryOnly(operands []string) bool {
+	for _, operand := range operands {
+		if memoryOnlyPattern.MatchString(operand) {
+			return true
+		}
+	}
+	return false
+}
+
+// registerOnly reports whether a form's ModRM operand must be a register,
+// as for MOVHLPS xmm1, xmm2.
+func registerOnly(operands []string) bool {
+	for _, operand := range operands {
+		if memoryPattern.MatchString(operand) {
+			return false
+		}
+	}
+	return true
+}
+
+// formOperandSize is the general-purpose operand size a form is for, or
+// zero when it doesn't have one.
+func formOperandSize(form X86Form) int {
+	for _, operand := range formOperands(form) {
+		if match := operandSizePattern.FindStringSubmatch(operand); match != nil {
+			switch match[1] {
+			case "16":
+				return 16
+			case "32":
+				return 32
+			}
+			return 64
+		}
+		switch operand {
+		case "AX":
+			return 16
+		case "EAX":
+			return 32
+		case "RAX":
+			return 64
+		}
+	}
+	return 0
+}
+
+// decodedOperandSize is the operand size a form is for, as Decode sees
+// it. Legacy forms with only memory operands, such as MOVS m16, m16, take
+// theirs from the memory size, and those with none, such as MOVSW, from
+// the mnemonic, so a REX.W or 66 prefix picks one.
+func decodedOperandSize(pattern x86Pattern, form X86Form) int {
+	if size := formOperandSize(form); size != 0 || pattern.class != "legacy" {
+		return size
+	}
+	operands := formOperands(form)
+	if len(operands) == 0 {
+		return sizedMnemonics[form.Mnemonic]
+	}
+	size := 0
+	for _, operand := range operands {
+		match := memorySizePattern.FindStringSubmatch(operand)
+		if match == nil {
+			return 0
+		}
+		bits := map[string]int{"16": 16, "32": 32, "64": 64}[match[1]]
+		if size != 0 && bits != size {
+			return 0
+		}
+		size = bits
+	}
+	return size
+}
+
+// preferOperandSize keeps the matches for the operand size in effect when
+// there are any, falling back to 64-bit forms for instructions such as
+// PUSH that default to 64 bits. Forms without an operand size are always
+// kept.
+func preferOperandSize(matches []X86Match, sizes []int, size int) []X86Match {
+	for _, want := range []int{size, 64} {
+		var kept []X86Match
+		sized := false
+		for i, match := range matches {
+			if sizes[i] == want {
+				sized = true
+			}
+			if sizes[i] == want || sizes[i] == 0 {
+				kept = append(kept, match)
+			}
+		}
+		if sized {
+			return kept
+		}
+		if size != 32 {
+			break
+		}
+	}
+	return matches
+}
+
+// validIn64 reports whether a form can be encoded in 64-bit mode.
+func validIn64(validity string) bool {
+	switch validity {
+	case "Invalid", "N.E.", "N.S.":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package instructions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeOperandSize(t *testing.T) {
+	set := testX86()
+	tests := []struct {
+		code string
+		want []string
+	}{
+		{"f3 48 a5", []string{"MOVS m64, m64", "MOVSQ"}},
+		{"f3 a5", []string{"MOVS m32, m32", "MOVSD"}},
+		{"66 a5", []string{"MOVS m16, m16", "MOVSW"}},
+		{"a4", []string{"MOVS m8, m8", "MOVSB"}},
+		{"92", []string{"XCHG EAX, r32", "XCHG r32, EAX"}},
+		{"49 91", []string{"XCHG RAX, r64", "XCHG r64, RAX"}},
+		{"86 c1", []string{"XCHG r/m8, r8"}},
+		{"40 86 c1", []string{"XCHG r/m8, r8"}},
+		{"48 0f b1 0e", []string{"CMPXCHG r/m64, r64"}},
+	}
+	for _, test := range tests {
+		code, err := ParseHex(test.code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := forms(set.Decode(code)); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Decode(%s) = %q, want %q", test.code, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,807 @@
+package instructions
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// X86Encoded is one way to encode an instruction: a form that accepts
+// its operands, and the bytes that form encodes them as.
+type X86Encoded struct {
+	Instruction X86Instruction
+	Form        X86Form
+	Bytes       []byte
+}
+
+var (
+	decorationPattern   = regexp.MustCompile(`\{([^}]*)\}`)
+	vectorRegister      = regexp.MustCompile(`^(xmm|ymm|zmm)([0-9]|[12][0-9]|3[01])$`)
+	otherRegister       = regexp.MustCompile(`^(k|mm)([0-7])$`)
+	x87Register         = regexp.MustCompile(`^st(?:\(([0-7])\)|([0-7]))?$`)
+	formGPRPattern      = regexp.MustCompile(`^r(8|16|32|64)$`)
+	formRegisterPattern = regexp.MustCompile(`^(xmm|ymm|zmm|mm|k)\d*$`)
+	formMemoryPattern   = regexp.MustCompile(`^(?:m|mem|m(\d+)(fp|int|bcd)?|m\d+&\d+|m\d+:\d+|m\d+/\d+byte|m\d+byte)$`)
+	formImmediate       = regexp.MustCompile(`^imm(8|16|32|64)$`)
+	formRelative        = regexp.MustCompile(`^rel(8|16|32)$`)
+)
+
+// gprNames are the general-purpose registers by width, in encoding order.
+var gprNames = map[int][]string{
+	64: {"rax", "rcx", "rdx", "rbx", "rsp", "rbp", "rsi", "rdi", "r8", "r9", "r10", "r11", "r12", "r13", "r14", "r15"},
+	32: {"eax", "ecx", "edx", "ebx", "esp", "ebp", "esi", "edi", "r8d", "r9d", "r10d", "r11d", "r12d", "r13d", "r14d", "r15d"},
+	16: {"ax", "cx", "dx", "bx", "sp", "bp", "si", "di", "r8w", "r9w", "r10w", "r11w", "r12w", "r13w", "r14w", "r15w"},
+	8:  {"al", "cl", "dl", "bl", "spl", "bpl", "sil", "dil", "r8b", "r9b", "r10b", "r11b", "r12b", "r13b", "r14b", "r15b"},
+}
+
+// highByteRegisters can't be encoded in an instruction with a REX prefix,
+// where their numbers mean SPL to DIL instead.
+var highByteRegisters = map[string]int{"ah": 4, "ch": 5, "dh": 6, "bh": 7}
+
+// memorySizes are the size keywords of Intel-syntax memory operands.
+var memorySizes = map[string]int{
+	"byte": 8, "word": 16, "dword": 32, "qword": 64, "tbyte": 80, "tword": 80,
+	"xmmword": 128, "oword": 128, "ymmword": 256, "zmmword": 512,
+}
+
+// UnknownMnemonicError is Encode's error for a mnemonic no record
+// documents. Suggestions are the closest mnemonics FuzzySearch finds.
+type UnknownMnemonicError struct {
+	Mnemonic    string
+	Suggestions []string
+}
+
+func (e *UnknownMnemonicError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("unknown mnemonic %q", e.Mnemonic)
+	}
+	return fmt.Sprintf("unknown mnemonic %q; did you mean %s?", e.Mnemonic, strings.Join(e.Suggestions, ", "))
+}
+
+// x86Operand is an operand in Intel syntax, such as "eax", "xmm1 {k1}{z}",
+// "qword ptr [rbx+rcx*8+16]" or "0x10".
+type x86Operand struct {
+	kind string // "register", "memory" or "immediate"
+
+	// Registers have a class, such as "gpr" or "xmm", a name and a number.
+	class  string
+	name   string
+	number int
+	// size is a register's width, or a memory operand's if it was given,
+	// in bits.
+	size int
+	// highByte is set for AH to BH.
+	highByte bool
+
+	// Memory operands address base + index*scale + displacement, with -1
+	// for a missing base or index.
+	base, index int
+	scale       int
+	disp        int64
+	rip         bool
+	addr32      bool
+
+	value int64
+
+	// mask is the opmask register of an EVEX destination, and zeroing its
+	// {z}.
+	mask    int
+	zeroing bool
+}
+
+// Encode returns the candidate encodings of an instruction written in
+// Intel syntax, one for each form that accepts the operands, shortest
+// first:
+//
+//	set.Encode("vpshufb", "ymm1", "ymm2", "[rax+8]")
+//
+// Encodings are for 64-bit mode. A relative branch's operand is its
+// target address, taken as if the instruction were at address 0, so
+// "jmp 0x10" jumps 16 bytes past its own start. VSIB addressing,
+// broadcasts and the APX extended registers aren't supported, so forms
+// needing them never match. An error means the mnemonic is unknown, as an
+// *UnknownMnemonicError, or an operand couldn't be read.
+func (s *X86) Encode(mnemonic string, operands ...string) ([]X86Encoded, error) {
+	positions := s.resolve(mnemonic)
+	if len(positions) == 0 {
+		return nil, s.unknownMnemonic(mnemonic)
+	}
+
+	parsed := make([]x86Operand, len(operands))
+	for i, operand := range operands {
+		var err error
+		if parsed[i], err = parseX86Operand(operand); err != nil {
+			return nil, err
+		}
+	}
+
+	mnemonic = strings.ToUpper(strings.TrimSpace(mnemonic))
+	s.patternsOnce.Do(s.parsePatterns)
+	var encoded []X86Encoded
+	for _, i := range positions {
+		record := s.records[i]
+		for j, form := range record.Forms {
+			pattern := s.patterns[i][j]
+			if pattern == nil || !validIn64(form.Valid64) || !strings.EqualFold(formMnemonic(form), mnemonic) {
+				continue
+			}
+			code, ok := encodeForm(*pattern, form, parsed)
+			if !ok {
+				continue
+			}
+			duplicate := false
+			for _, previous := range encoded {
+				duplicate = duplicate || bytes.Equal(previous.Bytes, code)
+			}
+			if !duplicate {
+				encoded = append(encoded, X86Encoded{Instruction: record, Form: form, Bytes: code})
+			}
+		}
+	}
+	sort.SliceStable(encoded, func(i, j int) bool { return len(encoded[i].Bytes) < len(encoded[j].Bytes) })
+	return encoded, nil
+}
+
+// unknownMnemonic builds the error for a mnemonic LookupMnemonic doesn't
+// know, suggesting the first mnemonics of the best three fuzzy matches.
+func (s *X86) unknownMnemonic(mnemonic string) error {
+	err := &UnknownMnemonicError{Mnemonic: strings.TrimSpace(mnemonic)}
+	seen := make(map[string]bool)
+	for _, record := range s.FuzzySearch(mnemonic) {
+		if len(err.Suggestions) == 3 {
+			break
+		}
+		names := X86Mnemonics(record)
+		if len(names) > 0 && !seen[names[0]] {
+			seen[names[0]] = true
+			err.Suggestions = append(err.Suggestions, names[0])
+		}
+	}
+	return err
+}
+
+func formMnemonic(form X86Form) string {
+	if form.Mnemonic != "" {
+		return form.Mnemonic
+	}
+	mnemonic, _, _ := strings.Cut(strings.TrimSpace(form.Instruction), " ")
+	return mnemonic
+}
+
+func parseX86Operand(text string) (x86Operand, error) {
+	operand := x86Operand{base: -1, index: -1}
+	text = strings.ToLower(strings.TrimSpace(text))
+	for _, decoration := range decorationPattern.FindAllStringSubmatch(text, -1) {
+		switch value := strings.TrimSpace(decoration[1]); {
+		case value == "z":
+			operand.zeroing = true
+		case otherRegister.MatchString(value) && value[0] == 'k':
+			operand.mask = int(value[1] - '0')
+		default:
+			return operand, fmt.Errorf("unsupported operand decoration {%s} in %q", value, text)
+		}
+	}
+	text = strings.TrimSpace(decorationPattern.ReplaceAllString(text, ""))
+	if text == "" {
+		return operand, fmt.Errorf("empty operand")
+	}
+
+	if register, ok := lookupRegister(text); ok {
+		register.mask, register.zeroing = operand.mask, operand.zeroing
+		return register, nil
+	}
+	if strings.HasSuffix(text, "]") {
+		return parseMemoryOperand(text, operand)
+	}
+	value, err := strconv.ParseInt(text, 0, 64)
+	if err != nil {
+		unsigned, uerr := strconv.ParseUint(text, 0, 64)
+		if uerr != nil {
+			return operand, fmt.Errorf("unrecognized operand %q", text)
+		}
+		value = int64(unsigned)
+	}
+	operand.kind = "immediate"
+	operand.value = value
+	return operand, nil
+}
+
+func lookupRegister(name string) (x86Operand, bool) {
+	register := x86Operand{kind: "register", name: name, base: -1, index: -1}
+	for size, names := range gprNames {
+		for number, gpr := range names {
+			if gpr == name {
+				register.class, register.size, register.number = "gpr", size, number
+				return register, true
+			}
+		}
+	}
+	if number, ok := highByteRegisters[name]; ok {
+		register.class, register.size, register.number, register.highByte = "gpr", 8, number, true
+		return register, true
+	}
+	if match := vectorRegister.FindStringSubmatch(name); match != nil {
+		register.class = match[1]
+		register.number, _ = strconv.Atoi(match[2])
+		register.size = map[string]int{"xmm": 128, "ymm": 256, "zmm": 512}[match[1]]
+		return register, true
+	}
+	if match := otherRegister.FindStringSubmatch(name); match != nil {
+		register.class = match[1]
+		register.number = int(match[2][0] - '0')
+		register.size = 64
+		return register, true
+	}
+	if match := x87Register.FindStringSubmatch(name); match != nil {
+		register.class = "st"
+		register.number, _ = strconv.Atoi(match[1] + match[2])
+		register.size = 80
+		return register, true
+	}
+	return register, false
+}
+
+// parseMemoryOperand reads "[base + index*scale + disp]", optionally
+// sized with "qword ptr" and the like.
+func parseMemoryOperand(text string, operand x86Operand) (x86Operand, error) {
+	operand.kind = "memory"
+	open := strings.Index(text, "[")
+	if open < 0 {
+		return operand, fmt.Errorf("unrecognized operand %q", text)
+	}
+	size := strings.Fields(strings.TrimSuffix(strings.TrimSpace(text[:open]), "ptr"))
+	switch len(size) {
+	case 0:
+	case 1:
+		bits, ok := memorySizes[size[0]]
+		if !ok {
+			return operand, fmt.Errorf("unsupported memory size %q in %q", size[0], text)
+		}
+		operand.size = bits
+	default:
+		return operand, fmt.Errorf("unrecognized operand %q", text)
+	}
+
+	address := strings.ReplaceAll(text[open+1:len(text)-1], " ", "")
+	address = strings.ReplaceAll(address, "-", "+-")
+	addressSize := 0
+	for _, term := range strings.Split(address, "+") {
+		if term == "" {
+			continue
+		}
+		name, scaleText, scaled := strings.Cut(term, "*")
+		if _, isRegister := lookupRegister(name); !isRegister && scaled {
+			name, scaleText = scaleText, name
+		}
+		if term == "rip" {
+			operand.rip = true
+			continue
+		}
+		register, isRegister := lookupRegister(name)
+		if !isRegister {
+			disp, err := strconv.ParseInt(term, 0, 64)
+			if err != nil {
+				return operand, fmt.Errorf("unrecognized address term %q in %q", term, text)
+			}
+			operand.disp += disp
+			continue
+		}
+		if register.class != "gpr" || (register.size != 64 && register.size != 32) || (addressSize != 0 && register.size != addressSize) {
+			return operand, fmt.Errorf("invalid address register %s in %q", name, text)
+		}
+		addressSize = register.size
+
+		scale := 1
+		if scaled {
+			var err error
+			if scale, err = strconv.Atoi(scaleText); err != nil || (scale != 1 && scale != 2 && scale != 4 && scale != 8) {
+				return operand, fmt.Errorf("invalid scale in %q", text)
+			}
+		}
+		switch {
+		case !scaled && operand.base < 0:
+			operand.base = register.number
+		case operand.index < 0:
+			operand.index, operand.scale = register.number, scale
+		default:
+			return operand, fmt.Errorf("too many registers in %q", text)
+		}
+	}
+	if operand.index == 4 {
+		return operand, fmt.Errorf("%q can't use the stack pointer as an index", text)
+	}
+	if operand.rip && (operand.base >= 0 || operand.index >= 0) {
+		return operand, fmt.Errorf("%q can't combine rip with other registers", text)
+	}
+	operand.addr32 = addressSize == 32
+	return operand, nil
+}
+
+// fits reports whether an operand can be given for a form's operand, such
+// as "r/m32", "xmm2/m128" or "imm8".
+//
+// Immediates narrower than the form's operand size are sign-extended, so
+// only values that survive that fit them.
+func (o x86Operand) fits(formOperand string, operandSize int) bool {
+	if (o.mask != 0 || o.zeroing) && !strings.Contains(formOperand, "{k") {
+		return false
+	}
+	typ := strings.TrimSpace(decorationPattern.ReplaceAllString(strings.ReplaceAll(formOperand, "*", ""), ""))
+	if strings.HasPrefix(typ, "<") {
+		return o.kind == "register" && strings.EqualFold(strings.Trim(typ, "<>"), o.name)
+	}
+	lower := strings.ToLower(typ)
+	alternatives := strings.Split(lower, "/")
+	if rest, ok := strings.CutPrefix(lower, "r/m"); ok {
+		alternatives = []string{"r" + rest, "m" + rest}
+	}
+	for _, alternative := range alternatives {
+		if o.fitsAlternative(alternative, operandSize) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o x86Operand) fitsAlternative(typ string, operandSize int) bool {
+	switch o.kind {
+	case "register":
+		if match := formGPRPattern.FindStringSubmatch(typ); match != nil {
+			size, _ := strconv.Atoi(match[1])
+			return o.class == "gpr" && o.size == size
+		}
+		if typ == "reg" {
+			return o.class == "gpr" && o.size >= 16
+		}
+		if match := formRegisterPattern.FindStringSubmatch(typ); match != nil {
+			return o.class == match[1]
+		}
+		if typ == "st(i)" {
+			return o.class == "st"
+		}
+		if typ == "st(0)" || typ == "st" {
+			return o.class == "st" && o.number == 0
+		}
+		return typ == o.name
+	case "memory":
+		match := formMemoryPattern.FindStringSubmatch(typ)
+		if match == nil {
+			return false
+		}
+		if match[1] == "" || o.size == 0 {
+			return true
+		}
+		size, _ := strconv.Atoi(match[1])
+		return size == o.size
+	case "immediate":
+		if typ == "1" {
+			return o.value == 1
+		}
+		if formRelative.MatchString(typ) {
+			// Whether the displacement fits depends on the encoding's
+			// length, so encodeForm checks it.
+			return true
+		}
+		match := formImmediate.FindStringSubmatch(typ)
+		if match == nil {
+			return false
+		}
+		bits, _ := strconv.Atoi(match[1])
+		switch {
+		case bits == 64:
+			return true
+		case bits < operandSize:
+			return o.value >= -(1<<(bits-1)) && o.value < 1<<(bits-1)
+		}
+		return o.value >= -(1<<(bits-1)) && o.value < 1<<bits
+	}
+	return false
+}
+
+// operandRoles says where each of a form's operands is encoded: "reg" or
+// "rm" in the ModRM byte, "vvvv" in a VEX or EVEX prefix, "opcode" added
+// to the opcode byte, "imm" as an immediate, "rel" as a branch
+// displacement, "is4" in the top of the last immediate byte, or
+// "implicit" for operands the opcode implies.
+func operandRoles(pattern x86Pattern, form X86Form, operands []string) []string {
+	roles := make([]string, len(operands))
+	if len(form.OperandDetails) == len(operands) {
+		complete := true
+		for i, detail := range form.OperandDetails {
+			encoding := strings.ToLower(detail.Encoding)
+			typ := strings.ToLower(strings.TrimSpace(decorationPattern.ReplaceAllString(operands[i], "")))
+			switch {
+			case encoding == "":
+				complete = false
+			case formRelative.MatchString(typ):
+				roles[i] = "rel"
+			case fixedRegister(typ) != fixedRegisterEncoding(encoding):
+				// Datasets from before rows sharing an Op/En were told
+				// apart give XCHG EAX, r32 the encodings of XCHG r32,
+				// EAX, so details that put a fixed register anywhere
+				// but the operand naming it are not trusted.
+				complete = false
+			case strings.Contains(encoding, "modrm:reg"):
+				roles[i] = "reg"
+			case strings.Contains(encoding, "modrm:r/m"):
+				roles[i] = "rm"
+			case strings.Contains(encoding, "vvvv"):
+				roles[i] = "vvvv"
+			case strings.Contains(encoding, "[7:4]"):
+				roles[i] = "is4"
+			case strings.Contains(encoding, "opcode"):
+				roles[i] = "opcode"
+			case strings.HasPrefix(encoding, "imm") || strings.HasPrefix(encoding, "ib") || strings.HasPrefix(encoding, "iw"):
+				roles[i] = "imm"
+			default:
+				roles[i] = "implicit"
+			}
+		}
+		if complete {
+			return roles
+		}
+	}
+
+	// The Op/En column spells the roles out, one letter per operand, for
+	// most legacy and VEX forms: RM, MR, RVM, MI, OI and so on.
+	if opEn := form.OpEn; len(opEn) == len(operands) && strings.Trim(opEn, "RMVIO1C") == "" {
+		for i, letter := range opEn {
+			roles[i] = map[rune]string{'R': "reg", 'M': "rm", 'V': "vvvv", 'I': "imm", 'O': "opcode", '1': "implicit", 'C': "implicit"}[letter]
+			if letter == 'R' && strings.Contains(opEn[:i], "R") {
+				roles[i] = "is4"
+			}
+		}
+		return roles
+	}
+
+	// Otherwise go by the operand types, in the SDM's usual orders.
+	var registers []int
+	for i, operand := range operands {
+		typ := strings.ToLower(strings.TrimSpace(decorationPattern.ReplaceAllString(operand, "")))
+		switch {
+		case strings.HasPrefix(typ, "<") || typ == "1" || typ == "st(0)" || fixedRegister(typ):
+			roles[i] = "implicit"
+		case formRelative.MatchString(typ):
+			roles[i] = "rel"
+		case strings.HasPrefix(typ, "imm"):
+			roles[i] = "imm"
+		default:
+			registers = append(registers, i)
+		}
+	}
+	if pattern.register && len(registers) > 0 {
+		roles[registers[0]] = "opcode"
+		return roles
+	}
+	if !pattern.modrm || len(registers) == 0 {
+		return roles
+	}
+
+	rm := -1
+	for _, i := range registers {
+		if memoryPattern.MatchString(strings.ToLower(operands[i])) || strings.Contains(strings.ToLower(operands[i]), "/m") {
+			rm = i
+		}
+	}
+	if rm < 0 {
+		rm = registers[len(registers)-1]
+		if pattern.reg >= 0 && pattern.class == "legacy" {
+			rm = registers[0]
+		}
+	}
+	roles[rm] = "rm"
+	next := "reg"
+	if pattern.reg >= 0 {
+		next = "vvvv"
+	}
+	for _, i := range registers {
+		if i == rm {
+			continue
+		}
+		roles[i] = next
+		switch next {
+		case "reg":
+			next = "vvvv"
+			if pattern.class == "legacy" {
+				next = "implicit"
+			}
+		case "vvvv":
+			next = "is4"
+		}
+	}
+	return roles
+}
+
+// fixedRegister reports whether a form's operand is a particular
+// general-purpose register, such as AL or DX, rather than a placeholder
+// such as r8.
+func fixedRegister(typ string) bool {
+	register, ok := lookupRegister(typ)
+	return ok && register.class == "gpr" && !formGPRPattern.MatchString(typ)
+}
+
+// fixedRegisterEncoding reports whether an operand encoding names the
+// registers an operand must be, as "AL/AX/EAX/RAX" does, rather than
+// where in the instruction it goes.
+func fixedRegisterEncoding(encoding string) bool {
+	for _, name := range strings.Split(encoding, "/") {
+		if register, ok := lookupRegister(strings.TrimSpace(name)); !ok || register.class != "gpr" {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeForm encodes operands with a form, if the form takes them.
+func encodeForm(pattern x86Pattern, form X86Form, operands []x86Operand) ([]byte, bool) {
+	formOperands := form.Operands
+	if len(formOperands) == 0 && len(operands) > 0 {
+		_, list, _ := strings.Cut(strings.TrimSpace(form.Instruction), " ")
+		formOperands = strings.Split(list, ",")
+	}
+	if len(formOperands) != len(operands) {
+		return nil, false
+	}
+	for i, operand := range operands {
+		if !operand.fits(formOperands[i], formOperandSize(form)) {
+			return nil, false
+		}
+	}
+	switch {
+	case pattern.class == "XOP", pattern.class == "REX2", pattern.opMap == "MAP4" || strings.HasPrefix(pattern.opMap, "XOP"):
+		return nil, false
+	}
+
+	var reg, rm, vvvv, opcodeRegister, is4 *x86Operand
+	var immediates []int64
+	relative := -1
+	mask, zeroing := 0, false
+	for i, role := range operandRoles(pattern, form, formOperands) {
+		operand := &operands[i]
+		if operand.mask != 0 || operand.zeroing {
+			mask, zeroing = operand.mask, operand.zeroing
+		}
+		switch role {
+		case "reg":
+			reg = operand
+		case "rm":
+			rm = operand
+		case "vvvv":
+			vvvv = operand
+		case "opcode":
+			opcodeRegister = operand
+		case "is4":
+			is4 = operand
+		case "imm":
+			immediates = append(immediates, operand.value)
+		case "rel":
+			relative = len(immediates)
+			immediates = append(immediates, operand.value)
+		}
+	}
+
+	immediateSizes := pattern.immediates
+	if is4 != nil && pattern.is4 {
+		immediateSizes = immediateSizes[:len(immediateSizes)-1]
+	}
+	if len(immediates) != len(immediateSizes) || (rm != nil) != pattern.modrm || (opcodeRegister != nil) != pattern.register {
+		return nil, false
+	}
+
+	var modrm []byte
+	var rexR, rexX, rexB, evexR, evexX, evexV int
+	evex := pattern.class == "EVEX"
+	if pattern.modrm {
+		regField := max(pattern.reg, 0)
+		if reg != nil {
+			regField = reg.number
+			rexR, evexR = reg.number>>3&1, reg.number>>4&1
+		}
+		var ok bool
+		if modrm, rexX, rexB, ok = encodeModRM(regField, *rm, evex); !ok {
+			return nil, false
+		}
+		if rm.kind == "register" {
+			evexX = rm.number >> 4 & 1
+		}
+	}
+	if opcodeRegister != nil {
+		rexB = opcodeRegister.number >> 3 & 1
+	}
+	vvvvNumber := 0
+	if vvvv != nil {
+		vvvvNumber = vvvv.number
+		evexV = vvvv.number >> 4 & 1
+	}
+
+	var code []byte
+	addr32 := rm != nil && rm.addr32
+	w := 0
+	if pattern.w == "W1" {
+		w = 1
+	}
+	switch pattern.class {
+	case "legacy":
+		for _, operand := range operands {
+			if operand.kind == "register" && operand.number > 15 {
+				return nil, false
+			}
+		}
+		if formOperandSize(form) == 16 && pattern.prefix != "66" {
+			code = append(code, 0x66)
+		}
+		if addr32 {
+			code = append(code, 0x67)
+		}
+		switch pattern.prefix {
+		case "66", "F2", "F3":
+			prefix := map[string]byte{"66": 0x66, "F2": 0xF2, "F3": 0xF3}[pattern.prefix]
+			if !bytes.Contains(code, []byte{prefix}) {
+				code = append(code, prefix)
+			}
+		}
+		needsREX, forbidsREX := w|rexR|rexX|rexB != 0, false
+		for _, operand := range operands {
+			if operand.class == "gpr" && operand.size == 8 {
+				needsREX = needsREX || (operand.number >= 4 && !operand.highByte)
+				forbidsREX = forbidsREX || operand.highByte
+			}
+		}
+		if needsREX && forbidsREX {
+			return nil, false
+		}
+		if needsREX {
+			code = append(code, byte(0x40|w<<3|rexR<<2|rexX<<1|rexB))
+		}
+		code = append(code, map[string][]byte{"0F": {0x0F}, "0F38": {0x0F, 0x38}, "0F3A": {0x0F, 0x3A}}[pattern.opMap]...)
+
+	case "VEX":
+		for _, operand := range operands {
+			if operand.kind == "register" && operand.number > 15 || operand.mask != 0 || operand.zeroing {
+				return nil, false
+			}
+		}
+		if addr32 {
+			code = append(code, 0x67)
+		}
+		mapBits := map[string]int{"0F": 1, "0F38": 2, "0F3A": 3}[pattern.opMap]
+		length := 0
+		if pattern.vectorLength == "256" || pattern.vectorLength == "1" {
+			length = 1
+		}
+		pp := vexPP(pattern.prefix)
+		if pattern.opMap == "0F" && w == 0 && rexX == 0 && rexB == 0 {
+			code = append(code, 0xC5, byte((rexR^1)<<7|(^vvvvNumber&15)<<3|length<<2|pp))
+		} else {
+			code = append(code, 0xC4, byte((rexR^1)<<7|(rexX^1)<<6|(rexB^1)<<5|mapBits), byte(w<<7|(^vvvvNumber&15)<<3|length<<2|pp))
+		}
+
+	case "EVEX":
+		if rm != nil && rm.kind == "memory" && (rm.base > 15 || rm.index > 15) {
+			return nil, false
+		}
+		if addr32 {
+			code = append(code, 0x67)
+		}
+		mapBits := map[string]int{"0F": 1, "0F38": 2, "0F3A": 3, "MAP5": 5, "MAP6": 6}[pattern.opMap]
+		length := 0
+		switch pattern.vectorLength {
+		case "256":
+			length = 1
+		case "512":
+			length = 2
+		}
+		if rm != nil && rm.kind == "register" {
+			rexX = evexX
+		}
+		z := 0
+		if zeroing {
+			z = 1
+		}
+		code = append(code, 0x62,
+			byte((rexR^1)<<7|(rexX^1)<<6|(rexB^1)<<5|(evexR^1)<<4|mapBits),
+			byte(w<<7|(^vvvvNumber&15)<<3|1<<2|vexPP(pattern.prefix)),
+			byte(z<<7|length<<5|(evexV^1)<<3|mask))
+
+	default:
+		return nil, false
+	}
+
+	opcode := append([]byte(nil), pattern.opcode...)
+	if opcodeRegister != nil {
+		opcode[len(opcode)-1] |= byte(opcodeRegister.number & 7)
+	}
+	code = append(code, opcode...)
+	code = append(code, modrm...)
+	if relative >= 0 {
+		// The displacement counts from the end of the instruction.
+		length := len(code)
+		for _, size := range immediateSizes {
+			length += size
+		}
+		if is4 != nil {
+			length++
+		}
+		bits := 8 * immediateSizes[relative]
+		displacement := immediates[relative] - int64(length)
+		if displacement < -(1<<(bits-1)) || displacement >= 1<<(bits-1) {
+			return nil, false
+		}
+		immediates[relative] = displacement
+	}
+	for i, size := range immediateSizes {
+		for b := 0; b < size; b++ {
+			code = append(code, byte(uint64(immediates[i])>>(8*b)))
+		}
+	}
+	if is4 != nil {
+		code = append(code, byte(is4.number<<4))
+	}
+	return code, true
+}
+
+func vexPP(prefix string) int {
+	return map[string]int{"66": 1, "F3": 2, "F2": 3}[prefix]
+}
+
+// encodeModRM encodes the ModRM byte and any SIB byte and displacement
+// for an r/m operand, returning them with the REX.X and REX.B bits the
+// operand needs. EVEX displacements are kept to 32 bits rather than
+// compressed, since compressing them depends on the form's tuple type.
+func encodeModRM(reg int, rm x86Operand, evex bool) ([]byte, int, int, bool) {
+	regBits := byte(reg&7) << 3
+	if rm.kind == "register" {
+		return []byte{0xC0 | regBits | byte(rm.number&7)}, 0, rm.number >> 3 & 1, true
+	}
+	if rm.kind != "memory" {
+		return nil, 0, 0, false
+	}
+
+	disp32 := func(disp int64) []byte {
+		return []byte{byte(disp), byte(disp >> 8), byte(disp >> 16), byte(disp >> 24)}
+	}
+	if rm.disp < -1<<31 || rm.disp >= 1<<31 {
+		return nil, 0, 0, false
+	}
+	if rm.rip {
+		return append([]byte{regBits | 5}, disp32(rm.disp)...), 0, 0, true
+	}
+
+	// mod picks the displacement size; RBP and R13 as a base have no
+	// form without one.
+	mod, disp := byte(0), []byte(nil)
+	if rm.base >= 0 {
+		switch {
+		case rm.disp == 0 && rm.base&7 != 5:
+		case rm.disp >= -128 && rm.disp < 128 && (!evex || rm.disp == 0):
+			mod, disp = 1, []byte{byte(rm.disp)}
+		default:
+			mod, disp = 2, disp32(rm.disp)
+		}
+	}
+
+	x, b := 0, 0
+	if rm.index < 0 && rm.base >= 0 && rm.base&7 != 4 {
+		b = rm.base >> 3 & 1
+		return append([]byte{mod<<6 | regBits | byte(rm.base&7)}, disp...), x, b, true
+	}
+
+	index := byte(4)
+	scale := byte(0)
+	if rm.index >= 0 {
+		index = byte(rm.index & 7)
+		x = rm.index >> 3 & 1
+		scale = map[int]byte{1: 0, 2: 1, 4: 2, 8: 3}[rm.scale]
+	}
+	base := byte(5)
+	if rm.base >= 0 {
+		base = byte(rm.base & 7)
+		b = rm.base >> 3 & 1
+	} else {
+		disp = disp32(rm.disp)
+	}
+	return append([]byte{mod<<6 | regBits | 4, scale<<6 | index<<3 | base}, disp...), x, b, true
+}
@@ -0,0 +1,206 @@
+package instructions
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"arisa/arisadata"
+)
+
+// testX86 is a small dataset shaped like the generator's output, with
+// the operand details an older generator gave XCHG's "O" forms: every one
+// got the encodings of the table's last "O" row.
+func testX86() *X86 {
+	stale := []arisadata.X86Operand{{Encoding: "opcode + rd", Access: "rw"}, {Encoding: "AX/EAX/RAX", Access: "rw"}}
+	form := func(opcode, instruction, opEn string, details []arisadata.X86Operand) X86Form {
+		mnemonic, list, _ := strings.Cut(instruction, " ")
+		var operands []string
+		for _, operand := range strings.Split(list, ",") {
+			if operand = strings.TrimSpace(operand); operand != "" {
+				operands = append(operands, operand)
+			}
+		}
+		return X86Form{Opcode: opcode, Instruction: instruction, Mnemonic: mnemonic, Operands: operands, OpEn: opEn, Valid64: "Valid", OperandDetails: details}
+	}
+	return NewX86([]X86Instruction{
+		{
+			URL:             "https://www.felixcloutier.com/x86/xchg",
+			InstructionName: "XCHG— Exchange Register/Memory With Register",
+			Forms: []X86Form{
+				form("90+rw", "XCHG AX, r16", "O", stale),
+				form("90+rw", "XCHG r16, AX", "O", stale),
+				form("90+rd", "XCHG EAX, r32", "O", stale),
+				form("REX.W + 90+rd", "XCHG RAX, r64", "O", stale),
+				form("90+rd", "XCHG r32, EAX", "O", stale),
+				form("REX.W + 90+rd", "XCHG r64, RAX", "O", stale),
+				form("86 /r", "XCHG r/m8, r8", "MR", nil),
+				form("REX + 86 /r", "XCHG r/m8, r8", "MR", nil),
+				form("87 /r", "XCHG r/m32, r32", "MR", nil),
+				form("REX.W + 87 /r", "XCHG r/m64, r64", "MR", nil),
+			},
+		},
+		{
+			URL:             "https://www.felixcloutier.com/x86/cmpxchg",
+			InstructionName: "CMPXCHG— Compare and Exchange",
+			Forms: []X86Form{
+				form("0F B1/r", "CMPXCHG r/m32, r32", "MR", nil),
+				form("REX.W + 0F B1/r", "CMPXCHG r/m64, r64", "MR", nil),
+			},
+		},
+		{
+			URL:             "https://www.felixcloutier.com/x86/jmp",
+			InstructionName: "JMP— Jump",
+			Forms: []X86Form{
+				form("EB cb", "JMP rel8", "D", nil),
+				form("E9 cd", "JMP rel32", "D", nil),
+				form("FF /4", "JMP r/m64", "M", nil),
+			},
+		},
+		{
+			URL:             "https://www.felixcloutier.com/x86/call",
+			InstructionName: "CALL— Call Procedure",
+			Forms: []X86Form{
+				form("E8 cd", "CALL rel32", "D", nil),
+				form("FF /2", "CALL r/m64", "M", nil),
+			},
+		},
+		{
+			URL:             "https://www.felixcloutier.com/x86/jcc",
+			InstructionName: "Jcc— Jump if Condition Is Met",
+			Forms: []X86Form{
+				form("75 cb", "JNE rel8", "D", nil),
+				form("0F 85 cd", "JNE rel32", "D", nil),
+			},
+		},
+		{
+			URL:             "https://www.felixcloutier.com/x86/movs:movsb:movsw:movsd:movsq",
+			InstructionName: "MOVS/MOVSB/MOVSW/MOVSD/MOVSQ— Move Data From String to String",
+			Forms: []X86Form{
+				form("A4", "MOVS m8, m8", "ZO", nil),
+				form("A5", "MOVS m16, m16", "ZO", nil),
+				form("A5", "MOVS m32, m32", "ZO", nil),
+				form("REX.W + A5", "MOVS m64, m64", "ZO", nil),
+				form("A4", "MOVSB", "ZO", nil),
+				form("A5", "MOVSW", "ZO", nil),
+				form("A5", "MOVSD", "ZO", nil),
+				form("REX.W + A5", "MOVSQ", "ZO", nil),
+			},
+		},
+	})
+}
+
+func TestEncode(t *testing.T) {
+	set := testX86()
+	tests := []struct {
+		mnemonic string
+		operands []string
+		want     string
+	}{
+		{"xchg", []string{"eax", "edx"}, "92"},
+		{"xchg", []string{"edx", "eax"}, "92"},
+		{"xchg", []string{"rax", "r9"}, "4991"},
+		{"xchg", []string{"ax", "bx"}, "6693"},
+		{"jmp", []string{"0x10"}, "eb0e"},
+		{"jmp", []string{"0"}, "ebfe"},
+		{"jmp", []string{"0x1000"}, "e9fb0f0000"},
+		{"call", []string{"0x100"}, "e8fb000000"},
+		{"jne", []string{"0x10"}, "750e"},
+		{"jne", []string{"0x10000"}, "0f85faff0000"},
+	}
+	for _, test := range tests {
+		encoded, err := set.Encode(test.mnemonic, test.operands...)
+		if err != nil {
+			t.Errorf("Encode(%s %s): %v", test.mnemonic, strings.Join(test.operands, ", "), err)
+			continue
+		}
+		if len(encoded) == 0 {
+			t.Errorf("Encode(%s %s) found no encoding", test.mnemonic, strings.Join(test.operands, ", "))
+			continue
+		}
+		if got := hex.EncodeToString(encoded[0].Bytes); got != test.want {
+			t.Errorf("Encode(%s %s) = %s, want %s", test.mnemonic, strings.Join(test.operands, ", "), got, test.want)
+		}
+	}
+}
+
+// TestEncodeDecodeRoundTrip checks that Decode finds the form every
+// encoding came from, at the length it was encoded with.
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	set := testX86()
+	tests := []struct {
+		mnemonic string
+		operands []string
+	}{
+		{"xchg", []string{"eax", "edx"}},
+		{"xchg", []string{"rax", "r9"}},
+		{"xchg", []string{"ecx", "r10d"}},
+		{"xchg", []string{"rbx", "rsi"}},
+		{"jmp", []string{"0x10"}},
+		{"jmp", []string{"0x12345"}},
+		{"jmp", []string{"rax"}},
+		{"jmp", []string{"qword ptr [rip+8]"}},
+		{"call", []string{"0x100"}},
+		{"call", []string{"[rbx+rcx*8+16]"}},
+		{"jne", []string{"0x10"}},
+		{"jne", []string{"-0x1000"}},
+	}
+	for _, test := range tests {
+		name := test.mnemonic + " " + strings.Join(test.operands, ", ")
+		encoded, err := set.Encode(test.mnemonic, test.operands...)
+		if err != nil || len(encoded) == 0 {
+			t.Errorf("Encode(%s) = %v, %v", name, encoded, err)
+			continue
+		}
+		for _, encoding := range encoded {
+			matches := set.Decode(encoding.Bytes)
+			found := false
+			for _, match := range matches {
+				if match.Form.Instruction == encoding.Form.Instruction && match.Length == len(encoding.Bytes) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("Decode(% x) from %s (%s) = %v, want the form back", encoding.Bytes, name, encoding.Form.Instruction, forms(matches))
+			}
+		}
+	}
+}
+
+func TestEncodeRejectsOutOfRangeBranches(t *testing.T) {
+	set := testX86()
+	encoded, err := set.Encode("jmp", "0x100000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, encoding := range encoded {
+		if bytes.HasPrefix(encoding.Bytes, []byte{0xEB}) || bytes.HasPrefix(encoding.Bytes, []byte{0xE9}) {
+			t.Errorf("Encode(jmp 0x100000000) = % x, want no relative encoding", encoding.Bytes)
+		}
+	}
+}
+
+func TestEncodeUnknownMnemonic(t *testing.T) {
+	set := testX86()
+	_, err := set.Encode("xchq", "eax", "ebx")
+	var unknown *UnknownMnemonicError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Encode(xchq) error = %v, want an UnknownMnemonicError", err)
+	}
+	if unknown.Mnemonic != "xchq" || len(unknown.Suggestions) == 0 || unknown.Suggestions[0] != "XCHG" {
+		t.Errorf("Encode(xchq) error = %+v, want XCHG suggested first", unknown)
+	}
+	if want := `unknown mnemonic "xchq"; did you mean XCHG`; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("error = %q, want it to start with %q", err, want)
+	}
+}
+
+func forms(matches []X86Match) []string {
+	var instructions []string
+	for _, match := range matches {
+		instructions = append(instructions, match.Form.Instruction)
+	}
+	return instructions
+}
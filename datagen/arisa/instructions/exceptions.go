@@ -0,0 +1,145 @@
+package instructions
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"arisa/arisadata"
+)
+
+// X86ExceptionRecord is one condition of an exceptions section.
+type X86ExceptionRecord = arisadata.X86ExceptionRecord
+
+// X86ExceptionMode is one exceptions section of a page: its key in
+// Exceptions and ExceptionRecords, and its heading.
+type X86ExceptionMode struct {
+	Key   string
+	Title string
+}
+
+// x86ExceptionModes orders and names the sections the way the SDM does;
+// any other section follows them under its own name.
+var x86ExceptionModes = []X86ExceptionMode{
+	{"protectedMode", "Protected Mode Exceptions"},
+	{"realAddressMode", "Real-Address Mode Exceptions"},
+	{"virtual8086Mode", "Virtual-8086 Mode Exceptions"},
+	{"compatibilityMode", "Compatibility Mode Exceptions"},
+	{"64BitMode", "64-Bit Mode Exceptions"},
+}
+
+var (
+	exceptionVectorPattern = regexp.MustCompile(`^#[A-Z]{1,2}(\([^)]*\))?`)
+	flattenedCellPattern   = regexp.MustCompile(`(?:^|;\s+)column_(\d+): `)
+)
+
+// X86ExceptionModes lists the sections of a page's Exceptions in the SDM's
+// order, then any others sorted by key. Some keys keep the "¶" of the
+// heading they came from, as in "simdFloating-Point¶".
+func X86ExceptionModes(record X86Instruction) []X86ExceptionMode {
+	var modes []X86ExceptionMode
+	known := make(map[string]bool)
+	for _, mode := range x86ExceptionModes {
+		for _, key := range []string{mode.Key, mode.Key + "¶"} {
+			known[key] = true
+			if _, ok := record.Exceptions[key]; ok {
+				modes = append(modes, X86ExceptionMode{Key: key, Title: mode.Title})
+			}
+		}
+	}
+
+	var others []string
+	for key := range record.Exceptions {
+		if !known[key] {
+			others = append(others, key)
+		}
+	}
+	sort.Strings(others)
+	for _, key := range others {
+		modes = append(modes, X86ExceptionMode{Key: key, Title: exceptionTitle(key)})
+	}
+	return modes
+}
+
+// exceptionTitle turns a key such as "simdFloating-Point¶" back into a
+// heading.
+func exceptionTitle(key string) string {
+	key = strings.TrimSuffix(key, "¶")
+	var b strings.Builder
+	var previous rune
+	for i, r := range key {
+		if i == 0 {
+			b.WriteString(strings.ToUpper(string(r)))
+		} else {
+			if r >= 'A' && r <= 'Z' && previous != '-' {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+		}
+		previous = r
+	}
+	title := strings.NewReplacer("Simd", "SIMD", "Fpu", "FPU").Replace(b.String())
+	if !strings.Contains(strings.ToLower(title), "exception") {
+		title += " Exceptions"
+	}
+	return title
+}
+
+// X86Exceptions returns the conditions of one of a page's exceptions
+// sections. Older datasets, the embedded snapshot among them, only have
+// the flattened "column_1: ...; column_2: ...;" text of the tables, which
+// the records are recovered from.
+func X86Exceptions(record X86Instruction, key string) []X86ExceptionRecord {
+	if records, ok := record.ExceptionRecords[strings.TrimSuffix(key, "¶")]; ok {
+		return records
+	}
+
+	var records []X86ExceptionRecord
+	var vector string
+	for _, paragraph := range record.Exceptions[key] {
+		if !strings.Contains(paragraph, "column_") {
+			text := strings.Join(strings.Fields(paragraph), " ")
+			if text == "" {
+				continue
+			}
+			leading := exceptionVectorPattern.FindString(text)
+			records = append(records, X86ExceptionRecord{Vector: leading, Condition: strings.TrimSpace(text[len(leading):])})
+			continue
+		}
+
+		for _, line := range strings.Split(paragraph, "\n") {
+			line = strings.TrimSuffix(strings.TrimSpace(line), ";")
+			keys := flattenedCellPattern.FindAllStringSubmatchIndex(line, -1)
+			columns := make(map[int]string, len(keys))
+			var order []int
+			for i, key := range keys {
+				end := len(line)
+				if i+1 < len(keys) {
+					end = keys[i+1][0]
+				}
+				n, _ := strconv.Atoi(line[key[2]:key[3]])
+				columns[n] = strings.Join(strings.Fields(line[key[1]:end]), " ")
+				order = append(order, n)
+			}
+			sort.Ints(order)
+
+			var cells []string
+			for _, n := range order {
+				if columns[n] != "" {
+					cells = append(cells, columns[n])
+				}
+			}
+			if len(cells) == 0 {
+				continue
+			}
+			// Rows continuing a rowspan'd vector carry only the
+			// condition, so they inherit the vector of the row above.
+			if exceptionVectorPattern.FindString(cells[0]) == cells[0] {
+				vector, cells = cells[0], cells[1:]
+			}
+			records = append(records, X86ExceptionRecord{Vector: vector, Condition: strings.Join(cells, " ")})
+		}
+	}
+	return records
+}
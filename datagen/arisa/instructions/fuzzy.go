@@ -0,0 +1,157 @@
+package instructions
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FuzzySearch is Search tolerant of typos, so "popcont" still finds
+// POPCNT. The records LookupMnemonic resolves the query to come first, so
+// "jne" finds Jcc, then exact, prefix and substring mnemonic matches, then
+// mnemonics a few edits away, then matches in the page title and
+// description, then descriptions with a close spelling of every query word.
+func (s *X86) FuzzySearch(query string) []X86Instruction {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	ranks := make([]int, len(s.records))
+	for i, record := range s.records {
+		ranks[i] = fuzzyRank(query, X86Mnemonics(record), record.InstructionName, record.DescriptionText)
+	}
+	for _, position := range s.resolve(query) {
+		ranks[position] = 100
+	}
+	return s.at(ranked(ranks))
+}
+
+// FuzzySearch is Search tolerant of typos, ranked as for the x86 dataset:
+// "invokedynamc" still finds invokedynamic.
+func (s *JVM) FuzzySearch(query string) []JVMInstruction {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	ranks := make([]int, len(s.records))
+	for i, record := range s.records {
+		ranks[i] = fuzzyRank(query, []string{record.Mnemonic}, record.Operation, record.Description)
+	}
+	return s.at(ranked(ranks))
+}
+
+// fuzzyRank extends searchRank's order with typo matches: a mnemonic
+// within editBudget of the query ranks between mnemonic substrings and
+// name matches, and text with a close match for every query word ranks
+// last. Zero means no match.
+func fuzzyRank(query string, mnemonics []string, name, text string) int {
+	rank := searchRank(query, mnemonics, name, text)
+	if rank >= 3 {
+		return rank*10 + 5
+	}
+
+	budget := editBudget(query)
+	closest := budget + 1
+	for _, mnemonic := range mnemonics {
+		closest = min(closest, editDistance(query, strings.ToLower(mnemonic), budget))
+	}
+	switch {
+	case closest <= budget:
+		return 30 - closest
+	case rank > 0:
+		return rank*10 + 5
+	case fuzzyWordsMatch(query, name+" "+text):
+		return 1
+	}
+	return 0
+}
+
+// editBudget is how many typos a word may have and still match: none for
+// the shortest words, which would otherwise match nearly everything.
+func editBudget(word string) int {
+	switch n := len(word); {
+	case n < 3:
+		return 0
+	case n < 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// fuzzyWordsMatch reports whether every word of the query is within its
+// edit budget of some word of the text.
+func fuzzyWordsMatch(query, text string) bool {
+	queryWords := words(query)
+	if len(queryWords) == 0 {
+		return false
+	}
+	textWords := make(map[string]bool)
+	for _, word := range words(strings.ToLower(text)) {
+		textWords[word] = true
+	}
+
+	for _, queryWord := range queryWords {
+		budget := editBudget(queryWord)
+		found := textWords[queryWord]
+		if !found {
+			for word := range textWords {
+				if editDistance(queryWord, word, budget) <= budget {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func words(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
+
+// editDistance is the optimal string alignment distance between a and b,
+// counting insertions, deletions, substitutions and swaps of adjacent
+// bytes. It gives up once the distance must exceed limit, returning
+// limit+1.
+func editDistance(a, b string, limit int) int {
+	if abs(len(a)-len(b)) > limit {
+		return limit + 1
+	}
+	// rows[0] is two rows back, for swaps; rows[2] is the row being
+	// filled.
+	rows := [3][]int{make([]int, len(b)+1), make([]int, len(b)+1), make([]int, len(b)+1)}
+	for j := range rows[1] {
+		rows[1][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current, previous := rows[2], rows[1]
+		current[0] = i
+		smallest := i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				current[j] = min(current[j], rows[0][j-2]+1)
+			}
+			smallest = min(smallest, current[j])
+		}
+		if smallest > limit {
+			return limit + 1
+		}
+		rows[0], rows[1], rows[2] = previous, current, rows[0]
+	}
+	return min(rows[1][len(b)], limit+1)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
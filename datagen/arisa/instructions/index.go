@@ -0,0 +1,214 @@
+package instructions
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"arisa/dataset"
+)
+
+// indexVersion is bumped whenever the index format or tokenization
+// changes, so indexes written by older versions are rebuilt.
+const indexVersion = 1
+
+// titleWeight is how many times a word in a record's title counts, so
+// that records named after the query outrank ones that mention it.
+const titleWeight = 3
+
+// Index is an inverted index of a dataset's text: for each lower-case
+// word, the records containing it. TextSearch builds one the first time
+// it's called; writing one beside the dataset with WriteIndex lets Load
+// pick it up instead.
+type Index struct {
+	Version int `json:"version"`
+	// Fingerprint is a hash of the indexed text, so an index is only
+	// used with the dataset it was built from.
+	Fingerprint string               `json:"fingerprint"`
+	Records     int                  `json:"records"`
+	Postings    map[string][]Posting `json:"postings"`
+
+	vocabulary []string
+}
+
+// Posting is a record containing a word, and how many times, with title
+// words weighted.
+type Posting struct {
+	Record int `json:"r"`
+	Count  int `json:"n"`
+}
+
+// IndexPath is where the index of the dataset at path is kept: beside it,
+// named for the uncompressed dataset, so x86.json and x86.json.zst share
+// x86.json.idx.
+func IndexPath(path string) string {
+	for _, extension := range dataset.Compressions {
+		path = strings.TrimSuffix(path, "."+extension)
+	}
+	return path + ".idx"
+}
+
+// WriteIndex writes an index, zstd-compressed.
+func WriteIndex(path string, index *Index) error {
+	content, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to marshal index: %w", err)
+	}
+	compressed, err := dataset.Compress(content, "zst")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, compressed, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadIndex reads an index written by WriteIndex.
+func ReadIndex(path string) (*Index, error) {
+	content, err := dataset.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var index Index
+	if err := json.Unmarshal(content, &index); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	if index.Version != indexVersion {
+		return nil, fmt.Errorf("%s: index version %d, want %d", path, index.Version, indexVersion)
+	}
+	index.prepare()
+	return &index, nil
+}
+
+// Terms is the number of distinct words indexed.
+func (x *Index) Terms() int {
+	return len(x.Postings)
+}
+
+// buildIndex indexes documents, one per record, each a list of text
+// fields whose first is the record's title.
+func buildIndex(documents [][]string) *Index {
+	index := &Index{
+		Version:     indexVersion,
+		Fingerprint: fingerprint(documents),
+		Records:     len(documents),
+		Postings:    make(map[string][]Posting),
+	}
+	for record, fields := range documents {
+		counts := make(map[string]int)
+		for i, field := range fields {
+			weight := 1
+			if i == 0 {
+				weight = titleWeight
+			}
+			for _, word := range words(strings.ToLower(field)) {
+				counts[word] += weight
+			}
+		}
+		for word, count := range counts {
+			index.Postings[word] = append(index.Postings[word], Posting{Record: record, Count: count})
+		}
+	}
+	index.prepare()
+	return index
+}
+
+// prepare sorts the vocabulary for prefix matching. Postings are already
+// in record order, since records are indexed in order.
+func (x *Index) prepare() {
+	x.vocabulary = sortedKeys(x.Postings)
+}
+
+// check reports whether the index was built from documents.
+func (x *Index) check(documents [][]string) error {
+	if x.Records != len(documents) || x.Fingerprint != fingerprint(documents) {
+		return fmt.Errorf("index was built from a different dataset")
+	}
+	return nil
+}
+
+// search returns the positions of the records containing every word of
+// query, best first by summed term frequency weighted by rarity. The last
+// word also matches as a prefix, for search-as-you-type.
+func (x *Index) search(query string) []int {
+	queryWords := words(strings.ToLower(query))
+	if len(queryWords) == 0 {
+		return nil
+	}
+
+	var scores map[int]float64
+	for i, word := range queryWords {
+		terms := []string{word}
+		if i == len(queryWords)-1 {
+			terms = x.prefixed(word)
+		}
+		matches := make(map[int]float64)
+		for _, term := range terms {
+			postings := x.Postings[term]
+			idf := math.Log(1 + float64(x.Records)/float64(len(postings)))
+			for _, posting := range postings {
+				if scores == nil || scores[posting.Record] > 0 {
+					matches[posting.Record] = max(matches[posting.Record], float64(posting.Count)*idf)
+				}
+			}
+		}
+		for record := range matches {
+			matches[record] += scores[record]
+		}
+		scores = matches
+		if len(scores) == 0 {
+			return nil
+		}
+	}
+
+	positions := make([]int, 0, len(scores))
+	for record := range scores {
+		positions = append(positions, record)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if scores[positions[i]] != scores[positions[j]] {
+			return scores[positions[i]] > scores[positions[j]]
+		}
+		return positions[i] < positions[j]
+	})
+	return positions
+}
+
+// prefixed lists the indexed words starting with prefix.
+func (x *Index) prefixed(prefix string) []string {
+	start := sort.SearchStrings(x.vocabulary, prefix)
+	end := start
+	for end < len(x.vocabulary) && strings.HasPrefix(x.vocabulary[end], prefix) {
+		end++
+	}
+	return x.vocabulary[start:end]
+}
+
+func fingerprint(documents [][]string) string {
+	hash := sha256.New()
+	for _, fields := range documents {
+		for _, field := range fields {
+			hash.Write([]byte(field))
+			hash.Write([]byte{0})
+		}
+		hash.Write([]byte{1})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// persistedIndex reads the index kept beside the dataset at path, if
+// there is one and it matches documents. Any other index is ignored, and
+// TextSearch builds its own.
+func persistedIndex(path string, documents [][]string) *Index {
+	index, err := ReadIndex(IndexPath(path))
+	if err != nil || index.check(documents) != nil {
+		return nil
+	}
+	return index
+}
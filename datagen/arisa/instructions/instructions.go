@@ -0,0 +1,92 @@
+// Package instructions loads the x86 and JVM datasets into typed records
+// and indexes them for lookup, search and filtering:
+//
+//	set, err := instructions.LoadX86("x86.json")
+//	forms := set.Lookup("vaddps")
+//
+// Unlike arisadata, which compiles a snapshot of the datasets into the
+// binary, it reads whichever copy it is given, plain, compressed, enveloped
+// or a legacy bare array. The Embedded constructors index arisadata's
+// snapshot instead, for programs that don't ship the files.
+//
+// TextSearch answers full-text queries over the descriptions from an
+// inverted index. Building it takes a moment, so tools that search often
+// can save it beside the dataset with WriteIndex and IndexPath, and Load
+// uses it from then on.
+package instructions
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"arisa/dataset"
+)
+
+// load reads a dataset file's records and metadata, which is nil for
+// legacy datasets.
+func load[T any](path string) ([]T, *dataset.Metadata, error) {
+	content, err := dataset.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	records, metadata, err := dataset.Unwrap(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	var decoded []T
+	if err := json.Unmarshal(records, &decoded); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	return decoded, metadata, nil
+}
+
+// searchRank orders Search results: exact mnemonic matches first, then
+// mnemonic prefixes, then mnemonics containing the query, then matches in
+// the name, then in the text. Zero means no match.
+func searchRank(query string, mnemonics []string, name, text string) int {
+	best := 0
+	for _, mnemonic := range mnemonics {
+		mnemonic = strings.ToLower(mnemonic)
+		switch {
+		case mnemonic == query:
+			return 5
+		case strings.HasPrefix(mnemonic, query):
+			best = max(best, 4)
+		case strings.Contains(mnemonic, query):
+			best = max(best, 3)
+		}
+	}
+	switch {
+	case best > 0:
+		return best
+	case strings.Contains(strings.ToLower(name), query):
+		return 2
+	case strings.Contains(strings.ToLower(text), query):
+		return 1
+	}
+	return 0
+}
+
+// ranked returns the positions with a non-zero rank, best first and in
+// dataset order among equals.
+func ranked(ranks []int) []int {
+	var positions []int
+	for i, rank := range ranks {
+		if rank > 0 {
+			positions = append(positions, i)
+		}
+	}
+	sort.SliceStable(positions, func(i, j int) bool { return ranks[positions[i]] > ranks[positions[j]] })
+	return positions
+}
+
+func sortedKeys[V any](index map[string]V) []string {
+	keys := make([]string, 0, len(index))
+	for key := range index {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
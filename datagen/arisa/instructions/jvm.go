@@ -0,0 +1,180 @@
+package instructions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"arisa/arisadata"
+	"arisa/dataset"
+	"arisa/schema"
+)
+
+type JVMInstruction = schema.JVMInstruction
+
+// JVM is an indexed JVM dataset. Its slices are shared; don't modify them.
+type JVM struct {
+	// Metadata describes the dataset file, and is nil for legacy
+	// datasets and the embedded snapshot.
+	Metadata *dataset.Metadata
+
+	records    []JVMInstruction
+	byMnemonic map[string]int
+	byOpcode   map[uint8]int
+	index      *Index
+	indexOnce  sync.Once
+}
+
+// LoadJVM reads and indexes a JVM dataset file, with the full-text index
+// kept beside it if it's up to date.
+func LoadJVM(path string) (*JVM, error) {
+	records, metadata, err := load[JVMInstruction](path)
+	if err != nil {
+		return nil, err
+	}
+	set := NewJVM(records)
+	set.Metadata = metadata
+	set.index = persistedIndex(path, set.documents())
+	return set, nil
+}
+
+// EmbeddedJVM indexes the JVM snapshot compiled into arisadata.
+func EmbeddedJVM() (*JVM, error) {
+	records, err := arisadata.JVM()
+	if err != nil {
+		return nil, err
+	}
+	return NewJVM(records), nil
+}
+
+// NewJVM indexes records already in memory, in opcode order. Records
+// without an opcode, which older datasets used for the unassigned range,
+// are dropped; the reserved opcodes are kept.
+func NewJVM(records []JVMInstruction) *JVM {
+	set := &JVM{byMnemonic: make(map[string]int), byOpcode: make(map[uint8]int)}
+	for _, record := range records {
+		if record.Opcode == "" {
+			continue
+		}
+		// Datasets written before opcodeByte existed only carry it in
+		// the "name = dec (0xhex)" opcode string.
+		if record.OpcodeByte == 0 {
+			var name string
+			fmt.Sscanf(record.Opcode, "%s = %d", &name, &record.OpcodeByte)
+		}
+		set.records = append(set.records, record)
+	}
+
+	sort.SliceStable(set.records, func(i, j int) bool { return set.records[i].OpcodeByte < set.records[j].OpcodeByte })
+
+	for i, record := range set.records {
+		set.byMnemonic[strings.ToLower(record.Mnemonic)] = i
+		// The wide-modified forms share wide's opcode; wide itself is the
+		// instruction that opcode encodes.
+		if existing, ok := set.byOpcode[record.OpcodeByte]; !ok || strings.Contains(set.records[existing].Mnemonic, " ") {
+			set.byOpcode[record.OpcodeByte] = i
+		}
+	}
+	return set
+}
+
+// All returns every record.
+func (s *JVM) All() []JVMInstruction {
+	return s.records
+}
+
+// Lookup returns the instruction with the given mnemonic, matched without
+// regard to case.
+func (s *JVM) Lookup(mnemonic string) (JVMInstruction, bool) {
+	position, ok := s.byMnemonic[strings.ToLower(strings.TrimSpace(mnemonic))]
+	if !ok {
+		return JVMInstruction{}, false
+	}
+	return s.records[position], true
+}
+
+// Opcode returns the instruction encoded by an opcode byte.
+func (s *JVM) Opcode(opcode uint8) (JVMInstruction, bool) {
+	position, ok := s.byOpcode[opcode]
+	if !ok {
+		return JVMInstruction{}, false
+	}
+	return s.records[position], true
+}
+
+// Mnemonics lists every mnemonic Lookup knows, lower-case and sorted.
+func (s *JVM) Mnemonics() []string {
+	return sortedKeys(s.byMnemonic)
+}
+
+// Search returns the instructions matching query without regard to case,
+// best first: by mnemonic, then by the one-line operation summary, then by
+// description.
+func (s *JVM) Search(query string) []JVMInstruction {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	ranks := make([]int, len(s.records))
+	for i, record := range s.records {
+		ranks[i] = searchRank(query, []string{record.Mnemonic}, record.Operation, record.Description)
+	}
+	return s.at(ranked(ranks))
+}
+
+// TextSearch returns the instructions whose mnemonic, operation summary,
+// description and notes contain every word of query, best first, with the
+// last word matched as a prefix. It uses the full-text index, building it
+// on first use if none was loaded.
+func (s *JVM) TextSearch(query string) []JVMInstruction {
+	s.indexOnce.Do(func() {
+		if s.index == nil {
+			s.index = s.BuildIndex()
+		}
+	})
+	return s.at(s.index.search(query))
+}
+
+// BuildIndex builds the full-text index TextSearch uses, for WriteIndex to
+// save beside the dataset.
+func (s *JVM) BuildIndex() *Index {
+	return buildIndex(s.documents())
+}
+
+// UseIndex has TextSearch use an index read with ReadIndex. It fails if
+// the index was built from a different dataset; call it before searching.
+func (s *JVM) UseIndex(index *Index) error {
+	if err := index.check(s.documents()); err != nil {
+		return err
+	}
+	s.index = index
+	return nil
+}
+
+func (s *JVM) documents() [][]string {
+	documents := make([][]string, len(s.records))
+	for i, record := range s.records {
+		documents[i] = []string{record.Mnemonic + " " + record.Operation, record.Description, record.Notes}
+	}
+	return documents
+}
+
+// Filter returns the instructions keep accepts, in opcode order.
+func (s *JVM) Filter(keep func(JVMInstruction) bool) []JVMInstruction {
+	var matches []JVMInstruction
+	for _, record := range s.records {
+		if keep(record) {
+			matches = append(matches, record)
+		}
+	}
+	return matches
+}
+
+func (s *JVM) at(positions []int) []JVMInstruction {
+	matches := make([]JVMInstruction, 0, len(positions))
+	for _, position := range positions {
+		matches = append(matches, s.records[position])
+	}
+	return matches
+}
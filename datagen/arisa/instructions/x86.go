@@ -0,0 +1,205 @@
+package instructions
+
+import (
+	"strings"
+	"sync"
+
+	"arisa/arisadata"
+	"arisa/dataset"
+)
+
+type (
+	X86Instruction = arisadata.X86Instruction
+	X86Form        = arisadata.X86Form
+)
+
+// X86 is an indexed x86 dataset. Its slices are shared; don't modify them.
+type X86 struct {
+	// Metadata describes the dataset file, and is nil for legacy
+	// datasets and the embedded snapshot.
+	Metadata *dataset.Metadata
+
+	records    []X86Instruction
+	byMnemonic map[string][]int
+	byAlias    map[string][]int
+	index      *Index
+	indexOnce  sync.Once
+
+	patterns     [][]*x86Pattern
+	patternsOnce sync.Once
+}
+
+// LoadX86 reads and indexes an x86 dataset file, with the full-text index
+// kept beside it if it's up to date.
+func LoadX86(path string) (*X86, error) {
+	records, metadata, err := load[X86Instruction](path)
+	if err != nil {
+		return nil, err
+	}
+	set := NewX86(records)
+	set.Metadata = metadata
+	set.index = persistedIndex(path, set.documents())
+	return set, nil
+}
+
+// EmbeddedX86 indexes the x86 snapshot compiled into arisadata.
+func EmbeddedX86() (*X86, error) {
+	records, err := arisadata.X86()
+	if err != nil {
+		return nil, err
+	}
+	return NewX86(records), nil
+}
+
+// NewX86 indexes records already in memory. Records for pages that
+// failed to scrape are dropped.
+func NewX86(records []X86Instruction) *X86 {
+	set := &X86{byMnemonic: make(map[string][]int), byAlias: make(map[string][]int)}
+	for _, record := range records {
+		if record.Error == "" {
+			set.records = append(set.records, record)
+		}
+	}
+	for i, record := range set.records {
+		for _, mnemonic := range X86Mnemonics(record) {
+			set.byMnemonic[mnemonic] = append(set.byMnemonic[mnemonic], i)
+		}
+		for _, alias := range record.Aliases {
+			for _, name := range aliasNames(alias) {
+				set.byAlias[name] = append(set.byAlias[name], i)
+			}
+		}
+	}
+	return set
+}
+
+// X86Mnemonics lists the upper-case mnemonics a record documents: the
+// ones named in its title, such as both ADDPD and VADDPD for
+// "ADDPD/VADDPD—Add Packed ...", and every form's.
+func X86Mnemonics(record X86Instruction) []string {
+	title, _, _ := strings.Cut(record.InstructionName, "—")
+	names := strings.Split(strings.Join(strings.Fields(title), ""), "/")
+	for _, form := range record.Forms {
+		names = append(names, form.Mnemonic)
+	}
+
+	var mnemonics []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if name != "" && !seen[name] {
+			seen[name] = true
+			mnemonics = append(mnemonics, name)
+		}
+	}
+	return mnemonics
+}
+
+// All returns every record.
+func (s *X86) All() []X86Instruction {
+	return s.records
+}
+
+// Lookup returns the records documenting a mnemonic, matched without
+// regard to case. A mnemonic can appear on more than one page, such as
+// MOV. LookupMnemonic also resolves the names assemblers use.
+func (s *X86) Lookup(mnemonic string) []X86Instruction {
+	return s.at(s.byMnemonic[strings.ToUpper(strings.TrimSpace(mnemonic))])
+}
+
+// Mnemonics lists every mnemonic Lookup knows, upper-case and sorted.
+func (s *X86) Mnemonics() []string {
+	return sortedKeys(s.byMnemonic)
+}
+
+// Search returns the records matching query without regard to case, best
+// first: by mnemonic, then by page title, then by description.
+func (s *X86) Search(query string) []X86Instruction {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	ranks := make([]int, len(s.records))
+	for i, record := range s.records {
+		ranks[i] = searchRank(query, X86Mnemonics(record), record.InstructionName, record.DescriptionText)
+	}
+	return s.at(ranked(ranks))
+}
+
+// Filter returns the records keep accepts, in dataset order.
+func (s *X86) Filter(keep func(X86Instruction) bool) []X86Instruction {
+	var matches []X86Instruction
+	for _, record := range s.records {
+		if keep(record) {
+			matches = append(matches, record)
+		}
+	}
+	return matches
+}
+
+// TextSearch returns the records whose title, description and operation
+// text contain every word of query, best first, with the last word
+// matched as a prefix. It uses the full-text index, building it on first
+// use if none was loaded.
+func (s *X86) TextSearch(query string) []X86Instruction {
+	s.indexOnce.Do(func() {
+		if s.index == nil {
+			s.index = s.BuildIndex()
+		}
+	})
+	return s.at(s.index.search(query))
+}
+
+// BuildIndex builds the full-text index TextSearch uses, for WriteIndex to
+// save beside the dataset.
+func (s *X86) BuildIndex() *Index {
+	return buildIndex(s.documents())
+}
+
+// UseIndex has TextSearch use an index read with ReadIndex. It fails if
+// the index was built from a different dataset; call it before searching.
+func (s *X86) UseIndex(index *Index) error {
+	if err := index.check(s.documents()); err != nil {
+		return err
+	}
+	s.index = index
+	return nil
+}
+
+func (s *X86) documents() [][]string {
+	documents := make([][]string, len(s.records))
+	for i, record := range s.records {
+		documents[i] = []string{record.InstructionName, record.DescriptionText, record.OperationText}
+	}
+	return documents
+}
+
+// X86Feature is a Filter predicate for records with a form requiring the
+// CPUID feature flag, such as "AVX2" or "AVX512F".
+func X86Feature(flag string) func(X86Instruction) bool {
+	flag = strings.ToUpper(flag)
+	return func(record X86Instruction) bool {
+		for _, recordFlag := range record.FeatureFlags {
+			if recordFlag == flag {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// X86Taxonomy is a Filter predicate for records in a category of the
+// shared taxonomy, such as "simd" or "system".
+func X86Taxonomy(category string) func(X86Instruction) bool {
+	return func(record X86Instruction) bool {
+		return strings.EqualFold(record.Taxonomy, category)
+	}
+}
+
+func (s *X86) at(positions []int) []X86Instruction {
+	matches := make([]X86Instruction, 0, len(positions))
+	for _, position := range positions {
+		matches = append(matches, s.records[position])
+	}
+	return matches
+}
@@ -0,0 +1,110 @@
+// Package manpage renders instruction pages as roff man pages, so they can
+// be read with man(1) once installed into a MANPATH directory:
+//
+//	man 7x86 vpshufb
+package manpage
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"arisa/docset"
+)
+
+// Path is where a page belongs under a man directory: "7x86" pages go in
+// man7 with a ".7x86" extension, which man finds given the full section.
+func Path(name, section string) string {
+	return filepath.Join("man"+section[:1], FileName(name)+"."+section)
+}
+
+// FileName lowercases a name and swaps the characters a file name can't
+// hold everywhere, such as the space in "wide iinc".
+func FileName(name string) string {
+	return strings.NewReplacer(" ", "-", "/", "_", ":", "_").Replace(strings.ToLower(name))
+}
+
+// Link is a page that pulls in another, for the other names a page covers.
+func Link(name, section string) []byte {
+	return []byte(".so " + filepath.ToSlash(Path(name, section)) + "\n")
+}
+
+// Render lays out page under the NAME line "names \- summary". Tables become
+// tagged paragraphs, one per row headed by its first cell, since a wide
+// table doesn't fit a terminal.
+func Render(page docset.Page, names []string, summary, section, manual string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, ".TH %s %s \"\" \"Arisa\" %s\n", quote(strings.ToUpper(FileName(names[0]))), section, quote(manual))
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- %s\n", escape(strings.ToLower(strings.Join(names, ", "))), escape(summary))
+	if page.Subtitle != "" {
+		fmt.Fprintf(&b, ".PP\n%s\n", escape(page.Subtitle))
+	}
+
+	for _, section := range page.Sections {
+		fmt.Fprintf(&b, ".SH %s\n", quote(strings.ToUpper(section.Heading)))
+		for _, paragraph := range section.Paragraphs {
+			fmt.Fprintf(&b, ".PP\n%s\n", escape(paragraph))
+		}
+		writeTable(&b, section.Table)
+		for _, item := range section.List {
+			fmt.Fprintf(&b, ".IP \\(bu 2\n%s\n", escape(item))
+		}
+		if section.Code != "" {
+			b.WriteString(".PP\n.RS 4\n.nf\n")
+			for _, line := range strings.Split(section.Code, "\n") {
+				b.WriteString(escape(line) + "\n")
+			}
+			b.WriteString(".fi\n.RE\n")
+		}
+	}
+
+	if page.Source != "" {
+		fmt.Fprintf(&b, ".SH SOURCE\n%s\n", escape(page.Source))
+	}
+	return []byte(b.String())
+}
+
+func writeTable(b *strings.Builder, rows [][]string) {
+	if len(rows) < 2 {
+		return
+	}
+	header := rows[0]
+	for _, row := range rows[1:] {
+		if len(row) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, ".TP\n\\fB%s\\fR\n", escape(row[0]))
+		first := true
+		for j, cell := range row[1:] {
+			if cell == "" {
+				continue
+			}
+			if !first {
+				b.WriteString(".br\n")
+			}
+			first = false
+			if j+1 < len(header) && header[j+1] != "" {
+				fmt.Fprintf(b, "%s: %s\n", escape(header[j+1]), escape(cell))
+			} else {
+				b.WriteString(escape(cell) + "\n")
+			}
+		}
+	}
+}
+
+// escape keeps text from being read as roff: backslashes are written as
+// \e, and no line may open with a control character.
+func escape(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, `\`, `\e`), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func quote(text string) string {
+	return `"` + strings.ReplaceAll(escape(text), `"`, `\(dq`) + `"`
+}
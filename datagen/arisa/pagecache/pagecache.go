@@ -0,0 +1,34 @@
+// Package pagecache maps page URLs to files in a directory of recorded
+// pages, which the scrapers write with --cache-dir and replay with
+// --offline.
+package pagecache
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Path maps a page URL to where its raw body is kept under dir, e.g.
+// https://www.felixcloutier.com/x86/add becomes
+// <dir>/www.felixcloutier.com/x86/add.html and a directory URL such as
+// .../x86/ becomes <dir>/www.felixcloutier.com/x86/index.html. A query
+// string, if any, is kept in the file name.
+func Path(dir, pageURL string) (string, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL for cache: %w", err)
+	}
+
+	name := strings.Trim(u.Path, "/")
+	if name == "" || strings.HasSuffix(u.Path, "/") {
+		name = path.Join(name, "index")
+	}
+	if u.RawQuery != "" {
+		name += "_" + strings.NewReplacer("/", "_", "&", "_", "=", "-", ";", "_").Replace(u.RawQuery)
+	}
+	host := strings.ReplaceAll(u.Host, ":", "_")
+	return filepath.Join(dir, host, filepath.FromSlash(name)+".html"), nil
+}
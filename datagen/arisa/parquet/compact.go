@@ -0,0 +1,104 @@
+package parquet
+
+import "bytes"
+
+// Thrift compact protocol type codes.
+const (
+	compactI32    = 5
+	compactI64    = 6
+	compactBinary = 8
+	compactList   = 9
+	compactStruct = 12
+)
+
+// compactWriter encodes Thrift structs in the compact protocol. Field IDs
+// are delta-encoded against the previous field of the same struct, so the
+// writer keeps a stack of the last ID written at each nesting level.
+type compactWriter struct {
+	buf    bytes.Buffer
+	fields []int16
+}
+
+func newCompactWriter() *compactWriter {
+	return &compactWriter{fields: []int16{0}}
+}
+
+func (w *compactWriter) fieldHeader(id int16, typ byte) {
+	last := &w.fields[len(w.fields)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+	*last = id
+}
+
+// varint writes a zigzag-encoded integer.
+func (w *compactWriter) varint(n int64) {
+	w.uvarint(uint64(n<<1) ^ uint64(n>>63))
+}
+
+func (w *compactWriter) uvarint(n uint64) {
+	for n >= 0x80 {
+		w.buf.WriteByte(byte(n) | 0x80)
+		n >>= 7
+	}
+	w.buf.WriteByte(byte(n))
+}
+
+func (w *compactWriter) i32(id int16, n int32) {
+	w.fieldHeader(id, compactI32)
+	w.varint(int64(n))
+}
+
+func (w *compactWriter) i64(id int16, n int64) {
+	w.fieldHeader(id, compactI64)
+	w.varint(n)
+}
+
+func (w *compactWriter) binary(id int16, s string) {
+	w.fieldHeader(id, compactBinary)
+	w.rawBinary(s)
+}
+
+func (w *compactWriter) rawBinary(s string) {
+	w.uvarint(uint64(len(s)))
+	w.buf.WriteString(s)
+}
+
+// beginList writes a list field's header; the caller then writes size
+// elements of elementType.
+func (w *compactWriter) beginList(id int16, elementType byte, size int) {
+	w.fieldHeader(id, compactList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elementType)
+		return
+	}
+	w.buf.WriteByte(0xf0 | elementType)
+	w.uvarint(uint64(size))
+}
+
+func (w *compactWriter) beginStruct(id int16) {
+	w.fieldHeader(id, compactStruct)
+	w.beginElement()
+}
+
+func (w *compactWriter) endStruct() {
+	w.endElement()
+}
+
+// beginElement starts a struct that is a list element, which has no field
+// header of its own.
+func (w *compactWriter) beginElement() {
+	w.fields = append(w.fields, 0)
+}
+
+func (w *compactWriter) endElement() {
+	w.stop()
+	w.fields = w.fields[:len(w.fields)-1]
+}
+
+func (w *compactWriter) stop() {
+	w.buf.WriteByte(0)
+}
@@ -0,0 +1,146 @@
+// Package parquet writes flat string tables as Apache Parquet files, so the
+// generators' exports can be loaded straight into DuckDB, Pandas or Spark.
+//
+// Only what those tables need is implemented: one row group, one
+// zstd-compressed PLAIN data page per column, and required UTF-8 columns.
+// The file metadata is encoded by hand in Thrift's compact protocol.
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const createdBy = "arisa"
+
+var magic = []byte("PAR1")
+
+// Parquet enum values, from parquet.thrift.
+const (
+	typeByteArray      = 6
+	repetitionRequired = 0
+	convertedUTF8      = 0
+	encodingPlain      = 0
+	codecZstd          = 6
+	pageData           = 0
+)
+
+// Encode returns a Parquet file holding rows under the given column names.
+// Every row must have one cell per column.
+func Encode(columns []string, rows [][]string) ([]byte, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns")
+	}
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return nil, fmt.Errorf("row %d has %d cells, want %d", i, len(row), len(columns))
+		}
+	}
+
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+	}
+	defer encoder.Close()
+
+	file := new(bytes.Buffer)
+	file.Write(magic)
+
+	chunks := make([]columnChunk, len(columns))
+	for i := range columns {
+		var page bytes.Buffer
+		for _, row := range rows {
+			binary.Write(&page, binary.LittleEndian, uint32(len(row[i])))
+			page.WriteString(row[i])
+		}
+		compressed := encoder.EncodeAll(page.Bytes(), nil)
+
+		header := newCompactWriter()
+		header.i32(1, pageData)
+		header.i32(2, int32(page.Len()))
+		header.i32(3, int32(len(compressed)))
+		header.beginStruct(5)
+		header.i32(1, int32(len(rows)))
+		header.i32(2, encodingPlain)
+		header.i32(3, 3) // RLE; unused, since the column is required
+		header.i32(4, 3)
+		header.endStruct()
+		header.stop()
+
+		chunks[i] = columnChunk{
+			offset:       int64(file.Len()),
+			uncompressed: int64(header.buf.Len() + page.Len()),
+			compressed:   int64(header.buf.Len() + len(compressed)),
+		}
+		file.Write(header.buf.Bytes())
+		file.Write(compressed)
+	}
+
+	footer := fileMetaData(columns, chunks, int64(len(rows)))
+	file.Write(footer)
+	binary.Write(file, binary.LittleEndian, uint32(len(footer)))
+	file.Write(magic)
+	return file.Bytes(), nil
+}
+
+type columnChunk struct {
+	offset       int64
+	uncompressed int64
+	compressed   int64
+}
+
+func fileMetaData(columns []string, chunks []columnChunk, numRows int64) []byte {
+	meta := newCompactWriter()
+	meta.i32(1, 1)
+
+	meta.beginList(2, compactStruct, len(columns)+1)
+	meta.beginElement()
+	meta.binary(4, "schema")
+	meta.i32(5, int32(len(columns)))
+	meta.endElement()
+	for _, column := range columns {
+		meta.beginElement()
+		meta.i32(1, typeByteArray)
+		meta.i32(3, repetitionRequired)
+		meta.binary(4, column)
+		meta.i32(6, convertedUTF8)
+		meta.endElement()
+	}
+
+	meta.i64(3, numRows)
+
+	var totalSize int64
+	for _, chunk := range chunks {
+		totalSize += chunk.uncompressed
+	}
+	meta.beginList(4, compactStruct, 1)
+	meta.beginElement()
+	meta.beginList(1, compactStruct, len(chunks))
+	for i, chunk := range chunks {
+		meta.beginElement()
+		meta.i64(2, chunk.offset)
+		meta.beginStruct(3)
+		meta.i32(1, typeByteArray)
+		meta.beginList(2, compactI32, 1)
+		meta.varint(encodingPlain)
+		meta.beginList(3, compactBinary, 1)
+		meta.rawBinary(columns[i])
+		meta.i32(4, codecZstd)
+		meta.i64(5, numRows)
+		meta.i64(6, chunk.uncompressed)
+		meta.i64(7, chunk.compressed)
+		meta.i64(9, chunk.offset)
+		meta.endStruct()
+		meta.endElement()
+	}
+	meta.i64(2, totalSize)
+	meta.i64(3, numRows)
+	meta.endElement()
+
+	meta.binary(6, createdBy)
+	meta.stop()
+	return meta.buf.Bytes()
+}
@@ -0,0 +1,72 @@
+//go:build parquetreader
+
+// The reader's dependencies are only fetched for this test, so it runs on
+// request: go test -tags parquetreader ./parquet
+
+package parquet
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+type formRow struct {
+	Mnemonic string `parquet:"name=mnemonic, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Opcode   string `parquet:"name=opcode, type=BYTE_ARRAY, convertedtype=UTF8"`
+	CPUID    string `parquet:"name=cpuid, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+// TestEncodeReadsBack checks Encode's output with an independent Parquet
+// reader rather than against our own idea of the format.
+func TestEncodeReadsBack(t *testing.T) {
+	want := []formRow{
+		{"XCHG", "REX.W + 87 /r", "X64"},
+		{"VPDPBUSD", "EVEX.512.66.0F38.W0 50 /r", "AVX512_VNNI"},
+		{"MOVS", "A5", ""},
+		{"FADD", "D8 /0", "x87 – “FPU”"},
+	}
+	// Pad the table out so the column pages aren't trivially small.
+	for i := 0; i < 500; i++ {
+		want = append(want, formRow{fmt.Sprintf("NOP%d", i), "0F 1F /0", strings.Repeat("X", i%7)})
+	}
+
+	rows := make([][]string, len(want))
+	for i, row := range want {
+		rows[i] = []string{row.Mnemonic, row.Opcode, row.CPUID}
+	}
+	content, err := Encode([]string{"mnemonic", "opcode", "cpuid"}, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := buffer.NewBufferFile(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := reader.NewParquetReader(file, new(formRow), 1)
+	if err != nil {
+		t.Fatalf("reader rejected the file: %v", err)
+	}
+	defer r.ReadStop()
+
+	if got := r.GetNumRows(); got != int64(len(want)) {
+		t.Fatalf("NumRows = %d, want %d", got, len(want))
+	}
+	if got := *r.Footer.CreatedBy; got != createdBy {
+		t.Errorf("CreatedBy = %q, want %q", got, createdBy)
+	}
+
+	got := make([]formRow, r.GetNumRows())
+	if err := r.Read(&got); err != nil {
+		t.Fatalf("failed to read rows: %v", err)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("row %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
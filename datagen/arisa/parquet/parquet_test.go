@@ -0,0 +1,12 @@
+package parquet
+
+import "testing"
+
+func TestEncodeRejectsRaggedRows(t *testing.T) {
+	if _, err := Encode([]string{"a", "b"}, [][]string{{"1", "2"}, {"3"}}); err == nil {
+		t.Error("Encode accepted a row with a missing cell")
+	}
+	if _, err := Encode(nil, nil); err == nil {
+		t.Error("Encode accepted a table without columns")
+	}
+}
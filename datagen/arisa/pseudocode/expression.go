@@ -0,0 +1,261 @@
+package pseudocode
+
+import (
+	"strings"
+
+	"arisa/schema"
+)
+
+// binaryOperator is an infix operator's normalized name and precedence.
+// Higher binds tighter; all of them associate to the left except ?:.
+type binaryOperator struct {
+	name       string
+	precedence int
+}
+
+var binarySymbols = map[string]binaryOperator{
+	"?":  {"?", 1},
+	"||": {"OR", 2},
+	"&&": {"AND", 4},
+	"=":  {"=", 6}, "==": {"=", 6}, "!=": {"!=", 6}, "<>": {"!=", 6},
+	"<": {"<", 6}, ">": {">", 6}, "<=": {"<=", 6}, ">=": {">=", 6},
+	"|":  {"|", 7},
+	"^":  {"^", 8},
+	"&":  {"&", 9},
+	"<<": {"<<", 10}, ">>": {">>", 10},
+	"+": {"+", 11}, "-": {"-", 11},
+	"*": {"*", 12}, "/": {"/", 12}, "%": {"MOD", 12},
+}
+
+var binaryWords = map[string]binaryOperator{
+	"OR":  {"OR", 2},
+	"XOR": {"XOR", 3},
+	"AND": {"AND", 4},
+	"MOD": {"MOD", 12},
+}
+
+const (
+	notPrecedence   = 5
+	unaryPrecedence = 13
+)
+
+// reserved are the words that can't be names in an expression.
+var reserved = map[string]bool{
+	"IF": true, "THEN": true, "ELSE": true, "ELSIF": true, "ELSEIF": true, "FI": true, "ENDIF": true,
+	"FOR": true, "TO": true, "DO": true, "OD": true, "ENDFOR": true, "WHILE": true, "ENDWHILE": true,
+	"CASE": true, "OF": true, "ESAC": true, "ENDCASE": true, "END": true, "BREAK": true, "GOTO": true,
+	"AND": true, "OR": true, "XOR": true, "NOT": true, "MOD": true,
+}
+
+// expression parses tokens as an expression, or keeps them as text.
+func (p *parser) expression(tokens []token) schema.Expression {
+	if expression, ok := p.parseExpression(tokens); ok {
+		return expression
+	}
+	return schema.Expression{Kind: "text", Text: p.raw(tokens)}
+}
+
+// parseExpression parses tokens as one expression, reporting whether all
+// of them were used.
+func (p *parser) parseExpression(tokens []token) (schema.Expression, bool) {
+	e := &expressionParser{parser: p, tokens: tokens}
+	expression := e.parse(0)
+	return expression, !e.failed && e.pos == len(tokens)
+}
+
+// expressionParser is a precedence-climbing parser over a statement's
+// tokens. It stops at the first thing it can't read and sets failed.
+type expressionParser struct {
+	*parser
+	tokens []token
+	pos    int
+	failed bool
+}
+
+func (e *expressionParser) peek() token {
+	if e.pos < len(e.tokens) {
+		return e.tokens[e.pos]
+	}
+	return token{kind: tokenEOF}
+}
+
+func (e *expressionParser) expect(symbol string) {
+	if isSymbol(e.peek(), symbol) {
+		e.pos++
+	} else {
+		e.failed = true
+	}
+}
+
+func (e *expressionParser) parse(minPrecedence int) schema.Expression {
+	left := e.unary()
+	for !e.failed {
+		operator, ok := e.binary(e.peek())
+		if !ok || operator.precedence < minPrecedence {
+			return left
+		}
+		e.pos++
+		if operator.name == "?" {
+			then := e.parse(0)
+			e.expect(":")
+			otherwise := e.parse(operator.precedence)
+			left = schema.Expression{Kind: "conditional", Operands: []schema.Expression{left, then, otherwise}}
+			continue
+		}
+		right := e.parse(operator.precedence + 1)
+		left = schema.Expression{Kind: "binary", Operator: operator.name, Operands: []schema.Expression{left, right}}
+	}
+	return left
+}
+
+func (e *expressionParser) binary(t token) (binaryOperator, bool) {
+	switch t.kind {
+	case tokenSymbol:
+		operator, ok := binarySymbols[t.text]
+		return operator, ok
+	case tokenWord:
+		operator, ok := binaryWords[strings.ToUpper(t.text)]
+		return operator, ok
+	}
+	return binaryOperator{}, false
+}
+
+func (e *expressionParser) unary() schema.Expression {
+	t := e.peek()
+	switch {
+	case isWord(t, "NOT") || isSymbol(t, "!"):
+		e.pos++
+		operand := e.parse(notPrecedence)
+		return schema.Expression{Kind: "unary", Operator: "NOT", Operands: []schema.Expression{operand}}
+	case isSymbol(t, "-") || isSymbol(t, "~"):
+		e.pos++
+		operand := e.parse(unaryPrecedence)
+		return schema.Expression{Kind: "unary", Operator: t.text, Operands: []schema.Expression{operand}}
+	case isSymbol(t, "+"):
+		e.pos++
+		return e.parse(unaryPrecedence)
+	}
+	start := e.pos
+	operand := e.postfix(e.primary())
+	if !e.failed && e.peek().kind == tokenNote {
+		// An operand remarked on, as in SRC2 *is memory*, is a
+		// condition in prose.
+		e.pos++
+		return schema.Expression{Kind: "text", Text: e.raw(e.tokens[start:e.pos])}
+	}
+	return operand
+}
+
+func (e *expressionParser) primary() schema.Expression {
+	t := e.peek()
+	switch {
+	case t.kind == tokenNumber:
+		e.pos++
+		return schema.Expression{Kind: "number", Value: t.text}
+	case t.kind == tokenNote:
+		e.pos++
+		return schema.Expression{Kind: "text", Text: t.text}
+	case t.kind == tokenWord && !reserved[strings.ToUpper(t.text)]:
+		e.pos++
+		if isSymbol(e.peek(), "(") {
+			return schema.Expression{Kind: "call", Name: t.text, Operands: e.arguments()}
+		}
+		return schema.Expression{Kind: "name", Name: t.text}
+	case isSymbol(t, "("):
+		e.pos++
+		inner := e.parse(0)
+		e.expect(")")
+		return inner
+	}
+	e.failed = true
+	return schema.Expression{}
+}
+
+// postfix applies the field accesses, indexes and bit slices following an
+// operand, as in src.byte[j] or DEST[127:64].
+func (e *expressionParser) postfix(operand schema.Expression) schema.Expression {
+	for !e.failed {
+		switch t := e.peek(); {
+		case isSymbol(t, "."):
+			e.pos++
+			field := e.peek()
+			if field.kind != tokenWord {
+				e.failed = true
+				return operand
+			}
+			e.pos++
+			operand = schema.Expression{Kind: "field", Name: field.text, Operands: []schema.Expression{operand}}
+		case isSymbol(t, "["):
+			inside := e.enclosed("]")
+			if e.failed {
+				return operand
+			}
+			operand = e.subscript(operand, inside)
+		default:
+			return operand
+		}
+	}
+	return operand
+}
+
+// subscript is operand[inside]: a slice if inside has a high and a low
+// bit, separated by ":", ".." or "...", or otherwise an index.
+func (e *expressionParser) subscript(operand schema.Expression, inside []token) schema.Expression {
+	for _, separator := range []string{":", "..", "..."} {
+		if bounds := splitTokens(inside, separator); len(bounds) == 2 {
+			high, highOK := e.parseExpression(bounds[0])
+			low, lowOK := e.parseExpression(bounds[1])
+			if !highOK || !lowOK {
+				e.failed = true
+				return operand
+			}
+			return schema.Expression{Kind: "slice", Operands: []schema.Expression{operand}, High: &high, Low: &low}
+		}
+	}
+	index, ok := e.parseExpression(inside)
+	if !ok {
+		e.failed = true
+		return operand
+	}
+	return schema.Expression{Kind: "index", Operands: []schema.Expression{operand, index}}
+}
+
+// arguments parses a call's parenthesized arguments. An argument that
+// isn't an expression, such as "x87 FPU", is kept as text rather than
+// failing the call.
+func (e *expressionParser) arguments() []schema.Expression {
+	inside := e.enclosed(")")
+	if e.failed || len(inside) == 0 {
+		return nil
+	}
+	var arguments []schema.Expression
+	for _, argument := range splitTokens(inside, ",") {
+		arguments = append(arguments, e.expression(argument))
+	}
+	return arguments
+}
+
+// enclosed consumes an opening parenthesis or bracket and returns the
+// tokens up to the matching close, consuming that too.
+func (e *expressionParser) enclosed(close string) []token {
+	e.pos++
+	start, depth := e.pos, 1
+	for ; e.pos < len(e.tokens); e.pos++ {
+		switch t := e.tokens[e.pos]; {
+		case isSymbol(t, "(") || isSymbol(t, "["):
+			depth++
+		case isSymbol(t, ")") || isSymbol(t, "]"):
+			depth--
+			if depth == 0 {
+				if !isSymbol(t, close) {
+					e.failed = true
+				}
+				inside := e.tokens[start:e.pos]
+				e.pos++
+				return inside
+			}
+		}
+	}
+	e.failed = true
+	return nil
+}
@@ -0,0 +1,280 @@
+package pseudocode
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenNumber
+	tokenSymbol
+	tokenNote
+	tokenNewline
+	tokenOther
+	tokenEOF
+)
+
+// token is a lexed word, number or symbol. Symbols and numbers are
+// normalized, so text is "-" for an en dash and "0xffff" for FFFFH; start
+// and end still locate the original in the source. indent is the column
+// its line starts at, which pairs up FIs in ELSE IF chains.
+type token struct {
+	kind       tokenKind
+	text       string
+	start, end int
+	line       int
+	indent     int
+}
+
+var (
+	// commentPattern matches (* ... *), /* ... */ and // comments, which
+	// may hold anything, including a stray FI.
+	commentPattern = regexp.MustCompile(`(?s)\(\*.*?\*\)|/\*.*?\*/|//[^\n]*`)
+
+	// sizedRegisterPattern matches the manual's names for a register of
+	// the address or operand size, such as (E)SI and (R|E)DI.
+	sizedRegisterPattern = regexp.MustCompile(`^\((?:R\|)?E\)[A-Z]{1,2}\b`)
+
+	// proseCommentPattern matches the "; zeroing-masking" remarks the
+	// AVX-512 pseudocode puts after a statement or on a line of their
+	// own. The semicolon is set apart from the statement, unlike one
+	// ending it, and a FI sometimes runs into the remark.
+	proseCommentPattern = regexp.MustCompile(`(?m)(?:^|[ \t]);[ \t]*(([A-Za-z]+)[^:=;\[\]{}\n]*?)(?:FI;?)?[ \t]*$`)
+
+	// notePattern matches remarks between asterisks, such as *no
+	// writemask* or *DEST[63:0] remains unchanged*, which stand in for a
+	// condition or a statement.
+	notePattern = regexp.MustCompile(`^\*([A-Za-z][^*\n]*[^*\s])\*`)
+
+	// intelHexPattern matches hex numbers written the manual's way, with
+	// an H suffix. Without a leading digit it takes four digits, so the
+	// AH to DH registers stay names.
+	intelHexPattern = regexp.MustCompile(`^(?:[0-9][0-9A-Fa-f]*|[0-9A-F]{4,})[Hh]$`)
+	cHexPattern     = regexp.MustCompile(`^0[xX][0-9A-Fa-f]+$`)
+	decimalPattern  = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+)
+
+// symbols are tried longest first.
+var symbols = []string{
+	":=", "...", "..", "=\u0338", "==", "!=", "<>", "<=", ">=", "<<", ">>", "&&", "||",
+	"+", "-", "*", "/", "%", "&", "|", "^", "~", "!", "=", "<", ">",
+	"(", ")", "[", "]", "{", "}", ",", ":", ";", "?", ".",
+}
+
+// unicodeSymbols are the typographic forms the manual uses for symbols.
+var unicodeSymbols = map[rune]string{
+	'←': ":=",
+	'«': "<<",
+	'»': ">>",
+	'≠': "!=",
+	'≤': "<=",
+	'≥': ">=",
+	'–': "-",
+	'−': "-",
+	'∗': "*",
+	'×': "*",
+}
+
+// continuations are the symbols and words that, ending a line, carry the
+// statement on to the next. "*" isn't among them, since prose such as
+// "IF k1[j] OR *no writemask*" ends lines with it.
+var continuations = map[string]bool{
+	"+": true, "&": true, "|": true, "^": true,
+	"=": true, "==": true, "!=": true, "<>": true, "<": true, ">": true, "<=": true, ">=": true,
+	"&&": true, "||": true, ",": true, ":=": true, "?": true,
+	"AND": true, "OR": true, "XOR": true,
+}
+
+// lex splits source into tokens. Comments are dropped, and newlines only
+// become tokens where they can end a statement: not inside parentheses or
+// brackets, and not after an operator that needs a right-hand side.
+func lex(source string) []token {
+	stripped := commentPattern.ReplaceAllStringFunc(source, func(comment string) string {
+		return blank(comment)
+	})
+	stripped = blankProseComments(stripped)
+
+	var tokens []token
+	line, lineStart, indent := 1, 0, -1
+	depth := 0
+	emit := func(kind tokenKind, text string, start, end int) {
+		if indent < 0 {
+			indent = utf8.RuneCountInString(stripped[lineStart:start])
+		}
+		tokens = append(tokens, token{kind: kind, text: text, start: start, end: end, line: line, indent: indent})
+	}
+
+	for i := 0; i < len(stripped); {
+		r, size := utf8.DecodeRuneInString(stripped[i:])
+		switch {
+		case r == '\n':
+			if depth == 0 && len(tokens) > 0 && !continues(tokens[len(tokens)-1]) {
+				tokens = append(tokens, token{kind: tokenNewline, start: i, end: i, line: line})
+			}
+			line++
+			lineStart, indent = i+1, -1
+			i++
+		case unicode.IsSpace(r):
+			i += size
+		case isWordStart(r) || (r == '#' && i+1 < len(stripped) && isWordStart(rune(stripped[i+1]))):
+			end := i + 1
+			for end < len(stripped) && isWordPart(rune(stripped[end])) {
+				end++
+			}
+			word := stripped[i:end]
+			if intelHexPattern.MatchString(word) {
+				emit(tokenNumber, "0x"+strings.ToLower(word[:len(word)-1]), i, end)
+			} else {
+				emit(tokenWord, word, i, end)
+			}
+			i = end
+		case r == '(' && sizedRegisterPattern.MatchString(stripped[i:]):
+			end := i + len(sizedRegisterPattern.FindString(stripped[i:]))
+			emit(tokenWord, stripped[i:end], i, end)
+			i = end
+		case r == '*' && startsNote(stripped, i):
+			note := notePattern.FindStringSubmatch(stripped[i:])
+			emit(tokenNote, note[1], i, i+len(note[0]))
+			i += len(note[0])
+		case r >= '0' && r <= '9':
+			end := i + 1
+			for end < len(stripped) && isWordPart(rune(stripped[end])) {
+				end++
+			}
+			if fraction := end + 1; decimalPattern.MatchString(stripped[i:end]) && fraction < len(stripped) && stripped[end] == '.' && isDigit(stripped[fraction]) {
+				for end = fraction; end < len(stripped) && isDigit(stripped[end]); end++ {
+				}
+			}
+			kind, text := numberKind(stripped[i:end])
+			emit(kind, text, i, end)
+			i = end
+		default:
+			text, width := symbolAt(stripped[i:])
+			if text == "" {
+				emit(tokenOther, string(r), i, i+size)
+				i += size
+				continue
+			}
+			emit(tokenSymbol, text, i, i+width)
+			switch text {
+			case "(", "[":
+				depth++
+			case ")", "]":
+				depth = max(depth-1, 0)
+			case ";":
+				// A statement never continues past a semicolon, so an
+				// unbalanced parenthesis in prose stops here.
+				depth = 0
+			}
+			i += width
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, start: len(stripped), end: len(stripped), line: line, indent: -1})
+}
+
+// numberKind classifies a word starting with a digit, normalizing hex to
+// 0x form. Words like "2nd" or "64bit" aren't numbers or names.
+func numberKind(word string) (tokenKind, string) {
+	switch {
+	case decimalPattern.MatchString(word):
+		return tokenNumber, word
+	case cHexPattern.MatchString(word):
+		return tokenNumber, strings.ToLower(word)
+	case intelHexPattern.MatchString(word):
+		return tokenNumber, "0x" + strings.ToLower(word[:len(word)-1])
+	}
+	return tokenOther, word
+}
+
+func symbolAt(text string) (string, int) {
+	for _, symbol := range symbols {
+		if strings.HasPrefix(text, symbol) {
+			if symbol == "=\u0338" {
+				// The manual's ≠ is often = with a combining slash.
+				return "!=", len(symbol)
+			}
+			return symbol, len(symbol)
+		}
+	}
+	r, size := utf8.DecodeRuneInString(text)
+	if symbol, ok := unicodeSymbols[r]; ok {
+		return symbol, size
+	}
+	return "", 0
+}
+
+func continues(last token) bool {
+	switch last.kind {
+	case tokenSymbol:
+		return continuations[last.text]
+	case tokenWord:
+		return continuations[strings.ToUpper(last.text)]
+	}
+	return false
+}
+
+// blankProseComments replaces the prose comments in text with spaces.
+func blankProseComments(text string) string {
+	matches := proseCommentPattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		if firstWord := text[match[4]:match[5]]; reserved[strings.ToUpper(firstWord)] {
+			continue
+		}
+		// Blank from the semicolon through the remark, keeping any FI
+		// that ran into it.
+		semicolon := strings.IndexByte(text[match[0]:], ';') + match[0]
+		b.WriteString(text[last:semicolon])
+		b.WriteString(blank(text[semicolon:match[3]]))
+		last = match[3]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// blank replaces everything but newlines in text with spaces, so token
+// positions and line numbers don't move.
+func blank(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' {
+			return r
+		}
+		return ' '
+	}, text)
+}
+
+// startsNote reports whether the asterisk at i opens a *remark*: it
+// starts a word, and its closing asterisk ends one. That keeps it apart
+// from multiplication, as in i*8.
+func startsNote(text string, i int) bool {
+	if i > 0 && !strings.ContainsRune(" \t(", rune(text[i-1])) {
+		return false
+	}
+	note := notePattern.FindStringIndex(text[i:])
+	if note == nil {
+		return false
+	}
+	end := i + note[1]
+	return end == len(text) || strings.ContainsRune(" \t\n);,", rune(text[end]))
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordStart(r rune) bool {
+	return r == '_' || (r < utf8.RuneSelf && unicode.IsLetter(r))
+}
+
+func isWordPart(r rune) bool {
+	return isWordStart(r) || (r >= '0' && r <= '9')
+}
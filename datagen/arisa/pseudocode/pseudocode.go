@@ -0,0 +1,441 @@
+// Package pseudocode parses the Operation pseudocode of the Intel manual,
+// as felixcloutier.com reproduces it, into schema.Statement trees for
+// symbolic execution and emulators. The pseudocode has no grammar and
+// mixes in prose, so the parser is forgiving: whatever it can't read as
+// code becomes a "text" node, and the raw Operation text stays the
+// authority.
+package pseudocode
+
+import (
+	"strings"
+
+	"arisa/schema"
+)
+
+// blockEnds are the words that close a block. Any of them ends the block
+// being parsed; the construct that opened it then decides whether the
+// word is its own.
+var blockEnds = map[string]bool{
+	"FI": true, "ENDIF": true, "ELSE": true, "ELSIF": true, "ELSEIF": true,
+	"ENDFOR": true, "OD": true, "ENDWHILE": true, "ESAC": true, "ENDCASE": true, "END": true,
+}
+
+type parser struct {
+	source string
+	tokens []token
+	pos    int
+	// closeIndent is the indent of the FI that closed the last IF, or -1
+	// if it wasn't closed.
+	closeIndent int
+}
+
+// Parse parses Operation pseudocode. It never fails: a FI or ENDFOR
+// without its opening statement is skipped, and a block left open runs
+// to the end of the text.
+func Parse(text string) []schema.Statement {
+	p := &parser{source: text, tokens: lex(text)}
+	var statements []schema.Statement
+	for {
+		statements = append(statements, p.parseBlock(-1)...)
+		if p.peek().kind == tokenEOF {
+			return statements
+		}
+		p.next()
+	}
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func isWord(t token, words ...string) bool {
+	if t.kind != tokenWord {
+		return false
+	}
+	for _, word := range words {
+		if strings.EqualFold(t.text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSymbol(t token, symbol string) bool {
+	return t.kind == tokenSymbol && t.text == symbol
+}
+
+func isBlockEnd(t token) bool {
+	return (t.kind == tokenWord && blockEnds[strings.ToUpper(t.text)]) || isSymbol(t, "}")
+}
+
+func (p *parser) skipSeparators() {
+	for t := p.peek(); t.kind == tokenNewline || isSymbol(t, ";"); t = p.peek() {
+		p.next()
+	}
+}
+
+func (p *parser) skipNewlines() {
+	for p.peek().kind == tokenNewline {
+		p.next()
+	}
+}
+
+// raw is the source text tokens came from, with its whitespace collapsed.
+func (p *parser) raw(tokens []token) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+	return strings.Join(strings.Fields(p.source[tokens[0].start:tokens[len(tokens)-1].end]), " ")
+}
+
+// parseBlock parses statements up to a block end or the end of the text.
+// Python-style blocks, whose header ends in a colon, have no closing word;
+// for them indent is the header's, and the block ends at the first
+// statement indented no further. Otherwise indent is -1.
+func (p *parser) parseBlock(indent int) []schema.Statement {
+	var statements []schema.Statement
+	for {
+		p.skipSeparators()
+		t := p.peek()
+		if t.kind == tokenEOF || isBlockEnd(t) || (indent >= 0 && t.indent <= indent) {
+			return statements
+		}
+		statements = append(statements, p.parseStatement())
+	}
+}
+
+func (p *parser) parseStatement() schema.Statement {
+	t := p.peek()
+	switch {
+	case isWord(t, "IF"):
+		return p.parseIf()
+	case isWord(t, "FOR"):
+		if statement, ok := p.parseFor(); ok {
+			return statement
+		}
+	case isWord(t, "WHILE"):
+		return p.parseWhile()
+	case isWord(t, "CASE"):
+		return p.parseCase()
+	case isWord(t, "BREAK"):
+		p.next()
+		p.collect()
+		return schema.Statement{Kind: "break", Line: t.line}
+	case isWord(t, "GOTO"):
+		p.next()
+		return schema.Statement{Kind: "goto", Line: t.line, Name: p.raw(p.collect())}
+	}
+	return p.simple(p.collect())
+}
+
+// collect takes the tokens of a simple statement: up to a semicolon, the
+// end of the line, or a block end.
+func (p *parser) collect() []token {
+	start := p.pos
+	for t := p.peek(); t.kind != tokenEOF && t.kind != tokenNewline && !isSymbol(t, ";"); t = p.peek() {
+		if p.pos > start && isBlockEnd(t) {
+			break
+		}
+		p.next()
+	}
+	return p.tokens[start:p.pos]
+}
+
+// collectHeader takes the tokens of an IF, FOR, WHILE or CASE header, up
+// to the end of the line or the first of stops. A trailing Python-style
+// colon is dropped, and reported.
+func (p *parser) collectHeader(stops ...string) (header []token, colon bool) {
+	var tokens []token
+	for t := p.peek(); t.kind != tokenEOF && !isSymbol(t, ";") && !isSymbol(t, "{") && !isWord(t, stops...); t = p.peek() {
+		p.next()
+		if t.kind == tokenNewline {
+			if !p.headerContinues(stops) {
+				p.pos--
+				break
+			}
+			continue
+		}
+		tokens = append(tokens, t)
+	}
+	if n := len(tokens); n > 0 && isSymbol(tokens[n-1], ":") {
+		return tokens[:n-1], true
+	}
+	return tokens, false
+}
+
+// headerContinues reports whether a header carries on past the newline
+// just taken: when the next line starts with AND or OR, or when it isn't
+// a statement and the line after it starts with one of stops, as in
+//
+//	IF (condition on one line
+//	    and more of it on the next)
+//	    THEN
+func (p *parser) headerContinues(stops []string) bool {
+	t := p.peek()
+	if isWord(t, "AND", "OR") {
+		return true
+	}
+	if len(stops) == 0 || isBlockEnd(t) || isWord(t, stops...) {
+		return false
+	}
+	for i := p.pos; i < len(p.tokens); i++ {
+		switch t := p.tokens[i]; {
+		case t.kind == tokenEOF, isSymbol(t, ";"), isSymbol(t, ":="):
+			return false
+		case t.kind == tokenNewline:
+			return isWord(p.tokens[i+1], stops...)
+		}
+	}
+	return false
+}
+
+// simple parses an assignment, a label, or an expression statement, or
+// keeps the statement as text.
+func (p *parser) simple(tokens []token) schema.Statement {
+	if len(tokens) == 0 {
+		return schema.Statement{Kind: "text", Line: p.peek().line}
+	}
+	line := tokens[0].line
+
+	depth := 0
+	for i, t := range tokens {
+		switch {
+		case isSymbol(t, "(") || isSymbol(t, "["):
+			depth++
+		case isSymbol(t, ")") || isSymbol(t, "]"):
+			depth--
+		case depth == 0 && isSymbol(t, ":=") && i > 0:
+			target := p.expression(tokens[:i])
+			value := p.expression(tokens[i+1:])
+			return schema.Statement{Kind: "assign", Line: line, Target: &target, Value: &value}
+		}
+	}
+
+	if len(tokens) == 1 && tokens[0].kind == tokenNote {
+		return schema.Statement{Kind: "text", Line: line, Text: tokens[0].text}
+	}
+	if n := len(tokens); n > 1 && isSymbol(tokens[n-1], ":") {
+		return schema.Statement{Kind: "label", Line: line, Name: p.raw(tokens[:n-1])}
+	}
+	if expression, ok := p.parseExpression(tokens); ok {
+		return schema.Statement{Kind: "expression", Line: line, Value: &expression}
+	}
+	return schema.Statement{Kind: "text", Line: line, Text: p.raw(tokens)}
+}
+
+// parseIf parses IF, or ELSIF as the IF nested in an ELSE, through its FI.
+// THEN is optional, and so is the FI of a Python-style "if cond:".
+func (p *parser) parseIf() schema.Statement {
+	start := p.next()
+	header, python := p.collectHeader("THEN")
+	condition := p.expression(header)
+	statement := schema.Statement{Kind: "if", Line: start.line, Condition: &condition}
+
+	p.skipNewlines()
+	if isWord(p.peek(), "THEN") {
+		p.next()
+	}
+	braced := p.openBrace()
+	blockIndent := -1
+	if python {
+		blockIndent = start.indent
+	}
+	statement.Then = p.parseBlock(blockIndent)
+
+	if braced && isSymbol(p.peek(), "}") {
+		p.next()
+	}
+	save := p.pos
+	p.skipSeparators()
+	t := p.peek()
+	switch {
+	case isWord(t, "ELSIF", "ELSEIF"):
+		statement.Else = []schema.Statement{p.parseIf()}
+	case isWord(t, "ELSE") && (!python || t.indent == start.indent):
+		elseToken := p.next()
+		if isSymbol(p.peek(), ":") {
+			p.next()
+		}
+		if t := p.peek(); isWord(t, "IF") && t.line == elseToken.line {
+			// The manual closes some ELSE IF chains with one FI, like
+			// ELSIF, and others with a FI for each IF. A nested IF
+			// closed by a FI lined up with this IF closes both.
+			statement.Else = []schema.Statement{p.parseIf()}
+			if p.closeIndent == start.indent {
+				return statement
+			}
+		}
+		braced = p.openBrace()
+		statement.Else = append(statement.Else, p.parseBlock(blockIndent)...)
+		p.close(braced, python)
+	case isWord(t, "FI", "ENDIF", "END"):
+		p.close(braced, python)
+	default:
+		p.pos = save
+		p.closeIndent = -1
+	}
+	return statement
+}
+
+// close consumes the word or brace closing an IF's last block, if it has
+// one.
+func (p *parser) close(braced, python bool) {
+	p.closeIndent = -1
+	t := p.peek()
+	switch {
+	case braced && isSymbol(t, "}"), !braced && !python && isWord(t, "FI", "ENDIF", "END"):
+		p.next()
+		p.closeIndent = t.indent
+	}
+}
+
+func (p *parser) openBrace() bool {
+	p.skipNewlines()
+	if isSymbol(p.peek(), "{") {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// parseFor parses "FOR i := 0 TO KL-1" or "for i = 0 to 7 {" through its
+// ENDFOR, OD or closing brace. A FOR that isn't a counted loop isn't
+// taken as one, since its body would swallow the rest of the text.
+func (p *parser) parseFor() (schema.Statement, bool) {
+	save := p.pos
+	start := p.next()
+	header, python := p.collectHeader("DO")
+
+	to := -1
+	for i, t := range header {
+		if isWord(t, "TO") {
+			to = i
+			break
+		}
+	}
+	if len(header) < 4 || header[0].kind != tokenWord || !(isSymbol(header[1], ":=") || isSymbol(header[1], "=")) || to < 3 {
+		p.pos = save
+		return schema.Statement{}, false
+	}
+	from := p.expression(header[2:to])
+	limit := p.expression(header[to+1:])
+	statement := schema.Statement{Kind: "for", Line: start.line, Variable: header[0].text, From: &from, To: &limit}
+	statement.Body = p.parseLoopBody(start, python, "ENDFOR", "OD", "END")
+	return statement, true
+}
+
+// parseWhile parses "WHILE cond DO" through its OD, ENDWHILE or closing
+// brace.
+func (p *parser) parseWhile() schema.Statement {
+	start := p.next()
+	header, python := p.collectHeader("DO")
+	condition := p.expression(header)
+	statement := schema.Statement{Kind: "while", Line: start.line, Condition: &condition}
+	statement.Body = p.parseLoopBody(start, python, "OD", "ENDWHILE", "END")
+	return statement
+}
+
+func (p *parser) parseLoopBody(start token, python bool, ends ...string) []schema.Statement {
+	p.skipNewlines()
+	if isWord(p.peek(), "DO") {
+		p.next()
+	}
+	braced := p.openBrace()
+	if python {
+		return p.parseBlock(start.indent)
+	}
+	body := p.parseBlock(-1)
+	if t := p.peek(); (braced && isSymbol(t, "}")) || (!braced && isWord(t, ends...)) {
+		p.next()
+	}
+	return body
+}
+
+// parseCase parses "CASE selector OF", its "label: statements" arms and
+// DEFAULT, through ESAC.
+func (p *parser) parseCase() schema.Statement {
+	start := p.next()
+	header, _ := p.collectHeader("OF")
+	if isWord(p.peek(), "OF") {
+		p.next()
+	}
+	selector := p.expression(header)
+	statement := schema.Statement{Kind: "case", Line: start.line, Value: &selector}
+
+	for {
+		p.skipSeparators()
+		t := p.peek()
+		if isWord(t, "ESAC", "ENDCASE", "END") {
+			p.next()
+			break
+		}
+		if t.kind == tokenEOF || isBlockEnd(t) {
+			break
+		}
+		if labels, ok := p.caseLabels(); ok {
+			statement.Cases = append(statement.Cases, schema.CaseArm{Labels: labels})
+			continue
+		}
+		if len(statement.Cases) == 0 {
+			statement.Cases = append(statement.Cases, schema.CaseArm{})
+		}
+		arm := &statement.Cases[len(statement.Cases)-1]
+		arm.Body = append(arm.Body, p.parseStatement())
+	}
+	return statement
+}
+
+// caseLabels parses the "0:", "1, 2:" or "DEFAULT:" starting an arm, if
+// the line starts with one.
+func (p *parser) caseLabels() ([]schema.Expression, bool) {
+	depth := 0
+	for i := p.pos; i < len(p.tokens); i++ {
+		t := p.tokens[i]
+		switch {
+		case t.kind == tokenEOF || t.kind == tokenNewline || isSymbol(t, ";") || isSymbol(t, ":=") || isSymbol(t, "?"):
+			return nil, false
+		case isSymbol(t, "(") || isSymbol(t, "["):
+			depth++
+		case isSymbol(t, ")") || isSymbol(t, "]"):
+			depth--
+		case depth == 0 && isSymbol(t, ":") && i > p.pos:
+			tokens := p.tokens[p.pos:i]
+			p.pos = i + 1
+			if len(tokens) == 1 && isWord(tokens[0], "DEFAULT") {
+				return nil, true
+			}
+			var labels []schema.Expression
+			for _, label := range splitTokens(tokens, ",") {
+				labels = append(labels, p.expression(label))
+			}
+			return labels, true
+		}
+	}
+	return nil, false
+}
+
+// splitTokens splits tokens at the top-level occurrences of symbol.
+func splitTokens(tokens []token, symbol string) [][]token {
+	var parts [][]token
+	depth, start := 0, 0
+	for i, t := range tokens {
+		switch {
+		case isSymbol(t, "(") || isSymbol(t, "["):
+			depth++
+		case isSymbol(t, ")") || isSymbol(t, "]"):
+			depth--
+		case depth == 0 && isSymbol(t, symbol):
+			parts = append(parts, tokens[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, tokens[start:])
+}
@@ -0,0 +1,325 @@
+// Package robots fetches and applies robots.txt rules (RFC 9309) and the
+// non-standard Crawl-delay extension, so the scrapers stay well-behaved
+// crawlers on every host they touch.
+package robots
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxSize is how much of a robots.txt is read; RFC 9309 allows crawlers to
+// ignore anything past 500 KiB.
+const maxSize = 500 << 10
+
+// ErrDisallowed is returned by Check for URLs robots.txt excludes.
+var ErrDisallowed = errors.New("disallowed by robots.txt")
+
+type rule struct {
+	allow   bool
+	length  int
+	pattern *regexp.Regexp
+}
+
+// Rules are the robots.txt rules that apply to one user agent on one host.
+type Rules struct {
+	rules      []rule
+	crawlDelay time.Duration
+}
+
+var (
+	allowAll    = &Rules{}
+	disallowAll = &Rules{rules: []rule{{allow: false, length: 1, pattern: regexp.MustCompile(`^/`)}}}
+)
+
+// Parse reads a robots.txt and keeps the groups for userAgent, falling back
+// to the "*" group when none names it. Agents are matched on the product
+// token, so "x86-scraper/1.0" is matched by "User-agent: x86-scraper".
+func Parse(r io.Reader, userAgent string) *Rules {
+	token := strings.ToLower(userAgent)
+	if i := strings.IndexAny(token, "/ "); i >= 0 {
+		token = token[:i]
+	}
+
+	var (
+		specific, wildcard      Rules
+		foundSpecific           bool
+		agents                  []string
+		inRules                 bool
+		matchSpecific, matchAny bool
+	)
+
+	scanner := bufio.NewScanner(io.LimitReader(r, maxSize))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if key == "user-agent" {
+			// A user-agent line after rules starts a new group.
+			if inRules {
+				agents = nil
+				inRules = false
+			}
+			agents = append(agents, strings.ToLower(value))
+			matchSpecific, matchAny = false, false
+			for _, agent := range agents {
+				if agent == "*" {
+					matchAny = true
+				} else if agent == token {
+					matchSpecific = true
+				}
+			}
+			if matchSpecific {
+				foundSpecific = true
+			}
+			continue
+		}
+
+		if len(agents) == 0 {
+			continue
+		}
+		inRules = true
+
+		var target *Rules
+		switch {
+		case matchSpecific:
+			target = &specific
+		case matchAny:
+			target = &wildcard
+		default:
+			continue
+		}
+
+		switch key {
+		case "allow", "disallow":
+			if value == "" {
+				continue
+			}
+			if pattern, err := compilePattern(value); err == nil {
+				target.rules = append(target.rules, rule{allow: key == "allow", length: len(value), pattern: pattern})
+			}
+		case "crawl-delay":
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+				target.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+		}
+	}
+
+	if foundSpecific {
+		return &specific
+	}
+	return &wildcard
+}
+
+// compilePattern turns a path pattern into a regexp anchored at the start,
+// with "*" matching any run of characters and a trailing "$" anchoring the
+// end.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.Compile(expr)
+}
+
+// Allowed reports whether the rules permit fetching u. The longest matching
+// rule wins and Allow wins a tie, as RFC 9309 specifies.
+func (r *Rules) Allowed(u *url.URL) bool {
+	target := u.EscapedPath()
+	if target == "" {
+		target = "/"
+	}
+	if target == "/robots.txt" {
+		return true
+	}
+	if u.RawQuery != "" {
+		target += "?" + u.RawQuery
+	}
+
+	best := -1
+	allowed := true
+	for _, rule := range r.rules {
+		if !rule.pattern.MatchString(target) {
+			continue
+		}
+		if rule.length > best || (rule.length == best && rule.allow) {
+			best = rule.length
+			allowed = rule.allow
+		}
+	}
+	return allowed
+}
+
+// CrawlDelay is the delay the host asks for between requests, or zero.
+func (r *Rules) CrawlDelay() time.Duration {
+	return r.crawlDelay
+}
+
+// Transient failures, a 5xx or an unreachable host, disallow the host only
+// until its robots.txt is fetched again, after a backoff that starts at
+// retryBase and doubles up to retryMax.
+const (
+	retryBase = time.Second
+	retryMax  = time.Minute
+)
+
+// entry is one origin's robots.txt. ready is closed once the fetch that
+// created it has finished and the other fields are set.
+type entry struct {
+	ready    chan struct{}
+	rules    *Rules
+	err      error
+	canceled bool
+	failures int
+	retryAt  time.Time
+}
+
+// Checker fetches each host's robots.txt once and answers from then on out
+// of its cache. It is safe for concurrent use; callers asking for a host
+// being fetched wait for that fetch rather than starting their own.
+type Checker struct {
+	client    *http.Client
+	userAgent string
+	mu        sync.Mutex
+	hosts     map[string]*entry
+}
+
+func NewChecker(client *http.Client, userAgent string) *Checker {
+	return &Checker{
+		client:    client,
+		userAgent: userAgent,
+		hosts:     make(map[string]*entry),
+	}
+}
+
+// Rules returns the rules for rawURL's host. A missing robots.txt (any 4xx)
+// allows everything, as RFC 9309 specifies. When the host's robots.txt
+// can't be reached or answers with a 5xx the host is treated as fully
+// disallowed and the error says why, until a retry after a backoff.
+func (c *Checker) Rules(ctx context.Context, rawURL string) (*Rules, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+	origin := u.Scheme + "://" + u.Host
+
+	for {
+		c.mu.Lock()
+		cached, ok := c.hosts[origin]
+		if !ok || c.expired(cached) {
+			fetching := &entry{ready: make(chan struct{})}
+			if ok {
+				fetching.failures = cached.failures
+			}
+			c.hosts[origin] = fetching
+			c.mu.Unlock()
+			return c.fetchEntry(ctx, origin, fetching)
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-cached.ready:
+		case <-ctx.Done():
+			return disallowAll, ctx.Err()
+		}
+		if !cached.canceled {
+			return cached.rules, cached.err
+		}
+	}
+}
+
+// expired reports whether a cached entry is a transient failure whose
+// backoff has run out, or a fetch abandoned when its caller gave up. c.mu
+// must be held.
+func (c *Checker) expired(cached *entry) bool {
+	select {
+	case <-cached.ready:
+	default:
+		return false
+	}
+	return cached.canceled || cached.err != nil && !time.Now().Before(cached.retryAt)
+}
+
+// fetchEntry fetches origin's robots.txt into fetching, which Rules has
+// already put in the cache, without holding c.mu.
+func (c *Checker) fetchEntry(ctx context.Context, origin string, fetching *entry) (*Rules, error) {
+	defer close(fetching.ready)
+
+	fetching.rules, fetching.err = c.fetch(ctx, origin)
+	switch {
+	case ctx.Err() != nil:
+		fetching.canceled = true
+	case fetching.err != nil:
+		delay := retryBase
+		for i := 0; i < fetching.failures && delay < retryMax; i++ {
+			delay *= 2
+		}
+		fetching.failures++
+		fetching.retryAt = time.Now().Add(min(delay, retryMax))
+	default:
+		fetching.failures = 0
+	}
+	return fetching.rules, fetching.err
+}
+
+// Check returns an error wrapping ErrDisallowed when rawURL may not be
+// fetched.
+func (c *Checker) Check(ctx context.Context, rawURL string) error {
+	rules, err := c.Rules(ctx, rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDisallowed, err)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse URL: %w", err)
+	}
+	if !rules.Allowed(u) {
+		return fmt.Errorf("%w: %s", ErrDisallowed, u.EscapedPath())
+	}
+	return nil
+}
+
+func (c *Checker) fetch(ctx context.Context, origin string) (*Rules, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", origin+"/robots.txt", nil)
+	if err != nil {
+		return disallowAll, fmt.Errorf("failed to create robots.txt request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return disallowAll, fmt.Errorf("failed to fetch robots.txt: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return Parse(resp.Body, c.userAgent), nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return allowAll, nil
+	default:
+		return disallowAll, fmt.Errorf("robots.txt returned %s", resp.Status)
+	}
+}
@@ -0,0 +1,82 @@
+package robots
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// serveRobots answers robots.txt requests with the given statuses in turn,
+// repeating the last, and counts them.
+func serveRobots(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1)) - 1
+		status := statuses[min(n, len(statuses)-1)]
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte("User-agent: *\nDisallow: /private\n"))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestCheckerMissingRobotsAllowsAll(t *testing.T) {
+	server, _ := serveRobots(t, http.StatusNotFound)
+	c := NewChecker(server.Client(), "test/1.0")
+	if err := c.Check(context.Background(), server.URL+"/private"); err != nil {
+		t.Errorf("Check with a 404 robots.txt = %v", err)
+	}
+}
+
+func TestCheckerRetriesServerErrors(t *testing.T) {
+	server, requests := serveRobots(t, http.StatusServiceUnavailable, http.StatusOK)
+	c := NewChecker(server.Client(), "test/1.0")
+	ctx := context.Background()
+
+	if err := c.Check(ctx, server.URL+"/page"); !errors.Is(err, ErrDisallowed) {
+		t.Fatalf("Check with a 503 robots.txt = %v, want ErrDisallowed", err)
+	}
+
+	c.mu.Lock()
+	for _, cached := range c.hosts {
+		cached.retryAt = cached.retryAt.Add(-retryMax)
+	}
+	c.mu.Unlock()
+
+	if err := c.Check(ctx, server.URL+"/page"); err != nil {
+		t.Errorf("Check after the retry = %v", err)
+	}
+	if err := c.Check(ctx, server.URL+"/private"); !errors.Is(err, ErrDisallowed) {
+		t.Errorf("Check of a disallowed path = %v, want ErrDisallowed", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("robots.txt fetched %d times, want 2", got)
+	}
+}
+
+func TestCheckerFetchesOncePerOrigin(t *testing.T) {
+	server, requests := serveRobots(t, http.StatusOK)
+	c := NewChecker(server.Client(), "test/1.0")
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.Check(context.Background(), server.URL+"/page"); err != nil {
+				t.Errorf("Check = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := requests.Load(); got != 1 {
+		t.Errorf("robots.txt fetched %d times, want 1", got)
+	}
+}
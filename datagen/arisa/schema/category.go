@@ -0,0 +1,47 @@
+package schema
+
+import "regexp"
+
+// Category is the fixed instruction taxonomy shared by every generator.
+// Sources name their groupings differently ("Core Instructions", "SSE2
+// Instructions", "Vector"), so each record keeps the original string and
+// adds one of these for cross-ISA queries.
+type Category string
+
+const (
+	CategoryGeneralPurpose Category = "general-purpose"
+	CategorySIMD           Category = "simd"
+	CategoryFloatingPoint  Category = "floating-point"
+	CategorySystem         Category = "system"
+	CategoryCrypto         Category = "crypto"
+	CategoryVirtualization Category = "virtualization"
+	CategorySecurity       Category = "security"
+	CategoryOther          Category = "other"
+)
+
+// categoryPatterns are tried in order, most specific first, so "AES-NI
+// (AVX)" is crypto rather than SIMD and "VMX Instructions" is
+// virtualization rather than system.
+var categoryPatterns = []struct {
+	pattern  *regexp.Regexp
+	category Category
+}{
+	{regexp.MustCompile(`(?i)\b(vmx|svm|virtuali[sz]ation|hypervisor)\b`), CategoryVirtualization},
+	{regexp.MustCompile(`(?i)\b(sgx\d*|smx|enclave|trustzone)\b`), CategorySecurity},
+	{regexp.MustCompile(`(?i)\b(v?aes\w*|sha\d*|crypto\w*|key ?locker|(wide_)?kl|v?pclmulqdq|gfni)\b`), CategoryCrypto},
+	{regexp.MustCompile(`(?i)\b(x87|fpu|floating[- ]point)\b`), CategoryFloatingPoint},
+	{regexp.MustCompile(`(?i)\b(mmx|sse\w*|ssse3|avx\w*|amx\w*|simd|vector|xeon phi|neon)\b`), CategorySIMD},
+	{regexp.MustCompile(`(?i)\b(system|privileged|supervisor)\b`), CategorySystem},
+	{regexp.MustCompile(`(?i)\b(core|base|general[- ]purpose|integer)\b`), CategoryGeneralPurpose},
+}
+
+// NormalizeCategory maps a source's category name onto the taxonomy,
+// returning CategoryOther when nothing matches.
+func NormalizeCategory(raw string) Category {
+	for _, entry := range categoryPatterns {
+		if entry.pattern.MatchString(raw) {
+			return entry.category
+		}
+	}
+	return CategoryOther
+}
@@ -0,0 +1,227 @@
+package schema
+
+//go:generate go run ./internal/gen
+
+import "fmt"
+
+const (
+	// InstructionSchemaFilename is where the JSON Schema for unified
+	// datasets is published, beside this file.
+	InstructionSchemaFilename = "instruction.schema.json"
+	InstructionSchemaID       = "https://raw.githubusercontent.com/aprlfm/Arisa/main/datagen/arisa/schema/" + InstructionSchemaFilename
+
+	// InstructionSchemaVersion goes in the envelope of every unified
+	// dataset. Bump it when a change to Instruction would break existing
+	// consumers.
+	InstructionSchemaVersion = 1
+)
+
+// ISA names used in Instruction.ISA.
+const (
+	ISAX86 = "x86"
+	ISAJVM = "jvm"
+)
+
+// Instruction is the ISA-neutral record every generator can emit, so a
+// consumer reads x86 and JVM data through one shape. The common core holds
+// what every ISA has; what only makes sense for one ISA lives in its
+// extension, X86 or JVM, which is nil for records of any other.
+//
+// The x86 and JVM generators write every run in this shape, to
+// x86_unified.json and jvm_unified.json. Their ISA-specific datasets are
+// still written beside it, since the embedded snapshot and the per-ISA
+// exports read detail this shape leaves out, such as raw tables.
+type Instruction struct {
+	ISA      string `json:"isa"`
+	Mnemonic string `json:"mnemonic"`
+	// Summary is a one-line description, such as the x86 page title or
+	// the JVM operation.
+	Summary  string   `json:"summary,omitempty"`
+	Category Category `json:"category"`
+	// Encodings lists every way the instruction is encoded: one per form
+	// for x86, and the single opcode for the JVM.
+	Encodings []Encoding `json:"encodings"`
+	// Operands are the instruction's explicit operands when every
+	// encoding shares them. x86 forms take different operands, so there
+	// they are on each Encoding instead.
+	Operands    []Operand `json:"operands,omitempty"`
+	Description string    `json:"description"`
+	Semantics   Semantics `json:"semantics"`
+	// Extensions are the ISA extensions or feature sets any encoding
+	// needs, such as "AVX512BW"; empty for a base instruction.
+	Extensions []string    `json:"extensions,omitempty"`
+	Source     Provenance  `json:"source"`
+	X86        *X86Details `json:"x86,omitempty"`
+	JVM        *JVMDetails `json:"jvm,omitempty"`
+}
+
+// Encoding is one machine encoding of an instruction. Opcode is in the
+// source's own notation, such as "VEX.128.66.0F38.WIG 00 /r" or "0x10",
+// and Length is the encoded size in bytes, or 0 when it varies.
+type Encoding struct {
+	Mnemonic   string       `json:"mnemonic"`
+	Syntax     string       `json:"syntax"`
+	Opcode     string       `json:"opcode"`
+	Length     int          `json:"length,omitempty"`
+	Operands   []Operand    `json:"operands,omitempty"`
+	Extensions []string     `json:"extensions,omitempty"`
+	X86        *X86Encoding `json:"x86,omitempty"`
+}
+
+// Operand is one explicit operand. Kind is "register", "memory",
+// "register/memory", "immediate", "relative", "pointer", "padding" or
+// "table"; Type is the source's finer name for it, such as "r/m32" or
+// "u1". Width is in bits and 0 when it varies, Access is "r", "w" or "rw",
+// and Encoding says where in the instruction it is encoded, such as
+// "ModRM:reg". A table's entries are described by Fields.
+type Operand struct {
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`
+	Kind        string    `json:"kind"`
+	Width       int       `json:"width,omitempty"`
+	Access      string    `json:"access,omitempty"`
+	Encoding    string    `json:"encoding,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Fields      []Operand `json:"fields,omitempty"`
+}
+
+// Semantics is what an instruction does. Operation is the source's
+// pseudocode or prose, OperationAST that pseudocode parsed where the
+// source has any, Flags maps each status flag to its effect ("set",
+// "cleared", "modified", "undefined", "unaffected" or "tested"), and
+// Exceptions lists the conditions that fault, keyed by mode for x86 and
+// by "linking" and "runtime" for the JVM.
+type Semantics struct {
+	Operation    string              `json:"operation,omitempty"`
+	OperationAST []Statement         `json:"operationAst,omitempty"`
+	Flags        map[string]string   `json:"flags,omitempty"`
+	Exceptions   map[string][]string `json:"exceptions,omitempty"`
+}
+
+// Provenance records where a record came from: the generator that wrote
+// it, the source it parsed ("felixcloutier", "jvms", "wikipedia"), the
+// page and anchor, and a hash of the content it was parsed from.
+type Provenance struct {
+	Generator   string `json:"generator"`
+	Source      string `json:"source"`
+	URL         string `json:"url,omitempty"`
+	Anchor      string `json:"anchor,omitempty"`
+	ContentHash string `json:"contentHash,omitempty"`
+}
+
+// X86Details is the x86 extension of Instruction. Group is the source's
+// own category, such as "SSE2 Instructions", and Title the full page
+// title, which may name several mnemonics.
+type X86Details struct {
+	Title      string   `json:"title"`
+	Group      string   `json:"group,omitempty"`
+	Parent     string   `json:"parent,omitempty"`
+	Aliases    []string `json:"aliases,omitempty"`
+	Intrinsics []string `json:"intrinsics,omitempty"`
+}
+
+// X86Encoding is the x86 extension of Encoding: the operand encoding
+// column, the validity in 64-bit and compatibility mode, the CPUID
+// requirement as written, and the encoding class ("legacy", "vex",
+// "evex", ...) with its opcode map and vector length.
+type X86Encoding struct {
+	OpEn         string `json:"opEn,omitempty"`
+	Valid64      string `json:"valid64,omitempty"`
+	ValidCompat  string `json:"validCompat,omitempty"`
+	CPUID        string `json:"cpuid,omitempty"`
+	Class        string `json:"class,omitempty"`
+	Map          string `json:"map,omitempty"`
+	VectorLength string `json:"vectorLength,omitempty"`
+	W            string `json:"w,omitempty"`
+}
+
+// JVMDetails is the JVM extension of Instruction: the opcode byte, the
+// format line, and the operand stack transition.
+type JVMDetails struct {
+	OpcodeByte     uint8           `json:"opcodeByte"`
+	Format         string          `json:"format"`
+	StackBefore    []JVMStackEntry `json:"stackBefore,omitempty"`
+	StackAfter     []JVMStackEntry `json:"stackAfter,omitempty"`
+	StackDelta     *int            `json:"stackDelta,omitempty"`
+	VariableLength bool            `json:"variableLength,omitempty"`
+	Modifies       string          `json:"modifies,omitempty"`
+	Reserved       bool            `json:"reserved,omitempty"`
+	Notes          string          `json:"notes,omitempty"`
+}
+
+// JVMOperands converts a JVM operand layout to unified operands.
+func JVMOperands(layout []JVMOperand) []Operand {
+	var operands []Operand
+	for _, operand := range layout {
+		kind := operand.Type
+		if kind != "padding" && kind != "table" {
+			kind = "immediate"
+		}
+		operands = append(operands, Operand{
+			Name:        operand.Name,
+			Type:        operand.Type,
+			Kind:        kind,
+			Width:       operand.Size * 8,
+			Description: operand.Description,
+			Fields:      JVMOperands(operand.Fields),
+		})
+	}
+	return operands
+}
+
+// Unified converts a JVM record to the ISA-neutral shape. category is
+// the record's place in the taxonomy, which JVMInstruction doesn't carry.
+func (i *JVMInstruction) Unified(generator string, category Category) Instruction {
+	operands := JVMOperands(i.OperandLayout)
+	length := i.Length
+	if i.VariableLength {
+		length = 0
+	}
+	exceptions := make(map[string][]string)
+	if i.LinkingExceptions != "" {
+		exceptions["linking"] = []string{i.LinkingExceptions}
+	}
+	if i.RuntimeExceptions != "" {
+		exceptions["runtime"] = []string{i.RuntimeExceptions}
+	}
+	if len(exceptions) == 0 {
+		exceptions = nil
+	}
+
+	return Instruction{
+		ISA:      ISAJVM,
+		Mnemonic: i.Mnemonic,
+		Summary:  i.Operation,
+		Category: category,
+		Encodings: []Encoding{{
+			Mnemonic: i.Mnemonic,
+			Syntax:   i.Format,
+			Opcode:   fmt.Sprintf("0x%02x", i.OpcodeByte),
+			Length:   length,
+			Operands: operands,
+		}},
+		Operands:    operands,
+		Description: i.Description,
+		Semantics: Semantics{
+			Operation:  i.Operation,
+			Exceptions: exceptions,
+		},
+		Source: Provenance{
+			Generator: generator,
+			Source:    i.Source,
+			URL:       i.SpecURL,
+			Anchor:    i.AnchorID,
+		},
+		JVM: &JVMDetails{
+			OpcodeByte:     i.OpcodeByte,
+			Format:         i.Format,
+			StackBefore:    i.StackBefore,
+			StackAfter:     i.StackAfter,
+			StackDelta:     i.StackDelta,
+			VariableLength: i.VariableLength,
+			Modifies:       i.Modifies,
+			Reserved:       i.Reserved,
+			Notes:          i.Notes,
+		},
+	}
+}
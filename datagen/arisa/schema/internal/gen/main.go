@@ -0,0 +1,24 @@
+// Command gen writes the JSON Schema for unified datasets, generated from
+// schema.Instruction, beside the package. It runs from go generate:
+//
+//	go generate ./schema
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"arisa/dataset"
+	"arisa/schema"
+)
+
+func main() {
+	content, err := dataset.Schema(schema.Instruction{}, schema.InstructionSchemaID, "Arisa instructions")
+	if err == nil {
+		err = os.WriteFile(schema.InstructionSchemaFilename, content, 0644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gen:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,103 @@
+// Package schema defines the typed records emitted by the Arisa data
+// generators so that producers and Go consumers agree on a single shape.
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JVMInstruction is a single JVM bytecode instruction as written to
+// jvm_instructions.json. Field order matches the emitted JSON.
+type JVMInstruction struct {
+	Mnemonic           string          `json:"mnemonic"`
+	Opcode             string          `json:"opcode,omitempty"`
+	OpcodeByte         uint8           `json:"opcodeByte"`
+	Operation          string          `json:"operation"`
+	Format             string          `json:"format"`
+	Operands           []string        `json:"operands,omitempty"`
+	OperandLayout      []JVMOperand    `json:"operandLayout,omitempty"`
+	Length             int             `json:"length,omitempty"`
+	VariableLength     bool            `json:"variableLength,omitempty"`
+	Modifies           string          `json:"modifies,omitempty"`
+	Reserved           bool            `json:"reserved,omitempty"`
+	OperandStackBefore string          `json:"operandStackBefore"`
+	OperandStackAfter  string          `json:"operandStackAfter"`
+	StackBefore        []JVMStackEntry `json:"stackBefore,omitempty"`
+	StackAfter         []JVMStackEntry `json:"stackAfter,omitempty"`
+	StackDelta         *int            `json:"stackDelta,omitempty"`
+	Description        string          `json:"description"`
+	LinkingExceptions  string          `json:"linkingExceptions,omitempty"`
+	RuntimeExceptions  string          `json:"runtimeExceptions,omitempty"`
+	Notes              string          `json:"notes,omitempty"`
+	Source             string          `json:"source"`
+	SpecURL            string          `json:"specUrl,omitempty"`
+	AnchorID           string          `json:"anchorId"`
+}
+
+// JVMOperand describes one encoded operand following the opcode byte.
+// Type is a class-file style scalar ("u1", "s2", "s4"), "padding" for
+// alignment bytes, or "table" for repeated entries whose element layout is
+// given in Fields. Size is the width in bytes and is 0 when it varies;
+// Count names the repeat count for tables.
+type JVMOperand struct {
+	Name        string       `json:"name"`
+	Type        string       `json:"type"`
+	Size        int          `json:"size,omitempty"`
+	Count       string       `json:"count,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Fields      []JVMOperand `json:"fields,omitempty"`
+}
+
+// JVMStackEntry is one operand stack value named by an instruction's
+// stack transition. Type is a JVM computational type ("int", "long",
+// "float", "double", "reference", "returnAddress") or "any" when it
+// depends on context. Category follows JVMS §2.11.1 and is 0 when the
+// instruction accepts either category; Width is the size in 32-bit slots.
+type JVMStackEntry struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Category int    `json:"category,omitempty"`
+	Width    int    `json:"width,omitempty"`
+	Variadic bool   `json:"variadic,omitempty"`
+}
+
+// JVMOpcodeRange is an inclusive span of opcode values that share a
+// status, such as the unassigned gap between breakpoint and impdep1.
+type JVMOpcodeRange struct {
+	Start  uint8  `json:"start"`
+	End    uint8  `json:"end"`
+	Status string `json:"status"`
+}
+
+// JVMOpcodeString renders the legacy "name = dec (0xhex)" opcode string.
+func JVMOpcodeString(mnemonic string, opcode uint8) string {
+	return fmt.Sprintf("%s = %d (0x%02x)", mnemonic, opcode, opcode)
+}
+
+// JVMAnchorID returns the anchor used to link an instruction into the
+// specification, e.g. "jvm-aload-0" for aload_0 or "jvm-wide-iinc" for
+// the wide-modified iinc.
+func JVMAnchorID(mnemonic string) string {
+	return "jvm-" + strings.NewReplacer("_", "-", " ", "-").Replace(mnemonic)
+}
+
+// Validate reports the first structural problem with the record.
+func (i *JVMInstruction) Validate() error {
+	if i.Mnemonic == "" {
+		return fmt.Errorf("missing mnemonic")
+	}
+	if i.Opcode != "" && i.Opcode != JVMOpcodeString(i.Mnemonic, i.OpcodeByte) {
+		return fmt.Errorf("%s: opcode %q does not match opcode byte 0x%02x", i.Mnemonic, i.Opcode, i.OpcodeByte)
+	}
+	if i.Format == "" {
+		return fmt.Errorf("%s: missing format", i.Mnemonic)
+	}
+	if !strings.HasPrefix(i.Format, i.Mnemonic) {
+		return fmt.Errorf("%s: format %q does not start with mnemonic", i.Mnemonic, i.Format)
+	}
+	if i.AnchorID != JVMAnchorID(i.Mnemonic) {
+		return fmt.Errorf("%s: unexpected anchor id %q", i.Mnemonic, i.AnchorID)
+	}
+	return nil
+}
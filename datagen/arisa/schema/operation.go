@@ -0,0 +1,71 @@
+package schema
+
+// Statement is one statement of an instruction's Operation pseudocode,
+// parsed by package pseudocode. Kind says which other fields are set:
+//
+//   - "assign": Target := Value
+//   - "if": Condition, Then and Else; ELSE IF and ELSIF chains nest an
+//     "if" as the only statement of Else
+//   - "for": Variable counts From up To, running Body
+//   - "while": Condition and Body
+//   - "case": Value is the selector and Cases the arms
+//   - "expression": Value alone, usually a call such as #GP(0)
+//   - "break", "goto" and "label": Name is the target or label, if any
+//   - "text": Text is a statement that isn't code, such as "DEST is
+//     undefined" or a heading naming the form that follows
+//
+// Line is the 1-based line of the Operation text the statement starts on.
+type Statement struct {
+	Kind      string      `json:"kind"`
+	Line      int         `json:"line"`
+	Target    *Expression `json:"target,omitempty"`
+	Value     *Expression `json:"value,omitempty"`
+	Condition *Expression `json:"condition,omitempty"`
+	Then      []Statement `json:"then,omitempty"`
+	Else      []Statement `json:"else,omitempty"`
+	Variable  string      `json:"variable,omitempty"`
+	From      *Expression `json:"from,omitempty"`
+	To        *Expression `json:"to,omitempty"`
+	Body      []Statement `json:"body,omitempty"`
+	Cases     []CaseArm   `json:"cases,omitempty"`
+	Name      string      `json:"name,omitempty"`
+	Text      string      `json:"text,omitempty"`
+}
+
+// CaseArm is one arm of a "case" statement: the values it matches, or
+// none for the DEFAULT arm, and the statements it runs.
+type CaseArm struct {
+	Labels []Expression `json:"labels,omitempty"`
+	Body   []Statement  `json:"body,omitempty"`
+}
+
+// Expression is a node of a parsed pseudocode expression. Kind says which
+// other fields are set:
+//
+//   - "name": Name, such as DEST, SRC1 or MAXVL
+//   - "number": Value, in decimal or as 0x-prefixed hex whichever way
+//     the source wrote it, so 0000FFFFH is "0x0000ffff"
+//   - "unary": Operator and its one operand
+//   - "binary": Operator and its two operands
+//   - "conditional": cond ? a : b, as three operands
+//   - "call": Name and the arguments as operands
+//   - "field": Name of a field of the one operand, as in EVEX.b
+//   - "index": element or bit of the first operand at the second
+//   - "slice": the bits of the one operand from High down to Low, as in
+//     DEST[127:64]
+//   - "text": Text is an expression that isn't code, such as "64-bit
+//     mode"
+//
+// Operators are "+", "-", "*", "/", "MOD", "<<", ">>", "&", "|", "^",
+// "~", "=", "!=", "<", ">", "<=", ">=", "AND", "OR", "XOR" and "NOT"; the
+// source's ==, ≠, &&, || and ! spellings are normalized to them.
+type Expression struct {
+	Kind     string       `json:"kind"`
+	Name     string       `json:"name,omitempty"`
+	Value    string       `json:"value,omitempty"`
+	Operator string       `json:"operator,omitempty"`
+	Operands []Expression `json:"operands,omitempty"`
+	High     *Expression  `json:"high,omitempty"`
+	Low      *Expression  `json:"low,omitempty"`
+	Text     string       `json:"text,omitempty"`
+}
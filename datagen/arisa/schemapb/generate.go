@@ -0,0 +1,5 @@
+// Package schemapb holds the protobuf schema for the Arisa datasets and its
+// generated Go bindings.
+package schemapb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative schema.proto
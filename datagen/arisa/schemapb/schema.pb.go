@@ -0,0 +1,1448 @@
+// Binary form of the datasets the Arisa generators write as JSON. Field
+// names follow the JSON keys. The raw HTML tables and provenance records
+// are left out: they exist for auditing the scrape, and the parsed forms,
+// operands and exceptions carry everything consumers query.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: schema.proto
+
+package schemapb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type X86Dataset struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instructions  []*X86Instruction      `protobuf:"bytes,1,rep,name=instructions,proto3" json:"instructions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *X86Dataset) Reset() {
+	*x = X86Dataset{}
+	mi := &file_schema_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *X86Dataset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*X86Dataset) ProtoMessage() {}
+
+func (x *X86Dataset) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use X86Dataset.ProtoReflect.Descriptor instead.
+func (*X86Dataset) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *X86Dataset) GetInstructions() []*X86Instruction {
+	if x != nil {
+		return x.Instructions
+	}
+	return nil
+}
+
+// X86Instruction is one felixcloutier.com page.
+type X86Instruction struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Url                 string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Category            string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Taxonomy            string                 `protobuf:"bytes,3,opt,name=taxonomy,proto3" json:"taxonomy,omitempty"`
+	InstructionName     string                 `protobuf:"bytes,4,opt,name=instruction_name,json=instructionName,proto3" json:"instruction_name,omitempty"`
+	Parent              string                 `protobuf:"bytes,5,opt,name=parent,proto3" json:"parent,omitempty"`
+	DescriptionText     string                 `protobuf:"bytes,6,opt,name=description_text,json=descriptionText,proto3" json:"description_text,omitempty"`
+	DescriptionMarkdown string                 `protobuf:"bytes,7,opt,name=description_markdown,json=descriptionMarkdown,proto3" json:"description_markdown,omitempty"`
+	OperationText       string                 `protobuf:"bytes,8,opt,name=operation_text,json=operationText,proto3" json:"operation_text,omitempty"`
+	FlagsAffectedText   string                 `protobuf:"bytes,9,opt,name=flags_affected_text,json=flagsAffectedText,proto3" json:"flags_affected_text,omitempty"`
+	Intrinsics          []string               `protobuf:"bytes,10,rep,name=intrinsics,proto3" json:"intrinsics,omitempty"`
+	Forms               []*X86Form             `protobuf:"bytes,11,rep,name=forms,proto3" json:"forms,omitempty"`
+	FlagsAffected       map[string]string      `protobuf:"bytes,12,rep,name=flags_affected,json=flagsAffected,proto3" json:"flags_affected,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Exceptions          []*X86Exception        `protobuf:"bytes,13,rep,name=exceptions,proto3" json:"exceptions,omitempty"`
+	FeatureFlags        []string               `protobuf:"bytes,14,rep,name=feature_flags,json=featureFlags,proto3" json:"feature_flags,omitempty"`
+	VmcsFields          string                 `protobuf:"bytes,15,opt,name=vmcs_fields,json=vmcsFields,proto3" json:"vmcs_fields,omitempty"`
+	ContentHash         string                 `protobuf:"bytes,16,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	Aliases             []string               `protobuf:"bytes,17,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	Error               string                 `protobuf:"bytes,18,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *X86Instruction) Reset() {
+	*x = X86Instruction{}
+	mi := &file_schema_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *X86Instruction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*X86Instruction) ProtoMessage() {}
+
+func (x *X86Instruction) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use X86Instruction.ProtoReflect.Descriptor instead.
+func (*X86Instruction) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *X86Instruction) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *X86Instruction) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *X86Instruction) GetTaxonomy() string {
+	if x != nil {
+		return x.Taxonomy
+	}
+	return ""
+}
+
+func (x *X86Instruction) GetInstructionName() string {
+	if x != nil {
+		return x.InstructionName
+	}
+	return ""
+}
+
+func (x *X86Instruction) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *X86Instruction) GetDescriptionText() string {
+	if x != nil {
+		return x.DescriptionText
+	}
+	return ""
+}
+
+func (x *X86Instruction) GetDescriptionMarkdown() string {
+	if x != nil {
+		return x.DescriptionMarkdown
+	}
+	return ""
+}
+
+func (x *X86Instruction) GetOperationText() string {
+	if x != nil {
+		return x.OperationText
+	}
+	return ""
+}
+
+func (x *X86Instruction) GetFlagsAffectedText() string {
+	if x != nil {
+		return x.FlagsAffectedText
+	}
+	return ""
+}
+
+func (x *X86Instruction) GetIntrinsics() []string {
+	if x != nil {
+		return x.Intrinsics
+	}
+	return nil
+}
+
+func (x *X86Instruction) GetForms() []*X86Form {
+	if x != nil {
+		return x.Forms
+	}
+	return nil
+}
+
+func (x *X86Instruction) GetFlagsAffected() map[string]string {
+	if x != nil {
+		return x.FlagsAffected
+	}
+	return nil
+}
+
+func (x *X86Instruction) GetExceptions() []*X86Exception {
+	if x != nil {
+		return x.Exceptions
+	}
+	return nil
+}
+
+func (x *X86Instruction) GetFeatureFlags() []string {
+	if x != nil {
+		return x.FeatureFlags
+	}
+	return nil
+}
+
+func (x *X86Instruction) GetVmcsFields() string {
+	if x != nil {
+		return x.VmcsFields
+	}
+	return ""
+}
+
+func (x *X86Instruction) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *X86Instruction) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *X86Instruction) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// X86Form is one row of an instruction's opcode table.
+type X86Form struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Opcode         string                 `protobuf:"bytes,1,opt,name=opcode,proto3" json:"opcode,omitempty"`
+	Instruction    string                 `protobuf:"bytes,2,opt,name=instruction,proto3" json:"instruction,omitempty"`
+	Mnemonic       string                 `protobuf:"bytes,3,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
+	Operands       []string               `protobuf:"bytes,4,rep,name=operands,proto3" json:"operands,omitempty"`
+	OpEn           string                 `protobuf:"bytes,5,opt,name=op_en,json=opEn,proto3" json:"op_en,omitempty"`
+	Valid64        string                 `protobuf:"bytes,6,opt,name=valid64,proto3" json:"valid64,omitempty"`
+	ValidCompat    string                 `protobuf:"bytes,7,opt,name=valid_compat,json=validCompat,proto3" json:"valid_compat,omitempty"`
+	ModeSupport    string                 `protobuf:"bytes,8,opt,name=mode_support,json=modeSupport,proto3" json:"mode_support,omitempty"`
+	Cpuid          string                 `protobuf:"bytes,9,opt,name=cpuid,proto3" json:"cpuid,omitempty"`
+	FeatureFlags   []string               `protobuf:"bytes,10,rep,name=feature_flags,json=featureFlags,proto3" json:"feature_flags,omitempty"`
+	Description    string                 `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
+	Iforms         []string               `protobuf:"bytes,12,rep,name=iforms,proto3" json:"iforms,omitempty"`
+	Encoding       *X86Encoding           `protobuf:"bytes,13,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	OperandDetails []*X86Operand          `protobuf:"bytes,14,rep,name=operand_details,json=operandDetails,proto3" json:"operand_details,omitempty"`
+	Notes          []string               `protobuf:"bytes,15,rep,name=notes,proto3" json:"notes,omitempty"`
+	Table          string                 `protobuf:"bytes,16,opt,name=table,proto3" json:"table,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *X86Form) Reset() {
+	*x = X86Form{}
+	mi := &file_schema_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *X86Form) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*X86Form) ProtoMessage() {}
+
+func (x *X86Form) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use X86Form.ProtoReflect.Descriptor instead.
+func (*X86Form) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *X86Form) GetOpcode() string {
+	if x != nil {
+		return x.Opcode
+	}
+	return ""
+}
+
+func (x *X86Form) GetInstruction() string {
+	if x != nil {
+		return x.Instruction
+	}
+	return ""
+}
+
+func (x *X86Form) GetMnemonic() string {
+	if x != nil {
+		return x.Mnemonic
+	}
+	return ""
+}
+
+func (x *X86Form) GetOperands() []string {
+	if x != nil {
+		return x.Operands
+	}
+	return nil
+}
+
+func (x *X86Form) GetOpEn() string {
+	if x != nil {
+		return x.OpEn
+	}
+	return ""
+}
+
+func (x *X86Form) GetValid64() string {
+	if x != nil {
+		return x.Valid64
+	}
+	return ""
+}
+
+func (x *X86Form) GetValidCompat() string {
+	if x != nil {
+		return x.ValidCompat
+	}
+	return ""
+}
+
+func (x *X86Form) GetModeSupport() string {
+	if x != nil {
+		return x.ModeSupport
+	}
+	return ""
+}
+
+func (x *X86Form) GetCpuid() string {
+	if x != nil {
+		return x.Cpuid
+	}
+	return ""
+}
+
+func (x *X86Form) GetFeatureFlags() []string {
+	if x != nil {
+		return x.FeatureFlags
+	}
+	return nil
+}
+
+func (x *X86Form) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *X86Form) GetIforms() []string {
+	if x != nil {
+		return x.Iforms
+	}
+	return nil
+}
+
+func (x *X86Form) GetEncoding() *X86Encoding {
+	if x != nil {
+		return x.Encoding
+	}
+	return nil
+}
+
+func (x *X86Form) GetOperandDetails() []*X86Operand {
+	if x != nil {
+		return x.OperandDetails
+	}
+	return nil
+}
+
+func (x *X86Form) GetNotes() []string {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *X86Form) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+type X86Encoding struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PrefixClass     string                 `protobuf:"bytes,1,opt,name=prefix_class,json=prefixClass,proto3" json:"prefix_class,omitempty"`
+	VectorLength    string                 `protobuf:"bytes,2,opt,name=vector_length,json=vectorLength,proto3" json:"vector_length,omitempty"`
+	MandatoryPrefix string                 `protobuf:"bytes,3,opt,name=mandatory_prefix,json=mandatoryPrefix,proto3" json:"mandatory_prefix,omitempty"`
+	Map             string                 `protobuf:"bytes,4,opt,name=map,proto3" json:"map,omitempty"`
+	EscapeBytes     []string               `protobuf:"bytes,5,rep,name=escape_bytes,json=escapeBytes,proto3" json:"escape_bytes,omitempty"`
+	Rex             bool                   `protobuf:"varint,6,opt,name=rex,proto3" json:"rex,omitempty"`
+	W               string                 `protobuf:"bytes,7,opt,name=w,proto3" json:"w,omitempty"`
+	Vvvv            string                 `protobuf:"bytes,8,opt,name=vvvv,proto3" json:"vvvv,omitempty"`
+	OpcodeByte      string                 `protobuf:"bytes,9,opt,name=opcode_byte,json=opcodeByte,proto3" json:"opcode_byte,omitempty"`
+	OpcodeBytes     []string               `protobuf:"bytes,10,rep,name=opcode_bytes,json=opcodeBytes,proto3" json:"opcode_bytes,omitempty"`
+	OpcodeRegister  string                 `protobuf:"bytes,11,opt,name=opcode_register,json=opcodeRegister,proto3" json:"opcode_register,omitempty"`
+	Modrm           string                 `protobuf:"bytes,12,opt,name=modrm,proto3" json:"modrm,omitempty"`
+	ModrmReg        *int32                 `protobuf:"varint,13,opt,name=modrm_reg,json=modrmReg,proto3,oneof" json:"modrm_reg,omitempty"`
+	Immediate       string                 `protobuf:"bytes,14,opt,name=immediate,proto3" json:"immediate,omitempty"`
+	Immediates      []string               `protobuf:"bytes,15,rep,name=immediates,proto3" json:"immediates,omitempty"`
+	ImmediateSize   int32                  `protobuf:"varint,16,opt,name=immediate_size,json=immediateSize,proto3" json:"immediate_size,omitempty"`
+	Masking         bool                   `protobuf:"varint,17,opt,name=masking,proto3" json:"masking,omitempty"`
+	ZeroMasking     bool                   `protobuf:"varint,18,opt,name=zero_masking,json=zeroMasking,proto3" json:"zero_masking,omitempty"`
+	Broadcast       bool                   `protobuf:"varint,19,opt,name=broadcast,proto3" json:"broadcast,omitempty"`
+	Rounding        bool                   `protobuf:"varint,20,opt,name=rounding,proto3" json:"rounding,omitempty"`
+	Sae             bool                   `protobuf:"varint,21,opt,name=sae,proto3" json:"sae,omitempty"`
+	Egpr            bool                   `protobuf:"varint,22,opt,name=egpr,proto3" json:"egpr,omitempty"`
+	Ndd             bool                   `protobuf:"varint,23,opt,name=ndd,proto3" json:"ndd,omitempty"`
+	Nf              bool                   `protobuf:"varint,24,opt,name=nf,proto3" json:"nf,omitempty"`
+	Zu              bool                   `protobuf:"varint,25,opt,name=zu,proto3" json:"zu,omitempty"`
+	Scc             bool                   `protobuf:"varint,26,opt,name=scc,proto3" json:"scc,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *X86Encoding) Reset() {
+	*x = X86Encoding{}
+	mi := &file_schema_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *X86Encoding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*X86Encoding) ProtoMessage() {}
+
+func (x *X86Encoding) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use X86Encoding.ProtoReflect.Descriptor instead.
+func (*X86Encoding) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *X86Encoding) GetPrefixClass() string {
+	if x != nil {
+		return x.PrefixClass
+	}
+	return ""
+}
+
+func (x *X86Encoding) GetVectorLength() string {
+	if x != nil {
+		return x.VectorLength
+	}
+	return ""
+}
+
+func (x *X86Encoding) GetMandatoryPrefix() string {
+	if x != nil {
+		return x.MandatoryPrefix
+	}
+	return ""
+}
+
+func (x *X86Encoding) GetMap() string {
+	if x != nil {
+		return x.Map
+	}
+	return ""
+}
+
+func (x *X86Encoding) GetEscapeBytes() []string {
+	if x != nil {
+		return x.EscapeBytes
+	}
+	return nil
+}
+
+func (x *X86Encoding) GetRex() bool {
+	if x != nil {
+		return x.Rex
+	}
+	return false
+}
+
+func (x *X86Encoding) GetW() string {
+	if x != nil {
+		return x.W
+	}
+	return ""
+}
+
+func (x *X86Encoding) GetVvvv() string {
+	if x != nil {
+		return x.Vvvv
+	}
+	return ""
+}
+
+func (x *X86Encoding) GetOpcodeByte() string {
+	if x != nil {
+		return x.OpcodeByte
+	}
+	return ""
+}
+
+func (x *X86Encoding) GetOpcodeBytes() []string {
+	if x != nil {
+		return x.OpcodeBytes
+	}
+	return nil
+}
+
+func (x *X86Encoding) GetOpcodeRegister() string {
+	if x != nil {
+		return x.OpcodeRegister
+	}
+	return ""
+}
+
+func (x *X86Encoding) GetModrm() string {
+	if x != nil {
+		return x.Modrm
+	}
+	return ""
+}
+
+func (x *X86Encoding) GetModrmReg() int32 {
+	if x != nil && x.ModrmReg != nil {
+		return *x.ModrmReg
+	}
+	return 0
+}
+
+func (x *X86Encoding) GetImmediate() string {
+	if x != nil {
+		return x.Immediate
+	}
+	return ""
+}
+
+func (x *X86Encoding) GetImmediates() []string {
+	if x != nil {
+		return x.Immediates
+	}
+	return nil
+}
+
+func (x *X86Encoding) GetImmediateSize() int32 {
+	if x != nil {
+		return x.ImmediateSize
+	}
+	return 0
+}
+
+func (x *X86Encoding) GetMasking() bool {
+	if x != nil {
+		return x.Masking
+	}
+	return false
+}
+
+func (x *X86Encoding) GetZeroMasking() bool {
+	if x != nil {
+		return x.ZeroMasking
+	}
+	return false
+}
+
+func (x *X86Encoding) GetBroadcast() bool {
+	if x != nil {
+		return x.Broadcast
+	}
+	return false
+}
+
+func (x *X86Encoding) GetRounding() bool {
+	if x != nil {
+		return x.Rounding
+	}
+	return false
+}
+
+func (x *X86Encoding) GetSae() bool {
+	if x != nil {
+		return x.Sae
+	}
+	return false
+}
+
+func (x *X86Encoding) GetEgpr() bool {
+	if x != nil {
+		return x.Egpr
+	}
+	return false
+}
+
+func (x *X86Encoding) GetNdd() bool {
+	if x != nil {
+		return x.Ndd
+	}
+	return false
+}
+
+func (x *X86Encoding) GetNf() bool {
+	if x != nil {
+		return x.Nf
+	}
+	return false
+}
+
+func (x *X86Encoding) GetZu() bool {
+	if x != nil {
+		return x.Zu
+	}
+	return false
+}
+
+func (x *X86Encoding) GetScc() bool {
+	if x != nil {
+		return x.Scc
+	}
+	return false
+}
+
+type X86Operand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Syntax        string                 `protobuf:"bytes,1,opt,name=syntax,proto3" json:"syntax,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Width         int32                  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Encoding      string                 `protobuf:"bytes,5,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	Access        string                 `protobuf:"bytes,6,opt,name=access,proto3" json:"access,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *X86Operand) Reset() {
+	*x = X86Operand{}
+	mi := &file_schema_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *X86Operand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*X86Operand) ProtoMessage() {}
+
+func (x *X86Operand) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use X86Operand.ProtoReflect.Descriptor instead.
+func (*X86Operand) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *X86Operand) GetSyntax() string {
+	if x != nil {
+		return x.Syntax
+	}
+	return ""
+}
+
+func (x *X86Operand) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *X86Operand) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *X86Operand) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *X86Operand) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+func (x *X86Operand) GetAccess() string {
+	if x != nil {
+		return x.Access
+	}
+	return ""
+}
+
+// X86Exception is one condition from an exceptions section; mode names
+// the section, such as "Real-Address Mode Exceptions".
+type X86Exception struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	Vector        string                 `protobuf:"bytes,2,opt,name=vector,proto3" json:"vector,omitempty"`
+	Condition     string                 `protobuf:"bytes,3,opt,name=condition,proto3" json:"condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *X86Exception) Reset() {
+	*x = X86Exception{}
+	mi := &file_schema_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *X86Exception) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*X86Exception) ProtoMessage() {}
+
+func (x *X86Exception) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use X86Exception.ProtoReflect.Descriptor instead.
+func (*X86Exception) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *X86Exception) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *X86Exception) GetVector() string {
+	if x != nil {
+		return x.Vector
+	}
+	return ""
+}
+
+func (x *X86Exception) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+type JVMDataset struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instructions  []*JVMInstruction      `protobuf:"bytes,1,rep,name=instructions,proto3" json:"instructions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JVMDataset) Reset() {
+	*x = JVMDataset{}
+	mi := &file_schema_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JVMDataset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JVMDataset) ProtoMessage() {}
+
+func (x *JVMDataset) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JVMDataset.ProtoReflect.Descriptor instead.
+func (*JVMDataset) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *JVMDataset) GetInstructions() []*JVMInstruction {
+	if x != nil {
+		return x.Instructions
+	}
+	return nil
+}
+
+type JVMInstruction struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Mnemonic           string                 `protobuf:"bytes,1,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
+	Opcode             string                 `protobuf:"bytes,2,opt,name=opcode,proto3" json:"opcode,omitempty"`
+	OpcodeByte         uint32                 `protobuf:"varint,3,opt,name=opcode_byte,json=opcodeByte,proto3" json:"opcode_byte,omitempty"`
+	Operation          string                 `protobuf:"bytes,4,opt,name=operation,proto3" json:"operation,omitempty"`
+	Format             string                 `protobuf:"bytes,5,opt,name=format,proto3" json:"format,omitempty"`
+	Operands           []string               `protobuf:"bytes,6,rep,name=operands,proto3" json:"operands,omitempty"`
+	OperandLayout      []*JVMOperand          `protobuf:"bytes,7,rep,name=operand_layout,json=operandLayout,proto3" json:"operand_layout,omitempty"`
+	Length             int32                  `protobuf:"varint,8,opt,name=length,proto3" json:"length,omitempty"`
+	VariableLength     bool                   `protobuf:"varint,9,opt,name=variable_length,json=variableLength,proto3" json:"variable_length,omitempty"`
+	Modifies           string                 `protobuf:"bytes,10,opt,name=modifies,proto3" json:"modifies,omitempty"`
+	Reserved           bool                   `protobuf:"varint,11,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	OperandStackBefore string                 `protobuf:"bytes,12,opt,name=operand_stack_before,json=operandStackBefore,proto3" json:"operand_stack_before,omitempty"`
+	OperandStackAfter  string                 `protobuf:"bytes,13,opt,name=operand_stack_after,json=operandStackAfter,proto3" json:"operand_stack_after,omitempty"`
+	StackBefore        []*JVMStackEntry       `protobuf:"bytes,14,rep,name=stack_before,json=stackBefore,proto3" json:"stack_before,omitempty"`
+	StackAfter         []*JVMStackEntry       `protobuf:"bytes,15,rep,name=stack_after,json=stackAfter,proto3" json:"stack_after,omitempty"`
+	StackDelta         *int32                 `protobuf:"varint,16,opt,name=stack_delta,json=stackDelta,proto3,oneof" json:"stack_delta,omitempty"`
+	Description        string                 `protobuf:"bytes,17,opt,name=description,proto3" json:"description,omitempty"`
+	LinkingExceptions  string                 `protobuf:"bytes,18,opt,name=linking_exceptions,json=linkingExceptions,proto3" json:"linking_exceptions,omitempty"`
+	RuntimeExceptions  string                 `protobuf:"bytes,19,opt,name=runtime_exceptions,json=runtimeExceptions,proto3" json:"runtime_exceptions,omitempty"`
+	Notes              string                 `protobuf:"bytes,20,opt,name=notes,proto3" json:"notes,omitempty"`
+	Source             string                 `protobuf:"bytes,21,opt,name=source,proto3" json:"source,omitempty"`
+	SpecUrl            string                 `protobuf:"bytes,22,opt,name=spec_url,json=specUrl,proto3" json:"spec_url,omitempty"`
+	AnchorId           string                 `protobuf:"bytes,23,opt,name=anchor_id,json=anchorId,proto3" json:"anchor_id,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *JVMInstruction) Reset() {
+	*x = JVMInstruction{}
+	mi := &file_schema_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JVMInstruction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JVMInstruction) ProtoMessage() {}
+
+func (x *JVMInstruction) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JVMInstruction.ProtoReflect.Descriptor instead.
+func (*JVMInstruction) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *JVMInstruction) GetMnemonic() string {
+	if x != nil {
+		return x.Mnemonic
+	}
+	return ""
+}
+
+func (x *JVMInstruction) GetOpcode() string {
+	if x != nil {
+		return x.Opcode
+	}
+	return ""
+}
+
+func (x *JVMInstruction) GetOpcodeByte() uint32 {
+	if x != nil {
+		return x.OpcodeByte
+	}
+	return 0
+}
+
+func (x *JVMInstruction) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *JVMInstruction) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *JVMInstruction) GetOperands() []string {
+	if x != nil {
+		return x.Operands
+	}
+	return nil
+}
+
+func (x *JVMInstruction) GetOperandLayout() []*JVMOperand {
+	if x != nil {
+		return x.OperandLayout
+	}
+	return nil
+}
+
+func (x *JVMInstruction) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *JVMInstruction) GetVariableLength() bool {
+	if x != nil {
+		return x.VariableLength
+	}
+	return false
+}
+
+func (x *JVMInstruction) GetModifies() string {
+	if x != nil {
+		return x.Modifies
+	}
+	return ""
+}
+
+func (x *JVMInstruction) GetReserved() bool {
+	if x != nil {
+		return x.Reserved
+	}
+	return false
+}
+
+func (x *JVMInstruction) GetOperandStackBefore() string {
+	if x != nil {
+		return x.OperandStackBefore
+	}
+	return ""
+}
+
+func (x *JVMInstruction) GetOperandStackAfter() string {
+	if x != nil {
+		return x.OperandStackAfter
+	}
+	return ""
+}
+
+func (x *JVMInstruction) GetStackBefore() []*JVMStackEntry {
+	if x != nil {
+		return x.StackBefore
+	}
+	return nil
+}
+
+func (x *JVMInstruction) GetStackAfter() []*JVMStackEntry {
+	if x != nil {
+		return x.StackAfter
+	}
+	return nil
+}
+
+func (x *JVMInstruction) GetStackDelta() int32 {
+	if x != nil && x.StackDelta != nil {
+		return *x.StackDelta
+	}
+	return 0
+}
+
+func (x *JVMInstruction) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *JVMInstruction) GetLinkingExceptions() string {
+	if x != nil {
+		return x.LinkingExceptions
+	}
+	return ""
+}
+
+func (x *JVMInstruction) GetRuntimeExceptions() string {
+	if x != nil {
+		return x.RuntimeExceptions
+	}
+	return ""
+}
+
+func (x *JVMInstruction) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *JVMInstruction) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *JVMInstruction) GetSpecUrl() string {
+	if x != nil {
+		return x.SpecUrl
+	}
+	return ""
+}
+
+func (x *JVMInstruction) GetAnchorId() string {
+	if x != nil {
+		return x.AnchorId
+	}
+	return ""
+}
+
+type JVMOperand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Count         string                 `protobuf:"bytes,4,opt,name=count,proto3" json:"count,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Fields        []*JVMOperand          `protobuf:"bytes,6,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JVMOperand) Reset() {
+	*x = JVMOperand{}
+	mi := &file_schema_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JVMOperand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JVMOperand) ProtoMessage() {}
+
+func (x *JVMOperand) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JVMOperand.ProtoReflect.Descriptor instead.
+func (*JVMOperand) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *JVMOperand) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JVMOperand) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *JVMOperand) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *JVMOperand) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+func (x *JVMOperand) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *JVMOperand) GetFields() []*JVMOperand {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type JVMStackEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Category      int32                  `protobuf:"varint,3,opt,name=category,proto3" json:"category,omitempty"`
+	Width         int32                  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Variadic      bool                   `protobuf:"varint,5,opt,name=variadic,proto3" json:"variadic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JVMStackEntry) Reset() {
+	*x = JVMStackEntry{}
+	mi := &file_schema_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JVMStackEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JVMStackEntry) ProtoMessage() {}
+
+func (x *JVMStackEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JVMStackEntry.ProtoReflect.Descriptor instead.
+func (*JVMStackEntry) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *JVMStackEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JVMStackEntry) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *JVMStackEntry) GetCategory() int32 {
+	if x != nil {
+		return x.Category
+	}
+	return 0
+}
+
+func (x *JVMStackEntry) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *JVMStackEntry) GetVariadic() bool {
+	if x != nil {
+		return x.Variadic
+	}
+	return false
+}
+
+var File_schema_proto protoreflect.FileDescriptor
+
+const file_schema_proto_rawDesc = "" +
+	"\n" +
+	"\fschema.proto\x12\x0farisa.schema.v1\"Q\n" +
+	"\n" +
+	"X86Dataset\x12C\n" +
+	"\finstructions\x18\x01 \x03(\v2\x1f.arisa.schema.v1.X86InstructionR\finstructions\"\x97\x06\n" +
+	"\x0eX86Instruction\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x1a\n" +
+	"\btaxonomy\x18\x03 \x01(\tR\btaxonomy\x12)\n" +
+	"\x10instruction_name\x18\x04 \x01(\tR\x0finstructionName\x12\x16\n" +
+	"\x06parent\x18\x05 \x01(\tR\x06parent\x12)\n" +
+	"\x10description_text\x18\x06 \x01(\tR\x0fdescriptionText\x121\n" +
+	"\x14description_markdown\x18\a \x01(\tR\x13descriptionMarkdown\x12%\n" +
+	"\x0eoperation_text\x18\b \x01(\tR\roperationText\x12.\n" +
+	"\x13flags_affected_text\x18\t \x01(\tR\x11flagsAffectedText\x12\x1e\n" +
+	"\n" +
+	"intrinsics\x18\n" +
+	" \x03(\tR\n" +
+	"intrinsics\x12.\n" +
+	"\x05forms\x18\v \x03(\v2\x18.arisa.schema.v1.X86FormR\x05forms\x12Y\n" +
+	"\x0eflags_affected\x18\f \x03(\v22.arisa.schema.v1.X86Instruction.FlagsAffectedEntryR\rflagsAffected\x12=\n" +
+	"\n" +
+	"exceptions\x18\r \x03(\v2\x1d.arisa.schema.v1.X86ExceptionR\n" +
+	"exceptions\x12#\n" +
+	"\rfeature_flags\x18\x0e \x03(\tR\ffeatureFlags\x12\x1f\n" +
+	"\vvmcs_fields\x18\x0f \x01(\tR\n" +
+	"vmcsFields\x12!\n" +
+	"\fcontent_hash\x18\x10 \x01(\tR\vcontentHash\x12\x18\n" +
+	"\aaliases\x18\x11 \x03(\tR\aaliases\x12\x14\n" +
+	"\x05error\x18\x12 \x01(\tR\x05error\x1a@\n" +
+	"\x12FlagsAffectedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x91\x04\n" +
+	"\aX86Form\x12\x16\n" +
+	"\x06opcode\x18\x01 \x01(\tR\x06opcode\x12 \n" +
+	"\vinstruction\x18\x02 \x01(\tR\vinstruction\x12\x1a\n" +
+	"\bmnemonic\x18\x03 \x01(\tR\bmnemonic\x12\x1a\n" +
+	"\boperands\x18\x04 \x03(\tR\boperands\x12\x13\n" +
+	"\x05op_en\x18\x05 \x01(\tR\x04opEn\x12\x18\n" +
+	"\avalid64\x18\x06 \x01(\tR\avalid64\x12!\n" +
+	"\fvalid_compat\x18\a \x01(\tR\vvalidCompat\x12!\n" +
+	"\fmode_support\x18\b \x01(\tR\vmodeSupport\x12\x14\n" +
+	"\x05cpuid\x18\t \x01(\tR\x05cpuid\x12#\n" +
+	"\rfeature_flags\x18\n" +
+	" \x03(\tR\ffeatureFlags\x12 \n" +
+	"\vdescription\x18\v \x01(\tR\vdescription\x12\x16\n" +
+	"\x06iforms\x18\f \x03(\tR\x06iforms\x128\n" +
+	"\bencoding\x18\r \x01(\v2\x1c.arisa.schema.v1.X86EncodingR\bencoding\x12D\n" +
+	"\x0foperand_details\x18\x0e \x03(\v2\x1b.arisa.schema.v1.X86OperandR\x0eoperandDetails\x12\x14\n" +
+	"\x05notes\x18\x0f \x03(\tR\x05notes\x12\x14\n" +
+	"\x05table\x18\x10 \x01(\tR\x05table\"\xe2\x05\n" +
+	"\vX86Encoding\x12!\n" +
+	"\fprefix_class\x18\x01 \x01(\tR\vprefixClass\x12#\n" +
+	"\rvector_length\x18\x02 \x01(\tR\fvectorLength\x12)\n" +
+	"\x10mandatory_prefix\x18\x03 \x01(\tR\x0fmandatoryPrefix\x12\x10\n" +
+	"\x03map\x18\x04 \x01(\tR\x03map\x12!\n" +
+	"\fescape_bytes\x18\x05 \x03(\tR\vescapeBytes\x12\x10\n" +
+	"\x03rex\x18\x06 \x01(\bR\x03rex\x12\f\n" +
+	"\x01w\x18\a \x01(\tR\x01w\x12\x12\n" +
+	"\x04vvvv\x18\b \x01(\tR\x04vvvv\x12\x1f\n" +
+	"\vopcode_byte\x18\t \x01(\tR\n" +
+	"opcodeByte\x12!\n" +
+	"\fopcode_bytes\x18\n" +
+	" \x03(\tR\vopcodeBytes\x12'\n" +
+	"\x0fopcode_register\x18\v \x01(\tR\x0eopcodeRegister\x12\x14\n" +
+	"\x05modrm\x18\f \x01(\tR\x05modrm\x12 \n" +
+	"\tmodrm_reg\x18\r \x01(\x05H\x00R\bmodrmReg\x88\x01\x01\x12\x1c\n" +
+	"\timmediate\x18\x0e \x01(\tR\timmediate\x12\x1e\n" +
+	"\n" +
+	"immediates\x18\x0f \x03(\tR\n" +
+	"immediates\x12%\n" +
+	"\x0eimmediate_size\x18\x10 \x01(\x05R\rimmediateSize\x12\x18\n" +
+	"\amasking\x18\x11 \x01(\bR\amasking\x12!\n" +
+	"\fzero_masking\x18\x12 \x01(\bR\vzeroMasking\x12\x1c\n" +
+	"\tbroadcast\x18\x13 \x01(\bR\tbroadcast\x12\x1a\n" +
+	"\brounding\x18\x14 \x01(\bR\brounding\x12\x10\n" +
+	"\x03sae\x18\x15 \x01(\bR\x03sae\x12\x12\n" +
+	"\x04egpr\x18\x16 \x01(\bR\x04egpr\x12\x10\n" +
+	"\x03ndd\x18\x17 \x01(\bR\x03ndd\x12\x0e\n" +
+	"\x02nf\x18\x18 \x01(\bR\x02nf\x12\x0e\n" +
+	"\x02zu\x18\x19 \x01(\bR\x02zu\x12\x10\n" +
+	"\x03scc\x18\x1a \x01(\bR\x03sccB\f\n" +
+	"\n" +
+	"_modrm_reg\"\x96\x01\n" +
+	"\n" +
+	"X86Operand\x12\x16\n" +
+	"\x06syntax\x18\x01 \x01(\tR\x06syntax\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05width\x18\x04 \x01(\x05R\x05width\x12\x1a\n" +
+	"\bencoding\x18\x05 \x01(\tR\bencoding\x12\x16\n" +
+	"\x06access\x18\x06 \x01(\tR\x06access\"X\n" +
+	"\fX86Exception\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x16\n" +
+	"\x06vector\x18\x02 \x01(\tR\x06vector\x12\x1c\n" +
+	"\tcondition\x18\x03 \x01(\tR\tcondition\"Q\n" +
+	"\n" +
+	"JVMDataset\x12C\n" +
+	"\finstructions\x18\x01 \x03(\v2\x1f.arisa.schema.v1.JVMInstructionR\finstructions\"\xf6\x06\n" +
+	"\x0eJVMInstruction\x12\x1a\n" +
+	"\bmnemonic\x18\x01 \x01(\tR\bmnemonic\x12\x16\n" +
+	"\x06opcode\x18\x02 \x01(\tR\x06opcode\x12\x1f\n" +
+	"\vopcode_byte\x18\x03 \x01(\rR\n" +
+	"opcodeByte\x12\x1c\n" +
+	"\toperation\x18\x04 \x01(\tR\toperation\x12\x16\n" +
+	"\x06format\x18\x05 \x01(\tR\x06format\x12\x1a\n" +
+	"\boperands\x18\x06 \x03(\tR\boperands\x12B\n" +
+	"\x0eoperand_layout\x18\a \x03(\v2\x1b.arisa.schema.v1.JVMOperandR\roperandLayout\x12\x16\n" +
+	"\x06length\x18\b \x01(\x05R\x06length\x12'\n" +
+	"\x0fvariable_length\x18\t \x01(\bR\x0evariableLength\x12\x1a\n" +
+	"\bmodifies\x18\n" +
+	" \x01(\tR\bmodifies\x12\x1a\n" +
+	"\breserved\x18\v \x01(\bR\breserved\x120\n" +
+	"\x14operand_stack_before\x18\f \x01(\tR\x12operandStackBefore\x12.\n" +
+	"\x13operand_stack_after\x18\r \x01(\tR\x11operandStackAfter\x12A\n" +
+	"\fstack_before\x18\x0e \x03(\v2\x1e.arisa.schema.v1.JVMStackEntryR\vstackBefore\x12?\n" +
+	"\vstack_after\x18\x0f \x03(\v2\x1e.arisa.schema.v1.JVMStackEntryR\n" +
+	"stackAfter\x12$\n" +
+	"\vstack_delta\x18\x10 \x01(\x05H\x00R\n" +
+	"stackDelta\x88\x01\x01\x12 \n" +
+	"\vdescription\x18\x11 \x01(\tR\vdescription\x12-\n" +
+	"\x12linking_exceptions\x18\x12 \x01(\tR\x11linkingExceptions\x12-\n" +
+	"\x12runtime_exceptions\x18\x13 \x01(\tR\x11runtimeExceptions\x12\x14\n" +
+	"\x05notes\x18\x14 \x01(\tR\x05notes\x12\x16\n" +
+	"\x06source\x18\x15 \x01(\tR\x06source\x12\x19\n" +
+	"\bspec_url\x18\x16 \x01(\tR\aspecUrl\x12\x1b\n" +
+	"\tanchor_id\x18\x17 \x01(\tR\banchorIdB\x0e\n" +
+	"\f_stack_delta\"\xb5\x01\n" +
+	"\n" +
+	"JVMOperand\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\tR\x05count\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x123\n" +
+	"\x06fields\x18\x06 \x03(\v2\x1b.arisa.schema.v1.JVMOperandR\x06fields\"\x85\x01\n" +
+	"\rJVMStackEntry\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\x05R\bcategory\x12\x14\n" +
+	"\x05width\x18\x04 \x01(\x05R\x05width\x12\x1a\n" +
+	"\bvariadic\x18\x05 \x01(\bR\bvariadicB\x10Z\x0earisa/schemapbb\x06proto3"
+
+var (
+	file_schema_proto_rawDescOnce sync.Once
+	file_schema_proto_rawDescData []byte
+)
+
+func file_schema_proto_rawDescGZIP() []byte {
+	file_schema_proto_rawDescOnce.Do(func() {
+		file_schema_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schema_proto_rawDesc), len(file_schema_proto_rawDesc)))
+	})
+	return file_schema_proto_rawDescData
+}
+
+var file_schema_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_schema_proto_goTypes = []any{
+	(*X86Dataset)(nil),     // 0: arisa.schema.v1.X86Dataset
+	(*X86Instruction)(nil), // 1: arisa.schema.v1.X86Instruction
+	(*X86Form)(nil),        // 2: arisa.schema.v1.X86Form
+	(*X86Encoding)(nil),    // 3: arisa.schema.v1.X86Encoding
+	(*X86Operand)(nil),     // 4: arisa.schema.v1.X86Operand
+	(*X86Exception)(nil),   // 5: arisa.schema.v1.X86Exception
+	(*JVMDataset)(nil),     // 6: arisa.schema.v1.JVMDataset
+	(*JVMInstruction)(nil), // 7: arisa.schema.v1.JVMInstruction
+	(*JVMOperand)(nil),     // 8: arisa.schema.v1.JVMOperand
+	(*JVMStackEntry)(nil),  // 9: arisa.schema.v1.JVMStackEntry
+	nil,                    // 10: arisa.schema.v1.X86Instruction.FlagsAffectedEntry
+}
+var file_schema_proto_depIdxs = []int32{
+	1,  // 0: arisa.schema.v1.X86Dataset.instructions:type_name -> arisa.schema.v1.X86Instruction
+	2,  // 1: arisa.schema.v1.X86Instruction.forms:type_name -> arisa.schema.v1.X86Form
+	10, // 2: arisa.schema.v1.X86Instruction.flags_affected:type_name -> arisa.schema.v1.X86Instruction.FlagsAffectedEntry
+	5,  // 3: arisa.schema.v1.X86Instruction.exceptions:type_name -> arisa.schema.v1.X86Exception
+	3,  // 4: arisa.schema.v1.X86Form.encoding:type_name -> arisa.schema.v1.X86Encoding
+	4,  // 5: arisa.schema.v1.X86Form.operand_details:type_name -> arisa.schema.v1.X86Operand
+	7,  // 6: arisa.schema.v1.JVMDataset.instructions:type_name -> arisa.schema.v1.JVMInstruction
+	8,  // 7: arisa.schema.v1.JVMInstruction.operand_layout:type_name -> arisa.schema.v1.JVMOperand
+	9,  // 8: arisa.schema.v1.JVMInstruction.stack_before:type_name -> arisa.schema.v1.JVMStackEntry
+	9,  // 9: arisa.schema.v1.JVMInstruction.stack_after:type_name -> arisa.schema.v1.JVMStackEntry
+	8,  // 10: arisa.schema.v1.JVMOperand.fields:type_name -> arisa.schema.v1.JVMOperand
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_schema_proto_init() }
+func file_schema_proto_init() {
+	if File_schema_proto != nil {
+		return
+	}
+	file_schema_proto_msgTypes[3].OneofWrappers = []any{}
+	file_schema_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schema_proto_rawDesc), len(file_schema_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_schema_proto_goTypes,
+		DependencyIndexes: file_schema_proto_depIdxs,
+		MessageInfos:      file_schema_proto_msgTypes,
+	}.Build()
+	File_schema_proto = out.File
+	file_schema_proto_goTypes = nil
+	file_schema_proto_depIdxs = nil
+}
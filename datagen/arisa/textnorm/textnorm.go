@@ -0,0 +1,199 @@
+// Package textnorm cleans up the text the scrapers pull out of converted
+// PDFs: mojibake, look-alike spaces, invisible characters and stray
+// private-use glyphs, followed by NFC normalization.
+package textnorm
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/unicode/norm"
+)
+
+// mojibakeMarkers are the lead characters UTF-8 text picks up when it is
+// decoded as Windows-1252 somewhere upstream, e.g. "â„¢" for "™" or "Ã©"
+// for "é".
+var mojibakeMarkers = []string{"â€", "â„", "Â", "Ã"}
+
+var textReplacer = strings.NewReplacer(
+	// Spaces that render the same as a regular one but break matching.
+	"\u00a0", " ", "\u2007", " ", "\u202f", " ",
+	// Invisible characters left over from the PDF conversion.
+	"\u200b", "", "\u200c", "", "\u200d", "", "\ufeff", "", "\u00ad", "",
+	// C1 controls that were Windows-1252 quotes decoded as Latin-1.
+	"\u0091", "‘", "\u0092", "’", "\u0093", "“", "\u0094", "”", "\u0096", "–", "\u0097", "—",
+	// A low-9 quote the SDM typesets in place of the comma inside a
+	// closing quote, as in “Interrupts and Exceptions‚”.
+	"‚”", ",”",
+	// Angle brackets from the Symbol font's private-use code points.
+	"\uf0e1", "⟨", "\uf0f1", "⟩",
+)
+
+// Text returns text with mojibake repaired, look-alike and invisible
+// characters replaced, and the result in NFC.
+func Text(text string) string {
+	if text == "" {
+		return text
+	}
+
+	for _, marker := range mojibakeMarkers {
+		if strings.Contains(text, marker) {
+			text = RepairMojibake(text)
+			break
+		}
+	}
+
+	return norm.NFC.String(textReplacer.Replace(text))
+}
+
+// RepairMojibake undoes UTF-8 that was decoded as Windows-1252. Each
+// character is mapped back to its Windows-1252 byte, and any byte sequence
+// that forms a valid multi-byte UTF-8 rune is replaced by that rune.
+// Everything else, such as a lone "®" next to a broken "â„¢", is kept.
+func RepairMojibake(text string) string {
+	runes := []rune(text)
+	raw := make([]byte, len(runes))
+	mapped := make([]bool, len(runes))
+	for i, r := range runes {
+		switch b, ok := charmap.Windows1252.EncodeRune(r); {
+		case ok && b >= 0x80:
+			raw[i], mapped[i] = b, true
+		case r >= 0x80 && r <= 0x9f:
+			// Bytes Windows-1252 leaves undefined pass through as C1 controls.
+			raw[i], mapped[i] = byte(r), true
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		if mapped[i] {
+			end := i
+			for end < len(runes) && end-i < utf8.UTFMax && mapped[end] {
+				end++
+			}
+			if r, size := utf8.DecodeRune(raw[i:end]); r != utf8.RuneError && size > 1 {
+				b.WriteRune(r)
+				i += size
+				continue
+			}
+		}
+		b.WriteRune(runes[i])
+		i++
+	}
+	return b.String()
+}
+
+// Collision is a set of map keys that Text turns into the same string,
+// such as "64-Bit Mode" and "64-Bit\u00a0Mode" among a table's headers.
+// Path locates the map by Go field names and indices, as in
+// "DetailsTable[3]".
+type Collision struct {
+	Path string
+	Key  string
+	Keys []string
+}
+
+func (c Collision) String() string {
+	return fmt.Sprintf("%s: %q all normalize to %q", c.Path, c.Keys, c.Key)
+}
+
+// Value applies Text to every settable string reachable from value, which
+// must be a pointer, including map keys. Keys that would collide are left
+// as they are, values still normalized, and reported rather than merged,
+// since merging would silently drop all but one of their values.
+func Value(value any) []Collision {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		panic("textnorm: Value needs a non-nil pointer")
+	}
+	var collisions []Collision
+	normalizeValue(v.Elem(), "", &collisions)
+	return collisions
+}
+
+func normalizeValue(v reflect.Value, path string, collisions *[]Collision) {
+	switch v.Kind() {
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(Text(v.String()))
+		}
+	case reflect.Ptr:
+		if !v.IsNil() {
+			normalizeValue(v.Elem(), path, collisions)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Type().Field(i); field.IsExported() {
+				normalizeValue(v.Field(i), joinPath(path, field.Name), collisions)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			normalizeValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), collisions)
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return
+		}
+		normalizeMap(v, path, collisions)
+	}
+}
+
+func normalizeMap(v reflect.Value, path string, collisions *[]Collision) {
+	type entry struct {
+		original, key, value reflect.Value
+	}
+	entries := make([]entry, 0, v.Len())
+	originals := make(map[string][]string)
+	iter := v.MapRange()
+	for iter.Next() {
+		key := reflect.New(v.Type().Key()).Elem()
+		key.Set(iter.Key())
+		normalizeValue(key, path, collisions)
+
+		value := reflect.New(v.Type().Elem()).Elem()
+		value.Set(iter.Value())
+		normalizeValue(value, fmt.Sprintf("%s[%v]", path, key), collisions)
+
+		entries = append(entries, entry{iter.Key(), key, value})
+		if key.Kind() == reflect.String {
+			originals[key.String()] = append(originals[key.String()], iter.Key().String())
+		}
+	}
+
+	var keys []string
+	for key, from := range originals {
+		if len(from) > 1 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		from := originals[key]
+		sort.Strings(from)
+		*collisions = append(*collisions, Collision{Path: path, Key: key, Keys: from})
+	}
+
+	normalized := reflect.MakeMapWithSize(v.Type(), v.Len())
+	for _, e := range entries {
+		key := e.key
+		if key.Kind() == reflect.String && len(originals[key.String()]) > 1 {
+			key = e.original
+		}
+		normalized.SetMapIndex(key, e.value)
+	}
+	if v.CanSet() {
+		v.Set(normalized)
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
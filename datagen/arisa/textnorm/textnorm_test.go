@@ -0,0 +1,78 @@
+package textnorm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{
+			// felixcloutier.com/x86/ret, Description.
+			name: "low-9 quote",
+			in:   "Refer to Chapter 6, “Procedure Calls, Interrupts, and Exceptions‚” and Chapter 17",
+			want: "Refer to Chapter 6, “Procedure Calls, Interrupts, and Exceptions,” and Chapter 17",
+		},
+		{
+			// felixcloutier.com/x86/xrstor, Operation.
+			name: "symbol font brackets",
+			in:   "XRSTOR_INFO := \uf0e1CPL,VMXNR,LAXA,COMPMASK\uf0f1;",
+			want: "XRSTOR_INFO := ⟨CPL,VMXNR,LAXA,COMPMASK⟩;",
+		},
+		{
+			name: "mojibake",
+			in:   "Intel® 64 and IA-32 Architectures Software Developerâ€™s Manual",
+			want: "Intel® 64 and IA-32 Architectures Software Developer’s Manual",
+		},
+		{
+			name: "spaces and invisibles",
+			in:   "64-Bit\u00a0Mode\u200b",
+			want: "64-Bit Mode",
+		},
+		{
+			name: "NFC",
+			in:   "Cafe\u0301",
+			want: "Café",
+		},
+	}
+	for _, test := range tests {
+		if got := Text(test.in); got != test.want {
+			t.Errorf("%s: Text(%q) = %q, want %q", test.name, test.in, got, test.want)
+		}
+	}
+}
+
+func TestValueReportsCollisions(t *testing.T) {
+	type record struct {
+		Name string
+		Rows []map[string]string
+	}
+	value := record{
+		Name: "XCHG\u00a0— Exchange",
+		Rows: []map[string]string{{
+			"Op/En":                "MR",
+			"64-Bit Mode":          "Valid",
+			"64-Bit\u00a0Mode":     "N.E.\u200b",
+			"Compat/Leg\u00a0Mode": "Valid",
+		}},
+	}
+
+	collisions := Value(&value)
+	want := []Collision{{Path: "Rows[0]", Key: "64-Bit Mode", Keys: []string{"64-Bit Mode", "64-Bit\u00a0Mode"}}}
+	if !reflect.DeepEqual(collisions, want) {
+		t.Errorf("collisions = %v, want %v", collisions, want)
+	}
+
+	if value.Name != "XCHG — Exchange" {
+		t.Errorf("Name = %q", value.Name)
+	}
+	row := value.Rows[0]
+	if row["64-Bit Mode"] != "Valid" || row["64-Bit\u00a0Mode"] != "N.E." {
+		t.Errorf("colliding keys weren't kept apart: %q", row)
+	}
+	if row["Compat/Leg Mode"] != "Valid" || len(row) != 4 {
+		t.Errorf("row = %q", row)
+	}
+}
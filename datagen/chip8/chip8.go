@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const outputFilename = "chip8.json"
+
+type Operand struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	High int    `json:"high"`
+	Low  int    `json:"low"`
+}
+
+type Chip8Instruction struct {
+	Pattern   string    `json:"pattern"`
+	Match     string    `json:"match"`
+	Mask      string    `json:"mask"`
+	Mnemonic  string    `json:"mnemonic"`
+	Syntax    string    `json:"syntax"`
+	Variant   string    `json:"variant"`
+	Operands  []Operand `json:"operands"`
+	Semantics string    `json:"semantics"`
+	VF        string    `json:"vf,omitempty"`
+	Quirks    []string  `json:"quirks,omitempty"`
+	AnchorID  string    `json:"anchorId"`
+}
+
+type instructionDef struct {
+	pattern   string
+	syntax    string
+	variant   string
+	semantics string
+	vf        string
+	quirks    []string
+}
+
+var operandKinds = map[string]struct {
+	name string
+	kind string
+}{
+	"NNN": {"addr", "address"},
+	"NN":  {"byte", "immediate"},
+	"N":   {"nibble", "immediate"},
+	"X":   {"Vx", "register"},
+	"Y":   {"Vy", "register"},
+}
+
+var instructionDefs = []instructionDef{
+	{"00E0", "CLS", "chip8", "Clear the display.", "", nil},
+	{"00EE", "RET", "chip8", "PC = stack[SP]; SP -= 1.", "", nil},
+	{"0NNN", "SYS addr", "chip8", "Call RCA 1802 machine code routine at NNN; ignored by most interpreters.", "", nil},
+	{"1NNN", "JP addr", "chip8", "PC = NNN.", "", nil},
+	{"2NNN", "CALL addr", "chip8", "SP += 1; stack[SP] = PC; PC = NNN.", "", nil},
+	{"3XNN", "SE Vx, byte", "chip8", "Skip next instruction if Vx == NN.", "", nil},
+	{"4XNN", "SNE Vx, byte", "chip8", "Skip next instruction if Vx != NN.", "", nil},
+	{"5XY0", "SE Vx, Vy", "chip8", "Skip next instruction if Vx == Vy.", "", nil},
+	{"6XNN", "LD Vx, byte", "chip8", "Vx = NN.", "", nil},
+	{"7XNN", "ADD Vx, byte", "chip8", "Vx = (Vx + NN) & 0xFF.", "unchanged", nil},
+	{"8XY0", "LD Vx, Vy", "chip8", "Vx = Vy.", "", nil},
+	{"8XY1", "OR Vx, Vy", "chip8", "Vx = Vx | Vy.", "reset to 0 on original COSMAC VIP", []string{"vf-reset"}},
+	{"8XY2", "AND Vx, Vy", "chip8", "Vx = Vx & Vy.", "reset to 0 on original COSMAC VIP", []string{"vf-reset"}},
+	{"8XY3", "XOR Vx, Vy", "chip8", "Vx = Vx ^ Vy.", "reset to 0 on original COSMAC VIP", []string{"vf-reset"}},
+	{"8XY4", "ADD Vx, Vy", "chip8", "Vx = (Vx + Vy) & 0xFF.", "1 on carry, else 0", nil},
+	{"8XY5", "SUB Vx, Vy", "chip8", "Vx = (Vx - Vy) & 0xFF.", "1 if Vx >= Vy (no borrow), else 0", nil},
+	{"8XY6", "SHR Vx {, Vy}", "chip8", "Vx = Vy >> 1 (COSMAC VIP) or Vx = Vx >> 1 (SUPER-CHIP).", "least significant bit before the shift", []string{"shift-uses-vy"}},
+	{"8XY7", "SUBN Vx, Vy", "chip8", "Vx = (Vy - Vx) & 0xFF.", "1 if Vy >= Vx (no borrow), else 0", nil},
+	{"8XYE", "SHL Vx {, Vy}", "chip8", "Vx = (Vy << 1) & 0xFF (COSMAC VIP) or Vx = (Vx << 1) & 0xFF (SUPER-CHIP).", "most significant bit before the shift", []string{"shift-uses-vy"}},
+	{"9XY0", "SNE Vx, Vy", "chip8", "Skip next instruction if Vx != Vy.", "", nil},
+	{"ANNN", "LD I, addr", "chip8", "I = NNN.", "", nil},
+	{"BNNN", "JP V0, addr", "chip8", "PC = NNN + V0 (COSMAC VIP) or PC = XNN + Vx (SUPER-CHIP).", "", []string{"jump-uses-vx"}},
+	{"CXNN", "RND Vx, byte", "chip8", "Vx = random byte & NN.", "", nil},
+	{"DXYN", "DRW Vx, Vy, nibble", "chip8", "Draw an 8xN sprite from memory at I at (Vx, Vy) using XOR.", "1 if any set pixel is erased, else 0", []string{"display-wait", "clipping"}},
+	{"EX9E", "SKP Vx", "chip8", "Skip next instruction if key Vx is pressed.", "", nil},
+	{"EXA1", "SKNP Vx", "chip8", "Skip next instruction if key Vx is not pressed.", "", nil},
+	{"FX07", "LD Vx, DT", "chip8", "Vx = delay timer.", "", nil},
+	{"FX0A", "LD Vx, K", "chip8", "Wait for a key press and store its value in Vx.", "", nil},
+	{"FX15", "LD DT, Vx", "chip8", "Delay timer = Vx.", "", nil},
+	{"FX18", "LD ST, Vx", "chip8", "Sound timer = Vx.", "", nil},
+	{"FX1E", "ADD I, Vx", "chip8", "I = I + Vx.", "", nil},
+	{"FX29", "LD F, Vx", "chip8", "I = address of the 4x5 font sprite for digit Vx & 0xF.", "", nil},
+	{"FX33", "LD B, Vx", "chip8", "Store the BCD digits of Vx at I, I+1, I+2.", "", nil},
+	{"FX55", "LD [I], Vx", "chip8", "Store V0..Vx to memory starting at I; I += X + 1 on COSMAC VIP.", "", []string{"memory-increments-i"}},
+	{"FX65", "LD Vx, [I]", "chip8", "Load V0..Vx from memory starting at I; I += X + 1 on COSMAC VIP.", "", []string{"memory-increments-i"}},
+	{"00CN", "SCD nibble", "schip", "Scroll the display down by N pixels.", "", nil},
+	{"00FB", "SCR", "schip", "Scroll the display right by 4 pixels.", "", nil},
+	{"00FC", "SCL", "schip", "Scroll the display left by 4 pixels.", "", nil},
+	{"00FD", "EXIT", "schip", "Exit the interpreter.", "", nil},
+	{"00FE", "LOW", "schip", "Switch to 64x32 low-resolution mode.", "", nil},
+	{"00FF", "HIGH", "schip", "Switch to 128x64 high-resolution mode.", "", nil},
+	{"DXY0", "DRW Vx, Vy, 0", "schip", "Draw a 16x16 sprite from memory at I at (Vx, Vy) in high-resolution mode.", "number of rows that collide or are clipped", nil},
+	{"FX30", "LD HF, Vx", "schip", "I = address of the 8x10 large font sprite for digit Vx.", "", nil},
+	{"FX75", "LD R, Vx", "schip", "Store V0..Vx in RPL user flags (X <= 7).", "", nil},
+	{"FX85", "LD Vx, R", "schip", "Load V0..Vx from RPL user flags (X <= 7).", "", nil},
+}
+
+type Generator struct {
+	logger *log.Logger
+}
+
+func NewGenerator() *Generator {
+	logger := log.NewWithOptions(os.Stderr, log.Options{
+		ReportCaller:    false,
+		ReportTimestamp: true,
+		TimeFormat:      time.Kitchen,
+		Prefix:          "chip8-datagen",
+	})
+
+	return &Generator{
+		logger: logger,
+	}
+}
+
+func (g *Generator) parsePattern(pattern string) (uint16, uint16, []Operand, error) {
+	if len(pattern) != 4 {
+		return 0, 0, nil, fmt.Errorf("pattern %q must have four nibbles", pattern)
+	}
+
+	var match, mask uint16
+	var operands []Operand
+
+	for i := 0; i < len(pattern); {
+		shift := uint(12 - 4*i)
+		c := pattern[i]
+
+		if value := strings.IndexByte("0123456789ABCDEF", c); value >= 0 {
+			match |= uint16(value) << shift
+			mask |= 0xF << shift
+			i++
+			continue
+		}
+
+		run := i
+		for run < len(pattern) && pattern[run] == c {
+			run++
+		}
+		token := pattern[i:run]
+
+		kind, ok := operandKinds[token]
+		if !ok {
+			return 0, 0, nil, fmt.Errorf("unknown operand %q in pattern %q", token, pattern)
+		}
+		operands = append(operands, Operand{Name: kind.name, Kind: kind.kind, High: 15 - 4*i, Low: 4 * (len(pattern) - run)})
+		i = run
+	}
+
+	return match, mask, operands, nil
+}
+
+func (g *Generator) buildInstructions() ([]Chip8Instruction, error) {
+	var instructions []Chip8Instruction
+
+	for _, def := range instructionDefs {
+		match, mask, operands, err := g.parsePattern(def.pattern)
+		if err != nil {
+			return nil, err
+		}
+		if operands == nil {
+			operands = []Operand{}
+		}
+
+		mnemonic := strings.Fields(def.syntax)[0]
+		instructions = append(instructions, Chip8Instruction{
+			Pattern:   def.pattern,
+			Match:     fmt.Sprintf("0x%04X", match),
+			Mask:      fmt.Sprintf("0x%04X", mask),
+			Mnemonic:  mnemonic,
+			Syntax:    def.syntax,
+			Variant:   def.variant,
+			Operands:  operands,
+			Semantics: def.semantics,
+			VF:        def.vf,
+			Quirks:    def.quirks,
+			AnchorID:  "chip8-" + strings.ToLower(def.pattern),
+		})
+	}
+
+	sort.SliceStable(instructions, func(i, j int) bool {
+		return instructions[i].Pattern < instructions[j].Pattern
+	})
+
+	return instructions, nil
+}
+
+func (g *Generator) saveData(instructions []Chip8Instruction) error {
+	g.logger.Info("Saving instruction data", "count", len(instructions))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(instructions); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(outputFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	g.logger.Info("Data saved successfully", "file", outputFilename)
+	return nil
+}
+
+func (g *Generator) Run() error {
+	g.logger.Info("Starting CHIP-8 instruction generator")
+
+	instructions, err := g.buildInstructions()
+	if err != nil {
+		return fmt.Errorf("failed to build instructions: %w", err)
+	}
+
+	if err := g.saveData(instructions); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+
+	g.logger.Info("Generation completed successfully")
+	return nil
+}
+
+func main() {
+	generator := NewGenerator()
+	if err := generator.Run(); err != nil {
+		generator.logger.Fatal("Generator failed", "error", err)
+	}
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const (
+	sourceURL      = "https://raw.githubusercontent.com/llvm/llvm-project/main/llvm/include/llvm/BinaryFormat/Dwarf.def"
+	outputFilename = "dwarf.json"
+	requestTimeout = 30 * time.Second
+)
+
+type DwarfConstant struct {
+	Name      string `json:"name"`
+	Value     uint64 `json:"value"`
+	ValueHex  string `json:"valueHex"`
+	Version   int    `json:"version"`
+	Vendor    string `json:"vendor"`
+	Kind      string `json:"kind,omitempty"`
+	Operands  string `json:"operands,omitempty"`
+	Arity     string `json:"arity,omitempty"`
+	Semantics string `json:"semantics,omitempty"`
+}
+
+type DwarfDataset struct {
+	ExpressionOps   []DwarfConstant `json:"expressionOps"`
+	Tags            []DwarfConstant `json:"tags"`
+	Attributes      []DwarfConstant `json:"attributes"`
+	LineStandardOps []DwarfConstant `json:"lineStandardOps"`
+	LineExtendedOps []DwarfConstant `json:"lineExtendedOps"`
+}
+
+var handleMacroPattern = regexp.MustCompile(`^HANDLE_DW_(TAG|AT|OP|LNS|LNE)\((.*)\)\s*$`)
+
+var opSemantics = map[string]string{
+	"addr":                "Push the target-address-sized constant operand.",
+	"deref":               "Pop an address and push the address-sized value loaded from it.",
+	"const1u":             "Push a 1-byte unsigned constant.",
+	"const1s":             "Push a 1-byte signed constant.",
+	"const2u":             "Push a 2-byte unsigned constant.",
+	"const2s":             "Push a 2-byte signed constant.",
+	"const4u":             "Push a 4-byte unsigned constant.",
+	"const4s":             "Push a 4-byte signed constant.",
+	"const8u":             "Push an 8-byte unsigned constant.",
+	"const8s":             "Push an 8-byte signed constant.",
+	"constu":              "Push a ULEB128 constant.",
+	"consts":              "Push an SLEB128 constant.",
+	"dup":                 "Duplicate the top stack entry.",
+	"drop":                "Pop the top stack entry.",
+	"over":                "Push a copy of the second stack entry.",
+	"pick":                "Push a copy of the stack entry at the 1-byte index operand.",
+	"swap":                "Swap the top two stack entries.",
+	"rot":                 "Rotate the top three stack entries.",
+	"xderef":              "Pop an address and an address-space identifier and push the value loaded from them.",
+	"abs":                 "Replace the top entry with its absolute value.",
+	"and":                 "Pop two entries and push their bitwise AND.",
+	"div":                 "Pop two entries and push the signed quotient of the second by the first.",
+	"minus":               "Pop two entries and push the second minus the first.",
+	"mod":                 "Pop two entries and push the second modulo the first.",
+	"mul":                 "Pop two entries and push their product.",
+	"neg":                 "Replace the top entry with its negation.",
+	"not":                 "Replace the top entry with its bitwise complement.",
+	"or":                  "Pop two entries and push their bitwise OR.",
+	"plus":                "Pop two entries and push their sum.",
+	"plus_uconst":         "Add the ULEB128 operand to the top entry.",
+	"shl":                 "Pop two entries and push the second shifted left by the first.",
+	"shr":                 "Pop two entries and push the second logically shifted right by the first.",
+	"shra":                "Pop two entries and push the second arithmetically shifted right by the first.",
+	"xor":                 "Pop two entries and push their bitwise XOR.",
+	"bra":                 "Pop the top entry and branch by the 2-byte signed offset if it is non-zero.",
+	"eq":                  "Pop two entries and push 1 if they are equal, otherwise 0.",
+	"ge":                  "Pop two entries and push 1 if the second is greater than or equal to the first.",
+	"gt":                  "Pop two entries and push 1 if the second is greater than the first.",
+	"le":                  "Pop two entries and push 1 if the second is less than or equal to the first.",
+	"lt":                  "Pop two entries and push 1 if the second is less than the first.",
+	"ne":                  "Pop two entries and push 1 if they are not equal.",
+	"skip":                "Unconditionally branch by the 2-byte signed offset.",
+	"regx":                "The object is located in the register numbered by the ULEB128 operand.",
+	"fbreg":               "Push the frame base plus the SLEB128 offset operand.",
+	"bregx":               "Push the contents of the ULEB128 register plus the SLEB128 offset.",
+	"piece":               "Describe a piece of the object of the ULEB128 byte size.",
+	"deref_size":          "Pop an address and push the value of the 1-byte size operand loaded from it, zero-extended.",
+	"xderef_size":         "Like DW_OP_xderef but loads the number of bytes given by the 1-byte operand.",
+	"nop":                 "No operation.",
+	"push_object_address": "Push the address of the object currently being evaluated.",
+	"call2":               "Evaluate the DW_AT_location of the DIE at the 2-byte CU-relative offset.",
+	"call4":               "Evaluate the DW_AT_location of the DIE at the 4-byte CU-relative offset.",
+	"call_ref":            "Evaluate the DW_AT_location of the DIE at the section offset operand.",
+	"form_tls_address":    "Pop a TLS offset and push the corresponding thread-local address.",
+	"call_frame_cfa":      "Push the canonical frame address computed from the call frame information.",
+	"bit_piece":           "Describe a piece of the object by ULEB128 bit size and bit offset.",
+	"implicit_value":      "The object has no location; its value is the block operand.",
+	"stack_value":         "The object has no location; its value is the top stack entry.",
+	"implicit_pointer":    "The object is a pointer to the DIE operand plus the SLEB128 byte offset.",
+	"addrx":               "Push the address at the ULEB128 index into .debug_addr.",
+	"constx":              "Push the constant at the ULEB128 index into .debug_addr.",
+	"entry_value":         "Push the value the block operand had on entry to the current subprogram.",
+	"const_type":          "Push a typed constant of the base type DIE operand.",
+	"regval_type":         "Push the contents of the ULEB128 register interpreted as the base type operand.",
+	"deref_type":          "Pop an address and push the typed value loaded from it.",
+	"xderef_type":         "Like DW_OP_deref_type with an additional address-space identifier.",
+	"convert":             "Convert the top entry to the base type DIE operand.",
+	"reinterpret":         "Reinterpret the bits of the top entry as the base type DIE operand.",
+}
+
+var lineSemantics = map[string]string{
+	"copy":               "Append a row to the line table and reset discriminator, basic_block, prologue_end, and epilogue_begin.",
+	"advance_pc":         "Advance the address by the ULEB128 operation advance.",
+	"advance_line":       "Advance the line register by the SLEB128 operand.",
+	"set_file":           "Set the file register to the ULEB128 operand.",
+	"set_column":         "Set the column register to the ULEB128 operand.",
+	"negate_stmt":        "Toggle the is_stmt register.",
+	"set_basic_block":    "Set the basic_block register to true.",
+	"const_add_pc":       "Advance the address by the increment of special opcode 255.",
+	"fixed_advance_pc":   "Add the unencoded 2-byte operand to the address and clear op_index.",
+	"set_prologue_end":   "Set the prologue_end register to true.",
+	"set_epilogue_begin": "Set the epilogue_begin register to true.",
+	"set_isa":            "Set the isa register to the ULEB128 operand.",
+	"end_sequence":       "Set end_sequence, append a row, and reset all registers to their initial values.",
+	"set_address":        "Set the address register to the relocatable address operand and clear op_index.",
+	"define_file":        "Define a new source file entry (removed in DWARF 5).",
+	"set_discriminator":  "Set the discriminator register to the ULEB128 operand.",
+}
+
+type Scraper struct {
+	client *http.Client
+	logger *log.Logger
+}
+
+func NewScraper() *Scraper {
+	logger := log.NewWithOptions(os.Stderr, log.Options{
+		ReportCaller:    false,
+		ReportTimestamp: true,
+		TimeFormat:      time.Kitchen,
+		Prefix:          "dwarf-scraper",
+	})
+
+	client := &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: false},
+			DisableKeepAlives: false,
+			MaxIdleConns:      10,
+			IdleConnTimeout:   90 * time.Second,
+		},
+	}
+
+	return &Scraper{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (s *Scraper) fetchDefinitions() ([]byte, error) {
+	s.logger.Info("Fetching DWARF definitions")
+
+	req, err := http.NewRequest("GET", sourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "dwarf-scraper/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	return body, nil
+}
+
+func (s *Scraper) parseDefinitions(source []byte) DwarfDataset {
+	var dataset DwarfDataset
+
+	scanner := bufio.NewScanner(bytes.NewReader(source))
+	for scanner.Scan() {
+		match := handleMacroPattern.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil {
+			continue
+		}
+
+		args := strings.Split(match[2], ",")
+		for i := range args {
+			args[i] = strings.TrimSpace(args[i])
+		}
+		if len(args) < 2 {
+			continue
+		}
+
+		value, err := strconv.ParseUint(args[0], 0, 64)
+		if err != nil {
+			s.logger.Warn("Skipping definition with invalid value", "line", scanner.Text())
+			continue
+		}
+
+		constant := DwarfConstant{
+			Value:    value,
+			ValueHex: fmt.Sprintf("0x%02x", value),
+		}
+
+		switch match[1] {
+		case "OP":
+			constant.Name = "DW_OP_" + args[1]
+			if len(args) >= 6 {
+				constant.Operands = args[2]
+				constant.Arity = args[3]
+				constant.Version, constant.Vendor = s.parseVersion(args[4]), args[5]
+			} else if len(args) >= 4 {
+				constant.Version, constant.Vendor = s.parseVersion(args[2]), args[3]
+			}
+			constant.Semantics = s.opSemantics(args[1])
+			dataset.ExpressionOps = append(dataset.ExpressionOps, constant)
+		case "TAG":
+			constant.Name = "DW_TAG_" + args[1]
+			if len(args) >= 4 {
+				constant.Version, constant.Vendor = s.parseVersion(args[2]), args[3]
+			}
+			if len(args) >= 5 {
+				constant.Kind = strings.TrimPrefix(args[4], "DW_KIND_")
+			}
+			dataset.Tags = append(dataset.Tags, constant)
+		case "AT":
+			constant.Name = "DW_AT_" + args[1]
+			if len(args) >= 4 {
+				constant.Version, constant.Vendor = s.parseVersion(args[2]), args[3]
+			}
+			dataset.Attributes = append(dataset.Attributes, constant)
+		case "LNS":
+			constant.Name = "DW_LNS_" + args[1]
+			constant.Version, constant.Vendor = 2, "DWARF"
+			constant.Semantics = lineSemantics[args[1]]
+			dataset.LineStandardOps = append(dataset.LineStandardOps, constant)
+		case "LNE":
+			constant.Name = "DW_LNE_" + args[1]
+			constant.Version, constant.Vendor = 2, "DWARF"
+			constant.Semantics = lineSemantics[args[1]]
+			dataset.LineExtendedOps = append(dataset.LineExtendedOps, constant)
+		}
+	}
+
+	return dataset
+}
+
+func (s *Scraper) parseVersion(text string) int {
+	version, err := strconv.Atoi(text)
+	if err != nil {
+		return 0
+	}
+	return version
+}
+
+func (s *Scraper) opSemantics(name string) string {
+	if semantics, ok := opSemantics[name]; ok {
+		return semantics
+	}
+
+	for prefix, format := range map[string]string{
+		"lit":  "Push the literal value %s.",
+		"reg":  "The object is located in register %s.",
+		"breg": "Push the contents of register %s plus the SLEB128 offset.",
+	} {
+		if n := strings.TrimPrefix(name, prefix); n != name {
+			if _, err := strconv.Atoi(n); err == nil {
+				return fmt.Sprintf(format, n)
+			}
+		}
+	}
+
+	return ""
+}
+
+func (s *Scraper) saveData(dataset DwarfDataset) error {
+	s.logger.Info("Saving DWARF data",
+		"ops", len(dataset.ExpressionOps),
+		"tags", len(dataset.Tags),
+		"attributes", len(dataset.Attributes))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(dataset); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(outputFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("Data saved successfully", "file", outputFilename)
+	return nil
+}
+
+func (s *Scraper) Run() error {
+	s.logger.Info("Starting DWARF constant scraper")
+
+	source, err := s.fetchDefinitions()
+	if err != nil {
+		return fmt.Errorf("failed to fetch definitions: %w", err)
+	}
+
+	dataset := s.parseDefinitions(source)
+
+	if err := s.saveData(dataset); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+
+	s.logger.Info("Scraping completed successfully")
+	return nil
+}
+
+func main() {
+	scraper := NewScraper()
+	if err := scraper.Run(); err != nil {
+		scraper.logger.Fatal("Scraper failed", "error", err)
+	}
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const (
+	sourceURL      = "https://raw.githubusercontent.com/llvm/llvm-project/main/llvm/lib/Target/Hexagon/HexagonDepInstrInfo.td"
+	outputFilename = "hexagon.json"
+	requestTimeout = 60 * time.Second
+)
+
+type InstructionClass struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Slots       []int  `json:"slots"`
+}
+
+type PacketInfo struct {
+	MaxInstructions int    `json:"maxInstructions"`
+	Slots           []int  `json:"slots"`
+	Description     string `json:"description"`
+}
+
+type HexagonInstruction struct {
+	Name     string   `json:"name"`
+	Syntax   string   `json:"syntax"`
+	Outputs  []string `json:"outputs"`
+	Inputs   []string `json:"inputs"`
+	Type     string   `json:"type"`
+	Class    string   `json:"class"`
+	Slots    []int    `json:"slots"`
+	Encoding string   `json:"encoding,omitempty"`
+	AnchorID string   `json:"anchorId"`
+}
+
+type HexagonDataset struct {
+	Packet       PacketInfo           `json:"packet"`
+	Classes      []InstructionClass   `json:"classes"`
+	Instructions []HexagonInstruction `json:"instructions"`
+}
+
+var instructionClasses = []InstructionClass{
+	{Name: "ALU32", Description: "32-bit ALU operations", Slots: []int{0, 1, 2, 3}},
+	{Name: "XTYPE", Description: "64-bit ALU, bit manipulation, multiply, shift, and predicate operations", Slots: []int{2, 3}},
+	{Name: "J", Description: "Jumps and calls", Slots: []int{2, 3}},
+	{Name: "NV", Description: "New-value jumps and stores", Slots: []int{0}},
+	{Name: "CR", Description: "Control register transfers and loop setup", Slots: []int{3}},
+	{Name: "LD", Description: "Memory loads", Slots: []int{0, 1}},
+	{Name: "ST", Description: "Memory stores", Slots: []int{0, 1}},
+	{Name: "SYSTEM", Description: "System, cache, and barrier operations", Slots: []int{0}},
+	{Name: "HVX", Description: "Hexagon Vector eXtensions", Slots: []int{0, 1, 2, 3}},
+	{Name: "DUPLEX", Description: "Paired 16-bit sub-instructions", Slots: []int{0, 1}},
+	{Name: "EXTENDER", Description: "Constant extender (immext)", Slots: []int{0, 1, 2, 3}},
+	{Name: "PSEUDO", Description: "Assembler mappings and pseudo instructions", Slots: []int{}},
+}
+
+var typeClasses = map[string]string{
+	"TypeALU32_2op":  "ALU32",
+	"TypeALU32_3op":  "ALU32",
+	"TypeALU32_ADDI": "ALU32",
+	"TypeALU64":      "XTYPE",
+	"TypeM":          "XTYPE",
+	"TypeS_2op":      "XTYPE",
+	"TypeS_3op":      "XTYPE",
+	"TypeJ":          "J",
+	"TypeCJ":         "J",
+	"TypeNCJ":        "NV",
+	"TypeCR":         "CR",
+	"TypeLD":         "LD",
+	"TypeV2LDST":     "LD",
+	"TypeV4LDST":     "LD",
+	"TypeST":         "ST",
+	"TypeSUBINSN":    "DUPLEX",
+	"TypeDUPLEX":     "DUPLEX",
+	"TypeEXTENDER":   "EXTENDER",
+	"TypeMAPPING":    "PSEUDO",
+	"TypeENDLOOP":    "PSEUDO",
+}
+
+var (
+	instructionDefPattern = regexp.MustCompile(`(?s)def (\w+) : HInst<\s*\(outs([^)]*)\),\s*\(ins([^)]*)\),\s*"((?:[^"\\]|\\.)*)",\s*(\w+),\s*(\w+)>`)
+	encodingBitsPattern   = regexp.MustCompile(`let Inst\{(\d+)(?:-(\d+))?\} = 0b([01]+);`)
+)
+
+type Scraper struct {
+	client *http.Client
+	logger *log.Logger
+}
+
+func NewScraper() *Scraper {
+	logger := log.NewWithOptions(os.Stderr, log.Options{
+		ReportCaller:    false,
+		ReportTimestamp: true,
+		TimeFormat:      time.Kitchen,
+		Prefix:          "hexagon-scraper",
+	})
+
+	client := &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: false},
+			DisableKeepAlives: false,
+			MaxIdleConns:      10,
+			IdleConnTimeout:   90 * time.Second,
+		},
+	}
+
+	return &Scraper{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (s *Scraper) fetchDefinitions() (string, error) {
+	s.logger.Info("Fetching Hexagon instruction definitions")
+
+	req, err := http.NewRequest("GET", sourceURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "hexagon-scraper/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read body: %w", err)
+	}
+
+	return string(body), nil
+}
+
+func (s *Scraper) parseInstructions(source string) []HexagonInstruction {
+	var instructions []HexagonInstruction
+
+	slotsByClass := make(map[string][]int)
+	for _, class := range instructionClasses {
+		slotsByClass[class.Name] = class.Slots
+	}
+
+	matches := instructionDefPattern.FindAllStringSubmatchIndex(source, -1)
+	for i, match := range matches {
+		end := len(source)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+
+		name := source[match[2]:match[3]]
+		instType := source[match[12]:match[13]]
+
+		class, ok := typeClasses[instType]
+		if !ok && strings.HasPrefix(instType, "TypeCVI_") {
+			class = "HVX"
+		} else if !ok {
+			class = "SYSTEM"
+		}
+
+		instructions = append(instructions, HexagonInstruction{
+			Name:     name,
+			Syntax:   strings.ReplaceAll(source[match[8]:match[9]], `\"`, `"`),
+			Outputs:  s.parseOperands(source[match[4]:match[5]]),
+			Inputs:   s.parseOperands(source[match[6]:match[7]]),
+			Type:     strings.TrimPrefix(instType, "Type"),
+			Class:    class,
+			Slots:    slotsByClass[class],
+			Encoding: s.parseEncoding(source[match[1]:end]),
+			AnchorID: "hexagon-" + strings.ToLower(strings.ReplaceAll(name, "_", "-")),
+		})
+	}
+
+	sort.Slice(instructions, func(i, j int) bool {
+		return instructions[i].Name < instructions[j].Name
+	})
+
+	return instructions
+}
+
+func (s *Scraper) parseOperands(text string) []string {
+	operands := []string{}
+	for _, operand := range strings.Split(text, ",") {
+		operand = strings.TrimSpace(operand)
+		if operand != "" {
+			operands = append(operands, operand)
+		}
+	}
+	return operands
+}
+
+func (s *Scraper) parseEncoding(body string) string {
+	bits := []byte(strings.Repeat("-", 32))
+	found := false
+
+	for _, match := range encodingBitsPattern.FindAllStringSubmatch(body, -1) {
+		high, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		low := high
+		if match[2] != "" {
+			if low, err = strconv.Atoi(match[2]); err != nil {
+				continue
+			}
+		}
+		if high > 31 || low < 0 || high-low+1 != len(match[3]) {
+			continue
+		}
+
+		for i, bit := range []byte(match[3]) {
+			bits[31-(high-i)] = bit
+		}
+		found = true
+	}
+
+	if !found {
+		return ""
+	}
+	return string(bits)
+}
+
+func (s *Scraper) saveData(dataset HexagonDataset) error {
+	s.logger.Info("Saving instruction data", "count", len(dataset.Instructions))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(dataset); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(outputFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("Data saved successfully", "file", outputFilename)
+	return nil
+}
+
+func (s *Scraper) Run() error {
+	s.logger.Info("Starting Hexagon instruction scraper")
+
+	source, err := s.fetchDefinitions()
+	if err != nil {
+		return fmt.Errorf("failed to fetch definitions: %w", err)
+	}
+
+	dataset := HexagonDataset{
+		Packet: PacketInfo{
+			MaxInstructions: 4,
+			Slots:           []int{0, 1, 2, 3},
+			Description:     "Up to four instructions execute in parallel as a packet; each instruction class may only occupy its listed slots.",
+		},
+		Classes:      instructionClasses,
+		Instructions: s.parseInstructions(source),
+	}
+
+	if err := s.saveData(dataset); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+
+	s.logger.Info("Scraping completed successfully")
+	return nil
+}
+
+func main() {
+	scraper := NewScraper()
+	if err := scraper.Run(); err != nil {
+		scraper.logger.Fatal("Scraper failed", "error", err)
+	}
+}
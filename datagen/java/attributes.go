@@ -0,0 +1,398 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+const attributesFilename = "classfile_attributes.json"
+
+type ClassFileAttribute struct {
+	Name             string           `json:"name"`
+	Section          string           `json:"section"`
+	Locations        []string         `json:"locations"`
+	Layout           []ClassFileField `json:"layout"`
+	Criticality      string           `json:"criticality"`
+	ClassFileVersion string           `json:"classFileVersion"`
+	JavaSE           string           `json:"javaSE"`
+	Description      string           `json:"description"`
+}
+
+const (
+	criticalJVM     = "jvm"
+	criticalLibrary = "library"
+	criticalTools   = "tools"
+)
+
+const (
+	locClass     = "ClassFile"
+	locField     = "field_info"
+	locMethod    = "method_info"
+	locCode      = "Code"
+	locComponent = "record_component_info"
+)
+
+func field(name, typ, description string, nested ...ClassFileField) ClassFileField {
+	return ClassFileField{Name: name, Type: typ, Description: description, Fields: nested}
+}
+
+func attributeLayout(fields ...ClassFileField) []ClassFileField {
+	return append([]ClassFileField{
+		field("attribute_name_index", "u2", "Index of the CONSTANT_Utf8 attribute name"),
+		field("attribute_length", "u4", "Length of the attribute excluding the initial six bytes"),
+	}, fields...)
+}
+
+var annotationsLayout = attributeLayout(
+	field("num_annotations", "u2", "Number of annotations"),
+	field("annotations", "annotation[num_annotations]", "Annotation structures"),
+)
+
+var parameterAnnotationsLayout = attributeLayout(
+	field("num_parameters", "u1", "Number of formal parameters with annotations"),
+	field("parameter_annotations", "table[num_parameters]", "Annotations per formal parameter",
+		field("num_annotations", "u2", "Number of annotations on the parameter"),
+		field("annotations", "annotation[num_annotations]", "Annotation structures"),
+	),
+)
+
+var typeAnnotationsLayout = attributeLayout(
+	field("num_annotations", "u2", "Number of type annotations"),
+	field("annotations", "type_annotation[num_annotations]", "Type annotation structures"),
+)
+
+var classFileAttributes = []ClassFileAttribute{
+	{
+		Name: "ConstantValue", Section: "4.7.2", Locations: []string{locField},
+		Layout: attributeLayout(
+			field("constantvalue_index", "u2", "Index of the constant value in the constant pool"),
+		),
+		Criticality: criticalJVM, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "Value of a constant expression field",
+	},
+	{
+		Name: "Code", Section: "4.7.3", Locations: []string{locMethod},
+		Layout: attributeLayout(
+			field("max_stack", "u2", "Maximum operand stack depth"),
+			field("max_locals", "u2", "Number of local variables including parameters"),
+			field("code_length", "u4", "Length of the bytecode array"),
+			field("code", "u1[code_length]", "JVM bytecode"),
+			field("exception_table_length", "u2", "Number of exception handlers"),
+			field("exception_table", "table[exception_table_length]", "Exception handlers",
+				field("start_pc", "u2", "Start of the protected range, inclusive"),
+				field("end_pc", "u2", "End of the protected range, exclusive"),
+				field("handler_pc", "u2", "Start of the handler"),
+				field("catch_type", "u2", "CONSTANT_Class index of the caught type, or 0 for any"),
+			),
+			field("attributes_count", "u2", "Number of attributes"),
+			field("attributes", "attribute_info[attributes_count]", "Code attributes"),
+		),
+		Criticality: criticalJVM, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "Bytecode and auxiliary information for a method",
+	},
+	{
+		Name: "StackMapTable", Section: "4.7.4", Locations: []string{locCode},
+		Layout: attributeLayout(
+			field("number_of_entries", "u2", "Number of stack map frames"),
+			field("entries", "stack_map_frame[number_of_entries]", "Stack map frames"),
+		),
+		Criticality: criticalJVM, ClassFileVersion: "50.0", JavaSE: "6",
+		Description: "Stack map frames used by type checking verification",
+	},
+	{
+		Name: "Exceptions", Section: "4.7.5", Locations: []string{locMethod},
+		Layout: attributeLayout(
+			field("number_of_exceptions", "u2", "Number of checked exception classes"),
+			field("exception_index_table", "u2[number_of_exceptions]", "CONSTANT_Class indices of thrown exceptions"),
+		),
+		Criticality: criticalLibrary, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "Checked exceptions a method may throw",
+	},
+	{
+		Name: "InnerClasses", Section: "4.7.6", Locations: []string{locClass},
+		Layout: attributeLayout(
+			field("number_of_classes", "u2", "Number of entries"),
+			field("classes", "table[number_of_classes]", "Nested class entries",
+				field("inner_class_info_index", "u2", "CONSTANT_Class of the nested class"),
+				field("outer_class_info_index", "u2", "CONSTANT_Class of the enclosing class, or 0"),
+				field("inner_name_index", "u2", "CONSTANT_Utf8 simple name, or 0 if anonymous"),
+				field("inner_class_access_flags", "u2", "Access flags of the nested class"),
+			),
+		),
+		Criticality: criticalLibrary, ClassFileVersion: "45.3", JavaSE: "1.1",
+		Description: "Nested classes referenced by or declared in the class",
+	},
+	{
+		Name: "EnclosingMethod", Section: "4.7.7", Locations: []string{locClass},
+		Layout: attributeLayout(
+			field("class_index", "u2", "CONSTANT_Class of the innermost enclosing class"),
+			field("method_index", "u2", "CONSTANT_NameAndType of the enclosing method, or 0"),
+		),
+		Criticality: criticalLibrary, ClassFileVersion: "49.0", JavaSE: "5.0",
+		Description: "Enclosing method of a local or anonymous class",
+	},
+	{
+		Name: "Synthetic", Section: "4.7.8", Locations: []string{locClass, locField, locMethod},
+		Layout:      attributeLayout(),
+		Criticality: criticalLibrary, ClassFileVersion: "45.3", JavaSE: "1.1",
+		Description: "Marks a member not present in source code",
+	},
+	{
+		Name: "Signature", Section: "4.7.9", Locations: []string{locClass, locField, locMethod, locComponent},
+		Layout: attributeLayout(
+			field("signature_index", "u2", "CONSTANT_Utf8 generic signature"),
+		),
+		Criticality: criticalLibrary, ClassFileVersion: "49.0", JavaSE: "5.0",
+		Description: "Generic signature of a class, member, or record component",
+	},
+	{
+		Name: "SourceFile", Section: "4.7.10", Locations: []string{locClass},
+		Layout: attributeLayout(
+			field("sourcefile_index", "u2", "CONSTANT_Utf8 source file name"),
+		),
+		Criticality: criticalLibrary, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "Name of the source file the class was compiled from",
+	},
+	{
+		Name: "SourceDebugExtension", Section: "4.7.11", Locations: []string{locClass},
+		Layout: attributeLayout(
+			field("debug_extension", "u1[attribute_length]", "Modified UTF-8 extended debugging information"),
+		),
+		Criticality: criticalTools, ClassFileVersion: "49.0", JavaSE: "5.0",
+		Description: "Extended debugging information such as SMAP data",
+	},
+	{
+		Name: "LineNumberTable", Section: "4.7.12", Locations: []string{locCode},
+		Layout: attributeLayout(
+			field("line_number_table_length", "u2", "Number of entries"),
+			field("line_number_table", "table[line_number_table_length]", "Bytecode to source line mapping",
+				field("start_pc", "u2", "Bytecode offset where the line begins"),
+				field("line_number", "u2", "Source line number"),
+			),
+		),
+		Criticality: criticalLibrary, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "Maps bytecode offsets to source line numbers",
+	},
+	{
+		Name: "LocalVariableTable", Section: "4.7.13", Locations: []string{locCode},
+		Layout: attributeLayout(
+			field("local_variable_table_length", "u2", "Number of entries"),
+			field("local_variable_table", "table[local_variable_table_length]", "Local variable ranges",
+				field("start_pc", "u2", "Start of the live range"),
+				field("length", "u2", "Length of the live range"),
+				field("name_index", "u2", "CONSTANT_Utf8 variable name"),
+				field("descriptor_index", "u2", "CONSTANT_Utf8 field descriptor"),
+				field("index", "u2", "Local variable slot"),
+			),
+		),
+		Criticality: criticalLibrary, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "Names and types of local variables for debuggers",
+	},
+	{
+		Name: "LocalVariableTypeTable", Section: "4.7.14", Locations: []string{locCode},
+		Layout: attributeLayout(
+			field("local_variable_type_table_length", "u2", "Number of entries"),
+			field("local_variable_type_table", "table[local_variable_type_table_length]", "Generic local variable ranges",
+				field("start_pc", "u2", "Start of the live range"),
+				field("length", "u2", "Length of the live range"),
+				field("name_index", "u2", "CONSTANT_Utf8 variable name"),
+				field("signature_index", "u2", "CONSTANT_Utf8 field signature"),
+				field("index", "u2", "Local variable slot"),
+			),
+		),
+		Criticality: criticalLibrary, ClassFileVersion: "49.0", JavaSE: "5.0",
+		Description: "Generic signatures of local variables for debuggers",
+	},
+	{
+		Name: "Deprecated", Section: "4.7.15", Locations: []string{locClass, locField, locMethod},
+		Layout:      attributeLayout(),
+		Criticality: criticalTools, ClassFileVersion: "45.3", JavaSE: "1.1",
+		Description: "Marks a class, interface, or member as deprecated",
+	},
+	{
+		Name: "RuntimeVisibleAnnotations", Section: "4.7.16", Locations: []string{locClass, locField, locMethod, locComponent},
+		Layout:      annotationsLayout,
+		Criticality: criticalTools, ClassFileVersion: "49.0", JavaSE: "5.0",
+		Description: "Declaration annotations visible through reflection",
+	},
+	{
+		Name: "RuntimeInvisibleAnnotations", Section: "4.7.17", Locations: []string{locClass, locField, locMethod, locComponent},
+		Layout:      annotationsLayout,
+		Criticality: criticalTools, ClassFileVersion: "49.0", JavaSE: "5.0",
+		Description: "Declaration annotations not visible through reflection",
+	},
+	{
+		Name: "RuntimeVisibleParameterAnnotations", Section: "4.7.18", Locations: []string{locMethod},
+		Layout:      parameterAnnotationsLayout,
+		Criticality: criticalTools, ClassFileVersion: "49.0", JavaSE: "5.0",
+		Description: "Parameter annotations visible through reflection",
+	},
+	{
+		Name: "RuntimeInvisibleParameterAnnotations", Section: "4.7.19", Locations: []string{locMethod},
+		Layout:      parameterAnnotationsLayout,
+		Criticality: criticalTools, ClassFileVersion: "49.0", JavaSE: "5.0",
+		Description: "Parameter annotations not visible through reflection",
+	},
+	{
+		Name: "RuntimeVisibleTypeAnnotations", Section: "4.7.20", Locations: []string{locClass, locField, locMethod, locCode, locComponent},
+		Layout:      typeAnnotationsLayout,
+		Criticality: criticalTools, ClassFileVersion: "52.0", JavaSE: "8",
+		Description: "Type annotations visible through reflection",
+	},
+	{
+		Name: "RuntimeInvisibleTypeAnnotations", Section: "4.7.21", Locations: []string{locClass, locField, locMethod, locCode, locComponent},
+		Layout:      typeAnnotationsLayout,
+		Criticality: criticalTools, ClassFileVersion: "52.0", JavaSE: "8",
+		Description: "Type annotations not visible through reflection",
+	},
+	{
+		Name: "AnnotationDefault", Section: "4.7.22", Locations: []string{locMethod},
+		Layout: attributeLayout(
+			field("default_value", "element_value", "Default value of the annotation interface element"),
+		),
+		Criticality: criticalTools, ClassFileVersion: "49.0", JavaSE: "5.0",
+		Description: "Default value of an annotation interface element",
+	},
+	{
+		Name: "BootstrapMethods", Section: "4.7.23", Locations: []string{locClass},
+		Layout: attributeLayout(
+			field("num_bootstrap_methods", "u2", "Number of bootstrap method specifiers"),
+			field("bootstrap_methods", "table[num_bootstrap_methods]", "Bootstrap method specifiers",
+				field("bootstrap_method_ref", "u2", "CONSTANT_MethodHandle of the bootstrap method"),
+				field("num_bootstrap_arguments", "u2", "Number of static arguments"),
+				field("bootstrap_arguments", "u2[num_bootstrap_arguments]", "Loadable constant pool indices"),
+			),
+		),
+		Criticality: criticalJVM, ClassFileVersion: "51.0", JavaSE: "7",
+		Description: "Bootstrap methods for invokedynamic and dynamic constants",
+	},
+	{
+		Name: "MethodParameters", Section: "4.7.24", Locations: []string{locMethod},
+		Layout: attributeLayout(
+			field("parameters_count", "u1", "Number of parameter entries"),
+			field("parameters", "table[parameters_count]", "Formal parameters",
+				field("name_index", "u2", "CONSTANT_Utf8 parameter name, or 0"),
+				field("access_flags", "u2", "ACC_FINAL, ACC_SYNTHETIC, ACC_MANDATED"),
+			),
+		),
+		Criticality: criticalTools, ClassFileVersion: "52.0", JavaSE: "8",
+		Description: "Names and flags of formal parameters",
+	},
+	{
+		Name: "Module", Section: "4.7.25", Locations: []string{locClass},
+		Layout: attributeLayout(
+			field("module_name_index", "u2", "CONSTANT_Module of the current module"),
+			field("module_flags", "u2", "ACC_OPEN, ACC_SYNTHETIC, ACC_MANDATED"),
+			field("module_version_index", "u2", "CONSTANT_Utf8 module version, or 0"),
+			field("requires_count", "u2", "Number of requires entries"),
+			field("requires", "table[requires_count]", "Module dependencies",
+				field("requires_index", "u2", "CONSTANT_Module of the dependency"),
+				field("requires_flags", "u2", "ACC_TRANSITIVE, ACC_STATIC_PHASE, ACC_SYNTHETIC, ACC_MANDATED"),
+				field("requires_version_index", "u2", "CONSTANT_Utf8 dependency version, or 0"),
+			),
+			field("exports_count", "u2", "Number of exports entries"),
+			field("exports", "table[exports_count]", "Exported packages",
+				field("exports_index", "u2", "CONSTANT_Package of the exported package"),
+				field("exports_flags", "u2", "ACC_SYNTHETIC, ACC_MANDATED"),
+				field("exports_to_count", "u2", "Number of target modules, 0 for unqualified"),
+				field("exports_to_index", "u2[exports_to_count]", "CONSTANT_Module targets"),
+			),
+			field("opens_count", "u2", "Number of opens entries"),
+			field("opens", "table[opens_count]", "Opened packages",
+				field("opens_index", "u2", "CONSTANT_Package of the opened package"),
+				field("opens_flags", "u2", "ACC_SYNTHETIC, ACC_MANDATED"),
+				field("opens_to_count", "u2", "Number of target modules, 0 for unqualified"),
+				field("opens_to_index", "u2[opens_to_count]", "CONSTANT_Module targets"),
+			),
+			field("uses_count", "u2", "Number of service interfaces used"),
+			field("uses_index", "u2[uses_count]", "CONSTANT_Class service interfaces"),
+			field("provides_count", "u2", "Number of provided services"),
+			field("provides", "table[provides_count]", "Service implementations",
+				field("provides_index", "u2", "CONSTANT_Class service interface"),
+				field("provides_with_count", "u2", "Number of implementations"),
+				field("provides_with_index", "u2[provides_with_count]", "CONSTANT_Class implementations"),
+			),
+		),
+		Criticality: criticalTools, ClassFileVersion: "53.0", JavaSE: "9",
+		Description: "Module declaration of a module-info class file",
+	},
+	{
+		Name: "ModulePackages", Section: "4.7.26", Locations: []string{locClass},
+		Layout: attributeLayout(
+			field("package_count", "u2", "Number of packages"),
+			field("package_index", "u2[package_count]", "CONSTANT_Package entries"),
+		),
+		Criticality: criticalTools, ClassFileVersion: "53.0", JavaSE: "9",
+		Description: "All packages of a module, including unexported ones",
+	},
+	{
+		Name: "ModuleMainClass", Section: "4.7.27", Locations: []string{locClass},
+		Layout: attributeLayout(
+			field("main_class_index", "u2", "CONSTANT_Class of the main class"),
+		),
+		Criticality: criticalTools, ClassFileVersion: "53.0", JavaSE: "9",
+		Description: "Main class of a module",
+	},
+	{
+		Name: "NestHost", Section: "4.7.28", Locations: []string{locClass},
+		Layout: attributeLayout(
+			field("host_class_index", "u2", "CONSTANT_Class of the nest host"),
+		),
+		Criticality: criticalJVM, ClassFileVersion: "55.0", JavaSE: "11",
+		Description: "Nest host of a nest member",
+	},
+	{
+		Name: "NestMembers", Section: "4.7.29", Locations: []string{locClass},
+		Layout: attributeLayout(
+			field("number_of_classes", "u2", "Number of nest members"),
+			field("classes", "u2[number_of_classes]", "CONSTANT_Class nest members"),
+		),
+		Criticality: criticalJVM, ClassFileVersion: "55.0", JavaSE: "11",
+		Description: "Classes and interfaces authorized to claim membership in the nest",
+	},
+	{
+		Name: "Record", Section: "4.7.30", Locations: []string{locClass},
+		Layout: attributeLayout(
+			field("components_count", "u2", "Number of record components"),
+			field("components", "record_component_info[components_count]", "Record components",
+				field("name_index", "u2", "CONSTANT_Utf8 component name"),
+				field("descriptor_index", "u2", "CONSTANT_Utf8 field descriptor"),
+				field("attributes_count", "u2", "Number of attributes"),
+				field("attributes", "attribute_info[attributes_count]", "Component attributes"),
+			),
+		),
+		Criticality: criticalLibrary, ClassFileVersion: "60.0", JavaSE: "16",
+		Description: "Components of a record class",
+	},
+	{
+		Name: "PermittedSubclasses", Section: "4.7.31", Locations: []string{locClass},
+		Layout: attributeLayout(
+			field("number_of_classes", "u2", "Number of permitted subclasses"),
+			field("classes", "u2[number_of_classes]", "CONSTANT_Class permitted subclasses"),
+		),
+		Criticality: criticalJVM, ClassFileVersion: "61.0", JavaSE: "17",
+		Description: "Classes and interfaces permitted to extend a sealed class",
+	},
+}
+
+func (s *Scraper) saveClassFileAttributes() error {
+	s.logger.Info("Saving class file attributes", "count", len(classFileAttributes))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(classFileAttributes); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(attributesFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("Class file attributes saved successfully", "file", attributesFilename)
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"arisa/cheader"
+	"arisa/schema"
+)
+
+const opcodeHeaderFilename = "jvm_opcodes.h"
+
+// buildOpcodeHeader emits the opcodes as a C enum in opcode order, each with
+// its one-line description, including the reserved breakpoint and impdep
+// opcodes.
+func (s *Scraper) buildOpcodeHeader(instructions []schema.JVMInstruction) []byte {
+	sorted := make([]schema.JVMInstruction, 0, len(instructions))
+	for _, inst := range instructions {
+		if inst.Opcode != "" {
+			sorted = append(sorted, inst)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].OpcodeByte < sorted[j].OpcodeByte })
+
+	header := cheader.New(opcodeHeaderFilename, s.sourceURL)
+	header.WriteString("enum jvm_opcode {\n")
+	for _, inst := range sorted {
+		fmt.Fprintf(header, "\t/* %s */\n", cheader.Comment(inst.Description))
+		fmt.Fprintf(header, "\tJVM_%s = 0x%02x,\n", cheader.Identifier(inst.Mnemonic), inst.OpcodeByte)
+	}
+	header.WriteString("};\n")
+	return header.Finish()
+}
+
+func (s *Scraper) saveOpcodeHeader(instructions []schema.JVMInstruction) error {
+	if err := ioutil.WriteFile(opcodeHeaderFilename, s.buildOpcodeHeader(instructions), 0644); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	s.logger.Info("Opcode header saved successfully", "file", opcodeHeaderFilename)
+	return nil
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+const constantPoolFilename = "constant_pool.json"
+
+type ClassFileField struct {
+	Name        string           `json:"name"`
+	Type        string           `json:"type"`
+	Description string           `json:"description"`
+	Fields      []ClassFileField `json:"fields,omitempty"`
+}
+
+type ConstantPoolTag struct {
+	Name             string           `json:"name"`
+	Tag              int              `json:"tag"`
+	Section          string           `json:"section"`
+	Structure        []ClassFileField `json:"structure"`
+	Size             int              `json:"size,omitempty"`
+	Slots            int              `json:"slots"`
+	Loadable         bool             `json:"loadable"`
+	ClassFileVersion string           `json:"classFileVersion"`
+	JavaSE           string           `json:"javaSE"`
+	Description      string           `json:"description"`
+}
+
+type ReferenceKind struct {
+	Name        string `json:"name"`
+	Kind        int    `json:"kind"`
+	Target      string `json:"target"`
+	Description string `json:"description"`
+}
+
+type ConstantPoolDataset struct {
+	Tags           []ConstantPoolTag `json:"tags"`
+	ReferenceKinds []ReferenceKind   `json:"referenceKinds"`
+}
+
+var tagField = field("tag", "u1", "Constant pool tag")
+
+var constantPoolTags = []ConstantPoolTag{
+	{
+		Name: "CONSTANT_Utf8", Tag: 1, Section: "4.4.7",
+		Structure: []ClassFileField{
+			tagField,
+			field("length", "u2", "Number of bytes in the bytes array"),
+			field("bytes", "u1[length]", "Modified UTF-8 encoded string"),
+		},
+		Slots: 1, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "Constant string value in modified UTF-8",
+	},
+	{
+		Name: "CONSTANT_Integer", Tag: 3, Section: "4.4.4",
+		Structure: []ClassFileField{
+			tagField,
+			field("bytes", "u4", "Big-endian int value"),
+		},
+		Size: 5, Slots: 1, Loadable: true, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "4-byte int constant",
+	},
+	{
+		Name: "CONSTANT_Float", Tag: 4, Section: "4.4.4",
+		Structure: []ClassFileField{
+			tagField,
+			field("bytes", "u4", "IEEE 754 binary32 float value"),
+		},
+		Size: 5, Slots: 1, Loadable: true, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "4-byte float constant",
+	},
+	{
+		Name: "CONSTANT_Long", Tag: 5, Section: "4.4.5",
+		Structure: []ClassFileField{
+			tagField,
+			field("high_bytes", "u4", "High 32 bits of the long value"),
+			field("low_bytes", "u4", "Low 32 bits of the long value"),
+		},
+		Size: 9, Slots: 2, Loadable: true, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "8-byte long constant; occupies two constant pool entries",
+	},
+	{
+		Name: "CONSTANT_Double", Tag: 6, Section: "4.4.5",
+		Structure: []ClassFileField{
+			tagField,
+			field("high_bytes", "u4", "High 32 bits of the IEEE 754 binary64 value"),
+			field("low_bytes", "u4", "Low 32 bits of the IEEE 754 binary64 value"),
+		},
+		Size: 9, Slots: 2, Loadable: true, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "8-byte double constant; occupies two constant pool entries",
+	},
+	{
+		Name: "CONSTANT_Class", Tag: 7, Section: "4.4.1",
+		Structure: []ClassFileField{
+			tagField,
+			field("name_index", "u2", "Index of a CONSTANT_Utf8 binary class or interface name"),
+		},
+		Size: 3, Slots: 1, Loadable: true, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "Class or interface reference",
+	},
+	{
+		Name: "CONSTANT_String", Tag: 8, Section: "4.4.3",
+		Structure: []ClassFileField{
+			tagField,
+			field("string_index", "u2", "Index of a CONSTANT_Utf8 holding the string contents"),
+		},
+		Size: 3, Slots: 1, Loadable: true, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "java.lang.String constant",
+	},
+	{
+		Name: "CONSTANT_Fieldref", Tag: 9, Section: "4.4.2",
+		Structure: []ClassFileField{
+			tagField,
+			field("class_index", "u2", "Index of a CONSTANT_Class for the declaring class or interface"),
+			field("name_and_type_index", "u2", "Index of a CONSTANT_NameAndType with a field descriptor"),
+		},
+		Size: 5, Slots: 1, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "Symbolic reference to a field",
+	},
+	{
+		Name: "CONSTANT_Methodref", Tag: 10, Section: "4.4.2",
+		Structure: []ClassFileField{
+			tagField,
+			field("class_index", "u2", "Index of a CONSTANT_Class for the declaring class"),
+			field("name_and_type_index", "u2", "Index of a CONSTANT_NameAndType with a method descriptor"),
+		},
+		Size: 5, Slots: 1, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "Symbolic reference to a class method",
+	},
+	{
+		Name: "CONSTANT_InterfaceMethodref", Tag: 11, Section: "4.4.2",
+		Structure: []ClassFileField{
+			tagField,
+			field("class_index", "u2", "Index of a CONSTANT_Class for the declaring interface"),
+			field("name_and_type_index", "u2", "Index of a CONSTANT_NameAndType with a method descriptor"),
+		},
+		Size: 5, Slots: 1, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "Symbolic reference to an interface method",
+	},
+	{
+		Name: "CONSTANT_NameAndType", Tag: 12, Section: "4.4.6",
+		Structure: []ClassFileField{
+			tagField,
+			field("name_index", "u2", "Index of a CONSTANT_Utf8 unqualified name or <init>"),
+			field("descriptor_index", "u2", "Index of a CONSTANT_Utf8 field or method descriptor"),
+		},
+		Size: 5, Slots: 1, ClassFileVersion: "45.3", JavaSE: "1.0.2",
+		Description: "Field or method name and descriptor without the owning class",
+	},
+	{
+		Name: "CONSTANT_MethodHandle", Tag: 15, Section: "4.4.8",
+		Structure: []ClassFileField{
+			tagField,
+			field("reference_kind", "u1", "Method handle kind (1-9), see referenceKinds"),
+			field("reference_index", "u2", "Index of the field, method, or interface method reference"),
+		},
+		Size: 4, Slots: 1, Loadable: true, ClassFileVersion: "51.0", JavaSE: "7",
+		Description: "Method handle constant",
+	},
+	{
+		Name: "CONSTANT_MethodType", Tag: 16, Section: "4.4.9",
+		Structure: []ClassFileField{
+			tagField,
+			field("descriptor_index", "u2", "Index of a CONSTANT_Utf8 method descriptor"),
+		},
+		Size: 3, Slots: 1, Loadable: true, ClassFileVersion: "51.0", JavaSE: "7",
+		Description: "Method type constant",
+	},
+	{
+		Name: "CONSTANT_Dynamic", Tag: 17, Section: "4.4.10",
+		Structure: []ClassFileField{
+			tagField,
+			field("bootstrap_method_attr_index", "u2", "Index into the BootstrapMethods attribute"),
+			field("name_and_type_index", "u2", "Index of a CONSTANT_NameAndType with a field descriptor"),
+		},
+		Size: 5, Slots: 1, Loadable: true, ClassFileVersion: "55.0", JavaSE: "11",
+		Description: "Dynamically-computed constant produced by a bootstrap method",
+	},
+	{
+		Name: "CONSTANT_InvokeDynamic", Tag: 18, Section: "4.4.10",
+		Structure: []ClassFileField{
+			tagField,
+			field("bootstrap_method_attr_index", "u2", "Index into the BootstrapMethods attribute"),
+			field("name_and_type_index", "u2", "Index of a CONSTANT_NameAndType with a method descriptor"),
+		},
+		Size: 5, Slots: 1, ClassFileVersion: "51.0", JavaSE: "7",
+		Description: "Dynamically-computed call site for invokedynamic",
+	},
+	{
+		Name: "CONSTANT_Module", Tag: 19, Section: "4.4.11",
+		Structure: []ClassFileField{
+			tagField,
+			field("name_index", "u2", "Index of a CONSTANT_Utf8 module name"),
+		},
+		Size: 3, Slots: 1, ClassFileVersion: "53.0", JavaSE: "9",
+		Description: "Module reference; only valid in module-info class files",
+	},
+	{
+		Name: "CONSTANT_Package", Tag: 20, Section: "4.4.12",
+		Structure: []ClassFileField{
+			tagField,
+			field("name_index", "u2", "Index of a CONSTANT_Utf8 package name in internal form"),
+		},
+		Size: 3, Slots: 1, ClassFileVersion: "53.0", JavaSE: "9",
+		Description: "Package exported or opened by a module; only valid in module-info class files",
+	},
+}
+
+var referenceKinds = []ReferenceKind{
+	{"REF_getField", 1, "CONSTANT_Fieldref", "getfield C.f:T"},
+	{"REF_getStatic", 2, "CONSTANT_Fieldref", "getstatic C.f:T"},
+	{"REF_putField", 3, "CONSTANT_Fieldref", "putfield C.f:T"},
+	{"REF_putStatic", 4, "CONSTANT_Fieldref", "putstatic C.f:T"},
+	{"REF_invokeVirtual", 5, "CONSTANT_Methodref", "invokevirtual C.m:(A*)T"},
+	{"REF_invokeStatic", 6, "CONSTANT_Methodref or CONSTANT_InterfaceMethodref", "invokestatic C.m:(A*)T"},
+	{"REF_invokeSpecial", 7, "CONSTANT_Methodref or CONSTANT_InterfaceMethodref", "invokespecial C.m:(A*)T"},
+	{"REF_newInvokeSpecial", 8, "CONSTANT_Methodref", "new C; dup; invokespecial C.<init>:(A*)V"},
+	{"REF_invokeInterface", 9, "CONSTANT_InterfaceMethodref", "invokeinterface C.m:(A*)T"},
+}
+
+func (s *Scraper) saveConstantPool() error {
+	dataset := ConstantPoolDataset{
+		Tags:           constantPoolTags,
+		ReferenceKinds: referenceKinds,
+	}
+	s.logger.Info("Saving constant pool tags", "count", len(dataset.Tags))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(dataset); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(constantPoolFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("Constant pool tags saved successfully", "file", constantPoolFilename)
+	return nil
+}
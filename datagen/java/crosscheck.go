@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"arisa/schema"
+)
+
+const asmOpcodesURL = "https://gitlab.ow2.org/asm/asm/-/raw/master/asm/src/main/java/org/objectweb/asm/Opcodes.java"
+
+// asmOpcodePattern matches opcode constants in ASM's Opcodes interface. Each
+// is annotated with the MethodVisitor method that visits it ("-" repeats the
+// previous one), which separates them from access flags and frame kinds.
+var asmOpcodePattern = regexp.MustCompile(`^\s*int\s+([A-Z0-9_]+)\s*=\s*(\d+);\s*//\s*(visit\w+|-)`)
+
+func (s *Scraper) fetchASMOpcodes() (map[string]uint8, error) {
+	s.logger.Info("Fetching ASM opcode constants", "url", asmOpcodesURL)
+
+	body, err := s.fetcher.Fetch(asmOpcodesURL)
+	if err != nil {
+		return nil, err
+	}
+
+	opcodes := make(map[string]uint8)
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		match := asmOpcodePattern.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+
+		value, err := strconv.ParseUint(match[2], 10, 8)
+		if err != nil {
+			continue
+		}
+		opcodes[strings.ToLower(match[1])] = uint8(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ASM opcodes: %w", err)
+	}
+
+	if len(opcodes) == 0 {
+		return nil, fmt.Errorf("no opcode constants found in ASM source")
+	}
+
+	return opcodes, nil
+}
+
+// compareASMOpcodes reports every disagreement between the dataset and
+// ASM. ASM omits the implicit-operand forms (aload_0, ldc_w, goto_w, ...),
+// wide, and the reserved opcodes, so only values ASM names are checked in
+// the reverse direction.
+func (s *Scraper) compareASMOpcodes(instructions []schema.JVMInstruction, asm map[string]uint8) []string {
+	byMnemonic := make(map[string]schema.JVMInstruction, len(instructions))
+	byOpcode := make(map[uint8]schema.JVMInstruction, len(instructions))
+	for _, inst := range instructions {
+		if inst.Opcode == "" || inst.Modifies != "" {
+			continue
+		}
+		byMnemonic[inst.Mnemonic] = inst
+		byOpcode[inst.OpcodeByte] = inst
+	}
+
+	asmByOpcode := make(map[uint8]string, len(asm))
+	for name, opcode := range asm {
+		asmByOpcode[opcode] = name
+	}
+
+	var mismatches []string
+
+	for name, opcode := range asm {
+		inst, ok := byMnemonic[name]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, fmt.Sprintf("%s (0x%02x) is missing from the dataset", name, opcode))
+		case inst.OpcodeByte != opcode:
+			mismatches = append(mismatches, fmt.Sprintf("%s is 0x%02x in the dataset but 0x%02x in ASM", name, inst.OpcodeByte, opcode))
+		}
+	}
+
+	for opcode, inst := range byOpcode {
+		if name, ok := asmByOpcode[opcode]; ok && name != inst.Mnemonic {
+			mismatches = append(mismatches, fmt.Sprintf("0x%02x is %s in the dataset but %s in ASM", opcode, inst.Mnemonic, name))
+		}
+	}
+
+	sort.Strings(mismatches)
+	return mismatches
+}
+
+// crossCheckOpcodes checks the dataset's opcodes against ASM's. Not being
+// able to fetch ASM fails the check like a mismatch does; --skip-crosscheck
+// is the way to go without it.
+func (s *Scraper) crossCheckOpcodes(instructions []schema.JVMInstruction) error {
+	if s.skipCrossCheck {
+		s.logger.Warn("Skipping ASM cross-check")
+		return nil
+	}
+
+	asm, err := s.fetchASMOpcodes()
+	if err != nil {
+		return fmt.Errorf("failed to fetch ASM opcodes (--skip-crosscheck skips the check): %w", err)
+	}
+
+	mismatches := s.compareASMOpcodes(instructions, asm)
+	for _, mismatch := range mismatches {
+		s.logger.Error("Opcode mismatch", "detail", mismatch)
+	}
+
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%d opcode mismatches against ASM", len(mismatches))
+	}
+
+	s.logger.Info("Opcodes match ASM", "checked", len(asm))
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"arisa/dataset"
+	"arisa/schema"
+)
+
+// DiffEntry is an instruction that was added, removed or changed. Changed
+// instructions list the fields that differ.
+type DiffEntry struct {
+	Mnemonic string                `json:"mnemonic"`
+	Opcode   string                `json:"opcode"`
+	Fields   []dataset.FieldChange `json:"fields,omitempty"`
+}
+
+// DatasetDiff is what changed between two datasets, matched by mnemonic.
+type DatasetDiff struct {
+	Added   []DiffEntry `json:"added"`
+	Changed []DiffEntry `json:"changed"`
+	Removed []DiffEntry `json:"removed"`
+}
+
+func (s *Scraper) diffDatasets(before, after []schema.JVMInstruction) (DatasetDiff, error) {
+	index := func(instructions []schema.JVMInstruction) map[string]schema.JVMInstruction {
+		byMnemonic := make(map[string]schema.JVMInstruction)
+		for _, inst := range instructions {
+			byMnemonic[inst.Mnemonic] = inst
+		}
+		return byMnemonic
+	}
+	old, current := index(before), index(after)
+
+	diff := DatasetDiff{Added: []DiffEntry{}, Changed: []DiffEntry{}, Removed: []DiffEntry{}}
+	for mnemonic, inst := range current {
+		entry := DiffEntry{Mnemonic: mnemonic, Opcode: inst.Opcode}
+		previous, ok := old[mnemonic]
+		if !ok {
+			diff.Added = append(diff.Added, entry)
+			continue
+		}
+		fields, err := dataset.DiffFields(previous, inst)
+		if err != nil {
+			return diff, fmt.Errorf("failed to compare %s: %w", mnemonic, err)
+		}
+		if len(fields) > 0 {
+			entry.Fields = fields
+			diff.Changed = append(diff.Changed, entry)
+		}
+	}
+	for mnemonic, inst := range old {
+		if _, ok := current[mnemonic]; !ok {
+			diff.Removed = append(diff.Removed, DiffEntry{Mnemonic: mnemonic, Opcode: inst.Opcode})
+		}
+	}
+
+	for _, entries := range [][]DiffEntry{diff.Added, diff.Changed, diff.Removed} {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Mnemonic < entries[j].Mnemonic })
+	}
+	return diff, nil
+}
+
+// Diff compares two datasets and prints the instructions that were added,
+// removed or changed, with the fields that changed, for reviewing a refresh
+// before publishing it.
+func (s *Scraper) Diff(args []string) error {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	format := flags.String("format", "text", "report format: text or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return fmt.Errorf("usage: diff [--format text|json] <old.json> <new.json>")
+	}
+
+	before, err := s.docsPages(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	after, err := s.docsPages(flags.Arg(1))
+	if err != nil {
+		return err
+	}
+	diff, err := s.diffDatasets(before, after)
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "json":
+		buffer := new(bytes.Buffer)
+		encoder := json.NewEncoder(buffer)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(diff); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		os.Stdout.Write(buffer.Bytes())
+	case "text":
+		s.writeDiffText(os.Stdout, diff)
+	default:
+		return fmt.Errorf("unsupported format %q", *format)
+	}
+	return nil
+}
+
+func (s *Scraper) writeDiffText(w io.Writer, diff DatasetDiff) {
+	sections := []struct {
+		title   string
+		marker  string
+		entries []DiffEntry
+	}{
+		{"Added", "+", diff.Added},
+		{"Removed", "-", diff.Removed},
+		{"Changed", "~", diff.Changed},
+	}
+	for _, section := range sections {
+		if len(section.entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s (%d):\n", section.title, len(section.entries))
+		for _, entry := range section.entries {
+			fmt.Fprintf(w, "  %s %s  %s\n", section.marker, entry.Mnemonic, entry.Opcode)
+			dataset.WriteFieldChanges(w, "      ", entry.Fields)
+		}
+	}
+	fmt.Fprintf(w, "%d added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"arisa/dataset"
+	"arisa/schema"
+)
+
+const defaultDocsDir = "docs"
+
+// Docs renders a dataset as one Markdown page per instruction, plus a
+// SUMMARY.md listing them in opcode order that doubles as an mdBook
+// summary.
+func (s *Scraper) Docs(args []string) error {
+	flags := flag.NewFlagSet("docs", flag.ContinueOnError)
+	outputDir := flags.String("out", defaultDocsDir, "directory to write the Markdown pages to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("usage: docs [--out dir] [file.json]")
+	}
+	filename := s.outputFilename
+	if flags.NArg() == 1 {
+		filename = flags.Arg(0)
+	}
+
+	pages, err := s.docsPages(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(*outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", *outputDir, err)
+	}
+	for _, inst := range pages {
+		target := filepath.Join(*outputDir, s.docsPageName(inst))
+		if err := ioutil.WriteFile(target, []byte(s.renderInstructionDoc(inst)), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", target, err)
+		}
+	}
+
+	var summary strings.Builder
+	summary.WriteString("# Summary\n\n")
+	for _, inst := range pages {
+		fmt.Fprintf(&summary, "- [%s](%s)\n", inst.Mnemonic, s.docsPageName(inst))
+	}
+	if err := ioutil.WriteFile(filepath.Join(*outputDir, "SUMMARY.md"), []byte(summary.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+
+	s.logger.Info("Docs saved", "dir", *outputDir, "pages", len(pages))
+	return nil
+}
+
+// docsPages reads a dataset and returns its instructions in opcode order.
+func (s *Scraper) docsPages(filename string) ([]schema.JVMInstruction, error) {
+	content, err := dataset.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	records, _, err := dataset.Unwrap(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", filename, err)
+	}
+	var instructions []schema.JVMInstruction
+	if err := json.Unmarshal(records, &instructions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", filename, err)
+	}
+
+	var pages []schema.JVMInstruction
+	for _, inst := range instructions {
+		// Older datasets carried the unassigned range as an opcodeless
+		// "(no name)" record.
+		if inst.Opcode != "" {
+			pages = append(pages, inst)
+		}
+	}
+	sort.SliceStable(pages, func(i, j int) bool { return s.docsOpcode(pages[i]) < s.docsOpcode(pages[j]) })
+	return pages, nil
+}
+
+// docsOpcode is the instruction's opcode, read from the opcode text for
+// datasets written before opcodeByte was added.
+func (s *Scraper) docsOpcode(inst schema.JVMInstruction) uint8 {
+	if inst.OpcodeByte == 0 && inst.Opcode != "" {
+		var name string
+		fmt.Sscanf(inst.Opcode, "%s = %d", &name, &inst.OpcodeByte)
+	}
+	return inst.OpcodeByte
+}
+
+// docsPageName names a page after its anchor, which is unique even for
+// the wide-modified forms whose mnemonics contain a space.
+func (s *Scraper) docsPageName(inst schema.JVMInstruction) string {
+	return s.docsSlug(inst) + ".md"
+}
+
+func (s *Scraper) docsSlug(inst schema.JVMInstruction) string {
+	return strings.TrimPrefix(schema.JVMAnchorID(inst.Mnemonic), "jvm-")
+}
+
+func (s *Scraper) renderInstructionDoc(inst schema.JVMInstruction) string {
+	var sections []string
+	add := func(heading, body string) {
+		if strings.TrimSpace(body) != "" {
+			sections = append(sections, "## "+heading+"\n\n"+strings.TrimSpace(body))
+		}
+	}
+
+	header := fmt.Sprintf("# %s\n\n%s", inst.Mnemonic, s.docsSubtitle(inst))
+	if inst.SpecURL != "" {
+		header += " Source: <" + inst.SpecURL + ">"
+	}
+	sections = append(sections, header)
+
+	add("Operation", inst.Operation)
+	add("Format", "```\n"+s.docsFormat(inst)+"\n```")
+	add("Operands", s.docsOperands(inst))
+	add("Operand Stack", s.docsOperandStack(inst))
+	add("Description", inst.Description)
+	add("Linking Exceptions", inst.LinkingExceptions)
+	add("Run-time Exceptions", inst.RuntimeExceptions)
+	add("Notes", inst.Notes)
+	return strings.Join(sections, "\n\n") + "\n"
+}
+
+// docsSubtitle gives the opcode in decimal and hex, as the specification's
+// Forms sections do.
+func (s *Scraper) docsSubtitle(inst schema.JVMInstruction) string {
+	opcode := s.docsOpcode(inst)
+	subtitle := fmt.Sprintf("Opcode: %d (0x%02x).", opcode, opcode)
+	if inst.Reserved {
+		subtitle += " Reserved."
+	}
+	return subtitle
+}
+
+// docsOperands lays out the operand bytes as a table when the layout is
+// known and lists their names otherwise.
+func (s *Scraper) docsOperands(inst schema.JVMInstruction) string {
+	rows := s.docsOperandRows(inst)
+	if len(rows) == 0 {
+		var lines []string
+		for _, operand := range inst.Operands {
+			lines = append(lines, "- "+operand)
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	var lines []string
+	for i, row := range rows {
+		for j, cell := range row {
+			row[j] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "| --- | --- | --- | --- |")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// docsOperandRows tabulates the operand layout under a header row, or
+// returns nil when the layout isn't known.
+func (s *Scraper) docsOperandRows(inst schema.JVMInstruction) [][]string {
+	if len(inst.OperandLayout) == 0 {
+		return nil
+	}
+
+	rows := [][]string{{"Name", "Type", "Size", "Description"}}
+	for _, operand := range inst.OperandLayout {
+		size := operand.Count
+		if operand.Size > 0 {
+			size = fmt.Sprint(operand.Size)
+		}
+		rows = append(rows, []string{s.docsCell(operand.Name), s.docsCell(operand.Type), s.docsCell(size), s.docsCell(operand.Description)})
+	}
+	return rows
+}
+
+// docsOperandStack draws the stack the way the specification does, with
+// the untouched part of the stack elided as "...".
+func (s *Scraper) docsOperandStack(inst schema.JVMInstruction) string {
+	before, after := strings.TrimSpace(inst.OperandStackBefore), strings.TrimSpace(inst.OperandStackAfter)
+	switch {
+	case before == "" && after == "":
+		return ""
+	case strings.EqualFold(before, "No change"):
+		return "No change"
+	}
+
+	return "```\n" + s.docsStackDiagram(before, after) + "\n```"
+}
+
+func (s *Scraper) docsStackDiagram(before, after string) string {
+	elide := func(entries string) string {
+		if entries == "" || strings.HasPrefix(entries, "...") {
+			return entries
+		}
+		return "..., " + entries
+	}
+	return elide(before) + " →\n" + elide(after)
+}
+
+func (s *Scraper) docsCell(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// docsFormat stacks the mnemonic and its operands one per line, as the
+// specification's Format sections do.
+func (s *Scraper) docsFormat(inst schema.JVMInstruction) string {
+	if len(inst.Operands) == 0 {
+		return inst.Format
+	}
+	return strings.Join(append([]string{inst.Mnemonic}, inst.Operands...), "\n")
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"arisa/docset"
+	"arisa/schema"
+)
+
+const docsetFilename = "jvm.docset"
+
+var docsetInfo = docset.Info{Identifier: "arisa-jvm", Name: "JVM Instructions", Family: "jvm"}
+
+// Docset builds a Dash docset from a dataset, with a page and a search
+// entry per instruction.
+func (s *Scraper) Docset(args []string) error {
+	flags := flag.NewFlagSet("docset", flag.ContinueOnError)
+	output := flags.String("out", docsetFilename, "docset bundle to write")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("usage: docset [--out jvm.docset] [file.json]")
+	}
+	filename := s.outputFilename
+	if flags.NArg() == 1 {
+		filename = flags.Arg(0)
+	}
+
+	instructions, err := s.docsPages(filename)
+	if err != nil {
+		return err
+	}
+
+	var pages []docset.Page
+	var entries []docset.Entry
+	for _, inst := range instructions {
+		page := s.docsetPage(inst)
+		pages = append(pages, page)
+		entries = append(entries, docset.Entry{Name: inst.Mnemonic, Type: "Instruction", Path: page.Path})
+	}
+
+	if err := docset.Write(*output, docsetInfo, pages, entries); err != nil {
+		return err
+	}
+	s.logger.Info("Docset saved", "file", *output, "pages", len(pages))
+	return nil
+}
+
+func (s *Scraper) docsetPage(inst schema.JVMInstruction) docset.Page {
+	page := docset.Page{
+		Path:     s.docsSlug(inst) + ".html",
+		Title:    inst.Mnemonic,
+		Subtitle: s.docsSubtitle(inst),
+		Source:   inst.SpecURL,
+	}
+	add := func(section docset.Section) {
+		if len(section.Paragraphs) > 0 || len(section.Table) > 0 || len(section.List) > 0 || section.Code != "" {
+			page.Sections = append(page.Sections, section)
+		}
+	}
+
+	add(docset.Section{Heading: "Operation", Paragraphs: s.docsetParagraphs(inst.Operation)})
+	add(docset.Section{Heading: "Format", Code: s.docsFormat(inst)})
+	if rows := s.docsOperandRows(inst); len(rows) > 0 {
+		add(docset.Section{Heading: "Operands", Table: rows})
+	} else {
+		add(docset.Section{Heading: "Operands", List: inst.Operands})
+	}
+	add(s.docsetOperandStack(inst))
+	add(docset.Section{Heading: "Description", Paragraphs: s.docsetParagraphs(inst.Description)})
+	add(docset.Section{Heading: "Linking Exceptions", Paragraphs: s.docsetParagraphs(inst.LinkingExceptions)})
+	add(docset.Section{Heading: "Run-time Exceptions", Paragraphs: s.docsetParagraphs(inst.RuntimeExceptions)})
+	add(docset.Section{Heading: "Notes", Paragraphs: s.docsetParagraphs(inst.Notes)})
+	return page
+}
+
+func (s *Scraper) docsetOperandStack(inst schema.JVMInstruction) docset.Section {
+	section := docset.Section{Heading: "Operand Stack"}
+	before, after := strings.TrimSpace(inst.OperandStackBefore), strings.TrimSpace(inst.OperandStackAfter)
+	switch {
+	case before == "" && after == "":
+	case strings.EqualFold(before, "No change"):
+		section.Paragraphs = []string{"No change"}
+	default:
+		section.Code = s.docsStackDiagram(before, after)
+	}
+	return section
+}
+
+// docsetParagraphs splits the blank-line separated paragraphs that
+// joinParagraphs produces.
+func (s *Scraper) docsetParagraphs(text string) []string {
+	var paragraphs []string
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	return paragraphs
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"arisa/pagecache"
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Fetcher retrieves the raw body of a source page. httpFetcher goes to the
+// network and cacheFetcher replays pages recorded with --cache-dir, which
+// lets the parsers be re-run against saved copies of the sources.
+type Fetcher interface {
+	Fetch(target string) ([]byte, error)
+}
+
+type httpFetcher struct {
+	*Scraper
+}
+
+func (s httpFetcher) Fetch(target string) ([]byte, error) {
+	if err := s.checkRobots(target); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", s.userAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	s.cachePage(target, body)
+	return body, nil
+}
+
+// checkRobots refuses URLs the host's robots.txt disallows, unless
+// --ignore-robots left s.robots nil. Every host is fetched from once, so
+// Crawl-delay never comes into play.
+func (s *Scraper) checkRobots(target string) error {
+	if s.robots == nil {
+		return nil
+	}
+	return s.robots.Check(context.Background(), target)
+}
+
+// cachePage records a fetched page under --cache-dir. Failing to record is
+// logged rather than returned since the page itself was fetched fine.
+func (s *Scraper) cachePage(target string, body []byte) {
+	if s.cacheDir == "" {
+		return
+	}
+
+	path, err := pagecache.Path(s.cacheDir, target)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+	}
+	if err == nil {
+		err = os.WriteFile(path, body, 0644)
+	}
+	if err != nil {
+		s.logger.Warn("Failed to cache page", "url", target, "error", err)
+	}
+}
+
+type cacheFetcher struct {
+	dir string
+}
+
+func (f cacheFetcher) Fetch(target string) ([]byte, error) {
+	path, err := pagecache.Path(f.dir, target)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("page not in cache: %w", err)
+	}
+	return body, nil
+}
+
+func (s *Scraper) fetchDocument(target string) (*goquery.Document, error) {
+	body, err := s.fetcher.Fetch(target)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+	return doc, nil
+}
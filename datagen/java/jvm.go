@@ -2,27 +2,42 @@ package main
 
 import (
 	"bytes"
-	"crypto/tls"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"html"
-	"io/ioutil"
 	"net/http"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"arisa/config"
+	"arisa/httpclient"
+	"arisa/robots"
+	"arisa/schema"
+
 	"github.com/PuerkitoBio/goquery"
 	"github.com/charmbracelet/log"
 )
 
-const (
-	sourceURL      = "https://en.wikipedia.org/wiki/List_of_Java_bytecode_instructions"
-	outputFilename = "jvm_instructions.json"
-	requestTimeout = 30 * time.Second
-)
+// defaultConfig is what a run uses when neither --config nor a flag says
+// otherwise. The instruction set is chapter 6 of the JVMS, a single page, so
+// there are no workers.
+var defaultConfig = config.Scraper{
+	RequestTimeout:   30 * time.Second,
+	Output:           "jvm_instructions.json",
+	BaseURL:          "https://docs.oracle.com/javase/specs/jvms/se24/html/jvms-6.html",
+	UserAgent:        "jvm-scraper/1.0",
+	MaxResponseBytes: 16 << 20,
+}
+
+// wikipediaURL is the instruction list scraped when the specification
+// can't be and --wikipedia-fallback allows it.
+const wikipediaURL = "https://en.wikipedia.org/wiki/List_of_Java_bytecode_instructions"
 
 type InstructionData struct {
 	Mnemonic     string `json:"mnemonic"`
@@ -34,11 +49,26 @@ type InstructionData struct {
 }
 
 type Scraper struct {
-	client *http.Client
-	logger *log.Logger
+	client           *http.Client
+	fetcher          Fetcher
+	cacheDir         string
+	userAgent        string
+	robots           *robots.Checker
+	logger           *log.Logger
+	sourceURL        string
+	outputFilename   string
+	protobufFilename string
+	splitDir         string
+	unifiedFilename  string
+	legacyArray      bool
+	formats          map[string]bool
+	// wikipediaFallback lets a run whose specification scrape fails ship
+	// Wikipedia's list instead, which lacks the specification's prose.
+	wikipediaFallback bool
+	skipCrossCheck    bool
 }
 
-func NewScraper() *Scraper {
+func NewScraper(cfg config.Scraper) (*Scraper, error) {
 	logger := log.NewWithOptions(os.Stderr, log.Options{
 		ReportCaller:    false,
 		ReportTimestamp: true,
@@ -46,20 +76,22 @@ func NewScraper() *Scraper {
 		Prefix:          "jvm-scraper",
 	})
 
-	client := &http.Client{
-		Timeout: requestTimeout,
-		Transport: &http.Transport{
-			TLSClientConfig:   &tls.Config{InsecureSkipVerify: false},
-			DisableKeepAlives: false,
-			MaxIdleConns:      10,
-			IdleConnTimeout:   90 * time.Second,
-		},
+	client, err := httpclient.New(cfg, 10)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up HTTP client: %w", err)
 	}
 
-	return &Scraper{
-		client: client,
-		logger: logger,
+	scraper := &Scraper{
+		client:          client,
+		userAgent:       cfg.UserAgent,
+		robots:          robots.NewChecker(client, cfg.UserAgent),
+		logger:          logger,
+		sourceURL:       cfg.BaseURL,
+		outputFilename:  cfg.Output,
+		unifiedFilename: defaultUnifiedFilename,
 	}
+	scraper.fetcher = httpFetcher{scraper}
+	return scraper, nil
 }
 
 func (s *Scraper) cleanText(text string) string {
@@ -70,30 +102,9 @@ func (s *Scraper) cleanText(text string) string {
 }
 
 func (s *Scraper) fetchPage() (*goquery.Document, error) {
-	s.logger.Info("Fetching instruction data")
-
-	req, err := http.NewRequest("GET", sourceURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("User-Agent", "jvm-scraper/1.0")
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch URL: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("bad status: %s", resp.Status)
-	}
-
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
-	}
+	s.logger.Info("Fetching Wikipedia instruction list", "url", wikipediaURL)
 
-	return doc, nil
+	return s.fetchDocument(wikipediaURL)
 }
 
 func (s *Scraper) parseInstructionTable(doc *goquery.Document) []InstructionData {
@@ -106,7 +117,7 @@ func (s *Scraper) parseInstructionTable(doc *goquery.Document) []InstructionData
 		}
 
 		instruction := InstructionData{
-			Mnemonic:     s.cleanText(cells.Eq(0).Text()),
+			Mnemonic:     strings.TrimRight(s.cleanText(cells.Eq(0).Text()), "†"),
 			OpcodeHex:    s.cleanText(cells.Eq(1).Text()),
 			OpcodeBinary: s.cleanText(cells.Eq(2).Text()),
 			OtherBytes:   s.cleanText(cells.Eq(3).Text()),
@@ -122,34 +133,33 @@ func (s *Scraper) parseInstructionTable(doc *goquery.Document) []InstructionData
 	return instructions
 }
 
-func (s *Scraper) convertToJVMFormat(instructions []InstructionData) []map[string]interface{} {
-	var jvmInstructions []map[string]interface{}
+func (s *Scraper) convertToJVMFormat(instructions []InstructionData) []schema.JVMInstruction {
+	var jvmInstructions []schema.JVMInstruction
 
 	for _, inst := range instructions {
-		jvmInst := make(map[string]interface{})
-
-		jvmInst["mnemonic"] = inst.Mnemonic
-
-		opcodeValue := s.extractOpcodeValue(inst.OpcodeHex)
-		if opcodeValue != "" {
-			jvmInst["opcode"] = fmt.Sprintf("%s = %s (0x%s)", inst.Mnemonic, opcodeValue, inst.OpcodeHex)
+		jvmInst := schema.JVMInstruction{
+			Mnemonic:    inst.Mnemonic,
+			Operation:   inst.Description,
+			Description: inst.Description,
+			Source:      "wikipedia",
+			AnchorID:    schema.JVMAnchorID(inst.Mnemonic),
 		}
 
-		jvmInst["operation"] = inst.Description
-
-		format := inst.Mnemonic
-		if inst.OtherBytes != "" {
-			format += " " + strings.ReplaceAll(inst.OtherBytes, ":", "")
+		// The unassigned range is listed as a "(no name)" row with a
+		// range for its opcode; jvm_opcode_ranges.json covers it.
+		opcode, err := strconv.ParseUint(strings.TrimSpace(inst.OpcodeHex), 16, 8)
+		if err != nil {
+			s.logger.Debug("Skipping row without a single opcode", "mnemonic", inst.Mnemonic, "opcode", inst.OpcodeHex)
+			continue
 		}
-		jvmInst["format"] = format
-
-		before, after := s.parseStack(inst.Stack)
-		jvmInst["operandStackBefore"] = before
-		jvmInst["operandStackAfter"] = after
+		jvmInst.OpcodeByte = uint8(opcode)
+		jvmInst.Opcode = schema.JVMOpcodeString(inst.Mnemonic, jvmInst.OpcodeByte)
 
-		jvmInst["description"] = inst.Description
+		jvmInst.Operands = s.parseOtherBytes(inst.OtherBytes)
+		jvmInst.Format = strings.Join(append([]string{inst.Mnemonic}, jvmInst.Operands...), " ")
 
-		jvmInst["anchorId"] = "jvm-" + strings.ReplaceAll(inst.Mnemonic, "_", "-")
+		jvmInst.OperandStackBefore, jvmInst.OperandStackAfter = s.parseStack(inst.Stack)
+		jvmInst.SpecURL = s.specURLFor(inst.Mnemonic, "")
 
 		jvmInstructions = append(jvmInstructions, jvmInst)
 	}
@@ -157,17 +167,19 @@ func (s *Scraper) convertToJVMFormat(instructions []InstructionData) []map[strin
 	return jvmInstructions
 }
 
-func (s *Scraper) extractOpcodeValue(hex string) string {
-	if hex == "" {
-		return ""
+func (s *Scraper) parseOtherBytes(otherBytes string) []string {
+	if _, names, ok := strings.Cut(otherBytes, ":"); ok {
+		otherBytes = names
 	}
 
-	hexValue := strings.TrimSpace(hex)
-	if val, err := strconv.ParseInt(hexValue, 16, 64); err == nil {
-		return strconv.FormatInt(val, 10)
+	var operands []string
+	for _, name := range strings.Split(otherBytes, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			operands = append(operands, name)
+		}
 	}
 
-	return ""
+	return operands
 }
 
 func (s *Scraper) parseStack(stack string) (string, string) {
@@ -195,7 +207,7 @@ func (s *Scraper) parseStack(stack string) (string, string) {
 	return "...", stack
 }
 
-func (s *Scraper) scrapeInstructions() ([]map[string]interface{}, error) {
+func (s *Scraper) scrapeWikipedia() ([]schema.JVMInstruction, error) {
 	doc, err := s.fetchPage()
 	if err != nil {
 		return nil, err
@@ -209,23 +221,96 @@ func (s *Scraper) scrapeInstructions() ([]map[string]interface{}, error) {
 	return jvmInstructions, nil
 }
 
-func (s *Scraper) saveData(instructions []map[string]interface{}) error {
+// scrapeInstructions scrapes the specification. A failure is fatal unless
+// --wikipedia-fallback was given, in which case Wikipedia's list is
+// scraped instead.
+func (s *Scraper) scrapeInstructions() ([]schema.JVMInstruction, error) {
+	jvmInstructions, specErr := s.scrapeSpec()
+	if specErr != nil {
+		if !s.wikipediaFallback {
+			return nil, fmt.Errorf("failed to scrape specification (--wikipedia-fallback scrapes Wikipedia instead): %w", specErr)
+		}
+		s.logger.Warn("Failed to scrape JVM specification, falling back to Wikipedia", "error", specErr)
+
+		var wikiErr error
+		if jvmInstructions, wikiErr = s.scrapeWikipedia(); wikiErr != nil {
+			return nil, fmt.Errorf("failed to scrape specification and Wikipedia: %w", errors.Join(specErr, wikiErr))
+		}
+	}
+
+	return s.finishInstructions(jvmInstructions), nil
+}
+
+// finishInstructions runs the passes that follow scraping: normalization,
+// the reserved opcodes, operand layouts, the wide forms and the stack
+// model.
+func (s *Scraper) finishInstructions(jvmInstructions []schema.JVMInstruction) []schema.JVMInstruction {
+	for i := range jvmInstructions {
+		s.normalizeInstruction(&jvmInstructions[i])
+	}
+	jvmInstructions = s.addReservedOpcodes(jvmInstructions)
+
+	for i := range jvmInstructions {
+		s.buildOperandLayout(&jvmInstructions[i])
+	}
+	jvmInstructions = s.expandWideForms(jvmInstructions)
+
+	for i := range jvmInstructions {
+		s.buildStackModel(&jvmInstructions[i])
+	}
+
+	return jvmInstructions
+}
+
+// sortInstructions orders instructions by mnemonic, then opcode, then spec
+// URL, so re-running against an unchanged spec writes identical bytes.
+func (s *Scraper) sortInstructions(instructions []schema.JVMInstruction) {
+	sort.SliceStable(instructions, func(i, j int) bool {
+		a, b := instructions[i], instructions[j]
+		if a.Mnemonic != b.Mnemonic {
+			return a.Mnemonic < b.Mnemonic
+		}
+		if a.OpcodeByte != b.OpcodeByte {
+			return a.OpcodeByte < b.OpcodeByte
+		}
+		return a.SpecURL < b.SpecURL
+	})
+}
+
+func (s *Scraper) saveData(instructions []schema.JVMInstruction) error {
 	s.logger.Info("Saving instruction data", "count", len(instructions))
+	s.sortInstructions(instructions)
+
+	for i := range instructions {
+		if err := instructions[i].Validate(); err != nil {
+			return fmt.Errorf("invalid instruction: %w", err)
+		}
+	}
 
 	buffer := new(bytes.Buffer)
 	encoder := json.NewEncoder(buffer)
 	encoder.SetEscapeHTML(false)
 	encoder.SetIndent("", "  ")
 
-	if err := encoder.Encode(instructions); err != nil {
+	if err := encoder.Encode(s.datasetDocument(instructions)); err != nil {
 		return fmt.Errorf("failed to encode JSON: %w", err)
 	}
 
-	if err := ioutil.WriteFile(outputFilename, buffer.Bytes(), 0644); err != nil {
+	if err := s.validateDataset(buffer.Bytes()); err != nil {
+		return fmt.Errorf("dataset does not match its schema: %w", err)
+	}
+
+	if err := s.writeDataset(buffer.Bytes()); err != nil {
 		return fmt.Errorf("failed to write JSON to file: %w", err)
 	}
 
-	s.logger.Info("Data saved successfully", "file", outputFilename)
+	s.logger.Info("Data saved successfully", "file", s.outputFilename, "backup", s.backupPath())
+
+	if s.formats["yaml"] {
+		if err := s.saveYAML(instructions); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -237,16 +322,170 @@ func (s *Scraper) Run() error {
 		return fmt.Errorf("failed to scrape instructions: %w", err)
 	}
 
+	if err := s.crossCheckOpcodes(instructions); err != nil {
+		return fmt.Errorf("failed to cross-check opcodes: %w", err)
+	}
+
 	if err := s.saveData(instructions); err != nil {
 		return fmt.Errorf("failed to save data: %w", err)
 	}
 
+	if s.splitDir != "" {
+		if err := s.saveSplit(instructions, s.splitDir, s.outputFilename); err != nil {
+			return fmt.Errorf("failed to save split dataset: %w", err)
+		}
+	}
+
+	if s.protobufFilename != "" {
+		if err := s.saveProtobuf(instructions); err != nil {
+			return fmt.Errorf("failed to save protobuf dataset: %w", err)
+		}
+	}
+
+	if err := s.saveDerivedFiles(instructions); err != nil {
+		return err
+	}
+
+	if err := s.saveConstantPool(); err != nil {
+		return fmt.Errorf("failed to save constant pool tags: %w", err)
+	}
+
+	if err := s.saveClassFileAttributes(); err != nil {
+		return fmt.Errorf("failed to save class file attributes: %w", err)
+	}
+
+	if err := s.saveVerificationTypes(); err != nil {
+		return fmt.Errorf("failed to save verification types: %w", err)
+	}
+
 	s.logger.Info("Scraping completed successfully")
 	return nil
 }
 
+// saveDerivedFiles writes the files built from the final instructions next
+// to the dataset: the unified cross-ISA dataset, the unassigned opcode
+// ranges and the C header.
+func (s *Scraper) saveDerivedFiles(instructions []schema.JVMInstruction) error {
+	if err := s.saveUnified(instructions, s.unifiedFilename); err != nil {
+		return fmt.Errorf("failed to save unified dataset: %w", err)
+	}
+
+	if err := s.saveOpcodeRanges(instructions); err != nil {
+		return fmt.Errorf("failed to save opcode ranges: %w", err)
+	}
+
+	if err := s.saveOpcodeHeader(instructions); err != nil {
+		return fmt.Errorf("failed to save opcode header: %w", err)
+	}
+	return nil
+}
+
 func main() {
-	scraper := NewScraper()
+	ignoreRobots := flag.Bool("ignore-robots", false, "don't fetch or honor robots.txt")
+	cacheDir := flag.String("cache-dir", "", "store the raw body of every fetched page in this directory")
+	offline := flag.Bool("offline", false, "re-parse the pages in --cache-dir without touching the network")
+	format := flag.String("format", "json", "comma-separated dataset formats to write: json, yaml (JSON is always written)")
+	protobufFile := flag.String("protobuf", "", "also write the dataset as a binary protobuf (arisa.schema.v1.JVMDataset) to this file")
+	legacyArray := flag.Bool("legacy-array", false, "write the dataset as a bare JSON array, without the metadata envelope")
+	unified := flag.String("unified", defaultUnifiedFilename, "file to write the dataset to in the unified cross-ISA schema (arisa/schema.Instruction), alongside the JVM dataset")
+	wikipediaFallback := flag.Bool("wikipedia-fallback", false, "scrape Wikipedia's instruction list if the specification can't be scraped, instead of failing")
+	skipCrossCheck := flag.Bool("skip-crosscheck", false, "don't check the opcodes against ASM's (the check fails the run if ASM can't be fetched)")
+	split := flag.String("split", "", "also write the dataset as one JSON file per opcode group (loads.json, control.json, ...) plus a manifest into this directory")
+	settings := config.Bind(flag.CommandLine, "jvm", defaultConfig)
+	flag.Parse()
+
+	cfg, err := settings.Resolve()
+	if err != nil {
+		log.Fatal("Invalid configuration", "error", err)
+	}
+
+	scraper, err := NewScraper(cfg)
+	if err != nil {
+		log.Fatal("Failed to create scraper", "error", err)
+	}
+	if *ignoreRobots {
+		scraper.robots = nil
+	}
+	scraper.cacheDir = *cacheDir
+	scraper.protobufFilename = *protobufFile
+	scraper.splitDir = *split
+	scraper.unifiedFilename = *unified
+	scraper.legacyArray = *legacyArray
+	scraper.wikipediaFallback = *wikipediaFallback
+	scraper.skipCrossCheck = *skipCrossCheck
+	if scraper.formats, err = config.ParseFormats(*format, "yaml"); err != nil {
+		scraper.logger.Fatal("Invalid format", "error", err)
+	}
+	if *offline {
+		if scraper.cacheDir == "" {
+			scraper.logger.Fatal("--offline requires --cache-dir")
+		}
+		scraper.fetcher = cacheFetcher{dir: scraper.cacheDir}
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "schema" {
+		if err := scraper.Schema(args[1:]); err != nil {
+			scraper.logger.Fatal("Schema failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "validate" {
+		if err := scraper.Validate(args[1:]); err != nil {
+			scraper.logger.Fatal("Validation failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "rebuild" {
+		if err := scraper.Rebuild(args[1:]); err != nil {
+			scraper.logger.Fatal("Rebuild failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "docs" {
+		if err := scraper.Docs(args[1:]); err != nil {
+			scraper.logger.Fatal("Docs failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "docset" {
+		if err := scraper.Docset(args[1:]); err != nil {
+			scraper.logger.Fatal("Docset failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "diff" {
+		if err := scraper.Diff(args[1:]); err != nil {
+			scraper.logger.Fatal("Diff failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "split" {
+		if err := scraper.Split(args[1:]); err != nil {
+			scraper.logger.Fatal("Split failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "unified" {
+		if err := scraper.Unified(args[1:]); err != nil {
+			scraper.logger.Fatal("Unified export failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "man" {
+		if err := scraper.ManPages(args[1:]); err != nil {
+			scraper.logger.Fatal("Man pages failed", "error", err)
+		}
+		return
+	}
+
 	if err := scraper.Run(); err != nil {
 		scraper.logger.Fatal("Scraper failed", "error", err)
 	}
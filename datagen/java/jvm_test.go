@@ -0,0 +1,243 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"arisa/pagecache"
+	"arisa/schema"
+)
+
+// replayScraper returns a scraper that reads pages from a cache holding
+// the testdata files given by URL, the way --offline replays a
+// --cache-dir.
+func replayScraper(t *testing.T, pages map[string]string) *Scraper {
+	t.Helper()
+	cfg := defaultConfig
+	cfg.Output = filepath.Join(t.TempDir(), "jvm_instructions.json")
+	s, err := NewScraper(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.logger.SetOutput(io.Discard)
+	s.robots = nil
+
+	dir := t.TempDir()
+	for pageURL, name := range pages {
+		body, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		path, err := pagecache.Path(dir, pageURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, body, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.fetcher = cacheFetcher{dir: dir}
+	return s
+}
+
+func TestParseInstructionTable(t *testing.T) {
+	s := replayScraper(t, map[string]string{wikipediaURL: "wikipedia.html"})
+	doc, err := s.fetchPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instructions := s.parseInstructionTable(doc)
+	var mnemonics []string
+	for _, inst := range instructions {
+		mnemonics = append(mnemonics, inst.Mnemonic)
+	}
+	want := []string{"aaload", "aload", "goto_w", "jsr", "pop", "(no name)"}
+	if !reflect.DeepEqual(mnemonics, want) {
+		t.Fatalf("mnemonics = %q, want %q", mnemonics, want)
+	}
+
+	gotoW := instructions[2]
+	if gotoW.OpcodeHex != "c8" || gotoW.OpcodeBinary != "1100 1000" || gotoW.Stack != "[no change]" {
+		t.Errorf("goto_w = %+v", gotoW)
+	}
+	if !strings.Contains(gotoW.Description, "branchbyte1 << 24") {
+		t.Errorf("goto_w description wasn't unescaped: %q", gotoW.Description)
+	}
+
+	// The "(no name)" row is the unassigned range, not an instruction.
+	converted := s.convertToJVMFormat(instructions)
+	if len(converted) != 5 {
+		t.Fatalf("got %d instructions, want 5", len(converted))
+	}
+	aload := converted[1]
+	if aload.OpcodeByte != 0x19 || !reflect.DeepEqual(aload.Operands, []string{"index"}) || aload.Format != "aload index" {
+		t.Errorf("aload = %+v", aload)
+	}
+	if aload.OperandStackBefore != "..." || aload.OperandStackAfter != "objectref" {
+		t.Errorf("aload stack = %q → %q", aload.OperandStackBefore, aload.OperandStackAfter)
+	}
+	if converted[2].OperandStackBefore != "No change" || len(converted[2].Operands) != 4 {
+		t.Errorf("goto_w = %+v", converted[2])
+	}
+	if converted[4].OperandStackBefore != "value" || converted[4].OperandStackAfter != "[empty]" {
+		t.Errorf("pop stack = %q → %q", converted[4].OperandStackBefore, converted[4].OperandStackAfter)
+	}
+}
+
+func TestParseSpecSections(t *testing.T) {
+	s := replayScraper(t, map[string]string{defaultConfig.BaseURL: "jvms-6.html"})
+	doc, err := s.fetchSpecPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sections := s.parseSpecSections(doc)
+	if len(sections) != 2 {
+		t.Fatalf("got %d sections, want 2", len(sections))
+	}
+	aload := sections[0]
+	if aload.Anchor != "jvms-6.5.aload" || aload.Operation != "Load reference from local variable" {
+		t.Errorf("aload = %+v", aload)
+	}
+	if !reflect.DeepEqual(aload.Format, []string{"aload", "index"}) {
+		t.Errorf("aload format = %q", aload.Format)
+	}
+	if aload.OperandStackBefore != "..." || aload.OperandStackAfter != "objectref" {
+		t.Errorf("aload stack = %q → %q", aload.OperandStackBefore, aload.OperandStackAfter)
+	}
+	if strings.Count(aload.Notes, "\n\n") != 1 {
+		t.Errorf("aload notes = %q", aload.Notes)
+	}
+
+	converted := s.convertSpecToJVMFormat(sections)
+	var forms []string
+	for _, inst := range converted {
+		forms = append(forms, inst.Opcode)
+	}
+	if want := []string{
+		schema.JVMOpcodeString("aload", 0x19),
+		schema.JVMOpcodeString("iconst_m1", 0x02),
+		schema.JVMOpcodeString("iconst_0", 0x03),
+		schema.JVMOpcodeString("iconst_1", 0x04),
+	}; !reflect.DeepEqual(forms, want) {
+		t.Errorf("opcodes = %q, want %q", forms, want)
+	}
+	if url := converted[2].SpecURL; url != defaultConfig.BaseURL+"#jvms-6.5.iconst_i" {
+		t.Errorf("iconst_0 SpecURL = %q", url)
+	}
+}
+
+// TestScrapeInstructionsFallsBackToWikipedia replays a run whose
+// specification fetch fails, which is fatal unless the fallback is asked
+// for.
+func TestScrapeInstructionsFallsBackToWikipedia(t *testing.T) {
+	s := replayScraper(t, map[string]string{wikipediaURL: "wikipedia.html"})
+	if _, err := s.scrapeInstructions(); err == nil {
+		t.Fatal("scrapeInstructions fell back to Wikipedia without --wikipedia-fallback")
+	}
+
+	s.wikipediaFallback = true
+	instructions, err := s.scrapeInstructions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, inst := range instructions {
+		if inst.Mnemonic == "aaload" {
+			found = true
+			if inst.Source != "wikipedia" {
+				t.Errorf("aaload source = %q", inst.Source)
+			}
+		}
+	}
+	if !found {
+		t.Error("aaload wasn't scraped from Wikipedia")
+	}
+}
+
+func TestNormalizeInstruction(t *testing.T) {
+	s := replayScraper(t, nil)
+	inst := schema.JVMInstruction{
+		Mnemonic:    "aload",
+		Description: "The local variable array of the current frame (§ 2.6).",
+		Notes:       "The aload instruction cannot be used to load a value of type return­Address.",
+	}
+	s.normalizeInstruction(&inst)
+
+	if want := "The local variable array of the current frame (§ 2.6)."; inst.Description != want {
+		t.Errorf("Description = %q, want %q", inst.Description, want)
+	}
+	if want := "The aload instruction cannot be used to load a value of type returnAddress."; inst.Notes != want {
+		t.Errorf("Notes = %q, want %q", inst.Notes, want)
+	}
+}
+
+// TestCrossCheckFailsWithoutASM replays a run where ASM's opcodes can't be
+// fetched, which fails the check unless it was skipped.
+func TestCrossCheckFailsWithoutASM(t *testing.T) {
+	s := replayScraper(t, nil)
+	if err := s.crossCheckOpcodes(nil); err == nil {
+		t.Error("cross-check passed without ASM's opcodes")
+	}
+
+	s.skipCrossCheck = true
+	if err := s.crossCheckOpcodes(nil); err != nil {
+		t.Errorf("skipped cross-check failed: %v", err)
+	}
+}
+
+func TestExpandWideForms(t *testing.T) {
+	s := replayScraper(t, nil)
+	var instructions []schema.JVMInstruction
+	for _, name := range wideModifiable {
+		inst := schema.JVMInstruction{
+			Mnemonic:    name,
+			Operation:   name + " operation",
+			Description: name + " description",
+			Operands:    []string{"index"},
+		}
+		s.buildOperandLayout(&inst)
+		instructions = append(instructions, inst)
+	}
+	instructions = s.expandWideForms(instructions)
+
+	wide := make(map[string]schema.JVMInstruction)
+	for _, inst := range instructions {
+		if inst.Modifies != "" {
+			wide[inst.Modifies] = inst
+		}
+	}
+	if len(wide) != len(wideModifiable) {
+		t.Fatalf("got %d wide forms, want %d", len(wide), len(wideModifiable))
+	}
+
+	aload := wide["aload"]
+	if want := "Extend aload's local variable index to 16 bits"; aload.Operation != want {
+		t.Errorf("wide aload Operation = %q, want %q", aload.Operation, want)
+	}
+	if !strings.Contains(aload.Description, "16-bit local variable index") || strings.Contains(aload.Description, "aload description") {
+		t.Errorf("wide aload Description = %q", aload.Description)
+	}
+	if aload.Length != 4 {
+		t.Errorf("wide aload Length = %d, want 4", aload.Length)
+	}
+
+	iinc := wide["iinc"]
+	if want := "Extend iinc's local variable index and increment to 16 bits"; iinc.Operation != want {
+		t.Errorf("wide iinc Operation = %q, want %q", iinc.Operation, want)
+	}
+	if !strings.Contains(iinc.Description, "signed 16-bit increment") {
+		t.Errorf("wide iinc Description = %q", iinc.Description)
+	}
+	if iinc.Length != 6 {
+		t.Errorf("wide iinc Length = %d, want 6", iinc.Length)
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"arisa/schema"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var formPattern = regexp.MustCompile(`^(\S+)\s*=\s*(\d+)\s*\(0x([0-9a-fA-F]+)\)`)
+
+// specAnchorGroups maps mnemonics that share a JVMS §6.5 section to the
+// section's anchor suffix, e.g. aload_0 through aload_3 to aload_n.
+var specAnchorGroups = []struct {
+	pattern *regexp.Regexp
+	anchor  string
+}{
+	{regexp.MustCompile(`^iconst_(m1|\d)$`), "iconst_i"},
+	{regexp.MustCompile(`^lconst_\d$`), "lconst_l"},
+	{regexp.MustCompile(`^fconst_\d$`), "fconst_f"},
+	{regexp.MustCompile(`^dconst_\d$`), "dconst_d"},
+	{regexp.MustCompile(`^if_icmp(eq|ne|lt|ge|gt|le)$`), "if_icmp_cond"},
+	{regexp.MustCompile(`^if_acmp(eq|ne)$`), "if_acmp_cond"},
+	{regexp.MustCompile(`^if(eq|ne|lt|ge|gt|le)$`), "if_cond"},
+	{regexp.MustCompile(`^([ilfda](load|store))_\d$`), "${1}_n"},
+	{regexp.MustCompile(`^wide .+$`), "wide"},
+}
+
+type SpecForm struct {
+	Mnemonic string
+	Opcode   uint8
+}
+
+type SpecInstruction struct {
+	Title              string
+	Anchor             string
+	Operation          string
+	Format             []string
+	Forms              []SpecForm
+	OperandStackBefore string
+	OperandStackAfter  string
+	Description        string
+	LinkingExceptions  string
+	RuntimeExceptions  string
+	Notes              string
+}
+
+func (s *Scraper) fetchSpecPage() (*goquery.Document, error) {
+	s.logger.Info("Fetching JVM specification", "url", s.sourceURL)
+
+	return s.fetchDocument(s.sourceURL)
+}
+
+func (s *Scraper) parseSpecSections(doc *goquery.Document) []SpecInstruction {
+	var instructions []SpecInstruction
+
+	doc.Find("div.section-execution").Each(func(i int, section *goquery.Selection) {
+		title := s.cleanText(section.Find("h3.title").First().Text())
+		if title == "" {
+			title = s.cleanText(section.AttrOr("title", ""))
+		}
+		if title == "" {
+			return
+		}
+
+		inst := SpecInstruction{
+			Title:  title,
+			Anchor: section.Find("h3.title a[name]").First().AttrOr("name", ""),
+		}
+
+		section.ChildrenFiltered("div.section").Each(func(j int, sub *goquery.Selection) {
+			heading := s.cleanText(sub.Find("h4.title").First().Text())
+			body := sub.Clone()
+			body.Find("div.titlepage").Remove()
+
+			switch heading {
+			case "Operation":
+				inst.Operation = s.cleanText(body.Text())
+			case "Format":
+				inst.Format = s.parseSpecFormat(body)
+			case "Forms":
+				inst.Forms = s.parseSpecForms(body)
+			case "Operand Stack":
+				inst.OperandStackBefore, inst.OperandStackAfter = s.parseSpecStack(body)
+			case "Description":
+				inst.Description = s.joinParagraphs(body)
+			case "Linking Exceptions":
+				inst.LinkingExceptions = s.joinParagraphs(body)
+			case "Run-time Exception", "Run-time Exceptions":
+				inst.RuntimeExceptions = s.joinParagraphs(body)
+			case "Notes":
+				inst.Notes = s.joinParagraphs(body)
+			}
+		})
+
+		if len(inst.Forms) == 0 {
+			s.logger.Warn("Specification section has no forms", "title", title)
+			return
+		}
+
+		instructions = append(instructions, inst)
+	})
+
+	return instructions
+}
+
+func (s *Scraper) parseSpecFormat(body *goquery.Selection) []string {
+	var format []string
+
+	body.Find("div.literallayout em").Each(func(i int, em *goquery.Selection) {
+		if text := s.cleanText(em.Text()); text != "" {
+			format = append(format, text)
+		}
+	})
+
+	return format
+}
+
+func (s *Scraper) parseSpecForms(body *goquery.Selection) []SpecForm {
+	var forms []SpecForm
+
+	body.Find("p").Each(func(i int, p *goquery.Selection) {
+		match := formPattern.FindStringSubmatch(s.cleanText(p.Text()))
+		if match == nil {
+			return
+		}
+
+		opcode, err := strconv.ParseUint(match[2], 10, 8)
+		if err != nil {
+			return
+		}
+
+		forms = append(forms, SpecForm{Mnemonic: match[1], Opcode: uint8(opcode)})
+	})
+
+	return forms
+}
+
+func (s *Scraper) parseSpecStack(body *goquery.Selection) (string, string) {
+	paragraphs := body.Find("p")
+
+	for i := 0; i < paragraphs.Length(); i++ {
+		text := s.cleanText(paragraphs.Eq(i).Text())
+		if !strings.HasSuffix(text, "→") {
+			continue
+		}
+
+		before := s.trimStackPrefix(strings.TrimSuffix(text, "→"))
+		after := ""
+		if i+1 < paragraphs.Length() {
+			after = s.trimStackPrefix(s.cleanText(paragraphs.Eq(i + 1).Text()))
+		}
+
+		if before == "" {
+			before = "..."
+		}
+		if after == "" {
+			after = "[empty]"
+		}
+
+		return before, after
+	}
+
+	text := s.cleanText(body.Text())
+	if text == "" || strings.EqualFold(text, "No change") {
+		return "No change", "No change"
+	}
+
+	return text, text
+}
+
+func (s *Scraper) trimStackPrefix(stack string) string {
+	stack = strings.TrimSpace(stack)
+	stack = strings.TrimPrefix(stack, "...")
+	stack = strings.TrimPrefix(stack, ",")
+	return strings.TrimSpace(stack)
+}
+
+func (s *Scraper) joinParagraphs(body *goquery.Selection) string {
+	var paragraphs []string
+
+	body.Find("p").Each(func(i int, p *goquery.Selection) {
+		if text := s.cleanText(p.Text()); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	})
+
+	if len(paragraphs) == 0 {
+		return s.cleanText(body.Text())
+	}
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+func (s *Scraper) convertSpecToJVMFormat(sections []SpecInstruction) []schema.JVMInstruction {
+	var jvmInstructions []schema.JVMInstruction
+
+	for _, section := range sections {
+		var operands []string
+		if len(section.Format) > 1 {
+			operands = section.Format[1:]
+		}
+
+		for _, form := range section.Forms {
+			jvmInstructions = append(jvmInstructions, schema.JVMInstruction{
+				Mnemonic:           form.Mnemonic,
+				Opcode:             schema.JVMOpcodeString(form.Mnemonic, form.Opcode),
+				OpcodeByte:         form.Opcode,
+				Operation:          section.Operation,
+				Format:             strings.Join(append([]string{form.Mnemonic}, operands...), " "),
+				Operands:           operands,
+				OperandStackBefore: section.OperandStackBefore,
+				OperandStackAfter:  section.OperandStackAfter,
+				Description:        section.Description,
+				LinkingExceptions:  section.LinkingExceptions,
+				RuntimeExceptions:  section.RuntimeExceptions,
+				Notes:              section.Notes,
+				Source:             "jvms",
+				SpecURL:            s.specURLFor(form.Mnemonic, section.Anchor),
+				AnchorID:           schema.JVMAnchorID(form.Mnemonic),
+			})
+		}
+	}
+
+	return jvmInstructions
+}
+
+func (s *Scraper) specURLFor(mnemonic, anchor string) string {
+	if anchor == "" {
+		anchor = s.specAnchor(mnemonic)
+	}
+	return s.sourceURL + "#" + anchor
+}
+
+func (s *Scraper) specAnchor(mnemonic string) string {
+	for _, group := range specAnchorGroups {
+		if group.pattern.MatchString(mnemonic) {
+			return "jvms-6.5." + group.pattern.ReplaceAllString(mnemonic, group.anchor)
+		}
+	}
+	return "jvms-6.5." + mnemonic
+}
+
+func (s *Scraper) scrapeSpec() ([]schema.JVMInstruction, error) {
+	doc, err := s.fetchSpecPage()
+	if err != nil {
+		return nil, err
+	}
+
+	sections := s.parseSpecSections(doc)
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("no instruction sections found in specification")
+	}
+	s.logger.Info("Parsed specification sections", "count", len(sections))
+
+	return s.convertSpecToJVMFormat(sections), nil
+}
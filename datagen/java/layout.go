@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"arisa/schema"
+)
+
+const wideOpcode = 0xc4
+
+var operandBytePattern = regexp.MustCompile(`^(\w*?)byte(\d)$`)
+
+// wideModifiable lists the instructions that may follow a wide prefix, in
+// JVMS order. iinc is the only one that also widens a second operand.
+var wideModifiable = []string{
+	"iload", "fload", "aload", "lload", "dload",
+	"istore", "fstore", "astore", "lstore", "dstore",
+	"ret", "iinc",
+}
+
+var signedOperands = map[string]bool{
+	"branch": true,
+	"value":  true,
+	"const":  true,
+}
+
+var switchPadding = schema.JVMOperand{
+	Name:        "padding",
+	Type:        "padding",
+	Description: "0-3 zero bytes so that default starts at a multiple of 4 bytes from the start of the code array",
+}
+
+var switchLayouts = map[string][]schema.JVMOperand{
+	"tableswitch": {
+		switchPadding,
+		{Name: "default", Type: "s4", Size: 4, Description: "Offset taken when index is out of range"},
+		{Name: "low", Type: "s4", Size: 4, Description: "Lowest index in the table"},
+		{Name: "high", Type: "s4", Size: 4, Description: "Highest index in the table"},
+		{Name: "offsets", Type: "table", Count: "high - low + 1", Description: "Jump offsets indexed by index - low",
+			Fields: []schema.JVMOperand{{Name: "offset", Type: "s4", Size: 4}}},
+	},
+	"lookupswitch": {
+		switchPadding,
+		{Name: "default", Type: "s4", Size: 4, Description: "Offset taken when no key matches"},
+		{Name: "npairs", Type: "s4", Size: 4, Description: "Number of match-offset pairs"},
+		{Name: "pairs", Type: "table", Count: "npairs", Description: "Match-offset pairs sorted by match",
+			Fields: []schema.JVMOperand{
+				{Name: "match", Type: "s4", Size: 4},
+				{Name: "offset", Type: "s4", Size: 4},
+			}},
+	},
+	"wide": {
+		{Name: "opcode", Type: "u1", Size: 1, Description: "Opcode of the modified instruction"},
+		{Name: "index", Type: "u2", Size: 2, Description: "Unsigned 16-bit local variable index"},
+		{Name: "const", Type: "s2", Size: 2, Description: "Signed 16-bit increment, present only when opcode is iinc"},
+	},
+}
+
+func (s *Scraper) buildOperandLayout(inst *schema.JVMInstruction) {
+	if layout, ok := switchLayouts[inst.Mnemonic]; ok {
+		inst.OperandLayout = layout
+		inst.VariableLength = true
+		inst.Operands = nil
+		for _, operand := range layout {
+			inst.Operands = append(inst.Operands, operand.Name)
+		}
+		inst.Format = strings.Join(append([]string{inst.Mnemonic}, inst.Operands...), " ")
+		return
+	}
+
+	var layout []schema.JVMOperand
+	for _, name := range inst.Operands {
+		base, size := name, 1
+		if match := operandBytePattern.FindStringSubmatch(name); match != nil {
+			base = match[1]
+			if base == "" {
+				base = "value"
+			}
+			if match[2] != "1" && len(layout) > 0 && layout[len(layout)-1].Name == base {
+				layout[len(layout)-1].Size++
+				continue
+			}
+		} else if name == "byte" {
+			base = "value"
+		}
+
+		layout = append(layout, schema.JVMOperand{Name: base, Size: size})
+	}
+
+	length := 1
+	for i := range layout {
+		prefix := "u"
+		if signedOperands[layout[i].Name] {
+			prefix = "s"
+		}
+		layout[i].Type = prefix + string(rune('0'+layout[i].Size))
+		length += layout[i].Size
+	}
+
+	inst.OperandLayout = layout
+	inst.Length = length
+}
+
+func (s *Scraper) expandWideForms(instructions []schema.JVMInstruction) []schema.JVMInstruction {
+	byMnemonic := make(map[string]schema.JVMInstruction, len(instructions))
+	for _, inst := range instructions {
+		byMnemonic[inst.Mnemonic] = inst
+	}
+
+	wideLayout := switchLayouts["wide"]
+
+	for _, name := range wideModifiable {
+		base, ok := byMnemonic[name]
+		if !ok {
+			s.logger.Warn("Missing base instruction for wide form", "mnemonic", name)
+			continue
+		}
+
+		layout := wideLayout[:2]
+		if name == "iinc" {
+			layout = wideLayout
+		}
+
+		mnemonic := "wide " + name
+		inst := schema.JVMInstruction{
+			Mnemonic:           mnemonic,
+			Opcode:             schema.JVMOpcodeString(mnemonic, wideOpcode),
+			OpcodeByte:         wideOpcode,
+			Operation:          s.wideOperation(name),
+			OperandLayout:      layout,
+			Modifies:           name,
+			OperandStackBefore: base.OperandStackBefore,
+			OperandStackAfter:  base.OperandStackAfter,
+			Description:        s.wideDescription(name),
+			LinkingExceptions:  base.LinkingExceptions,
+			RuntimeExceptions:  base.RuntimeExceptions,
+			Notes:              base.Notes,
+			Source:             base.Source,
+			SpecURL:            s.specURLFor(mnemonic, ""),
+			AnchorID:           schema.JVMAnchorID(mnemonic),
+		}
+
+		inst.Length = 2
+		for _, operand := range layout[1:] {
+			inst.Operands = append(inst.Operands, operand.Name)
+			inst.Length += operand.Size
+		}
+		inst.Format = strings.Join(append([]string{mnemonic}, inst.Operands...), " ")
+
+		instructions = append(instructions, inst)
+	}
+
+	return instructions
+}
+
+// wideOperation and wideDescription describe what wide changes about the
+// instruction it modifies: a 16-bit local variable index, and for iinc a
+// 16-bit increment as well. The rest of its behavior is documented on the
+// modified instruction's own record.
+func (s *Scraper) wideOperation(name string) string {
+	if name == "iinc" {
+		return "Extend iinc's local variable index and increment to 16 bits"
+	}
+	return fmt.Sprintf("Extend %s's local variable index to 16 bits", name)
+}
+
+func (s *Scraper) wideDescription(name string) string {
+	const index = "an unsigned 16-bit local variable index, (indexbyte1 << 8) | indexbyte2"
+	if name == "iinc" {
+		return "Executes iinc with " + index + ", and a signed 16-bit increment, (constbyte1 << 8) | constbyte2, " +
+			"in place of its 8-bit index and increment. The local variable at index is incremented by the sign-extended const; " +
+			"otherwise it behaves like iinc."
+	}
+	return fmt.Sprintf("Executes %s with %s, in place of its 8-bit index, so it can reach any local variable "+
+		"up to 65535; otherwise it behaves like %s.", name, index, name)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"arisa/manpage"
+)
+
+const (
+	defaultManDir = "man"
+	manSection    = "7jvm"
+	manManual     = "JVM Instructions"
+)
+
+// ManPages writes a man page per instruction, laid out for a MANPATH
+// directory, so after copying the output into /usr/local/share/man (or
+// adding it to MANPATH) "man 7jvm invokevirtual" works.
+func (s *Scraper) ManPages(args []string) error {
+	flags := flag.NewFlagSet("man", flag.ContinueOnError)
+	outputDir := flags.String("out", defaultManDir, "man directory to write the pages under")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("usage: man [--out dir] [file.json]")
+	}
+	filename := s.outputFilename
+	if flags.NArg() == 1 {
+		filename = flags.Arg(0)
+	}
+
+	instructions, err := s.docsPages(filename)
+	if err != nil {
+		return err
+	}
+
+	for _, inst := range instructions {
+		target := filepath.Join(*outputDir, manpage.Path(inst.Mnemonic, manSection))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+		}
+		content := manpage.Render(s.docsetPage(inst), []string{inst.Mnemonic}, inst.Operation, manSection, manManual)
+		if err := ioutil.WriteFile(target, content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", target, err)
+		}
+	}
+
+	s.logger.Info("Man pages saved", "dir", *outputDir, "pages", len(instructions))
+	return nil
+}
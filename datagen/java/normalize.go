@@ -0,0 +1,20 @@
+package main
+
+import (
+	"arisa/schema"
+	"arisa/textnorm"
+)
+
+// normalizeInstruction applies textnorm.Text to every string reachable from
+// the instruction, the same cleanup the x86 scraper gives its pages. Map
+// keys that normalize to the same string keep their scraped spelling and
+// are reported instead of merged.
+func (s *Scraper) normalizeInstruction(inst *schema.JVMInstruction) {
+	for _, collision := range textnorm.Value(inst) {
+		s.logger.Warn("Keys collide after normalization, keeping them as scraped",
+			"mnemonic", inst.Mnemonic,
+			"field", collision.Path,
+			"key", collision.Key,
+			"keys", collision.Keys)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// backupPath is where the previous dataset is kept while a new one is
+// written over it.
+func (s *Scraper) backupPath() string {
+	return s.outputFilename + ".bak"
+}
+
+// writeDataset replaces the dataset with content, first copying the current
+// file to backupPath. Both writes are atomic, so a crash or full disk leaves
+// either the old or the new dataset in place, never a truncated one.
+func (s *Scraper) writeDataset(content []byte) error {
+	previous, err := os.ReadFile(s.outputFilename)
+	switch {
+	case err == nil && !json.Valid(previous):
+		// Keep the last good backup rather than replacing it with a
+		// dataset that can't be read.
+		s.logger.Warn("Previous data is corrupt, not backing it up", "file", s.outputFilename)
+	case err == nil:
+		if err := s.writeFileAtomic(s.backupPath(), previous); err != nil {
+			return fmt.Errorf("failed to back up previous data: %w", err)
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("failed to read previous data for backup: %w", err)
+	}
+
+	return s.writeFileAtomic(s.outputFilename, content)
+}
+
+// writeFileAtomic writes through a temporary file so an interrupted save
+// never leaves a truncated file behind.
+func (s *Scraper) writeFileAtomic(target string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(target))
+}
+
+// syncDir flushes a directory, so a file renamed into it survives a crash
+// along with its contents.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open directory: %w", err)
+	}
+	defer dir.Close()
+	if err := dir.Sync(); err != nil {
+		return fmt.Errorf("failed to sync directory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"arisa/schema"
+	"arisa/schemapb"
+	"google.golang.org/protobuf/proto"
+)
+
+// saveProtobuf writes the instructions as a binary schemapb.JVMDataset
+// alongside the JSON.
+func (s *Scraper) saveProtobuf(instructions []schema.JVMInstruction) error {
+	dataset := &schemapb.JVMDataset{Instructions: make([]*schemapb.JVMInstruction, 0, len(instructions))}
+	for _, instruction := range instructions {
+		converted := &schemapb.JVMInstruction{
+			Mnemonic:           instruction.Mnemonic,
+			Opcode:             instruction.Opcode,
+			OpcodeByte:         uint32(instruction.OpcodeByte),
+			Operation:          instruction.Operation,
+			Format:             instruction.Format,
+			Operands:           instruction.Operands,
+			OperandLayout:      protoOperands(instruction.OperandLayout),
+			Length:             int32(instruction.Length),
+			VariableLength:     instruction.VariableLength,
+			Modifies:           instruction.Modifies,
+			Reserved:           instruction.Reserved,
+			OperandStackBefore: instruction.OperandStackBefore,
+			OperandStackAfter:  instruction.OperandStackAfter,
+			StackBefore:        protoStackEntries(instruction.StackBefore),
+			StackAfter:         protoStackEntries(instruction.StackAfter),
+			Description:        instruction.Description,
+			LinkingExceptions:  instruction.LinkingExceptions,
+			RuntimeExceptions:  instruction.RuntimeExceptions,
+			Notes:              instruction.Notes,
+			Source:             instruction.Source,
+			SpecUrl:            instruction.SpecURL,
+			AnchorId:           instruction.AnchorID,
+		}
+		if instruction.StackDelta != nil {
+			converted.StackDelta = proto.Int32(int32(*instruction.StackDelta))
+		}
+		dataset.Instructions = append(dataset.Instructions, converted)
+	}
+
+	encoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(dataset)
+	if err != nil {
+		return fmt.Errorf("failed to encode protobuf: %w", err)
+	}
+
+	if err := ioutil.WriteFile(s.protobufFilename, encoded, 0644); err != nil {
+		return fmt.Errorf("failed to write protobuf to file: %w", err)
+	}
+
+	s.logger.Info("Saved protobuf dataset", "file", s.protobufFilename, "bytes", len(encoded))
+	return nil
+}
+
+func protoOperands(operands []schema.JVMOperand) []*schemapb.JVMOperand {
+	var converted []*schemapb.JVMOperand
+	for _, operand := range operands {
+		converted = append(converted, &schemapb.JVMOperand{
+			Name:        operand.Name,
+			Type:        operand.Type,
+			Size:        int32(operand.Size),
+			Count:       operand.Count,
+			Description: operand.Description,
+			Fields:      protoOperands(operand.Fields),
+		})
+	}
+	return converted
+}
+
+func protoStackEntries(entries []schema.JVMStackEntry) []*schemapb.JVMStackEntry {
+	var converted []*schemapb.JVMStackEntry
+	for _, entry := range entries {
+		converted = append(converted, &schemapb.JVMStackEntry{
+			Name:     entry.Name,
+			Type:     entry.Type,
+			Category: int32(entry.Category),
+			Width:    int32(entry.Width),
+			Variadic: entry.Variadic,
+		})
+	}
+	return converted
+}
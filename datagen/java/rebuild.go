@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"arisa/dataset"
+	"arisa/schema"
+)
+
+// legacyCountPattern matches the byte count the first Wikipedia scraper left
+// at the front of an operand list, as in "goto 2 branchbyte1, branchbyte2".
+var legacyCountPattern = regexp.MustCompile(`^(\d+(?:/\d+)?\+?)\s+`)
+
+// Rebuild re-runs the passes that follow scraping over the saved dataset
+// and writes it back, along with the files derived from it, without
+// fetching anything.
+func (s *Scraper) Rebuild(args []string) error {
+	flags := flag.NewFlagSet("rebuild", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("usage: rebuild")
+	}
+
+	content, err := dataset.ReadFile(s.outputFilename)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", s.outputFilename, err)
+	}
+	records, _, err := dataset.Unwrap(content)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", s.outputFilename, err)
+	}
+	var saved []schema.JVMInstruction
+	if err := json.Unmarshal(records, &saved); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", s.outputFilename, err)
+	}
+	if len(saved) == 0 {
+		return fmt.Errorf("no dataset to rebuild at %s", s.outputFilename)
+	}
+
+	if s.fromWikipedia(saved) {
+		s.logger.Warn("Dataset was scraped from Wikipedia, not the specification; re-run the scraper to replace it", "file", s.outputFilename)
+	}
+
+	instructions := s.finishInstructions(s.scrapedInstructions(saved))
+	if err := s.saveData(instructions); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+	return s.saveDerivedFiles(instructions)
+}
+
+// scrapedInstructions returns the saved instructions as the scrape produced
+// them, before the wide forms were added. Datasets written before source
+// was recorded all came from Wikipedia's list, in a layout of their own, so
+// their records are turned back into its table rows and converted again.
+func (s *Scraper) scrapedInstructions(saved []schema.JVMInstruction) []schema.JVMInstruction {
+	legacy := true
+	for _, inst := range saved {
+		if inst.Source != "" {
+			legacy = false
+			break
+		}
+	}
+
+	if !legacy {
+		var instructions []schema.JVMInstruction
+		for _, inst := range saved {
+			if inst.Modifies == "" {
+				instructions = append(instructions, inst)
+			}
+		}
+		return instructions
+	}
+
+	rows := make([]InstructionData, 0, len(saved))
+	for _, inst := range saved {
+		row := InstructionData{
+			Mnemonic:    strings.TrimRight(inst.Mnemonic, "†"),
+			OtherBytes:  strings.TrimSpace(strings.TrimPrefix(inst.Format, inst.Mnemonic)),
+			Stack:       s.legacyStack(inst.OperandStackBefore, inst.OperandStackAfter),
+			Description: inst.Description,
+		}
+		if match := formPattern.FindStringSubmatch(inst.Opcode); match != nil {
+			row.OpcodeHex = match[3]
+		}
+		if count := legacyCountPattern.FindStringSubmatch(row.OtherBytes); count != nil {
+			row.OtherBytes = count[1] + ": " + row.OtherBytes[len(count[0]):]
+		}
+		rows = append(rows, row)
+	}
+	return s.convertToJVMFormat(rows)
+}
+
+// fromWikipedia reports whether a saved dataset came from Wikipedia's list
+// rather than the specification, which datasets written before source was
+// recorded all did.
+func (s *Scraper) fromWikipedia(saved []schema.JVMInstruction) bool {
+	for _, inst := range saved {
+		if inst.Source != "" && inst.Source != "wikipedia" {
+			return false
+		}
+	}
+	return true
+}
+
+// legacyStack undoes parseStack, which filled in "..." and "[empty]" for
+// an empty side and "No change" for both.
+func (s *Scraper) legacyStack(before, after string) string {
+	if before == "No change" && after == "No change" {
+		return "[no change]"
+	}
+	if before == "..." {
+		before = ""
+	}
+	if after == "[empty]" {
+		after = ""
+	}
+	return strings.TrimSpace(before + " → " + after)
+}
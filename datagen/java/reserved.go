@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"arisa/schema"
+)
+
+const opcodeRangesFilename = "jvm_opcode_ranges.json"
+
+// reservedOpcodes are defined by JVMS §6.2 but must never appear in a
+// class file, so Chapter 6 has no per-instruction section for them.
+var reservedOpcodes = []struct {
+	mnemonic    string
+	opcode      uint8
+	description string
+}{
+	{"breakpoint", 0xca, "Reserved for use by debuggers to implement breakpoints"},
+	{"impdep1", 0xfe, "Reserved for implementation-dependent operations within debuggers and backdoors"},
+	{"impdep2", 0xff, "Reserved for implementation-dependent operations within debuggers and backdoors"},
+}
+
+func (s *Scraper) addReservedOpcodes(instructions []schema.JVMInstruction) []schema.JVMInstruction {
+	index := make(map[string]int, len(instructions))
+	for i, inst := range instructions {
+		index[inst.Mnemonic] = i
+	}
+
+	for _, def := range reservedOpcodes {
+		if i, ok := index[def.mnemonic]; ok {
+			instructions[i].Reserved = true
+			instructions[i].SpecURL = s.sourceURL + "#jvms-6.2"
+			continue
+		}
+
+		instructions = append(instructions, schema.JVMInstruction{
+			Mnemonic:           def.mnemonic,
+			Opcode:             schema.JVMOpcodeString(def.mnemonic, def.opcode),
+			OpcodeByte:         def.opcode,
+			Operation:          def.description,
+			Format:             def.mnemonic,
+			OperandStackBefore: "No change",
+			OperandStackAfter:  "No change",
+			Description:        def.description,
+			Reserved:           true,
+			Source:             "jvms",
+			SpecURL:            s.sourceURL + "#jvms-6.2",
+			AnchorID:           schema.JVMAnchorID(def.mnemonic),
+		})
+	}
+
+	return instructions
+}
+
+func (s *Scraper) buildOpcodeRanges(instructions []schema.JVMInstruction) []schema.JVMOpcodeRange {
+	var assigned [256]bool
+	for _, inst := range instructions {
+		if inst.Opcode != "" {
+			assigned[inst.OpcodeByte] = true
+		}
+	}
+
+	var ranges []schema.JVMOpcodeRange
+	for op := 0; op < len(assigned); op++ {
+		if assigned[op] {
+			continue
+		}
+
+		end := op
+		for end+1 < len(assigned) && !assigned[end+1] {
+			end++
+		}
+
+		ranges = append(ranges, schema.JVMOpcodeRange{
+			Start:  uint8(op),
+			End:    uint8(end),
+			Status: "unassigned",
+		})
+		op = end
+	}
+
+	return ranges
+}
+
+func (s *Scraper) saveOpcodeRanges(instructions []schema.JVMInstruction) error {
+	ranges := s.buildOpcodeRanges(instructions)
+	s.logger.Info("Saving unassigned opcode ranges", "count", len(ranges))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(ranges); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(opcodeRangesFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("Opcode ranges saved successfully", "file", opcodeRangesFilename)
+	return nil
+}
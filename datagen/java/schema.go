@@ -0,0 +1,116 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"arisa/dataset"
+	"arisa/schema"
+)
+
+const (
+	schemaFilename = "jvm_instructions.schema.json"
+	schemaID       = "https://raw.githubusercontent.com/aprlfm/Arisa/main/datagen/java/" + schemaFilename
+
+	// schemaVersion goes in every dataset's envelope. Bump it when a
+	// change to schema.JVMInstruction would break existing consumers.
+	schemaVersion = 1
+)
+
+// datasetSchema generates the JSON Schema for jvm_instructions.json from
+// schema.JVMInstruction, so it can't drift from what saveData writes.
+func (s *Scraper) datasetSchema() ([]byte, error) {
+	return dataset.Schema(schema.JVMInstruction{}, schemaID, "JVM instructions")
+}
+
+// datasetDocument is what saveData encodes: the records in their metadata
+// envelope, or on their own with --legacy-array.
+func (s *Scraper) datasetDocument(instructions []schema.JVMInstruction) any {
+	if s.legacyArray {
+		return instructions
+	}
+	return dataset.Envelope{
+		Metadata: dataset.StableMetadata(dataset.NewMetadata("jvm-scraper", schemaVersion, s.sources(instructions), len(instructions)), instructions, s.outputFilename),
+		Records:  instructions,
+	}
+}
+
+// sources lists the pages instructions were scraped from: the
+// specification, and Wikipedia when it stood in for it.
+func (s *Scraper) sources(instructions []schema.JVMInstruction) []string {
+	sources := []string{s.sourceURL}
+	for _, inst := range instructions {
+		if inst.Source == "wikipedia" {
+			return append(sources, wikipediaURL)
+		}
+	}
+	return sources
+}
+
+// validateDataset checks an encoded dataset against datasetSchema before it
+// is written.
+func (s *Scraper) validateDataset(content []byte) error {
+	datasetSchema, err := s.datasetSchema()
+	if err != nil {
+		return err
+	}
+	validator, err := dataset.NewValidator(datasetSchema)
+	if err != nil {
+		return err
+	}
+	return validator.Validate(content)
+}
+
+// Schema prints the dataset schema, or writes it to the given file.
+func (s *Scraper) Schema(args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: schema [file]")
+	}
+
+	datasetSchema, err := s.datasetSchema()
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		_, err := os.Stdout.Write(datasetSchema)
+		return err
+	}
+	if err := ioutil.WriteFile(args[0], datasetSchema, 0644); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	s.logger.Info("Schema saved", "file", args[0])
+	return nil
+}
+
+// Validate checks dataset files against the schema, which defaults to the
+// one generated from the structs. With no files it checks the output
+// dataset.
+func (s *Scraper) Validate(args []string) error {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	schemaFile := flags.String("schema", "", "validate against this schema file instead of the built-in one")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{s.outputFilename}
+	}
+
+	var datasetSchema []byte
+	var err error
+	if *schemaFile != "" {
+		datasetSchema, err = os.ReadFile(*schemaFile)
+	} else {
+		datasetSchema, err = s.datasetSchema()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load schema: %w", err)
+	}
+	validator, err := dataset.NewValidator(datasetSchema)
+	if err != nil {
+		return err
+	}
+	return validator.ValidateFiles(os.Stdout, files)
+}
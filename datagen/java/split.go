@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"arisa/dataset"
+	"arisa/schema"
+)
+
+// opcodeGroups are the opcode ranges JVMS Chapter 7 groups its mnemonic
+// table by, each ending at the group's last opcode.
+var opcodeGroups = []struct {
+	last uint8
+	name string
+}{
+	{0x14, "constants"},
+	{0x35, "loads"},
+	{0x56, "stores"},
+	{0x5f, "stack"},
+	{0x84, "math"},
+	{0x93, "conversions"},
+	{0xa6, "comparisons"},
+	{0xb1, "control"},
+	{0xc3, "references"},
+	{0xc9, "extended"},
+	{0xff, "reserved"},
+}
+
+// Split writes a dataset as one file per opcode group, for consumers that
+// only care about a subset.
+func (s *Scraper) Split(args []string) error {
+	flags := flag.NewFlagSet("split", flag.ContinueOnError)
+	outputDir := flags.String("out", "jvm", "directory to write the per-group files and manifest to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("usage: split [--out dir] [file.json]")
+	}
+	filename := s.outputFilename
+	if flags.NArg() == 1 {
+		filename = flags.Arg(0)
+	}
+
+	instructions, err := s.docsPages(filename)
+	if err != nil {
+		return err
+	}
+	return s.saveSplit(instructions, *outputDir, filename)
+}
+
+// saveSplit writes dir/<group>.json for every opcode group, such as
+// loads.json or control.json, plus a manifest naming source as the dataset
+// they came from.
+func (s *Scraper) saveSplit(instructions []schema.JVMInstruction, dir, source string) error {
+	groups := make(map[string][]schema.JVMInstruction)
+	for _, inst := range instructions {
+		if inst.Opcode == "" {
+			continue
+		}
+		group := s.opcodeGroup(s.docsOpcode(inst))
+		groups[group] = append(groups[group], inst)
+	}
+
+	write := func(path string, content []byte) error {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		return ioutil.WriteFile(path, content, 0644)
+	}
+	manifest, err := dataset.WriteSplit(dir, filepath.Base(source), groups, write)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Saved split dataset", "dir", dir, "files", len(manifest.Files))
+	return nil
+}
+
+func (s *Scraper) opcodeGroup(opcode uint8) string {
+	for _, group := range opcodeGroups {
+		if opcode <= group.last {
+			return group.name
+		}
+	}
+	return "reserved"
+}
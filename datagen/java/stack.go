@@ -0,0 +1,173 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	"arisa/schema"
+)
+
+var (
+	stackNamePattern  = regexp.MustCompile(`^-?[\w.]+$`)
+	conversionPattern = regexp.MustCompile(`^([ilfd])2([ilfdbcs])$`)
+	shiftPattern      = regexp.MustCompile(`^([il])(shl|shr|ushr)$`)
+	typedPattern      = regexp.MustCompile(`^([ilfdabcs])(aload|astore|load|store|const|return|add|sub|mul|div|rem|neg|and|or|xor|cmp|cmpl|cmpg|ipush)`)
+)
+
+var typePrefixes = map[byte]string{
+	'i': "int",
+	'l': "long",
+	'f': "float",
+	'd': "double",
+	'a': "reference",
+	'b': "int",
+	'c': "int",
+	's': "int",
+}
+
+var intStackNames = map[string]bool{
+	"index":  true,
+	"count":  true,
+	"length": true,
+	"key":    true,
+}
+
+// category1Only lists untyped stack manipulations whose operands must be
+// category 1 values in every form.
+var category1Only = map[string]bool{
+	"pop":    true,
+	"dup":    true,
+	"dup_x1": true,
+	"swap":   true,
+}
+
+func (s *Scraper) parseStackEntries(mnemonic, stack string, after bool) ([]schema.JVMStackEntry, bool) {
+	var entries []schema.JVMStackEntry
+
+	depth := 0
+	for _, token := range strings.Split(stack, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" || token == "..." || token == "[empty]" {
+			continue
+		}
+
+		variadic := strings.HasPrefix(token, "[")
+		if depth > 0 {
+			depth += strings.Count(token, "[") - strings.Count(token, "]")
+			continue
+		}
+		depth += strings.Count(token, "[") - strings.Count(token, "]")
+
+		name := strings.Trim(token, "[]{} .")
+		if !stackNamePattern.MatchString(name) {
+			return nil, false
+		}
+
+		entry := s.classifyStackEntry(mnemonic, name, after)
+		if variadic {
+			entry.Name = strings.TrimRight(name, "0123456789") + "s"
+			entry.Variadic = true
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, true
+}
+
+func (s *Scraper) classifyStackEntry(mnemonic, name string, after bool) schema.JVMStackEntry {
+	entry := schema.JVMStackEntry{Name: name, Type: s.stackEntryType(mnemonic, name, after)}
+
+	switch entry.Type {
+	case "long", "double":
+		entry.Category = 2
+	case "any":
+		switch {
+		case category1Only[mnemonic], mnemonic == "ldc", mnemonic == "ldc_w":
+			entry.Category = 1
+		case mnemonic == "ldc2_w":
+			entry.Category = 2
+		}
+	default:
+		entry.Category = 1
+	}
+	entry.Width = entry.Category
+
+	return entry
+}
+
+func (s *Scraper) stackEntryType(mnemonic, name string, after bool) string {
+	switch {
+	case strings.Contains(name, "ref") || name == "null":
+		return "reference"
+	case name == "address":
+		return "returnAddress"
+	case intStackNames[name] || strings.HasPrefix(name, "count"):
+		return "int"
+	case name == "result" && (strings.Contains(mnemonic, "cmp") || mnemonic == "instanceof"):
+		return "int"
+	}
+
+	if match := conversionPattern.FindStringSubmatch(mnemonic); match != nil {
+		if after {
+			return typePrefixes[match[2][0]]
+		}
+		return typePrefixes[match[1][0]]
+	}
+
+	if match := shiftPattern.FindStringSubmatch(mnemonic); match != nil {
+		if name == "value2" {
+			return "int"
+		}
+		return typePrefixes[match[1][0]]
+	}
+
+	switch {
+	case mnemonic == "ifnull" || mnemonic == "ifnonnull" || strings.HasPrefix(mnemonic, "if_acmp"):
+		return "reference"
+	case strings.HasPrefix(mnemonic, "if"):
+		return "int"
+	case mnemonic == "tableswitch" || mnemonic == "lookupswitch":
+		return "int"
+	}
+
+	if match := typedPattern.FindStringSubmatch(mnemonic); match != nil {
+		return typePrefixes[match[1][0]]
+	}
+
+	return "any"
+}
+
+func (s *Scraper) buildStackModel(inst *schema.JVMInstruction) {
+	if inst.OperandStackBefore == "No change" && inst.OperandStackAfter == "No change" {
+		delta := 0
+		inst.StackDelta = &delta
+		return
+	}
+
+	before, ok := s.parseStackEntries(inst.Mnemonic, inst.OperandStackBefore, false)
+	if !ok {
+		return
+	}
+	after, ok := s.parseStackEntries(inst.Mnemonic, inst.OperandStackAfter, true)
+	if !ok {
+		return
+	}
+
+	inst.StackBefore = before
+	inst.StackAfter = after
+
+	delta := 0
+	for _, entry := range before {
+		if entry.Width == 0 || entry.Variadic {
+			return
+		}
+		delta -= entry.Width
+	}
+	for _, entry := range after {
+		if entry.Width == 0 || entry.Variadic {
+			return
+		}
+		delta += entry.Width
+	}
+	inst.StackDelta = &delta
+}
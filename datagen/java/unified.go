@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+
+	"arisa/dataset"
+	"arisa/schema"
+)
+
+// defaultUnifiedFilename is where every run writes the dataset in the
+// unified schema, next to the JVM dataset it is converted from.
+const defaultUnifiedFilename = "jvm_unified.json"
+
+// Unified converts a dataset to the ISA-neutral schema.Instruction shape
+// shared with the x86 generator.
+func (s *Scraper) Unified(args []string) error {
+	flags := flag.NewFlagSet("unified", flag.ContinueOnError)
+	output := flags.String("out", defaultUnifiedFilename, "file to write the unified dataset to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("usage: unified [--out file] [file.json]")
+	}
+	filename := s.outputFilename
+	if flags.NArg() == 1 {
+		filename = flags.Arg(0)
+	}
+
+	instructions, err := s.docsPages(filename)
+	if err != nil {
+		return err
+	}
+	return s.saveUnified(instructions, *output)
+}
+
+// saveUnified writes the instructions as schema.Instruction records in a
+// metadata envelope, validated against the unified schema.
+func (s *Scraper) saveUnified(instructions []schema.JVMInstruction, filename string) error {
+	records := make([]schema.Instruction, 0, len(instructions))
+	for _, inst := range instructions {
+		if inst.Opcode == "" {
+			continue
+		}
+		// Older datasets only carry the opcode in the "name = dec (0xhex)"
+		// string.
+		inst.OpcodeByte = s.docsOpcode(inst)
+		records = append(records, inst.Unified("jvm-scraper", s.unifiedCategory(inst)))
+	}
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	document := dataset.Envelope{
+		Metadata: dataset.StableMetadata(dataset.NewMetadata("jvm-scraper", schema.InstructionSchemaVersion, s.sources(instructions), len(records)), records, filename),
+		Records:  records,
+	}
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("failed to encode unified dataset: %w", err)
+	}
+
+	unifiedSchema, err := dataset.Schema(schema.Instruction{}, schema.InstructionSchemaID, "Arisa instructions")
+	if err != nil {
+		return err
+	}
+	validator, err := dataset.NewValidator(unifiedSchema)
+	if err != nil {
+		return err
+	}
+	if err := validator.Validate(buffer.Bytes()); err != nil {
+		return fmt.Errorf("unified dataset does not match its schema: %w", err)
+	}
+
+	if err := s.writeFileAtomic(filename, buffer.Bytes()); err != nil {
+		return fmt.Errorf("failed to write unified dataset: %w", err)
+	}
+	s.logger.Info("Saved unified dataset", "file", filename, "count", len(records))
+	return nil
+}
+
+// unifiedCategory places an instruction in the shared taxonomy: float and
+// double arithmetic and comparisons, and conversions to or from them, are
+// floating-point, the reserved opcodes are other, and everything else is
+// general-purpose.
+func (s *Scraper) unifiedCategory(inst schema.JVMInstruction) schema.Category {
+	switch s.opcodeGroup(inst.OpcodeByte) {
+	case "reserved":
+		return schema.CategoryOther
+	case "math", "conversions", "comparisons":
+		if strings.HasPrefix(inst.Mnemonic, "f") || strings.HasPrefix(inst.Mnemonic, "d") ||
+			strings.HasSuffix(inst.Mnemonic, "2f") || strings.HasSuffix(inst.Mnemonic, "2d") {
+			return schema.CategoryFloatingPoint
+		}
+	}
+	return schema.CategoryGeneralPurpose
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+const verificationFilename = "verification.json"
+
+type VerificationType struct {
+	Name        string `json:"name"`
+	Parent      string `json:"parent,omitempty"`
+	Size        int    `json:"size,omitempty"`
+	Description string `json:"description"`
+}
+
+type VerificationItem struct {
+	Name             string           `json:"name"`
+	Tag              int              `json:"tag"`
+	VerificationType string           `json:"verificationType"`
+	Layout           []ClassFileField `json:"layout,omitempty"`
+}
+
+type StackMapFrameType struct {
+	Name        string           `json:"name"`
+	TagStart    int              `json:"tagStart"`
+	TagEnd      int              `json:"tagEnd"`
+	Layout      []ClassFileField `json:"layout"`
+	Description string           `json:"description"`
+}
+
+type VerificationDataset struct {
+	Types  []VerificationType  `json:"types"`
+	Items  []VerificationItem  `json:"items"`
+	Frames []StackMapFrameType `json:"frames"`
+}
+
+// verificationTypes is the type checker's subtype hierarchy from JVMS
+// §4.10.1.2. Size is the number of local variable or operand stack slots a
+// value occupies and is omitted for abstract supertypes.
+var verificationTypes = []VerificationType{
+	{Name: "top", Description: "Supertype of all verification types; an unusable slot"},
+	{Name: "oneWord", Parent: "top", Size: 1, Description: "Any category 1 value"},
+	{Name: "twoWord", Parent: "top", Size: 2, Description: "Any category 2 value"},
+	{Name: "int", Parent: "oneWord", Size: 1, Description: "int, and boolean, byte, char, short after widening"},
+	{Name: "float", Parent: "oneWord", Size: 1, Description: "float"},
+	{Name: "reference", Parent: "oneWord", Size: 1, Description: "Any reference value"},
+	{Name: "uninitialized", Parent: "reference", Size: 1, Description: "Object created by new whose constructor has not run"},
+	{Name: "uninitializedThis", Parent: "uninitialized", Size: 1, Description: "this inside a constructor before the superclass constructor is called"},
+	{Name: "uninitialized(Offset)", Parent: "uninitialized", Size: 1, Description: "Result of the new instruction at bytecode Offset"},
+	{Name: "class(java/lang/Object)", Parent: "reference", Size: 1, Description: "Root of the class, interface, and array types"},
+	{Name: "arrayOf(Type)", Parent: "class(java/lang/Object)", Size: 1, Description: "Array types, also assignable to Cloneable and java.io.Serializable"},
+	{Name: "null", Parent: "class(java/lang/Object)", Size: 1, Description: "The null reference, assignable to every class, interface, and array type"},
+	{Name: "long", Parent: "twoWord", Size: 2, Description: "long"},
+	{Name: "double", Parent: "twoWord", Size: 2, Description: "double"},
+}
+
+var verificationItems = []VerificationItem{
+	{Name: "ITEM_Top", Tag: 0, VerificationType: "top"},
+	{Name: "ITEM_Integer", Tag: 1, VerificationType: "int"},
+	{Name: "ITEM_Float", Tag: 2, VerificationType: "float"},
+	{Name: "ITEM_Double", Tag: 3, VerificationType: "double"},
+	{Name: "ITEM_Long", Tag: 4, VerificationType: "long"},
+	{Name: "ITEM_Null", Tag: 5, VerificationType: "null"},
+	{Name: "ITEM_UninitializedThis", Tag: 6, VerificationType: "uninitializedThis"},
+	{Name: "ITEM_Object", Tag: 7, VerificationType: "class(java/lang/Object)",
+		Layout: []ClassFileField{field("cpool_index", "u2", "CONSTANT_Class of the class, interface, or array type")}},
+	{Name: "ITEM_Uninitialized", Tag: 8, VerificationType: "uninitialized(Offset)",
+		Layout: []ClassFileField{field("offset", "u2", "Code offset of the new instruction that created the object")}},
+}
+
+var frameTypeField = field("frame_type", "u1", "Frame type tag")
+
+var offsetDeltaField = field("offset_delta", "u2", "Bytecode offset delta from the previous frame")
+
+var stackMapFrameTypes = []StackMapFrameType{
+	{
+		Name: "same_frame", TagStart: 0, TagEnd: 63,
+		Layout:      []ClassFileField{frameTypeField},
+		Description: "Same locals as the previous frame and an empty stack; offset_delta is frame_type",
+	},
+	{
+		Name: "same_locals_1_stack_item_frame", TagStart: 64, TagEnd: 127,
+		Layout: []ClassFileField{
+			frameTypeField,
+			field("stack", "verification_type_info[1]", "The single operand stack entry"),
+		},
+		Description: "Same locals as the previous frame and one stack item; offset_delta is frame_type - 64",
+	},
+	{
+		Name: "reserved", TagStart: 128, TagEnd: 246,
+		Layout:      []ClassFileField{frameTypeField},
+		Description: "Reserved for future use",
+	},
+	{
+		Name: "same_locals_1_stack_item_frame_extended", TagStart: 247, TagEnd: 247,
+		Layout: []ClassFileField{
+			frameTypeField,
+			offsetDeltaField,
+			field("stack", "verification_type_info[1]", "The single operand stack entry"),
+		},
+		Description: "Same locals as the previous frame and one stack item with an explicit offset_delta",
+	},
+	{
+		Name: "chop_frame", TagStart: 248, TagEnd: 250,
+		Layout:      []ClassFileField{frameTypeField, offsetDeltaField},
+		Description: "Previous locals with the last 251 - frame_type locals removed and an empty stack",
+	},
+	{
+		Name: "same_frame_extended", TagStart: 251, TagEnd: 251,
+		Layout:      []ClassFileField{frameTypeField, offsetDeltaField},
+		Description: "Same locals as the previous frame and an empty stack with an explicit offset_delta",
+	},
+	{
+		Name: "append_frame", TagStart: 252, TagEnd: 254,
+		Layout: []ClassFileField{
+			frameTypeField,
+			offsetDeltaField,
+			field("locals", "verification_type_info[frame_type - 251]", "Additional locals"),
+		},
+		Description: "Previous locals plus frame_type - 251 additional locals and an empty stack",
+	},
+	{
+		Name: "full_frame", TagStart: 255, TagEnd: 255,
+		Layout: []ClassFileField{
+			frameTypeField,
+			offsetDeltaField,
+			field("number_of_locals", "u2", "Number of local entries"),
+			field("locals", "verification_type_info[number_of_locals]", "Local variable types"),
+			field("number_of_stack_items", "u2", "Number of stack entries"),
+			field("stack", "verification_type_info[number_of_stack_items]", "Operand stack types"),
+		},
+		Description: "Explicit locals and operand stack",
+	},
+}
+
+func (s *Scraper) saveVerificationTypes() error {
+	dataset := VerificationDataset{
+		Types:  verificationTypes,
+		Items:  verificationItems,
+		Frames: stackMapFrameTypes,
+	}
+	s.logger.Info("Saving verification types", "types", len(dataset.Types), "frames", len(dataset.Frames))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(dataset); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(verificationFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("Verification types saved successfully", "file", verificationFilename)
+	return nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"arisa/config"
+	"arisa/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// saveYAML writes the instructions as YAML for reviewing and diffing by
+// hand. It goes through the JSON encoding of the schema structs, so the
+// keys always match the JSON dataset.
+func (s *Scraper) saveYAML(instructions []schema.JVMInstruction) error {
+	encoded, err := yaml.Marshal(instructions)
+	if err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	target := config.FormatPath(s.outputFilename, "yaml")
+	if err := ioutil.WriteFile(target, encoded, 0644); err != nil {
+		return fmt.Errorf("failed to write YAML to file: %w", err)
+	}
+
+	s.logger.Info("YAML saved successfully", "file", target)
+	return nil
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/charmbracelet/log"
+)
+
+const (
+	sourceURL      = "https://llvm.org/docs/LangRef.html"
+	outputFilename = "llvm_ir.json"
+	requestTimeout = 30 * time.Second
+)
+
+type LLVMInstruction struct {
+	Mnemonic  string   `json:"mnemonic"`
+	Category  string   `json:"category"`
+	Syntax    []string `json:"syntax"`
+	Overview  string   `json:"overview"`
+	Arguments string   `json:"arguments"`
+	Semantics string   `json:"semantics"`
+	Examples  []string `json:"examples"`
+	URL       string   `json:"url"`
+	AnchorID  string   `json:"anchorId"`
+}
+
+type Scraper struct {
+	client *http.Client
+	logger *log.Logger
+}
+
+func NewScraper() *Scraper {
+	logger := log.NewWithOptions(os.Stderr, log.Options{
+		ReportCaller:    false,
+		ReportTimestamp: true,
+		TimeFormat:      time.Kitchen,
+		Prefix:          "llvm-scraper",
+	})
+
+	client := &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: false},
+			DisableKeepAlives: false,
+			MaxIdleConns:      10,
+			IdleConnTimeout:   90 * time.Second,
+		},
+	}
+
+	return &Scraper{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (s *Scraper) cleanText(text string) string {
+	text = regexp.MustCompile(`\s+`).ReplaceAllString(text, " ")
+	return strings.TrimSpace(text)
+}
+
+func (s *Scraper) fetchPage() (*goquery.Document, error) {
+	s.logger.Info("Fetching LangRef")
+
+	req, err := http.NewRequest("GET", sourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "llvm-scraper/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	return doc, nil
+}
+
+func (s *Scraper) parseInstructions(doc *goquery.Document) []LLVMInstruction {
+	var instructions []LLVMInstruction
+
+	doc.Find("section[id$='-instruction']").Each(func(_ int, section *goquery.Selection) {
+		heading := section.ChildrenFiltered("h4, h5").First()
+		mnemonic := s.cleanText(heading.Find("code").First().Text())
+		if mnemonic == "" {
+			return
+		}
+
+		id, _ := section.Attr("id")
+		instruction := LLVMInstruction{
+			Mnemonic: mnemonic,
+			Category: s.parseCategory(section),
+			URL:      sourceURL + "#" + id,
+			AnchorID: "llvm-" + strings.ReplaceAll(mnemonic, ".", "-"),
+		}
+
+		section.ChildrenFiltered("section").Each(func(_ int, subsection *goquery.Selection) {
+			title := strings.TrimSuffix(s.cleanText(subsection.ChildrenFiltered("h5, h6").First().Text()), ":")
+
+			switch strings.ToLower(title) {
+			case "syntax":
+				subsection.Find("pre").Each(func(_ int, pre *goquery.Selection) {
+					instruction.Syntax = append(instruction.Syntax, s.splitCodeLines(pre.Text())...)
+				})
+			case "overview":
+				instruction.Overview = s.collectParagraphs(subsection)
+			case "arguments":
+				instruction.Arguments = s.collectParagraphs(subsection)
+			case "semantics":
+				instruction.Semantics = s.collectParagraphs(subsection)
+			case "example", "examples":
+				subsection.Find("pre").Each(func(_ int, pre *goquery.Selection) {
+					instruction.Examples = append(instruction.Examples, s.splitCodeLines(pre.Text())...)
+				})
+			}
+		})
+
+		instructions = append(instructions, instruction)
+	})
+
+	return instructions
+}
+
+func (s *Scraper) parseCategory(section *goquery.Selection) string {
+	parent := section.ParentsFiltered("section").First()
+	return strings.TrimSuffix(s.cleanText(parent.ChildrenFiltered("h3, h4").First().Text()), "¶")
+}
+
+func (s *Scraper) collectParagraphs(section *goquery.Selection) string {
+	var content []string
+	section.ChildrenFiltered("p, ul, ol, div").Each(func(_ int, node *goquery.Selection) {
+		text := s.cleanText(node.Text())
+		if text != "" {
+			content = append(content, text)
+		}
+	})
+	return strings.Join(content, "\n")
+}
+
+func (s *Scraper) splitCodeLines(code string) []string {
+	var lines []string
+	for _, line := range strings.Split(code, "\n") {
+		line = strings.TrimRight(line, " \t")
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func (s *Scraper) saveData(instructions []LLVMInstruction) error {
+	s.logger.Info("Saving instruction data", "count", len(instructions))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(instructions); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(outputFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("Data saved successfully", "file", outputFilename)
+	return nil
+}
+
+func (s *Scraper) Run() error {
+	s.logger.Info("Starting LLVM IR instruction scraper")
+
+	doc, err := s.fetchPage()
+	if err != nil {
+		return fmt.Errorf("failed to scrape instructions: %w", err)
+	}
+
+	instructions := s.parseInstructions(doc)
+	s.logger.Info("Parsed instructions", "count", len(instructions))
+
+	if err := s.saveData(instructions); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+
+	s.logger.Info("Scraping completed successfully")
+	return nil
+}
+
+func main() {
+	scraper := NewScraper()
+	if err := scraper.Run(); err != nil {
+		scraper.logger.Fatal("Scraper failed", "error", err)
+	}
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const (
+	sourceURL      = "https://sourceware.org/git/?p=binutils-gdb.git;a=blob_plain;f=opcodes/loongarch-opc.c;hb=HEAD"
+	outputFilename = "loongarch.json"
+	requestTimeout = 30 * time.Second
+)
+
+type BitField struct {
+	Low   int `json:"low"`
+	Width int `json:"width"`
+}
+
+type LoongArchOperand struct {
+	Kind   string     `json:"kind"`
+	Fields []BitField `json:"fields"`
+	Shift  int        `json:"shift,omitempty"`
+}
+
+type LoongArchInstruction struct {
+	Mnemonic  string             `json:"mnemonic"`
+	Match     string             `json:"match"`
+	Mask      string             `json:"mask"`
+	Format    string             `json:"format"`
+	Operands  []LoongArchOperand `json:"operands"`
+	Extension string             `json:"extension"`
+	AnchorID  string             `json:"anchorId"`
+}
+
+var tableExtensions = map[string]string{
+	"loongarch_macro_opcodes":            "",
+	"loongarch_alias_opcodes":            "",
+	"loongarch_fix_opcodes":              "base",
+	"loongarch_single_float_opcodes":     "f",
+	"loongarch_double_float_opcodes":     "d",
+	"loongarch_imm_opcodes":              "base",
+	"loongarch_privilege_opcodes":        "privileged",
+	"loongarch_float_load_store_opcodes": "f",
+	"loongarch_load_store_opcodes":       "base",
+	"loongarch_jmp_opcodes":              "base",
+	"loongarch_lsx_opcodes":              "lsx",
+	"loongarch_lasx_opcodes":             "lasx",
+	"loongarch_lvz_opcodes":              "lvz",
+	"loongarch_lbt_opcodes":              "lbt",
+}
+
+var operandKinds = map[string]string{
+	"r": "gpr",
+	"f": "fpr",
+	"c": "fcc",
+	"v": "vr",
+	"x": "xr",
+	"u": "uimm",
+	"s": "simm",
+}
+
+var (
+	opcodeTablePattern = regexp.MustCompile(`struct loongarch_opcode (\w+)\[\] =`)
+	opcodeEntryPattern = regexp.MustCompile(`\{\s*(0x[0-9a-fA-F]+),\s*(0x[0-9a-fA-F]+),\s*"([^"]+)",\s*"([^"]*)"`)
+	operandPattern     = regexp.MustCompile(`^([a-z]+)([0-9:|]+)(?:<<([0-9]+))?$`)
+)
+
+type Scraper struct {
+	client *http.Client
+	logger *log.Logger
+}
+
+func NewScraper() *Scraper {
+	logger := log.NewWithOptions(os.Stderr, log.Options{
+		ReportCaller:    false,
+		ReportTimestamp: true,
+		TimeFormat:      time.Kitchen,
+		Prefix:          "loongarch-scraper",
+	})
+
+	client := &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: false},
+			DisableKeepAlives: false,
+			MaxIdleConns:      10,
+			IdleConnTimeout:   90 * time.Second,
+		},
+	}
+
+	return &Scraper{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (s *Scraper) fetchOpcodeSource() (string, error) {
+	s.logger.Info("Fetching LoongArch opcode table")
+
+	req, err := http.NewRequest("GET", sourceURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "loongarch-scraper/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read body: %w", err)
+	}
+
+	return string(body), nil
+}
+
+func (s *Scraper) parseInstructions(source string) []LoongArchInstruction {
+	var instructions []LoongArchInstruction
+
+	tables := opcodeTablePattern.FindAllStringSubmatchIndex(source, -1)
+	for i, table := range tables {
+		end := len(source)
+		if i+1 < len(tables) {
+			end = tables[i+1][0]
+		}
+
+		name := source[table[2]:table[3]]
+		extension, known := tableExtensions[name]
+		if !known {
+			extension = strings.TrimSuffix(strings.TrimPrefix(name, "loongarch_"), "_opcodes")
+		}
+		if extension == "" {
+			continue
+		}
+
+		for _, entry := range opcodeEntryPattern.FindAllStringSubmatch(source[table[1]:end], -1) {
+			match, err := strconv.ParseUint(entry[1], 0, 32)
+			if err != nil || match == 0 {
+				continue
+			}
+			mask, err := strconv.ParseUint(entry[2], 0, 32)
+			if err != nil {
+				continue
+			}
+
+			instructions = append(instructions, LoongArchInstruction{
+				Mnemonic:  entry[3],
+				Match:     fmt.Sprintf("0x%08x", match),
+				Mask:      fmt.Sprintf("0x%08x", mask),
+				Format:    entry[4],
+				Operands:  s.parseOperands(entry[4]),
+				Extension: extension,
+				AnchorID:  "loongarch-" + strings.ReplaceAll(entry[3], ".", "-"),
+			})
+		}
+	}
+
+	sort.SliceStable(instructions, func(i, j int) bool {
+		if instructions[i].Mnemonic != instructions[j].Mnemonic {
+			return instructions[i].Mnemonic < instructions[j].Mnemonic
+		}
+		return instructions[i].Match < instructions[j].Match
+	})
+
+	return instructions
+}
+
+func (s *Scraper) parseOperands(format string) []LoongArchOperand {
+	operands := []LoongArchOperand{}
+	if format == "" {
+		return operands
+	}
+
+	for _, spec := range strings.Split(format, ",") {
+		match := operandPattern.FindStringSubmatch(strings.TrimSpace(spec))
+		if match == nil {
+			s.logger.Debug("Skipping unrecognized operand", "operand", spec)
+			continue
+		}
+
+		kind, ok := operandKinds[match[1][:1]]
+		if !ok {
+			kind = match[1]
+		}
+
+		operand := LoongArchOperand{Kind: kind}
+		for _, field := range strings.Split(match[2], "|") {
+			parts := strings.SplitN(field, ":", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			low, errLow := strconv.Atoi(parts[0])
+			width, errWidth := strconv.Atoi(parts[1])
+			if errLow != nil || errWidth != nil {
+				continue
+			}
+			operand.Fields = append(operand.Fields, BitField{Low: low, Width: width})
+		}
+		if match[3] != "" {
+			operand.Shift, _ = strconv.Atoi(match[3])
+		}
+
+		operands = append(operands, operand)
+	}
+
+	return operands
+}
+
+func (s *Scraper) saveData(instructions []LoongArchInstruction) error {
+	s.logger.Info("Saving instruction data", "count", len(instructions))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(instructions); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(outputFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("Data saved successfully", "file", outputFilename)
+	return nil
+}
+
+func (s *Scraper) Run() error {
+	s.logger.Info("Starting LoongArch instruction scraper")
+
+	source, err := s.fetchOpcodeSource()
+	if err != nil {
+		return fmt.Errorf("failed to fetch opcode source: %w", err)
+	}
+
+	instructions := s.parseInstructions(source)
+
+	if err := s.saveData(instructions); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+
+	s.logger.Info("Scraping completed successfully")
+	return nil
+}
+
+func main() {
+	scraper := NewScraper()
+	if err := scraper.Run(); err != nil {
+		scraper.logger.Fatal("Scraper failed", "error", err)
+	}
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const outputFilename = "8051.json"
+
+type MCS51Instruction struct {
+	Opcode      string   `json:"opcode"`
+	Mnemonic    string   `json:"mnemonic"`
+	Syntax      string   `json:"syntax"`
+	Operands    []string `json:"operands"`
+	Bytes       int      `json:"bytes"`
+	Cycles      int      `json:"cycles"`
+	Flags       []string `json:"flags"`
+	Description string   `json:"description"`
+	AnchorID    string   `json:"anchorId"`
+}
+
+type instructionDef struct {
+	opcode      int
+	syntax      string
+	bytes       int
+	cycles      int
+	flags       []string
+	description string
+}
+
+var (
+	carry      = []string{"C"}
+	arithmetic = []string{"C", "OV", "AC"}
+	multiply   = []string{"C", "OV"}
+)
+
+var instructionDefs = []instructionDef{
+	{0x00, "NOP", 1, 1, nil, "No operation."},
+	{0x01, "AJMP addr11", 2, 2, nil, "Absolute jump within the current 2 KiB page."},
+	{0x02, "LJMP addr16", 3, 2, nil, "Long jump."},
+	{0x03, "RR A", 1, 1, nil, "Rotate accumulator right."},
+	{0x04, "INC A", 1, 1, nil, "Increment accumulator."},
+	{0x05, "INC direct", 2, 1, nil, "Increment direct byte."},
+	{0x06, "INC @Ri", 1, 1, nil, "Increment indirect RAM."},
+	{0x08, "INC Rn", 1, 1, nil, "Increment register."},
+	{0x10, "JBC bit, rel", 3, 2, nil, "Jump if bit is set and clear bit."},
+	{0x11, "ACALL addr11", 2, 2, nil, "Absolute call within the current 2 KiB page."},
+	{0x12, "LCALL addr16", 3, 2, nil, "Long call."},
+	{0x13, "RRC A", 1, 1, carry, "Rotate accumulator right through carry."},
+	{0x14, "DEC A", 1, 1, nil, "Decrement accumulator."},
+	{0x15, "DEC direct", 2, 1, nil, "Decrement direct byte."},
+	{0x16, "DEC @Ri", 1, 1, nil, "Decrement indirect RAM."},
+	{0x18, "DEC Rn", 1, 1, nil, "Decrement register."},
+	{0x20, "JB bit, rel", 3, 2, nil, "Jump if direct bit is set."},
+	{0x22, "RET", 1, 2, nil, "Return from subroutine."},
+	{0x23, "RL A", 1, 1, nil, "Rotate accumulator left."},
+	{0x24, "ADD A, #data", 2, 1, arithmetic, "Add immediate data to accumulator."},
+	{0x25, "ADD A, direct", 2, 1, arithmetic, "Add direct byte to accumulator."},
+	{0x26, "ADD A, @Ri", 1, 1, arithmetic, "Add indirect RAM to accumulator."},
+	{0x28, "ADD A, Rn", 1, 1, arithmetic, "Add register to accumulator."},
+	{0x30, "JNB bit, rel", 3, 2, nil, "Jump if direct bit is not set."},
+	{0x32, "RETI", 1, 2, nil, "Return from interrupt."},
+	{0x33, "RLC A", 1, 1, carry, "Rotate accumulator left through carry."},
+	{0x34, "ADDC A, #data", 2, 1, arithmetic, "Add immediate data to accumulator with carry."},
+	{0x35, "ADDC A, direct", 2, 1, arithmetic, "Add direct byte to accumulator with carry."},
+	{0x36, "ADDC A, @Ri", 1, 1, arithmetic, "Add indirect RAM to accumulator with carry."},
+	{0x38, "ADDC A, Rn", 1, 1, arithmetic, "Add register to accumulator with carry."},
+	{0x40, "JC rel", 2, 2, nil, "Jump if carry is set."},
+	{0x42, "ORL direct, A", 2, 1, nil, "OR accumulator to direct byte."},
+	{0x43, "ORL direct, #data", 3, 2, nil, "OR immediate data to direct byte."},
+	{0x44, "ORL A, #data", 2, 1, nil, "OR immediate data to accumulator."},
+	{0x45, "ORL A, direct", 2, 1, nil, "OR direct byte to accumulator."},
+	{0x46, "ORL A, @Ri", 1, 1, nil, "OR indirect RAM to accumulator."},
+	{0x48, "ORL A, Rn", 1, 1, nil, "OR register to accumulator."},
+	{0x50, "JNC rel", 2, 2, nil, "Jump if carry is not set."},
+	{0x52, "ANL direct, A", 2, 1, nil, "AND accumulator to direct byte."},
+	{0x53, "ANL direct, #data", 3, 2, nil, "AND immediate data to direct byte."},
+	{0x54, "ANL A, #data", 2, 1, nil, "AND immediate data to accumulator."},
+	{0x55, "ANL A, direct", 2, 1, nil, "AND direct byte to accumulator."},
+	{0x56, "ANL A, @Ri", 1, 1, nil, "AND indirect RAM to accumulator."},
+	{0x58, "ANL A, Rn", 1, 1, nil, "AND register to accumulator."},
+	{0x60, "JZ rel", 2, 2, nil, "Jump if accumulator is zero."},
+	{0x62, "XRL direct, A", 2, 1, nil, "Exclusive-OR accumulator to direct byte."},
+	{0x63, "XRL direct, #data", 3, 2, nil, "Exclusive-OR immediate data to direct byte."},
+	{0x64, "XRL A, #data", 2, 1, nil, "Exclusive-OR immediate data to accumulator."},
+	{0x65, "XRL A, direct", 2, 1, nil, "Exclusive-OR direct byte to accumulator."},
+	{0x66, "XRL A, @Ri", 1, 1, nil, "Exclusive-OR indirect RAM to accumulator."},
+	{0x68, "XRL A, Rn", 1, 1, nil, "Exclusive-OR register to accumulator."},
+	{0x70, "JNZ rel", 2, 2, nil, "Jump if accumulator is not zero."},
+	{0x72, "ORL C, bit", 2, 2, carry, "OR direct bit to carry."},
+	{0x73, "JMP @A+DPTR", 1, 2, nil, "Jump indirect relative to DPTR."},
+	{0x74, "MOV A, #data", 2, 1, nil, "Move immediate data to accumulator."},
+	{0x75, "MOV direct, #data", 3, 2, nil, "Move immediate data to direct byte."},
+	{0x76, "MOV @Ri, #data", 2, 1, nil, "Move immediate data to indirect RAM."},
+	{0x78, "MOV Rn, #data", 2, 1, nil, "Move immediate data to register."},
+	{0x80, "SJMP rel", 2, 2, nil, "Short jump."},
+	{0x82, "ANL C, bit", 2, 2, carry, "AND direct bit to carry."},
+	{0x83, "MOVC A, @A+PC", 1, 2, nil, "Move code byte relative to PC to accumulator."},
+	{0x84, "DIV AB", 1, 4, multiply, "Divide A by B; C is cleared, OV set on division by zero."},
+	{0x85, "MOV direct, direct", 3, 2, nil, "Move direct byte to direct byte; the source address is encoded first."},
+	{0x86, "MOV direct, @Ri", 2, 2, nil, "Move indirect RAM to direct byte."},
+	{0x88, "MOV direct, Rn", 2, 2, nil, "Move register to direct byte."},
+	{0x90, "MOV DPTR, #data16", 3, 2, nil, "Load data pointer with a 16-bit constant."},
+	{0x92, "MOV bit, C", 2, 2, nil, "Move carry to direct bit."},
+	{0x93, "MOVC A, @A+DPTR", 1, 2, nil, "Move code byte relative to DPTR to accumulator."},
+	{0x94, "SUBB A, #data", 2, 1, arithmetic, "Subtract immediate data from accumulator with borrow."},
+	{0x95, "SUBB A, direct", 2, 1, arithmetic, "Subtract direct byte from accumulator with borrow."},
+	{0x96, "SUBB A, @Ri", 1, 1, arithmetic, "Subtract indirect RAM from accumulator with borrow."},
+	{0x98, "SUBB A, Rn", 1, 1, arithmetic, "Subtract register from accumulator with borrow."},
+	{0xA0, "ORL C, /bit", 2, 2, carry, "OR complement of direct bit to carry."},
+	{0xA2, "MOV C, bit", 2, 1, carry, "Move direct bit to carry."},
+	{0xA3, "INC DPTR", 1, 2, nil, "Increment data pointer."},
+	{0xA4, "MUL AB", 1, 4, multiply, "Multiply A and B; C is cleared, OV set if the product exceeds 255."},
+	{0xA6, "MOV @Ri, direct", 2, 2, nil, "Move direct byte to indirect RAM."},
+	{0xA8, "MOV Rn, direct", 2, 2, nil, "Move direct byte to register."},
+	{0xB0, "ANL C, /bit", 2, 2, carry, "AND complement of direct bit to carry."},
+	{0xB2, "CPL bit", 2, 1, nil, "Complement direct bit."},
+	{0xB3, "CPL C", 1, 1, carry, "Complement carry."},
+	{0xB4, "CJNE A, #data, rel", 3, 2, carry, "Compare immediate to accumulator and jump if not equal."},
+	{0xB5, "CJNE A, direct, rel", 3, 2, carry, "Compare direct byte to accumulator and jump if not equal."},
+	{0xB6, "CJNE @Ri, #data, rel", 3, 2, carry, "Compare immediate to indirect RAM and jump if not equal."},
+	{0xB8, "CJNE Rn, #data, rel", 3, 2, carry, "Compare immediate to register and jump if not equal."},
+	{0xC0, "PUSH direct", 2, 2, nil, "Push direct byte onto the stack."},
+	{0xC2, "CLR bit", 2, 1, nil, "Clear direct bit."},
+	{0xC3, "CLR C", 1, 1, carry, "Clear carry."},
+	{0xC4, "SWAP A", 1, 1, nil, "Swap nibbles within the accumulator."},
+	{0xC5, "XCH A, direct", 2, 1, nil, "Exchange direct byte with accumulator."},
+	{0xC6, "XCH A, @Ri", 1, 1, nil, "Exchange indirect RAM with accumulator."},
+	{0xC8, "XCH A, Rn", 1, 1, nil, "Exchange register with accumulator."},
+	{0xD0, "POP direct", 2, 2, nil, "Pop direct byte from the stack."},
+	{0xD2, "SETB bit", 2, 1, nil, "Set direct bit."},
+	{0xD3, "SETB C", 1, 1, carry, "Set carry."},
+	{0xD4, "DA A", 1, 1, carry, "Decimal adjust accumulator."},
+	{0xD5, "DJNZ direct, rel", 3, 2, nil, "Decrement direct byte and jump if not zero."},
+	{0xD6, "XCHD A, @Ri", 1, 1, nil, "Exchange low nibble of indirect RAM with accumulator."},
+	{0xD8, "DJNZ Rn, rel", 2, 2, nil, "Decrement register and jump if not zero."},
+	{0xE0, "MOVX A, @DPTR", 1, 2, nil, "Move external RAM (16-bit address) to accumulator."},
+	{0xE2, "MOVX A, @Ri", 1, 2, nil, "Move external RAM (8-bit address) to accumulator."},
+	{0xE4, "CLR A", 1, 1, nil, "Clear accumulator."},
+	{0xE5, "MOV A, direct", 2, 1, nil, "Move direct byte to accumulator."},
+	{0xE6, "MOV A, @Ri", 1, 1, nil, "Move indirect RAM to accumulator."},
+	{0xE8, "MOV A, Rn", 1, 1, nil, "Move register to accumulator."},
+	{0xF0, "MOVX @DPTR, A", 1, 2, nil, "Move accumulator to external RAM (16-bit address)."},
+	{0xF2, "MOVX @Ri, A", 1, 2, nil, "Move accumulator to external RAM (8-bit address)."},
+	{0xF4, "CPL A", 1, 1, nil, "Complement accumulator."},
+	{0xF5, "MOV direct, A", 2, 1, nil, "Move accumulator to direct byte."},
+	{0xF6, "MOV @Ri, A", 1, 1, nil, "Move accumulator to indirect RAM."},
+	{0xF8, "MOV Rn, A", 1, 1, nil, "Move accumulator to register."},
+}
+
+type Generator struct {
+	logger *log.Logger
+}
+
+func NewGenerator() *Generator {
+	logger := log.NewWithOptions(os.Stderr, log.Options{
+		ReportCaller:    false,
+		ReportTimestamp: true,
+		TimeFormat:      time.Kitchen,
+		Prefix:          "8051-datagen",
+	})
+
+	return &Generator{
+		logger: logger,
+	}
+}
+
+func (g *Generator) expand(def instructionDef) []instructionDef {
+	var count, stride int
+	var placeholder string
+
+	switch {
+	case strings.Contains(def.syntax, "addr11"):
+		count, stride = 8, 0x20
+	case strings.Contains(def.syntax, "@Ri"):
+		count, stride, placeholder = 2, 1, "@Ri"
+	case strings.Contains(def.syntax, "Rn"):
+		count, stride, placeholder = 8, 1, "Rn"
+	default:
+		return []instructionDef{def}
+	}
+
+	var expanded []instructionDef
+	for i := 0; i < count; i++ {
+		variant := def
+		variant.opcode = def.opcode + i*stride
+		switch placeholder {
+		case "@Ri":
+			variant.syntax = strings.Replace(def.syntax, "@Ri", "@R"+strconv.Itoa(i), 1)
+		case "Rn":
+			variant.syntax = strings.Replace(def.syntax, "Rn", "R"+strconv.Itoa(i), 1)
+		}
+		expanded = append(expanded, variant)
+	}
+	return expanded
+}
+
+func (g *Generator) buildInstructions() ([]MCS51Instruction, error) {
+	var instructions []MCS51Instruction
+	seen := make(map[int]string)
+
+	for _, def := range instructionDefs {
+		for _, variant := range g.expand(def) {
+			if previous, ok := seen[variant.opcode]; ok {
+				return nil, fmt.Errorf("opcode %02X assigned to both %q and %q", variant.opcode, previous, variant.syntax)
+			}
+			seen[variant.opcode] = variant.syntax
+
+			fields := strings.SplitN(variant.syntax, " ", 2)
+			operands := []string{}
+			if len(fields) == 2 {
+				for _, operand := range strings.Split(fields[1], ",") {
+					operands = append(operands, strings.TrimSpace(operand))
+				}
+			}
+
+			flags := variant.flags
+			if flags == nil {
+				flags = []string{}
+			}
+
+			opcode := fmt.Sprintf("%02X", variant.opcode)
+			instructions = append(instructions, MCS51Instruction{
+				Opcode:      opcode,
+				Mnemonic:    fields[0],
+				Syntax:      variant.syntax,
+				Operands:    operands,
+				Bytes:       variant.bytes,
+				Cycles:      variant.cycles,
+				Flags:       flags,
+				Description: variant.description,
+				AnchorID:    "8051-" + strings.ToLower(opcode),
+			})
+		}
+	}
+
+	sort.Slice(instructions, func(i, j int) bool {
+		return instructions[i].Opcode < instructions[j].Opcode
+	})
+
+	return instructions, nil
+}
+
+func (g *Generator) saveData(instructions []MCS51Instruction) error {
+	g.logger.Info("Saving instruction data", "count", len(instructions))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(instructions); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(outputFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	g.logger.Info("Data saved successfully", "file", outputFilename)
+	return nil
+}
+
+func (g *Generator) Run() error {
+	g.logger.Info("Starting 8051 instruction generator")
+
+	instructions, err := g.buildInstructions()
+	if err != nil {
+		return fmt.Errorf("failed to build instructions: %w", err)
+	}
+
+	if err := g.saveData(instructions); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+
+	g.logger.Info("Generation completed successfully")
+	return nil
+}
+
+func main() {
+	generator := NewGenerator()
+	if err := generator.Run(); err != nil {
+		generator.logger.Fatal("Generator failed", "error", err)
+	}
+}
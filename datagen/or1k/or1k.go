@@ -0,0 +1,339 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const outputFilename = "or1k.json"
+
+type EncodingField struct {
+	Name  string `json:"name"`
+	High  int    `json:"high"`
+	Low   int    `json:"low"`
+	Value *int   `json:"value,omitempty"`
+}
+
+type Encoding struct {
+	Width   int             `json:"width"`
+	Format  string          `json:"format"`
+	Pattern string          `json:"pattern"`
+	Fields  []EncodingField `json:"fields"`
+}
+
+type OR1KInstruction struct {
+	Mnemonic    string   `json:"mnemonic"`
+	Class       string   `json:"class"`
+	Syntax      string   `json:"syntax"`
+	Description string   `json:"description"`
+	Encoding    Encoding `json:"encoding"`
+	AnchorID    string   `json:"anchorId"`
+}
+
+type formatField struct {
+	name      string
+	high, low int
+}
+
+var instructionFormats = map[string][]formatField{
+	"J":      {{"opcode", 31, 26}, {"N", 25, 0}},
+	"JR":     {{"opcode", 31, 26}, {"rsv", 25, 16}, {"B", 15, 11}, {"rsv2", 10, 0}},
+	"NOP":    {{"opcode", 31, 26}, {"op", 25, 24}, {"rsv", 23, 16}, {"K", 15, 0}},
+	"MOVHI":  {{"opcode", 31, 26}, {"D", 25, 21}, {"rsv", 20, 17}, {"op", 16, 16}, {"K", 15, 0}},
+	"SYS":    {{"opcode", 31, 26}, {"op", 25, 16}, {"K", 15, 0}},
+	"RFE":    {{"opcode", 31, 26}, {"rsv", 25, 0}},
+	"I":      {{"opcode", 31, 26}, {"D", 25, 21}, {"A", 20, 16}, {"I", 15, 0}},
+	"MACI":   {{"opcode", 31, 26}, {"rsv", 25, 21}, {"A", 20, 16}, {"I", 15, 0}},
+	"SHIFTI": {{"opcode", 31, 26}, {"D", 25, 21}, {"A", 20, 16}, {"rsv", 15, 8}, {"op", 7, 6}, {"L", 5, 0}},
+	"SFI":    {{"opcode", 31, 26}, {"op", 25, 21}, {"A", 20, 16}, {"I", 15, 0}},
+	"STORE":  {{"opcode", 31, 26}, {"Ihi", 25, 21}, {"A", 20, 16}, {"B", 15, 11}, {"Ilo", 10, 0}},
+	"ALU":    {{"opcode", 31, 26}, {"D", 25, 21}, {"A", 20, 16}, {"B", 15, 11}, {"rsv", 10, 10}, {"op3", 9, 8}, {"op2", 7, 6}, {"rsv2", 5, 4}, {"op", 3, 0}},
+	"SF":     {{"opcode", 31, 26}, {"op", 25, 21}, {"A", 20, 16}, {"B", 15, 11}, {"rsv", 10, 0}},
+	"MAC":    {{"opcode", 31, 26}, {"rsv", 25, 21}, {"A", 20, 16}, {"B", 15, 11}, {"rsv2", 10, 4}, {"op", 3, 0}},
+	"FPU":    {{"opcode", 31, 26}, {"D", 25, 21}, {"A", 20, 16}, {"B", 15, 11}, {"rsv", 10, 8}, {"op", 7, 0}},
+}
+
+type instructionDef struct {
+	mnemonic    string
+	class       string
+	format      string
+	fixed       map[string]int
+	syntax      string
+	description string
+}
+
+type f = map[string]int
+
+var instructionDefs = []instructionDef{
+	{"l.j", "ORBIS32", "J", f{"opcode": 0x00}, "l.j N", "Jump to PC-relative target."},
+	{"l.jal", "ORBIS32", "J", f{"opcode": 0x01}, "l.jal N", "Jump to PC-relative target and link in r9."},
+	{"l.bnf", "ORBIS32", "J", f{"opcode": 0x03}, "l.bnf N", "Branch if flag is clear."},
+	{"l.bf", "ORBIS32", "J", f{"opcode": 0x04}, "l.bf N", "Branch if flag is set."},
+	{"l.nop", "ORBIS32", "NOP", f{"opcode": 0x05, "op": 1}, "l.nop K", "No operation."},
+	{"l.movhi", "ORBIS32", "MOVHI", f{"opcode": 0x06, "op": 0}, "l.movhi rD, K", "Move immediate into high half of register."},
+	{"l.macrc", "ORBIS32", "MOVHI", f{"opcode": 0x06, "op": 1, "K": 0}, "l.macrc rD", "Read MAC result into register and clear accumulator."},
+	{"l.sys", "ORBIS32", "SYS", f{"opcode": 0x08, "op": 0x000}, "l.sys K", "System call exception."},
+	{"l.trap", "ORBIS32", "SYS", f{"opcode": 0x08, "op": 0x100}, "l.trap K", "Trap exception."},
+	{"l.msync", "ORBIS32", "SYS", f{"opcode": 0x08, "op": 0x200, "K": 0}, "l.msync", "Memory synchronization."},
+	{"l.psync", "ORBIS32", "SYS", f{"opcode": 0x08, "op": 0x280, "K": 0}, "l.psync", "Pipeline synchronization."},
+	{"l.csync", "ORBIS32", "SYS", f{"opcode": 0x08, "op": 0x300, "K": 0}, "l.csync", "Context synchronization."},
+	{"l.rfe", "ORBIS32", "RFE", f{"opcode": 0x09}, "l.rfe", "Return from exception."},
+	{"l.jr", "ORBIS32", "JR", f{"opcode": 0x11}, "l.jr rB", "Jump to register."},
+	{"l.jalr", "ORBIS32", "JR", f{"opcode": 0x12}, "l.jalr rB", "Jump to register and link in r9."},
+	{"l.maci", "ORBIS32", "MACI", f{"opcode": 0x13}, "l.maci rA, I", "Multiply register by immediate and accumulate."},
+	{"l.lwa", "ORBIS32", "I", f{"opcode": 0x1B}, "l.lwa rD, I(rA)", "Load single word atomic (load-linked)."},
+	{"l.cust1", "ORBIS32", "J", f{"opcode": 0x1C}, "l.cust1", "Reserved for custom instructions."},
+	{"l.cust2", "ORBIS32", "J", f{"opcode": 0x1D}, "l.cust2", "Reserved for custom instructions."},
+	{"l.cust3", "ORBIS32", "J", f{"opcode": 0x1E}, "l.cust3", "Reserved for custom instructions."},
+	{"l.cust4", "ORBIS32", "J", f{"opcode": 0x1F}, "l.cust4", "Reserved for custom instructions."},
+	{"l.ld", "ORBIS64", "I", f{"opcode": 0x20}, "l.ld rD, I(rA)", "Load double word."},
+	{"l.lwz", "ORBIS32", "I", f{"opcode": 0x21}, "l.lwz rD, I(rA)", "Load single word and extend with zero."},
+	{"l.lws", "ORBIS32", "I", f{"opcode": 0x22}, "l.lws rD, I(rA)", "Load single word and extend with sign."},
+	{"l.lbz", "ORBIS32", "I", f{"opcode": 0x23}, "l.lbz rD, I(rA)", "Load byte and extend with zero."},
+	{"l.lbs", "ORBIS32", "I", f{"opcode": 0x24}, "l.lbs rD, I(rA)", "Load byte and extend with sign."},
+	{"l.lhz", "ORBIS32", "I", f{"opcode": 0x25}, "l.lhz rD, I(rA)", "Load half word and extend with zero."},
+	{"l.lhs", "ORBIS32", "I", f{"opcode": 0x26}, "l.lhs rD, I(rA)", "Load half word and extend with sign."},
+	{"l.addi", "ORBIS32", "I", f{"opcode": 0x27}, "l.addi rD, rA, I", "Add signed immediate."},
+	{"l.addic", "ORBIS32", "I", f{"opcode": 0x28}, "l.addic rD, rA, I", "Add signed immediate with carry."},
+	{"l.andi", "ORBIS32", "I", f{"opcode": 0x29}, "l.andi rD, rA, K", "AND with zero-extended immediate."},
+	{"l.ori", "ORBIS32", "I", f{"opcode": 0x2A}, "l.ori rD, rA, K", "OR with zero-extended immediate."},
+	{"l.xori", "ORBIS32", "I", f{"opcode": 0x2B}, "l.xori rD, rA, I", "Exclusive OR with signed immediate."},
+	{"l.muli", "ORBIS32", "I", f{"opcode": 0x2C}, "l.muli rD, rA, I", "Multiply by signed immediate."},
+	{"l.mfspr", "ORBIS32", "I", f{"opcode": 0x2D}, "l.mfspr rD, rA, K", "Move from special-purpose register."},
+	{"l.slli", "ORBIS32", "SHIFTI", f{"opcode": 0x2E, "op": 0}, "l.slli rD, rA, L", "Shift left logical by immediate."},
+	{"l.srli", "ORBIS32", "SHIFTI", f{"opcode": 0x2E, "op": 1}, "l.srli rD, rA, L", "Shift right logical by immediate."},
+	{"l.srai", "ORBIS32", "SHIFTI", f{"opcode": 0x2E, "op": 2}, "l.srai rD, rA, L", "Shift right arithmetic by immediate."},
+	{"l.rori", "ORBIS32", "SHIFTI", f{"opcode": 0x2E, "op": 3}, "l.rori rD, rA, L", "Rotate right by immediate."},
+	{"l.sfeqi", "ORBIS32", "SFI", f{"opcode": 0x2F, "op": 0x0}, "l.sfeqi rA, I", "Set flag if equal to immediate."},
+	{"l.sfnei", "ORBIS32", "SFI", f{"opcode": 0x2F, "op": 0x1}, "l.sfnei rA, I", "Set flag if not equal to immediate."},
+	{"l.sfgtui", "ORBIS32", "SFI", f{"opcode": 0x2F, "op": 0x2}, "l.sfgtui rA, I", "Set flag if greater than immediate, unsigned."},
+	{"l.sfgeui", "ORBIS32", "SFI", f{"opcode": 0x2F, "op": 0x3}, "l.sfgeui rA, I", "Set flag if greater or equal to immediate, unsigned."},
+	{"l.sfltui", "ORBIS32", "SFI", f{"opcode": 0x2F, "op": 0x4}, "l.sfltui rA, I", "Set flag if less than immediate, unsigned."},
+	{"l.sfleui", "ORBIS32", "SFI", f{"opcode": 0x2F, "op": 0x5}, "l.sfleui rA, I", "Set flag if less or equal to immediate, unsigned."},
+	{"l.sfgtsi", "ORBIS32", "SFI", f{"opcode": 0x2F, "op": 0xA}, "l.sfgtsi rA, I", "Set flag if greater than immediate, signed."},
+	{"l.sfgesi", "ORBIS32", "SFI", f{"opcode": 0x2F, "op": 0xB}, "l.sfgesi rA, I", "Set flag if greater or equal to immediate, signed."},
+	{"l.sfltsi", "ORBIS32", "SFI", f{"opcode": 0x2F, "op": 0xC}, "l.sfltsi rA, I", "Set flag if less than immediate, signed."},
+	{"l.sflesi", "ORBIS32", "SFI", f{"opcode": 0x2F, "op": 0xD}, "l.sflesi rA, I", "Set flag if less or equal to immediate, signed."},
+	{"l.mtspr", "ORBIS32", "STORE", f{"opcode": 0x30}, "l.mtspr rA, rB, K", "Move to special-purpose register."},
+	{"l.mac", "ORBIS32", "MAC", f{"opcode": 0x31, "op": 0x1}, "l.mac rA, rB", "Multiply signed and accumulate."},
+	{"l.msb", "ORBIS32", "MAC", f{"opcode": 0x31, "op": 0x2}, "l.msb rA, rB", "Multiply signed and subtract."},
+	{"l.macu", "ORBIS32", "MAC", f{"opcode": 0x31, "op": 0x3}, "l.macu rA, rB", "Multiply unsigned and accumulate."},
+	{"l.msbu", "ORBIS32", "MAC", f{"opcode": 0x31, "op": 0x4}, "l.msbu rA, rB", "Multiply unsigned and subtract."},
+	{"lf.add.s", "ORFPX32", "FPU", f{"opcode": 0x32, "op": 0x00}, "lf.add.s rD, rA, rB", "Add single-precision floats."},
+	{"lf.sub.s", "ORFPX32", "FPU", f{"opcode": 0x32, "op": 0x01}, "lf.sub.s rD, rA, rB", "Subtract single-precision floats."},
+	{"lf.mul.s", "ORFPX32", "FPU", f{"opcode": 0x32, "op": 0x02}, "lf.mul.s rD, rA, rB", "Multiply single-precision floats."},
+	{"lf.div.s", "ORFPX32", "FPU", f{"opcode": 0x32, "op": 0x03}, "lf.div.s rD, rA, rB", "Divide single-precision floats."},
+	{"lf.itof.s", "ORFPX32", "FPU", f{"opcode": 0x32, "op": 0x04, "B": 0}, "lf.itof.s rD, rA", "Convert integer to single-precision float."},
+	{"lf.ftoi.s", "ORFPX32", "FPU", f{"opcode": 0x32, "op": 0x05, "B": 0}, "lf.ftoi.s rD, rA", "Convert single-precision float to integer."},
+	{"lf.rem.s", "ORFPX32", "FPU", f{"opcode": 0x32, "op": 0x06}, "lf.rem.s rD, rA, rB", "Remainder of single-precision floats."},
+	{"lf.madd.s", "ORFPX32", "FPU", f{"opcode": 0x32, "op": 0x07}, "lf.madd.s rD, rA, rB", "Multiply single-precision floats and add to rD."},
+	{"lf.sfeq.s", "ORFPX32", "FPU", f{"opcode": 0x32, "op": 0x08, "D": 0}, "lf.sfeq.s rA, rB", "Set flag if single-precision floats are equal."},
+	{"lf.sfne.s", "ORFPX32", "FPU", f{"opcode": 0x32, "op": 0x09, "D": 0}, "lf.sfne.s rA, rB", "Set flag if single-precision floats are not equal."},
+	{"lf.sfgt.s", "ORFPX32", "FPU", f{"opcode": 0x32, "op": 0x0A, "D": 0}, "lf.sfgt.s rA, rB", "Set flag if single-precision float is greater than."},
+	{"lf.sfge.s", "ORFPX32", "FPU", f{"opcode": 0x32, "op": 0x0B, "D": 0}, "lf.sfge.s rA, rB", "Set flag if single-precision float is greater or equal."},
+	{"lf.sflt.s", "ORFPX32", "FPU", f{"opcode": 0x32, "op": 0x0C, "D": 0}, "lf.sflt.s rA, rB", "Set flag if single-precision float is less than."},
+	{"lf.sfle.s", "ORFPX32", "FPU", f{"opcode": 0x32, "op": 0x0D, "D": 0}, "lf.sfle.s rA, rB", "Set flag if single-precision float is less or equal."},
+	{"lf.add.d", "ORFPX64", "FPU", f{"opcode": 0x32, "op": 0x10}, "lf.add.d rD, rA, rB", "Add double-precision floats."},
+	{"lf.sub.d", "ORFPX64", "FPU", f{"opcode": 0x32, "op": 0x11}, "lf.sub.d rD, rA, rB", "Subtract double-precision floats."},
+	{"lf.mul.d", "ORFPX64", "FPU", f{"opcode": 0x32, "op": 0x12}, "lf.mul.d rD, rA, rB", "Multiply double-precision floats."},
+	{"lf.div.d", "ORFPX64", "FPU", f{"opcode": 0x32, "op": 0x13}, "lf.div.d rD, rA, rB", "Divide double-precision floats."},
+	{"lf.itof.d", "ORFPX64", "FPU", f{"opcode": 0x32, "op": 0x14, "B": 0}, "lf.itof.d rD, rA", "Convert integer to double-precision float."},
+	{"lf.ftoi.d", "ORFPX64", "FPU", f{"opcode": 0x32, "op": 0x15, "B": 0}, "lf.ftoi.d rD, rA", "Convert double-precision float to integer."},
+	{"lf.rem.d", "ORFPX64", "FPU", f{"opcode": 0x32, "op": 0x16}, "lf.rem.d rD, rA, rB", "Remainder of double-precision floats."},
+	{"lf.madd.d", "ORFPX64", "FPU", f{"opcode": 0x32, "op": 0x17}, "lf.madd.d rD, rA, rB", "Multiply double-precision floats and add to rD."},
+	{"lf.sfeq.d", "ORFPX64", "FPU", f{"opcode": 0x32, "op": 0x18, "D": 0}, "lf.sfeq.d rA, rB", "Set flag if double-precision floats are equal."},
+	{"lf.sfne.d", "ORFPX64", "FPU", f{"opcode": 0x32, "op": 0x19, "D": 0}, "lf.sfne.d rA, rB", "Set flag if double-precision floats are not equal."},
+	{"lf.sfgt.d", "ORFPX64", "FPU", f{"opcode": 0x32, "op": 0x1A, "D": 0}, "lf.sfgt.d rA, rB", "Set flag if double-precision float is greater than."},
+	{"lf.sfge.d", "ORFPX64", "FPU", f{"opcode": 0x32, "op": 0x1B, "D": 0}, "lf.sfge.d rA, rB", "Set flag if double-precision float is greater or equal."},
+	{"lf.sflt.d", "ORFPX64", "FPU", f{"opcode": 0x32, "op": 0x1C, "D": 0}, "lf.sflt.d rA, rB", "Set flag if double-precision float is less than."},
+	{"lf.sfle.d", "ORFPX64", "FPU", f{"opcode": 0x32, "op": 0x1D, "D": 0}, "lf.sfle.d rA, rB", "Set flag if double-precision float is less or equal."},
+	{"l.swa", "ORBIS32", "STORE", f{"opcode": 0x33}, "l.swa I(rA), rB", "Store single word atomic (store-conditional)."},
+	{"l.sd", "ORBIS64", "STORE", f{"opcode": 0x34}, "l.sd I(rA), rB", "Store double word."},
+	{"l.sw", "ORBIS32", "STORE", f{"opcode": 0x35}, "l.sw I(rA), rB", "Store single word."},
+	{"l.sb", "ORBIS32", "STORE", f{"opcode": 0x36}, "l.sb I(rA), rB", "Store byte."},
+	{"l.sh", "ORBIS32", "STORE", f{"opcode": 0x37}, "l.sh I(rA), rB", "Store half word."},
+	{"l.add", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 0, "op": 0x0}, "l.add rD, rA, rB", "Add signed."},
+	{"l.addc", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 0, "op": 0x1}, "l.addc rD, rA, rB", "Add signed with carry."},
+	{"l.sub", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 0, "op": 0x2}, "l.sub rD, rA, rB", "Subtract signed."},
+	{"l.and", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 0, "op": 0x3}, "l.and rD, rA, rB", "Bitwise AND."},
+	{"l.or", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 0, "op": 0x4}, "l.or rD, rA, rB", "Bitwise OR."},
+	{"l.xor", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 0, "op": 0x5}, "l.xor rD, rA, rB", "Bitwise exclusive OR."},
+	{"l.mul", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 3, "op2": 0, "op": 0x6}, "l.mul rD, rA, rB", "Multiply signed."},
+	{"l.muld", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 3, "op2": 0, "op": 0x7, "D": 0}, "l.muld rA, rB", "Multiply signed to MAC accumulator."},
+	{"l.sll", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 0, "op": 0x8}, "l.sll rD, rA, rB", "Shift left logical."},
+	{"l.srl", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 1, "op": 0x8}, "l.srl rD, rA, rB", "Shift right logical."},
+	{"l.sra", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 2, "op": 0x8}, "l.sra rD, rA, rB", "Shift right arithmetic."},
+	{"l.ror", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 3, "op": 0x8}, "l.ror rD, rA, rB", "Rotate right."},
+	{"l.div", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 3, "op2": 0, "op": 0x9}, "l.div rD, rA, rB", "Divide signed."},
+	{"l.divu", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 3, "op2": 0, "op": 0xA}, "l.divu rD, rA, rB", "Divide unsigned."},
+	{"l.mulu", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 3, "op2": 0, "op": 0xB}, "l.mulu rD, rA, rB", "Multiply unsigned."},
+	{"l.muldu", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 3, "op2": 0, "op": 0xC, "D": 0}, "l.muldu rA, rB", "Multiply unsigned to MAC accumulator."},
+	{"l.exths", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 0, "op": 0xC, "B": 0}, "l.exths rD, rA", "Extend half word with sign."},
+	{"l.extbs", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 1, "op": 0xC, "B": 0}, "l.extbs rD, rA", "Extend byte with sign."},
+	{"l.exthz", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 2, "op": 0xC, "B": 0}, "l.exthz rD, rA", "Extend half word with zero."},
+	{"l.extbz", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 3, "op": 0xC, "B": 0}, "l.extbz rD, rA", "Extend byte with zero."},
+	{"l.extws", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 0, "op": 0xD, "B": 0}, "l.extws rD, rA", "Extend word with sign."},
+	{"l.extwz", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 1, "op": 0xD, "B": 0}, "l.extwz rD, rA", "Extend word with zero."},
+	{"l.cmov", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 0, "op": 0xE}, "l.cmov rD, rA, rB", "Conditional move on flag."},
+	{"l.ff1", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 0, "op2": 0, "op": 0xF, "B": 0}, "l.ff1 rD, rA", "Find first set bit."},
+	{"l.fl1", "ORBIS32", "ALU", f{"opcode": 0x38, "op3": 1, "op2": 0, "op": 0xF, "B": 0}, "l.fl1 rD, rA", "Find last set bit."},
+	{"l.sfeq", "ORBIS32", "SF", f{"opcode": 0x39, "op": 0x0}, "l.sfeq rA, rB", "Set flag if equal."},
+	{"l.sfne", "ORBIS32", "SF", f{"opcode": 0x39, "op": 0x1}, "l.sfne rA, rB", "Set flag if not equal."},
+	{"l.sfgtu", "ORBIS32", "SF", f{"opcode": 0x39, "op": 0x2}, "l.sfgtu rA, rB", "Set flag if greater than, unsigned."},
+	{"l.sfgeu", "ORBIS32", "SF", f{"opcode": 0x39, "op": 0x3}, "l.sfgeu rA, rB", "Set flag if greater or equal, unsigned."},
+	{"l.sfltu", "ORBIS32", "SF", f{"opcode": 0x39, "op": 0x4}, "l.sfltu rA, rB", "Set flag if less than, unsigned."},
+	{"l.sfleu", "ORBIS32", "SF", f{"opcode": 0x39, "op": 0x5}, "l.sfleu rA, rB", "Set flag if less or equal, unsigned."},
+	{"l.sfgts", "ORBIS32", "SF", f{"opcode": 0x39, "op": 0xA}, "l.sfgts rA, rB", "Set flag if greater than, signed."},
+	{"l.sfges", "ORBIS32", "SF", f{"opcode": 0x39, "op": 0xB}, "l.sfges rA, rB", "Set flag if greater or equal, signed."},
+	{"l.sflts", "ORBIS32", "SF", f{"opcode": 0x39, "op": 0xC}, "l.sflts rA, rB", "Set flag if less than, signed."},
+	{"l.sfles", "ORBIS32", "SF", f{"opcode": 0x39, "op": 0xD}, "l.sfles rA, rB", "Set flag if less or equal, signed."},
+	{"l.cust5", "ORBIS32", "J", f{"opcode": 0x3C}, "l.cust5", "Reserved for custom instructions."},
+	{"l.cust6", "ORBIS32", "J", f{"opcode": 0x3D}, "l.cust6", "Reserved for custom instructions."},
+	{"l.cust7", "ORBIS32", "J", f{"opcode": 0x3E}, "l.cust7", "Reserved for custom instructions."},
+	{"l.cust8", "ORBIS32", "J", f{"opcode": 0x3F}, "l.cust8", "Reserved for custom instructions."},
+}
+
+type Generator struct {
+	logger *log.Logger
+}
+
+func NewGenerator() *Generator {
+	logger := log.NewWithOptions(os.Stderr, log.Options{
+		ReportCaller:    false,
+		ReportTimestamp: true,
+		TimeFormat:      time.Kitchen,
+		Prefix:          "or1k-datagen",
+	})
+
+	return &Generator{
+		logger: logger,
+	}
+}
+
+func (g *Generator) buildEncoding(def instructionDef) (Encoding, error) {
+	layout, ok := instructionFormats[def.format]
+	if !ok {
+		return Encoding{}, fmt.Errorf("unknown format %q for %s", def.format, def.mnemonic)
+	}
+
+	width := layout[0].high + 1
+	pattern := []byte(strings.Repeat("-", width))
+	fields := make([]EncodingField, 0, len(layout))
+	used := 0
+
+	for _, field := range layout {
+		encodingField := EncodingField{Name: field.name, High: field.high, Low: field.low}
+
+		value, fixed := def.fixed[field.name]
+		if fixed {
+			used++
+		} else if strings.HasPrefix(field.name, "rsv") {
+			value, fixed = 0, true
+		}
+
+		if fixed {
+			if value >= 1<<(field.high-field.low+1) {
+				return Encoding{}, fmt.Errorf("value %d does not fit field %s of %s", value, field.name, def.mnemonic)
+			}
+			v := value
+			encodingField.Value = &v
+			for bit := field.low; bit <= field.high; bit++ {
+				if value&(1<<(bit-field.low)) != 0 {
+					pattern[width-1-bit] = '1'
+				} else {
+					pattern[width-1-bit] = '0'
+				}
+			}
+		}
+
+		fields = append(fields, encodingField)
+	}
+
+	if used != len(def.fixed) {
+		return Encoding{}, fmt.Errorf("%s fixes fields not present in format %s", def.mnemonic, def.format)
+	}
+
+	return Encoding{
+		Width:   width,
+		Format:  def.format,
+		Pattern: string(pattern),
+		Fields:  fields,
+	}, nil
+}
+
+func (g *Generator) buildInstructions() ([]OR1KInstruction, error) {
+	var instructions []OR1KInstruction
+
+	for _, def := range instructionDefs {
+		encoding, err := g.buildEncoding(def)
+		if err != nil {
+			return nil, err
+		}
+
+		instructions = append(instructions, OR1KInstruction{
+			Mnemonic:    def.mnemonic,
+			Class:       def.class,
+			Syntax:      def.syntax,
+			Description: def.description,
+			Encoding:    encoding,
+			AnchorID:    "or1k-" + strings.ReplaceAll(def.mnemonic, ".", "-"),
+		})
+	}
+
+	sort.Slice(instructions, func(i, j int) bool {
+		return instructions[i].Mnemonic < instructions[j].Mnemonic
+	})
+
+	return instructions, nil
+}
+
+func (g *Generator) saveData(instructions []OR1KInstruction) error {
+	g.logger.Info("Saving instruction data", "count", len(instructions))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(instructions); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(outputFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	g.logger.Info("Data saved successfully", "file", outputFilename)
+	return nil
+}
+
+func (g *Generator) Run() error {
+	g.logger.Info("Starting OR1K instruction generator")
+
+	instructions, err := g.buildInstructions()
+	if err != nil {
+		return fmt.Errorf("failed to build instructions: %w", err)
+	}
+
+	if err := g.saveData(instructions); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+
+	g.logger.Info("Generation completed successfully")
+	return nil
+}
+
+func main() {
+	generator := NewGenerator()
+	if err := generator.Run(); err != nil {
+		generator.logger.Fatal("Generator failed", "error", err)
+	}
+}
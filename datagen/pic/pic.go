@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const (
+	outputFilename = "pic.json"
+	wordWidth      = 14
+)
+
+type EncodingField struct {
+	Name string `json:"name"`
+	High int    `json:"high"`
+	Low  int    `json:"low"`
+}
+
+type PICInstruction struct {
+	Mnemonic    string          `json:"mnemonic"`
+	Syntax      string          `json:"syntax"`
+	Class       string          `json:"class"`
+	Pattern     string          `json:"pattern"`
+	Match       string          `json:"match"`
+	Mask        string          `json:"mask"`
+	Fields      []EncodingField `json:"fields"`
+	Cycles      int             `json:"cycles"`
+	SkipCycles  int             `json:"skipCycles,omitempty"`
+	StatusFlags []string        `json:"statusFlags"`
+	Description string          `json:"description"`
+	AnchorID    string          `json:"anchorId"`
+}
+
+type instructionDef struct {
+	mnemonic    string
+	syntax      string
+	pattern     string
+	cycles      int
+	skipCycles  int
+	flags       []string
+	description string
+}
+
+var fieldNames = map[byte]string{
+	'd': "d",
+	'f': "f",
+	'b': "b",
+	'k': "k",
+}
+
+var controlInstructions = map[string]bool{
+	"CLRWDT": true,
+	"RETFIE": true,
+	"RETURN": true,
+	"SLEEP":  true,
+}
+
+var instructionDefs = []instructionDef{
+	{"ADDWF", "ADDWF f, d", "000111dfffffff", 1, 0, []string{"C", "DC", "Z"}, "Add W and f."},
+	{"ANDWF", "ANDWF f, d", "000101dfffffff", 1, 0, []string{"Z"}, "AND W with f."},
+	{"CLRF", "CLRF f", "0000011fffffff", 1, 0, []string{"Z"}, "Clear f."},
+	{"CLRW", "CLRW", "0000010xxxxxxx", 1, 0, []string{"Z"}, "Clear W."},
+	{"COMF", "COMF f, d", "001001dfffffff", 1, 0, []string{"Z"}, "Complement f."},
+	{"DECF", "DECF f, d", "000011dfffffff", 1, 0, []string{"Z"}, "Decrement f."},
+	{"DECFSZ", "DECFSZ f, d", "001011dfffffff", 1, 2, nil, "Decrement f, skip if 0."},
+	{"INCF", "INCF f, d", "001010dfffffff", 1, 0, []string{"Z"}, "Increment f."},
+	{"INCFSZ", "INCFSZ f, d", "001111dfffffff", 1, 2, nil, "Increment f, skip if 0."},
+	{"IORWF", "IORWF f, d", "000100dfffffff", 1, 0, []string{"Z"}, "Inclusive OR W with f."},
+	{"MOVF", "MOVF f, d", "001000dfffffff", 1, 0, []string{"Z"}, "Move f."},
+	{"MOVWF", "MOVWF f", "0000001fffffff", 1, 0, nil, "Move W to f."},
+	{"NOP", "NOP", "0000000xx00000", 1, 0, nil, "No operation."},
+	{"RLF", "RLF f, d", "001101dfffffff", 1, 0, []string{"C"}, "Rotate left f through carry."},
+	{"RRF", "RRF f, d", "001100dfffffff", 1, 0, []string{"C"}, "Rotate right f through carry."},
+	{"SUBWF", "SUBWF f, d", "000010dfffffff", 1, 0, []string{"C", "DC", "Z"}, "Subtract W from f."},
+	{"SWAPF", "SWAPF f, d", "001110dfffffff", 1, 0, nil, "Swap nibbles in f."},
+	{"XORWF", "XORWF f, d", "000110dfffffff", 1, 0, []string{"Z"}, "Exclusive OR W with f."},
+	{"BCF", "BCF f, b", "0100bbbfffffff", 1, 0, nil, "Bit clear f."},
+	{"BSF", "BSF f, b", "0101bbbfffffff", 1, 0, nil, "Bit set f."},
+	{"BTFSC", "BTFSC f, b", "0110bbbfffffff", 1, 2, nil, "Bit test f, skip if clear."},
+	{"BTFSS", "BTFSS f, b", "0111bbbfffffff", 1, 2, nil, "Bit test f, skip if set."},
+	{"ADDLW", "ADDLW k", "11111xkkkkkkkk", 1, 0, []string{"C", "DC", "Z"}, "Add literal and W."},
+	{"ANDLW", "ANDLW k", "111001kkkkkkkk", 1, 0, []string{"Z"}, "AND literal with W."},
+	{"CALL", "CALL k", "100kkkkkkkkkkk", 2, 0, nil, "Call subroutine."},
+	{"CLRWDT", "CLRWDT", "00000001100100", 1, 0, []string{"TO", "PD"}, "Clear watchdog timer."},
+	{"GOTO", "GOTO k", "101kkkkkkkkkkk", 2, 0, nil, "Go to address."},
+	{"IORLW", "IORLW k", "111000kkkkkkkk", 1, 0, []string{"Z"}, "Inclusive OR literal with W."},
+	{"MOVLW", "MOVLW k", "1100xxkkkkkkkk", 1, 0, nil, "Move literal to W."},
+	{"RETFIE", "RETFIE", "00000000001001", 2, 0, nil, "Return from interrupt."},
+	{"RETLW", "RETLW k", "1101xxkkkkkkkk", 2, 0, nil, "Return with literal in W."},
+	{"RETURN", "RETURN", "00000000001000", 2, 0, nil, "Return from subroutine."},
+	{"SLEEP", "SLEEP", "00000001100011", 1, 0, []string{"TO", "PD"}, "Go into standby mode."},
+	{"SUBLW", "SUBLW k", "11110xkkkkkkkk", 1, 0, []string{"C", "DC", "Z"}, "Subtract W from literal."},
+	{"XORLW", "XORLW k", "111010kkkkkkkk", 1, 0, []string{"Z"}, "Exclusive OR literal with W."},
+}
+
+type Generator struct {
+	logger *log.Logger
+}
+
+func NewGenerator() *Generator {
+	logger := log.NewWithOptions(os.Stderr, log.Options{
+		ReportCaller:    false,
+		ReportTimestamp: true,
+		TimeFormat:      time.Kitchen,
+		Prefix:          "pic-datagen",
+	})
+
+	return &Generator{
+		logger: logger,
+	}
+}
+
+func (g *Generator) parsePattern(def instructionDef) (uint16, uint16, []EncodingField, error) {
+	if len(def.pattern) != wordWidth {
+		return 0, 0, nil, fmt.Errorf("pattern for %s must be %d bits", def.mnemonic, wordWidth)
+	}
+
+	var match, mask uint16
+	fields := []EncodingField{}
+
+	for i := 0; i < wordWidth; {
+		bit := uint(wordWidth - 1 - i)
+		c := def.pattern[i]
+
+		switch c {
+		case '0', '1':
+			mask |= 1 << bit
+			if c == '1' {
+				match |= 1 << bit
+			}
+			i++
+			continue
+		case 'x':
+			i++
+			continue
+		}
+
+		name, ok := fieldNames[c]
+		if !ok {
+			return 0, 0, nil, fmt.Errorf("unknown field %q in pattern for %s", c, def.mnemonic)
+		}
+
+		run := i
+		for run < wordWidth && def.pattern[run] == c {
+			run++
+		}
+		fields = append(fields, EncodingField{Name: name, High: int(bit), Low: wordWidth - run})
+		i = run
+	}
+
+	return match, mask, fields, nil
+}
+
+func (g *Generator) classify(def instructionDef) string {
+	switch {
+	case strings.HasPrefix(def.pattern, "01"):
+		return "bit-oriented"
+	case strings.HasPrefix(def.pattern, "00") && !controlInstructions[def.mnemonic]:
+		return "byte-oriented"
+	default:
+		return "literal-control"
+	}
+}
+
+func (g *Generator) buildInstructions() ([]PICInstruction, error) {
+	var instructions []PICInstruction
+
+	for _, def := range instructionDefs {
+		match, mask, fields, err := g.parsePattern(def)
+		if err != nil {
+			return nil, err
+		}
+
+		flags := def.flags
+		if flags == nil {
+			flags = []string{}
+		}
+
+		instructions = append(instructions, PICInstruction{
+			Mnemonic:    def.mnemonic,
+			Syntax:      def.syntax,
+			Class:       g.classify(def),
+			Pattern:     def.pattern,
+			Match:       fmt.Sprintf("0x%04X", match),
+			Mask:        fmt.Sprintf("0x%04X", mask),
+			Fields:      fields,
+			Cycles:      def.cycles,
+			SkipCycles:  def.skipCycles,
+			StatusFlags: flags,
+			Description: def.description,
+			AnchorID:    "pic-" + strings.ToLower(def.mnemonic),
+		})
+	}
+
+	sort.Slice(instructions, func(i, j int) bool {
+		return instructions[i].Mnemonic < instructions[j].Mnemonic
+	})
+
+	return instructions, nil
+}
+
+func (g *Generator) saveData(instructions []PICInstruction) error {
+	g.logger.Info("Saving instruction data", "count", len(instructions))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(instructions); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(outputFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	g.logger.Info("Data saved successfully", "file", outputFilename)
+	return nil
+}
+
+func (g *Generator) Run() error {
+	g.logger.Info("Starting PIC midrange instruction generator")
+
+	instructions, err := g.buildInstructions()
+	if err != nil {
+		return fmt.Errorf("failed to build instructions: %w", err)
+	}
+
+	if err := g.saveData(instructions); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+
+	g.logger.Info("Generation completed successfully")
+	return nil
+}
+
+func main() {
+	generator := NewGenerator()
+	if err := generator.Run(); err != nil {
+		generator.logger.Fatal("Generator failed", "error", err)
+	}
+}
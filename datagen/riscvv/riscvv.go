@@ -0,0 +1,479 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const (
+	sourceURL      = "https://raw.githubusercontent.com/riscv/riscv-opcodes/master/extensions/rv_v"
+	outputFilename = "riscv_vector.json"
+	requestTimeout = 30 * time.Second
+)
+
+type VTypeField struct {
+	Name        string `json:"name"`
+	High        int    `json:"high"`
+	Low         int    `json:"low"`
+	Description string `json:"description"`
+}
+
+type LMULSetting struct {
+	Encoding   string `json:"encoding"`
+	LMUL       string `json:"lmul"`
+	Registers  int    `json:"registers"`
+	Fractional bool   `json:"fractional"`
+}
+
+type SEWSetting struct {
+	Encoding string `json:"encoding"`
+	SEW      int    `json:"sew"`
+}
+
+type VLRule struct {
+	Condition string `json:"condition"`
+	AVL       string `json:"avl"`
+	Effect    string `json:"effect"`
+}
+
+type VectorConfig struct {
+	VType    []VTypeField  `json:"vtype"`
+	LMUL     []LMULSetting `json:"lmul"`
+	SEW      []SEWSetting  `json:"sew"`
+	VLMAX    string        `json:"vlmax"`
+	VLRules  []VLRule      `json:"vlRules"`
+	TailMask []string      `json:"tailMaskPolicy"`
+	Reserved []string      `json:"reserved"`
+}
+
+type ElementWidths struct {
+	Dest    string `json:"dest"`
+	Source2 string `json:"source2,omitempty"`
+	Source1 string `json:"source1,omitempty"`
+	Index   string `json:"index,omitempty"`
+}
+
+type VectorInstruction struct {
+	Mnemonic      string        `json:"mnemonic"`
+	Match         string        `json:"match"`
+	Mask          string        `json:"mask"`
+	Fields        []string      `json:"fields"`
+	Category      string        `json:"category"`
+	Class         string        `json:"class"`
+	Masking       string        `json:"masking"`
+	Widening      bool          `json:"widening"`
+	Narrowing     bool          `json:"narrowing"`
+	Reduction     bool          `json:"reduction"`
+	MaskResult    bool          `json:"maskResult"`
+	AddressMode   string        `json:"addressMode,omitempty"`
+	ElementWidths ElementWidths `json:"elementWidths"`
+	AnchorID      string        `json:"anchorId"`
+}
+
+type VectorDataset struct {
+	Config       VectorConfig        `json:"config"`
+	Instructions []VectorInstruction `json:"instructions"`
+}
+
+var vectorConfig = VectorConfig{
+	VType: []VTypeField{
+		{Name: "vill", High: 63, Low: 63, Description: "Illegal value if set; remaining vtype bits are zero and vector instructions other than vset{i}vl{i} raise an illegal-instruction exception."},
+		{Name: "vma", High: 7, Low: 7, Description: "Vector mask agnostic."},
+		{Name: "vta", High: 6, Low: 6, Description: "Vector tail agnostic."},
+		{Name: "vsew", High: 5, Low: 3, Description: "Selected element width (SEW)."},
+		{Name: "vlmul", High: 2, Low: 0, Description: "Vector register group multiplier (LMUL)."},
+	},
+	LMUL: []LMULSetting{
+		{Encoding: "101", LMUL: "1/8", Registers: 1, Fractional: true},
+		{Encoding: "110", LMUL: "1/4", Registers: 1, Fractional: true},
+		{Encoding: "111", LMUL: "1/2", Registers: 1, Fractional: true},
+		{Encoding: "000", LMUL: "1", Registers: 1},
+		{Encoding: "001", LMUL: "2", Registers: 2},
+		{Encoding: "010", LMUL: "4", Registers: 4},
+		{Encoding: "011", LMUL: "8", Registers: 8},
+	},
+	SEW: []SEWSetting{
+		{Encoding: "000", SEW: 8},
+		{Encoding: "001", SEW: 16},
+		{Encoding: "010", SEW: 32},
+		{Encoding: "011", SEW: 64},
+	},
+	VLMAX: "LMUL * VLEN / SEW",
+	VLRules: []VLRule{
+		{Condition: "rs1 != x0", AVL: "x[rs1]", Effect: "vl = min(AVL, VLMAX); ceil(AVL / 2) <= vl <= VLMAX permitted when AVL < 2 * VLMAX"},
+		{Condition: "rs1 = x0, rd != x0", AVL: "~0", Effect: "vl = VLMAX"},
+		{Condition: "rs1 = x0, rd = x0", AVL: "vl", Effect: "vl unchanged; vtype updated only if VLMAX is unchanged, otherwise vill is set"},
+		{Condition: "vsetivli", AVL: "uimm[4:0]", Effect: "vl = min(uimm, VLMAX)"},
+	},
+	TailMask: []string{
+		"Undisturbed elements keep their previous destination value.",
+		"Agnostic elements may keep their previous value or be overwritten with all ones.",
+		"Tail elements are those with index >= vl; masked-off elements are those with v0.mask[i] = 0.",
+		"Mask destination registers are always treated as tail-agnostic.",
+	},
+	Reserved: []string{
+		"vlmul = 100 is reserved.",
+		"SEW > ELEN or LMUL < SEW / ELEN sets vill.",
+		"EMUL outside [1/8, 8] for widening, narrowing, or load/store forms is reserved.",
+	},
+}
+
+var opcodeCategories = map[uint64]string{
+	0: "OPIVV", 1: "OPFVV", 2: "OPMVV", 3: "OPIVI", 4: "OPIVX", 5: "OPFVF", 6: "OPMVX", 7: "OPCFG",
+}
+
+var (
+	bitRangePattern  = regexp.MustCompile(`^(\d+)(?:\.\.(\d+))?=(\S+)$`)
+	memoryEEWPattern = regexp.MustCompile(`(?:e|ei)(8|16|32|64)`)
+	extensionPattern = regexp.MustCompile(`\.vf([248])$`)
+)
+
+type Scraper struct {
+	client *http.Client
+	logger *log.Logger
+}
+
+func NewScraper() *Scraper {
+	logger := log.NewWithOptions(os.Stderr, log.Options{
+		ReportCaller:    false,
+		ReportTimestamp: true,
+		TimeFormat:      time.Kitchen,
+		Prefix:          "riscvv-scraper",
+	})
+
+	client := &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: false},
+			DisableKeepAlives: false,
+			MaxIdleConns:      10,
+			IdleConnTimeout:   90 * time.Second,
+		},
+	}
+
+	return &Scraper{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (s *Scraper) fetchOpcodes() ([]byte, error) {
+	s.logger.Info("Fetching RISC-V vector opcodes")
+
+	req, err := http.NewRequest("GET", sourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "riscvv-scraper/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	return body, nil
+}
+
+func (s *Scraper) parseOpcodes(source []byte) []VectorInstruction {
+	var instructions []VectorInstruction
+
+	scanner := bufio.NewScanner(bytes.NewReader(source))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "$") {
+			continue
+		}
+
+		tokens := strings.Fields(line)
+		instruction, ok := s.parseOpcodeLine(tokens[0], tokens[1:])
+		if !ok {
+			s.logger.Debug("Skipping unrecognized line", "line", line)
+			continue
+		}
+		instructions = append(instructions, instruction)
+	}
+
+	sort.Slice(instructions, func(i, j int) bool {
+		return instructions[i].Mnemonic < instructions[j].Mnemonic
+	})
+
+	return instructions
+}
+
+func (s *Scraper) parseOpcodeLine(mnemonic string, tokens []string) (VectorInstruction, bool) {
+	var match, mask uint64
+	fields := []string{}
+	fixed := make(map[int]uint64)
+
+	for _, token := range tokens {
+		constraint := bitRangePattern.FindStringSubmatch(token)
+		if constraint == nil {
+			fields = append(fields, token)
+			continue
+		}
+
+		high, _ := strconv.Atoi(constraint[1])
+		low := high
+		if constraint[2] != "" {
+			low, _ = strconv.Atoi(constraint[2])
+		}
+		value, err := strconv.ParseUint(constraint[3], 0, 32)
+		if err != nil || high < low || high > 31 {
+			return VectorInstruction{}, false
+		}
+
+		width := uint(high - low + 1)
+		match |= (value & (1<<width - 1)) << uint(low)
+		mask |= (1<<width - 1) << uint(low)
+		fixed[low] = value
+	}
+
+	instruction := VectorInstruction{
+		Mnemonic: mnemonic,
+		Match:    fmt.Sprintf("0x%08x", match),
+		Mask:     fmt.Sprintf("0x%08x", mask),
+		Fields:   fields,
+		AnchorID: "riscvv-" + strings.ReplaceAll(mnemonic, ".", "-"),
+	}
+
+	opcode := match & 0x7f
+	switch opcode {
+	case 0x07, 0x27:
+		s.classifyMemory(&instruction, opcode == 0x27)
+	case 0x57:
+		instruction.Category = opcodeCategories[(match>>12)&0x7]
+		s.classifyArithmetic(&instruction)
+	default:
+		return VectorInstruction{}, false
+	}
+
+	instruction.Masking = s.maskingMode(mnemonic, fields)
+	return instruction, true
+}
+
+func (s *Scraper) classifyMemory(instruction *VectorInstruction, store bool) {
+	name := instruction.Mnemonic
+	instruction.Category = "LOAD-FP"
+	instruction.Class = "load"
+	if store {
+		instruction.Category = "STORE-FP"
+		instruction.Class = "store"
+	}
+
+	eew := "EEW"
+	if match := memoryEEWPattern.FindStringSubmatch(name); match != nil {
+		eew = match[1]
+	}
+
+	switch {
+	case strings.HasPrefix(name, "vlm.") || strings.HasPrefix(name, "vsm."):
+		instruction.AddressMode = "mask"
+		instruction.MaskResult = !store
+		instruction.ElementWidths = ElementWidths{Dest: "8"}
+	case strings.Contains(name, "re") && strings.HasPrefix(name, "vl") && len(name) > 3 && name[2] >= '1' && name[2] <= '8':
+		instruction.AddressMode = "whole-register"
+		instruction.ElementWidths = ElementWidths{Dest: eew}
+	case strings.HasPrefix(name, "vs") && len(name) > 3 && name[2] >= '1' && name[2] <= '8' && strings.Contains(name, "r."):
+		instruction.AddressMode = "whole-register"
+		instruction.ElementWidths = ElementWidths{Dest: "8"}
+	case strings.HasPrefix(name, "vlux") || strings.HasPrefix(name, "vsux"):
+		instruction.AddressMode = "indexed-unordered"
+		instruction.ElementWidths = ElementWidths{Dest: "SEW", Index: eew}
+	case strings.HasPrefix(name, "vlox") || strings.HasPrefix(name, "vsox"):
+		instruction.AddressMode = "indexed-ordered"
+		instruction.ElementWidths = ElementWidths{Dest: "SEW", Index: eew}
+	case strings.HasPrefix(name, "vlse") || strings.HasPrefix(name, "vsse"):
+		instruction.AddressMode = "strided"
+		instruction.ElementWidths = ElementWidths{Dest: eew}
+	case strings.HasSuffix(name, "ff.v"):
+		instruction.AddressMode = "fault-only-first"
+		instruction.ElementWidths = ElementWidths{Dest: eew}
+	default:
+		instruction.AddressMode = "unit-stride"
+		instruction.ElementWidths = ElementWidths{Dest: eew}
+	}
+}
+
+func (s *Scraper) classifyArithmetic(instruction *VectorInstruction) {
+	name := instruction.Mnemonic
+	base := strings.SplitN(name, ".", 2)[0]
+	suffix := ""
+	if parts := strings.SplitN(name, ".", 2); len(parts) == 2 {
+		suffix = parts[1]
+	}
+
+	widths := ElementWidths{Dest: "SEW", Source2: "SEW", Source1: "SEW"}
+	if strings.Contains(suffix, "x") || strings.Contains(suffix, "f") {
+		widths.Source1 = "scalar"
+	}
+	if strings.HasSuffix(suffix, "i") {
+		widths.Source1 = "imm"
+	}
+
+	switch {
+	case instruction.Category == "OPCFG":
+		instruction.Class = "config"
+		widths = ElementWidths{Dest: "scalar"}
+	case strings.Contains(base, "red"):
+		instruction.Class = "reduction"
+		instruction.Reduction = true
+		if strings.HasPrefix(base, "vwred") || strings.HasPrefix(base, "vfwred") {
+			instruction.Widening = true
+			widths.Dest = "2*SEW"
+			widths.Source1 = "2*SEW"
+		}
+	case strings.HasPrefix(base, "vms") || strings.HasPrefix(base, "vmf") || base == "vmadc" || base == "vmsbc":
+		instruction.Class = "compare"
+		instruction.MaskResult = true
+		widths.Dest = "mask"
+		if suffix == "m" {
+			widths = ElementWidths{Dest: "mask", Source2: "mask"}
+			instruction.Class = "mask"
+		}
+	case suffix == "mm":
+		instruction.Class = "mask"
+		instruction.MaskResult = true
+		widths = ElementWidths{Dest: "mask", Source2: "mask", Source1: "mask"}
+	case base == "vcpop" || base == "vfirst" || base == "vpopc":
+		instruction.Class = "mask"
+		widths = ElementWidths{Dest: "scalar", Source2: "mask"}
+	case base == "viota" || base == "vid":
+		instruction.Class = "mask"
+		widths = ElementWidths{Dest: "SEW", Source2: "mask"}
+	case name == "vmv.x.s" || name == "vfmv.f.s":
+		instruction.Class = "move"
+		widths = ElementWidths{Dest: "scalar", Source2: "SEW"}
+	case name == "vmv.s.x" || name == "vfmv.s.f":
+		instruction.Class = "move"
+		widths = ElementWidths{Dest: "SEW", Source1: "scalar"}
+	case extensionPattern.MatchString(name):
+		instruction.Class = "extend"
+		factor := extensionPattern.FindStringSubmatch(name)[1]
+		widths = ElementWidths{Dest: "SEW", Source2: "SEW/" + factor}
+	case strings.HasPrefix(base, "vw") || strings.HasPrefix(base, "vfw"):
+		instruction.Class = "widening"
+		instruction.Widening = true
+		widths.Dest = "2*SEW"
+		if strings.HasPrefix(suffix, "w") {
+			widths.Source2 = "2*SEW"
+		}
+		if strings.HasPrefix(base, "vfwcvt") {
+			widths.Source1 = ""
+		}
+	case strings.HasPrefix(base, "vn") || strings.HasPrefix(base, "vfn"):
+		instruction.Class = "narrowing"
+		instruction.Narrowing = true
+		widths.Source2 = "2*SEW"
+		if strings.HasPrefix(base, "vfncvt") {
+			widths.Source1 = ""
+		}
+	case strings.HasPrefix(base, "vslide") || base == "vrgather" || base == "vrgatherei16" || base == "vcompress":
+		instruction.Class = "permute"
+		if base == "vrgatherei16" {
+			widths.Source1 = "16"
+		}
+		if base == "vcompress" {
+			widths.Source1 = "mask"
+		}
+	case strings.HasPrefix(base, "vmv") && strings.HasSuffix(base, "r"):
+		instruction.Class = "move"
+		widths = ElementWidths{Dest: "SEW", Source2: "SEW"}
+	case strings.HasPrefix(base, "vf"):
+		instruction.Class = "float"
+	default:
+		instruction.Class = "integer"
+	}
+
+	if strings.HasPrefix(base, "vfcvt") || strings.HasPrefix(base, "vfsqrt") || strings.HasPrefix(base, "vfclass") || strings.HasPrefix(base, "vfrsqrt7") || strings.HasPrefix(base, "vfrec7") {
+		widths.Source1 = ""
+	}
+
+	instruction.ElementWidths = widths
+}
+
+func (s *Scraper) maskingMode(mnemonic string, fields []string) string {
+	for _, field := range fields {
+		if field == "vm" {
+			return "v0.t"
+		}
+	}
+
+	switch strings.SplitN(mnemonic, ".", 2)[0] {
+	case "vadc", "vsbc", "vmerge", "vfmerge", "vmadc", "vmsbc":
+		if strings.HasSuffix(mnemonic, "m") {
+			return "v0-operand"
+		}
+	}
+	return "none"
+}
+
+func (s *Scraper) saveData(dataset VectorDataset) error {
+	s.logger.Info("Saving instruction data", "count", len(dataset.Instructions))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(dataset); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(outputFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("Data saved successfully", "file", outputFilename)
+	return nil
+}
+
+func (s *Scraper) Run() error {
+	s.logger.Info("Starting RISC-V vector instruction scraper")
+
+	source, err := s.fetchOpcodes()
+	if err != nil {
+		return fmt.Errorf("failed to fetch opcodes: %w", err)
+	}
+
+	dataset := VectorDataset{
+		Config:       vectorConfig,
+		Instructions: s.parseOpcodes(source),
+	}
+
+	if err := s.saveData(dataset); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+
+	s.logger.Info("Scraping completed successfully")
+	return nil
+}
+
+func main() {
+	scraper := NewScraper()
+	if err := scraper.Run(); err != nil {
+		scraper.logger.Fatal("Scraper failed", "error", err)
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const (
+	sourceURL      = "https://sourceware.org/git/?p=binutils-gdb.git;a=blob_plain;f=opcodes/s390-opc.txt;hb=HEAD"
+	outputFilename = "s390x.json"
+	requestTimeout = 30 * time.Second
+)
+
+type S390xInstruction struct {
+	Mnemonic     string   `json:"mnemonic"`
+	Opcode       string   `json:"opcode"`
+	Format       string   `json:"format"`
+	OperandForm  string   `json:"operandForm"`
+	Length       int      `json:"length"`
+	Description  string   `json:"description"`
+	MinimumLevel string   `json:"minimumLevel"`
+	Modes        []string `json:"modes"`
+	Flags        []string `json:"flags,omitempty"`
+	AnchorID     string   `json:"anchorId"`
+}
+
+var opcodeLinePattern = regexp.MustCompile(`^([0-9a-fA-F]+)\s+(\S+)\s+(\S+)\s+"([^"]*)"\s+(\S+)\s+(\S+)(?:\s+(.*))?$`)
+
+type Scraper struct {
+	client *http.Client
+	logger *log.Logger
+}
+
+func NewScraper() *Scraper {
+	logger := log.NewWithOptions(os.Stderr, log.Options{
+		ReportCaller:    false,
+		ReportTimestamp: true,
+		TimeFormat:      time.Kitchen,
+		Prefix:          "s390x-scraper",
+	})
+
+	client := &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: false},
+			DisableKeepAlives: false,
+			MaxIdleConns:      10,
+			IdleConnTimeout:   90 * time.Second,
+		},
+	}
+
+	return &Scraper{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (s *Scraper) fetchOpcodeTable() ([]byte, error) {
+	s.logger.Info("Fetching s390 opcode table")
+
+	req, err := http.NewRequest("GET", sourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "s390x-scraper/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	return body, nil
+}
+
+func (s *Scraper) parseOpcodeTable(source []byte) []S390xInstruction {
+	var instructions []S390xInstruction
+
+	scanner := bufio.NewScanner(bytes.NewReader(source))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		match := opcodeLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			s.logger.Debug("Skipping unrecognized line", "line", line)
+			continue
+		}
+
+		opcode := strings.ToUpper(match[1])
+		operandForm := match[3]
+		format := strings.SplitN(operandForm, "_", 2)[0]
+
+		instruction := S390xInstruction{
+			Mnemonic:     match[2],
+			Opcode:       opcode,
+			Format:       format,
+			OperandForm:  operandForm,
+			Length:       s.instructionLength(opcode),
+			Description:  match[4],
+			MinimumLevel: match[5],
+			Modes:        strings.Split(match[6], ","),
+			AnchorID:     "s390x-" + strings.ToLower(match[2]),
+		}
+		if match[7] != "" {
+			instruction.Flags = strings.Split(strings.TrimSpace(match[7]), ",")
+		}
+
+		instructions = append(instructions, instruction)
+	}
+
+	sort.SliceStable(instructions, func(i, j int) bool {
+		if instructions[i].Mnemonic != instructions[j].Mnemonic {
+			return instructions[i].Mnemonic < instructions[j].Mnemonic
+		}
+		return instructions[i].Opcode < instructions[j].Opcode
+	})
+
+	return instructions
+}
+
+func (s *Scraper) instructionLength(opcode string) int {
+	if len(opcode) < 2 {
+		return 0
+	}
+
+	first, err := strconv.ParseUint(opcode[:2], 16, 8)
+	if err != nil {
+		return 0
+	}
+
+	switch first >> 6 {
+	case 0:
+		return 2
+	case 1, 2:
+		return 4
+	default:
+		return 6
+	}
+}
+
+func (s *Scraper) saveData(instructions []S390xInstruction) error {
+	s.logger.Info("Saving instruction data", "count", len(instructions))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(instructions); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(outputFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("Data saved successfully", "file", outputFilename)
+	return nil
+}
+
+func (s *Scraper) Run() error {
+	s.logger.Info("Starting s390x instruction scraper")
+
+	source, err := s.fetchOpcodeTable()
+	if err != nil {
+		return fmt.Errorf("failed to fetch opcode table: %w", err)
+	}
+
+	instructions := s.parseOpcodeTable(source)
+
+	if err := s.saveData(instructions); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+
+	s.logger.Info("Scraping completed successfully")
+	return nil
+}
+
+func main() {
+	scraper := NewScraper()
+	if err := scraper.Run(); err != nil {
+		scraper.logger.Fatal("Scraper failed", "error", err)
+	}
+}
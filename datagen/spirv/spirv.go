@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const (
+	sourceURL      = "https://raw.githubusercontent.com/KhronosGroup/SPIRV-Headers/main/include/spirv/unified1/spirv.core.grammar.json"
+	outputFilename = "spirv.json"
+	requestTimeout = 30 * time.Second
+)
+
+type GrammarOperand struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Quantifier string `json:"quantifier"`
+}
+
+type GrammarInstruction struct {
+	OpName       string           `json:"opname"`
+	Class        string           `json:"class"`
+	OpCode       int              `json:"opcode"`
+	Operands     []GrammarOperand `json:"operands"`
+	Capabilities []string         `json:"capabilities"`
+	Extensions   []string         `json:"extensions"`
+	Version      string           `json:"version"`
+	LastVersion  string           `json:"lastVersion"`
+}
+
+type Grammar struct {
+	MajorVersion int                  `json:"major_version"`
+	MinorVersion int                  `json:"minor_version"`
+	Revision     int                  `json:"revision"`
+	Instructions []GrammarInstruction `json:"instructions"`
+}
+
+type SPIRVOperand struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name,omitempty"`
+	Quantifier string `json:"quantifier"`
+}
+
+type SPIRVInstruction struct {
+	Mnemonic     string         `json:"mnemonic"`
+	Opcode       int            `json:"opcode"`
+	OpcodeHex    string         `json:"opcodeHex"`
+	Class        string         `json:"class"`
+	Operands     []SPIRVOperand `json:"operands"`
+	Capabilities []string       `json:"capabilities"`
+	Extensions   []string       `json:"extensions"`
+	Version      string         `json:"version,omitempty"`
+	LastVersion  string         `json:"lastVersion,omitempty"`
+	AnchorID     string         `json:"anchorId"`
+}
+
+type Scraper struct {
+	client *http.Client
+	logger *log.Logger
+}
+
+func NewScraper() *Scraper {
+	logger := log.NewWithOptions(os.Stderr, log.Options{
+		ReportCaller:    false,
+		ReportTimestamp: true,
+		TimeFormat:      time.Kitchen,
+		Prefix:          "spirv-scraper",
+	})
+
+	client := &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: false},
+			DisableKeepAlives: false,
+			MaxIdleConns:      10,
+			IdleConnTimeout:   90 * time.Second,
+		},
+	}
+
+	return &Scraper{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (s *Scraper) fetchGrammar() (*Grammar, error) {
+	s.logger.Info("Fetching SPIR-V core grammar")
+
+	req, err := http.NewRequest("GET", sourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "spirv-scraper/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	var grammar Grammar
+	if err := json.NewDecoder(resp.Body).Decode(&grammar); err != nil {
+		return nil, fmt.Errorf("failed to decode grammar: %w", err)
+	}
+
+	s.logger.Info("Fetched grammar",
+		"version", fmt.Sprintf("%d.%d", grammar.MajorVersion, grammar.MinorVersion),
+		"revision", grammar.Revision,
+		"instructions", len(grammar.Instructions))
+
+	return &grammar, nil
+}
+
+func (s *Scraper) convertToSPIRVFormat(grammar *Grammar) []SPIRVInstruction {
+	var instructions []SPIRVInstruction
+
+	for _, inst := range grammar.Instructions {
+		operands := make([]SPIRVOperand, 0, len(inst.Operands))
+		for _, operand := range inst.Operands {
+			operands = append(operands, SPIRVOperand{
+				Kind:       operand.Kind,
+				Name:       strings.Trim(operand.Name, "'"),
+				Quantifier: s.normalizeQuantifier(operand.Quantifier),
+			})
+		}
+
+		capabilities := inst.Capabilities
+		if capabilities == nil {
+			capabilities = []string{}
+		}
+		extensions := inst.Extensions
+		if extensions == nil {
+			extensions = []string{}
+		}
+
+		version := inst.Version
+		if version == "None" {
+			version = ""
+		}
+
+		instructions = append(instructions, SPIRVInstruction{
+			Mnemonic:     inst.OpName,
+			Opcode:       inst.OpCode,
+			OpcodeHex:    fmt.Sprintf("0x%04X", inst.OpCode),
+			Class:        inst.Class,
+			Operands:     operands,
+			Capabilities: capabilities,
+			Extensions:   extensions,
+			Version:      version,
+			LastVersion:  inst.LastVersion,
+			AnchorID:     "spirv-" + inst.OpName,
+		})
+	}
+
+	return instructions
+}
+
+func (s *Scraper) normalizeQuantifier(quantifier string) string {
+	switch quantifier {
+	case "?":
+		return "optional"
+	case "*":
+		return "variadic"
+	default:
+		return "required"
+	}
+}
+
+func (s *Scraper) saveData(instructions []SPIRVInstruction) error {
+	s.logger.Info("Saving instruction data", "count", len(instructions))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(instructions); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(outputFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("Data saved successfully", "file", outputFilename)
+	return nil
+}
+
+func (s *Scraper) Run() error {
+	s.logger.Info("Starting SPIR-V instruction scraper")
+
+	grammar, err := s.fetchGrammar()
+	if err != nil {
+		return fmt.Errorf("failed to fetch grammar: %w", err)
+	}
+
+	instructions := s.convertToSPIRVFormat(grammar)
+
+	if err := s.saveData(instructions); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+
+	s.logger.Info("Scraping completed successfully")
+	return nil
+}
+
+func main() {
+	scraper := NewScraper()
+	if err := scraper.Run(); err != nil {
+		scraper.logger.Fatal("Scraper failed", "error", err)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import "strings"
+
+type TileConfigField struct {
+	Name        string `json:"name"`
+	Offset      int    `json:"offset"`
+	Size        int    `json:"size"`
+	Count       int    `json:"count,omitempty"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+type TilePalette struct {
+	ID          int    `json:"id"`
+	Tiles       int    `json:"tiles,omitempty"`
+	TileBytes   int    `json:"tileBytes,omitempty"`
+	MaxRows     int    `json:"maxRows,omitempty"`
+	BytesPerRow int    `json:"bytesPerRow,omitempty"`
+	Description string `json:"description"`
+}
+
+type TileOperand struct {
+	Operand string `json:"operand"`
+	Role    string `json:"role"`
+	Shape   string `json:"shape,omitempty"`
+	Element string `json:"element,omitempty"`
+}
+
+type AMXInfo struct {
+	Mnemonic     string            `json:"mnemonic"`
+	TileOperands []TileOperand     `json:"tileOperands,omitempty"`
+	Accumulator  string            `json:"accumulator,omitempty"`
+	Palettes     []TilePalette     `json:"palettes,omitempty"`
+	TileConfig   []TileConfigField `json:"tileConfig,omitempty"`
+}
+
+var tilePalettes = []TilePalette{
+	{ID: 0, Description: "Initialization state; tiles are not configured."},
+	{ID: 1, Tiles: 8, TileBytes: 1024, MaxRows: 16, BytesPerRow: 64, Description: "Eight 1 KiB tile registers (TMM0-TMM7)."},
+}
+
+var tileConfigLayout = []TileConfigField{
+	{Name: "palette_id", Offset: 0, Size: 1, Type: "uint8", Description: "Palette selector; 0 releases tile state."},
+	{Name: "start_row", Offset: 1, Size: 1, Type: "uint8", Description: "Restart row for interrupted TILELOADD/TILESTORED."},
+	{Name: "reserved", Offset: 2, Size: 14, Type: "uint8[14]", Description: "Must be zero."},
+	{Name: "colsb", Offset: 16, Size: 32, Count: 16, Type: "uint16[16]", Description: "Bytes per row for each tile; entries beyond the palette's tile count must be zero."},
+	{Name: "rows", Offset: 48, Size: 16, Count: 16, Type: "uint8[16]", Description: "Row count for each tile; entries beyond the palette's tile count must be zero."},
+}
+
+var tileDotProducts = map[string][3]string{
+	"TDPBSSD":   {"int8", "int8", "int32"},
+	"TDPBSUD":   {"int8", "uint8", "int32"},
+	"TDPBUSD":   {"uint8", "int8", "int32"},
+	"TDPBUUD":   {"uint8", "uint8", "int32"},
+	"TDPBF16PS": {"bf16", "bf16", "fp32"},
+	"TDPFP16PS": {"fp16", "fp16", "fp32"},
+}
+
+func (s *Scraper) linkAMX(data *InstructionData) {
+	data.AMX = nil
+
+	page := data.URL[strings.LastIndex(data.URL, "/")+1:]
+	for _, name := range strings.Split(page, ":") {
+		mnemonic := strings.ToUpper(name)
+		info := AMXInfo{Mnemonic: mnemonic}
+
+		switch {
+		case mnemonic == "LDTILECFG" || mnemonic == "STTILECFG":
+			info.Palettes = tilePalettes
+			info.TileConfig = tileConfigLayout
+		case mnemonic == "TILELOADD" || mnemonic == "TILELOADDT1":
+			info.TileOperands = []TileOperand{{Operand: "tmm1", Role: "destination", Shape: "rows x colsb"}}
+		case mnemonic == "TILESTORED":
+			info.TileOperands = []TileOperand{{Operand: "tmm1", Role: "source", Shape: "rows x colsb"}}
+		case mnemonic == "TILEZERO":
+			info.TileOperands = []TileOperand{{Operand: "tmm1", Role: "destination", Shape: "rows x colsb"}}
+		case mnemonic == "TILERELEASE":
+			info.Palettes = tilePalettes[:1]
+		default:
+			types, ok := tileDotProducts[mnemonic]
+			if !ok {
+				continue
+			}
+			info.Accumulator = types[2]
+			info.TileOperands = []TileOperand{
+				{Operand: "tmm1", Role: "accumulator", Shape: "M x N", Element: types[2]},
+				{Operand: "tmm2", Role: "source1", Shape: "M x K", Element: types[0]},
+				{Operand: "tmm3", Role: "source2", Shape: "K/4 x N*4", Element: types[1]},
+			}
+			if types[2] == "fp32" {
+				info.TileOperands[2].Shape = "K/2 x N*2"
+			}
+		}
+
+		data.AMX = append(data.AMX, info)
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+const (
+	apxFilename  = "apx.json"
+	apxSourceURL = "https://www.intel.com/content/www/us/en/developer/articles/technical/advanced-performance-extensions-apx.html"
+)
+
+type APXRegister struct {
+	Name    string   `json:"name"`
+	Number  int      `json:"number"`
+	Aliases []string `json:"aliases"`
+}
+
+type APXPrefixField struct {
+	Name        string `json:"name"`
+	Location    string `json:"location"`
+	Description string `json:"description"`
+}
+
+type APXInstruction struct {
+	Mnemonic    string          `json:"mnemonic"`
+	Instruction string          `json:"instruction"`
+	Opcode      string          `json:"opcode"`
+	Description string          `json:"description"`
+	Encoding    *OpcodeEncoding `json:"encoding"`
+	LegacyURL   string          `json:"legacyUrl,omitempty"`
+}
+
+type APXDataset struct {
+	Source       string           `json:"source"`
+	Registers    []APXRegister    `json:"registers"`
+	REX2         []APXPrefixField `json:"rex2"`
+	EVEX         []APXPrefixField `json:"evex"`
+	Instructions []APXInstruction `json:"instructions"`
+}
+
+var apxREX2Fields = []APXPrefixField{
+	{Name: "M0", Location: "P0[7]", Description: "Opcode map select: 0 = legacy map 0, 1 = map 1 (0F)."},
+	{Name: "R4", Location: "P0[6]", Description: "High bit of ModRM.reg extension for EGPRs."},
+	{Name: "X4", Location: "P0[5]", Description: "High bit of SIB.index extension for EGPRs."},
+	{Name: "B4", Location: "P0[4]", Description: "High bit of ModRM.rm / SIB.base / opcode register extension for EGPRs."},
+	{Name: "W", Location: "P0[3]", Description: "Operand size, as REX.W."},
+	{Name: "R3", Location: "P0[2]", Description: "Equivalent to REX.R."},
+	{Name: "X3", Location: "P0[1]", Description: "Equivalent to REX.X."},
+	{Name: "B3", Location: "P0[0]", Description: "Equivalent to REX.B."},
+}
+
+var apxEVEXFields = []APXPrefixField{
+	{Name: "R4", Location: "P0[4]", Description: "EVEX.R' reused as fifth bit of ModRM.reg for EGPRs."},
+	{Name: "B4", Location: "P0[3]", Description: "Previously reserved; fifth bit of ModRM.rm / SIB.base for EGPRs."},
+	{Name: "X4", Location: "P1[2]", Description: "Previously fixed to 1; fifth bit of SIB.index for EGPRs."},
+	{Name: "ND", Location: "P2[4]", Description: "New data destination: EVEX.vvvv encodes a non-destructive destination register."},
+	{Name: "NF", Location: "P2[2]", Description: "No flags: suppresses status flag updates."},
+	{Name: "SCC", Location: "P2[3:0]", Description: "Source condition code for CCMPscc/CTESTscc."},
+}
+
+type apxDef struct {
+	opcode      string
+	instruction string
+	description string
+}
+
+var apxDefs = []apxDef{
+	{"EVEX.LLZ.NP.MAP4.W0.ND1 00 /r", "ADD r8, r/m8, r8", "Add r8 to r/m8 into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1.NF1 01 /r", "{NF} ADD r64, r/m64, r64", "Add without updating flags, new destination."},
+	{"EVEX.LLZ.NP.MAP4.ND1 81 /0 id", "ADD r64, r/m64, imm32", "Add sign-extended imm32 into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 11 /r", "ADC r64, r/m64, r64", "Add with carry into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 19 /r", "SBB r64, r/m64, r64", "Subtract with borrow into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 29 /r", "SUB r64, r/m64, r64", "Subtract into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 21 /r", "AND r64, r/m64, r64", "Bitwise AND into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 09 /r", "OR r64, r/m64, r64", "Bitwise OR into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 31 /r", "XOR r64, r/m64, r64", "Bitwise XOR into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 FF /0", "INC r64, r/m64", "Increment into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 FF /1", "DEC r64, r/m64", "Decrement into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 F7 /3", "NEG r64, r/m64", "Two's complement negate into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 F7 /2", "NOT r64, r/m64", "One's complement into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 AF /r", "IMUL r64, r64, r/m64", "Signed multiply into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 D3 /4", "SHL r64, r/m64, CL", "Shift left by CL into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 C1 /5 ib", "SHR r64, r/m64, imm8", "Logical shift right into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 C1 /7 ib", "SAR r64, r/m64, imm8", "Arithmetic shift right into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 C1 /0 ib", "ROL r64, r/m64, imm8", "Rotate left into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 C1 /1 ib", "ROR r64, r/m64, imm8", "Rotate right into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 24 /r ib", "SHLD r64, r/m64, r64, imm8", "Double-precision shift left into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.ND1 2C /r ib", "SHRD r64, r/m64, r64, imm8", "Double-precision shift right into a new destination register."},
+	{"EVEX.LLZ.66.MAP4.ND1 66 /r", "ADCX r64, r64, r/m64", "Unsigned add with carry flag into a new destination register."},
+	{"EVEX.LLZ.F3.MAP4.ND1 66 /r", "ADOX r64, r64, r/m64", "Unsigned add with overflow flag into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.NF1 88 /r", "{NF} POPCNT r64, r/m64", "Population count without updating flags."},
+	{"EVEX.LLZ.NP.MAP4.NF1 F5 /r", "{NF} LZCNT r64, r/m64", "Count leading zeros without updating flags."},
+	{"EVEX.LLZ.NP.MAP4.NF1 F4 /r", "{NF} TZCNT r64, r/m64", "Count trailing zeros without updating flags."},
+	{"EVEX.LLZ.NP.MAP4.ND1 40 /r", "CMOVO r64, r/m64, r64", "Conditional move into a new destination register."},
+	{"EVEX.LLZ.NP.MAP4.NF1 44 /r", "CFCMOVE r64, r/m64", "Conditional faulting move; memory faults are suppressed when the condition is false."},
+	{"EVEX.LLZ.F2.MAP4.ZU1 44 /0", "SETZUE r/m8", "Set byte on condition and zero the upper bits of the destination."},
+	{"EVEX.LLZ.NP.MAP4.SCC 39 /r", "CCMPE r/m64, r64", "Conditional compare; sets flags from the default flags value when the source condition is false."},
+	{"EVEX.LLZ.NP.MAP4.SCC 85 /r", "CTESTE r/m64, r64", "Conditional test; sets flags from the default flags value when the source condition is false."},
+	{"EVEX.LLZ.NP.MAP4.ND1 FF /6", "PUSH2 r64, r64", "Push two 64-bit registers."},
+	{"EVEX.LLZ.NP.MAP4.ND1 8F /0", "POP2 r64, r64", "Pop two 64-bit registers."},
+	{"REX2.M0.W1 50+rd", "PUSHP r64", "Push with a balanced push/pop hint."},
+	{"REX2.M0.W1 58+rd", "POPP r64", "Pop with a balanced push/pop hint."},
+	{"REX2.M0.W0 A1 io", "JMPABS imm64", "Absolute jump to a 64-bit immediate address."},
+}
+
+var apxRegisterAliases = []string{"R%dD", "R%dW", "R%dB"}
+
+func (s *Scraper) buildAPXRegisters() []APXRegister {
+	var registers []APXRegister
+	for number := 16; number < 32; number++ {
+		register := APXRegister{Name: fmt.Sprintf("R%d", number), Number: number}
+		for _, alias := range apxRegisterAliases {
+			register.Aliases = append(register.Aliases, fmt.Sprintf(alias, number))
+		}
+		registers = append(registers, register)
+	}
+	return registers
+}
+
+func (s *Scraper) buildAPXInstructions(instructions []InstructionData) []APXInstruction {
+	legacyURLs := make(map[string]string)
+	for _, data := range instructions {
+		for _, form := range data.Forms {
+			if _, ok := legacyURLs[form.Mnemonic]; !ok && form.Mnemonic != "" {
+				legacyURLs[form.Mnemonic] = data.URL
+			}
+		}
+	}
+
+	var records []APXInstruction
+	for _, def := range apxDefs {
+		form := InstructionForm{Opcode: def.opcode, Instruction: strings.TrimPrefix(def.instruction, "{NF} ")}
+		form.Mnemonic, form.Operands = s.splitInstructionSyntax(form.Instruction)
+
+		records = append(records, APXInstruction{
+			Mnemonic:    form.Mnemonic,
+			Instruction: def.instruction,
+			Opcode:      def.opcode,
+			Description: def.description,
+			Encoding:    s.parseOpcodeEncoding(form),
+			LegacyURL:   legacyURLs[form.Mnemonic],
+		})
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Mnemonic < records[j].Mnemonic
+	})
+	return records
+}
+
+func (s *Scraper) saveAPX(instructions []InstructionData) error {
+	dataset := APXDataset{
+		Source:       apxSourceURL,
+		Registers:    s.buildAPXRegisters(),
+		REX2:         apxREX2Fields,
+		EVEX:         apxEVEXFields,
+		Instructions: s.buildAPXInstructions(instructions),
+	}
+
+	s.logger.Info("Saving APX data", "count", len(dataset.Instructions))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(dataset); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(apxFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("APX data saved successfully", "file", apxFilename)
+	return nil
+}
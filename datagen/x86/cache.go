@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"arisa/pagecache"
+	"github.com/PuerkitoBio/goquery"
+)
+
+// cacheFetcher replays pages recorded with --cache-dir for --offline runs,
+// without touching the network.
+type cacheFetcher struct {
+	*Scraper
+}
+
+func (s cacheFetcher) Fetch(_ context.Context, pageURL string) (*goquery.Document, pageValidators, error) {
+	return s.loadCachedDocument(pageURL)
+}
+
+func (s *Scraper) readCachedPage(pageURL string) ([]byte, error) {
+	target, err := pagecache.Path(s.cacheDir, pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := os.ReadFile(target)
+	if err != nil {
+		return nil, fmt.Errorf("page not in cache: %w", err)
+	}
+	return body, nil
+}
+
+// loadCachedDocument parses a page from the cache for --offline runs. The
+// previous record's validators are carried over since nothing was fetched.
+func (s *Scraper) loadCachedDocument(pageURL string) (*goquery.Document, pageValidators, error) {
+	body, err := s.readCachedPage(pageURL)
+	if err != nil {
+		return nil, pageValidators{}, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, pageValidators{}, fmt.Errorf("failed to parse cached HTML: %w", err)
+	}
+
+	s.metrics.observeCacheRead()
+	previous := s.previousData[pageURL]
+	return doc, pageValidators{ETag: previous.ETag, LastModified: previous.LastModified}, nil
+}
+
+// cachePage stores a page's raw HTML. Failing to cache is logged rather
+// than returned since the page itself was fetched fine.
+func (s *Scraper) cachePage(pageURL string, body []byte) {
+	if s.cacheDir == "" || s.dryRun {
+		return
+	}
+
+	target, err := pagecache.Path(s.cacheDir, pageURL)
+	if err == nil {
+		err = s.writeFileAtomic(target, body)
+	}
+	if err != nil {
+		s.logger.Warn("Failed to cache page", "url", pageURL, "error", err)
+	}
+}
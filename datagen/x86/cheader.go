@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"arisa/cheader"
+)
+
+const opcodeHeaderFilename = "x86_opcodes.h"
+
+// opcodeHeaderMaps orders the opcode map enum; maps not listed here, such as
+// the APX and AVX10 ones, follow in name order.
+var opcodeHeaderMaps = []string{"legacy", "0F", "0F38", "0F3A"}
+
+// buildOpcodeHeader emits one constant per instruction form that packs its
+// opcode map and opcode byte, X86_OPCODE(map, byte), named after the form's
+// syntax. Forms whose opcode byte couldn't be parsed are left out.
+func (s *Scraper) buildOpcodeHeader(instructions []InstructionData) []byte {
+	type constant struct {
+		name, comment string
+		mapName       string
+		opcode        string
+	}
+
+	var constants []constant
+	used := make(map[string]string)
+	maps := make(map[string]bool)
+	for _, data := range instructions {
+		if data.Error != "" {
+			continue
+		}
+		for _, form := range data.Forms {
+			// Register-coded opcodes such as B8+rd keep only the base byte.
+			if form.Encoding == nil || len(form.Encoding.OpcodeByte) < 2 || form.Encoding.Map == "" {
+				continue
+			}
+
+			syntax := form.Instruction
+			if syntax == "" {
+				syntax = form.Mnemonic
+			}
+			base := cheader.Identifier(strings.ReplaceAll(syntax, "r/m", "rm"))
+			if base == "" {
+				continue
+			}
+
+			// The same form is often listed on more than one page, such as
+			// Jcc's on both JZ and JE; it is defined once. Different
+			// encodings of the same syntax are told apart by prefix class.
+			value := form.Encoding.Map + " " + form.Encoding.OpcodeByte[:2]
+			name := "X86_" + base
+			if previous, ok := used[name]; ok && previous == value {
+				continue
+			} else if ok {
+				name += "_" + cheader.Identifier(form.Encoding.PrefixClass)
+			}
+			for i, unique := 2, name; used[name] != "" && used[name] != value; i++ {
+				name = fmt.Sprintf("%s_%d", unique, i)
+			}
+			if used[name] == value {
+				continue
+			}
+			used[name] = value
+			maps[form.Encoding.Map] = true
+
+			comment := fmt.Sprintf("%s (%s)", form.Instruction, form.Opcode)
+			if form.Description != "" {
+				comment += ": " + form.Description
+			}
+			constants = append(constants, constant{
+				name:    name,
+				comment: cheader.Comment(comment),
+				mapName: form.Encoding.Map,
+				opcode:  form.Encoding.OpcodeByte[:2],
+			})
+		}
+	}
+
+	var mapOrder []string
+	for _, name := range opcodeHeaderMaps {
+		mapOrder = append(mapOrder, name)
+		delete(maps, name)
+	}
+	var extra []string
+	for name := range maps {
+		extra = append(extra, name)
+	}
+	sort.Strings(extra)
+	mapOrder = append(mapOrder, extra...)
+
+	header := cheader.New(opcodeHeaderFilename, s.indexURL)
+	header.WriteString("enum x86_opcode_map {\n")
+	for i, name := range mapOrder {
+		fmt.Fprintf(header, "\tX86_MAP_%s = %d,\n", cheader.Identifier(name), i)
+	}
+	header.WriteString("};\n\n")
+	header.WriteString("#define X86_OPCODE(map, byte) (((map) << 8) | (byte))\n")
+	header.WriteString("#define X86_OPCODE_MAP(opcode) ((enum x86_opcode_map)((opcode) >> 8))\n")
+	header.WriteString("#define X86_OPCODE_BYTE(opcode) ((opcode) & 0xff)\n\n")
+
+	for _, c := range constants {
+		fmt.Fprintf(header, "/* %s */\n", c.comment)
+		fmt.Fprintf(header, "#define %s X86_OPCODE(X86_MAP_%s, 0x%s)\n", c.name, cheader.Identifier(c.mapName), c.opcode)
+	}
+	return header.Finish()
+}
+
+func (s *Scraper) saveOpcodeHeader(instructions []InstructionData) error {
+	if err := ioutil.WriteFile(opcodeHeaderFilename, s.buildOpcodeHeader(instructions), 0644); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	s.logger.Info("Opcode header saved successfully", "file", opcodeHeaderFilename)
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const defaultCheckpointEvery = 100
+
+// checkpoint appends scraped records to a JSON Lines file in batches while
+// a run is in progress, so a run that dies before saveData doesn't lose
+// everything it fetched. loadExistingData replays the file on the next run
+// and saveData's caller deletes it once the dataset itself is written.
+type checkpoint struct {
+	file    *os.File
+	writer  *bufio.Writer
+	every   int
+	pending int
+}
+
+func (s *Scraper) checkpointPath() string {
+	return s.outputFilename + ".checkpoint"
+}
+
+// openCheckpoint returns nil when checkpointing is off or the file can't be
+// opened; the run goes on without it either way.
+func (s *Scraper) openCheckpoint() *checkpoint {
+	if s.checkpointEvery <= 0 {
+		return nil
+	}
+
+	file, err := os.OpenFile(s.checkpointPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		s.logger.Warn("Failed to open checkpoint, continuing without one", "file", s.checkpointPath(), "error", err)
+		return nil
+	}
+	return &checkpoint{file: file, writer: bufio.NewWriter(file), every: s.checkpointEvery}
+}
+
+// add queues a record and writes the batch out once every records have
+// been queued.
+func (c *checkpoint) add(data InstructionData) error {
+	if c == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint record: %w", err)
+	}
+	c.writer.Write(line)
+	c.writer.WriteByte('\n')
+
+	c.pending++
+	if c.pending < c.every {
+		return nil
+	}
+	return c.flush()
+}
+
+func (c *checkpoint) flush() error {
+	if c == nil || c.pending == 0 {
+		return nil
+	}
+	c.pending = 0
+
+	if err := c.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := c.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (c *checkpoint) close() error {
+	if c == nil {
+		return nil
+	}
+	err := c.flush()
+	if closeErr := c.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// loadCheckpoint replays records a previous run checkpointed but never got
+// to save. They are newer than the dataset, so they replace its records the
+// same way freshly scraped ones would. A record cut off by the crash ends
+// the replay.
+func (s *Scraper) loadCheckpoint() error {
+	file, err := os.Open(s.checkpointPath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open checkpoint: %w", err)
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	replayed := 0
+	for {
+		var item InstructionData
+		if err := decoder.Decode(&item); err != nil {
+			if !errors.Is(err, io.EOF) {
+				s.logger.Warn("Checkpoint ends with an incomplete record", "file", s.checkpointPath(), "error", err)
+			}
+			break
+		}
+
+		s.previousData[item.URL] = item
+		if item.Error == "" {
+			s.successfullyScraped[item.URL] = true
+		} else {
+			delete(s.successfullyScraped, item.URL)
+		}
+		replayed++
+	}
+
+	s.logger.Info("Resumed from checkpoint", "file", s.checkpointPath(), "records", replayed)
+	return nil
+}
+
+func (s *Scraper) removeCheckpoint() {
+	if err := os.Remove(s.checkpointPath()); err != nil && !os.IsNotExist(err) {
+		s.logger.Warn("Failed to remove checkpoint", "file", s.checkpointPath(), "error", err)
+	}
+}
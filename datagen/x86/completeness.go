@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+type CompletenessGap struct {
+	Mnemonic string
+	URL      string
+	Problem  string
+}
+
+// checkCompleteness compares every instruction linked from the index page
+// against the final dataset and reports the ones that are missing or were
+// recorded with an error.
+func (s *Scraper) checkCompleteness(instructions []InstructionData) []CompletenessGap {
+	byURL := make(map[string]InstructionData, len(instructions))
+	for _, data := range instructions {
+		byURL[data.URL] = data
+		for _, alias := range data.Aliases {
+			byURL[alias] = data
+		}
+	}
+
+	var gaps []CompletenessGap
+	for url, mnemonic := range s.indexEntries {
+		data, ok := byURL[url]
+		switch {
+		case !ok:
+			gaps = append(gaps, CompletenessGap{Mnemonic: mnemonic, URL: url, Problem: "missing from dataset"})
+		case data.Error != "":
+			gaps = append(gaps, CompletenessGap{Mnemonic: mnemonic, URL: url, Problem: data.Error})
+		}
+	}
+
+	sort.Slice(gaps, func(i, j int) bool {
+		return gaps[i].Mnemonic < gaps[j].Mnemonic
+	})
+	return gaps
+}
+
+func (s *Scraper) verifyCompleteness(instructions []InstructionData) error {
+	gaps := s.checkCompleteness(instructions)
+	for _, gap := range gaps {
+		s.logger.Warn("Incomplete instruction", "mnemonic", gap.Mnemonic, "url", gap.URL, "problem", gap.Problem)
+	}
+
+	if len(gaps) == 0 {
+		s.logger.Info("Dataset covers the index", "instructions", len(s.indexEntries))
+		return nil
+	}
+
+	s.logger.Warn("Dataset is incomplete", "gaps", len(gaps), "indexed", len(s.indexEntries))
+	if s.requireComplete {
+		return fmt.Errorf("%w: %d of %d indexed instructions missing or failed", errIncomplete, len(gaps), len(s.indexEntries))
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const defaultMinWorkers = 4
+
+// concurrencyLimit caps how many workers fetch at once, adapting the cap to
+// how the site responds: it halves when 429 or 5xx responses come back and
+// grows by one after each window of that many clean responses. That keeps a
+// long run from turning into a wall of rate-limit errors while still using
+// the full pool when the site is keeping up.
+type concurrencyLimit struct {
+	mu           sync.Mutex
+	cond         *sync.Cond
+	logger       *log.Logger
+	adaptive     bool
+	limit        int
+	active       int
+	min          int
+	max          int
+	successes    int
+	lastDecrease time.Time
+}
+
+func (s *Scraper) newConcurrencyLimit(workers int) *concurrencyLimit {
+	l := &concurrencyLimit{
+		logger:   s.logger,
+		adaptive: s.adaptive,
+		limit:    workers,
+		min:      min(max(s.minWorkers, 1), workers),
+		max:      workers,
+	}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// acquire waits for a free slot. It returns the context's error, without a
+// slot, once ctx is cancelled.
+func (l *concurrencyLimit) acquire(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.cond.Broadcast()
+	})
+	defer stop()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for l.active >= l.limit {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		l.cond.Wait()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	l.active++
+	return nil
+}
+
+func (l *concurrencyLimit) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.active--
+	l.cond.Signal()
+}
+
+// observe adjusts the limit for the status of a request sent at started.
+// Failures of requests sent before the last decrease are ignored, since
+// they were made under the old limit and would otherwise halve it again for
+// the same overload. It is safe to call on a nil limit, as happens for the
+// index page before any workers start.
+func (l *concurrencyLimit) observe(status int, started time.Time) {
+	if l == nil || !l.adaptive {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if status == http.StatusTooManyRequests || status >= 500 {
+		l.successes = 0
+		if l.limit == l.min || started.Before(l.lastDecrease) {
+			return
+		}
+		previous := l.limit
+		l.limit = max(l.limit/2, l.min)
+		l.lastDecrease = time.Now()
+		l.logger.Warn("Server is struggling, reducing concurrency", "status", status, "from", previous, "to", l.limit)
+		return
+	}
+
+	l.successes++
+	if l.limit < l.max && l.successes >= l.limit {
+		l.successes = 0
+		l.limit++
+		l.cond.Broadcast()
+		l.logger.Debug("Increasing concurrency", "to", l.limit)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+)
+
+var errNotModified = errors.New("page not modified")
+
+// pageValidators are the HTTP cache validators a page was served with,
+// kept so the next run can ask for it conditionally.
+type pageValidators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// merge keeps the previous validators for any the 304 response didn't
+// repeat; servers may send just one of them.
+func (v pageValidators) merge(etag, lastModified string) (string, string) {
+	if v.ETag != "" {
+		etag = v.ETag
+	}
+	if v.LastModified != "" {
+		lastModified = v.LastModified
+	}
+	return etag, lastModified
+}
+
+// setConditionalHeaders asks the server to answer 304 when the page still
+// matches the copy in the previous dataset. Pages that failed last time are
+// always fetched in full.
+func (s *Scraper) setConditionalHeaders(req *http.Request, pageURL string) {
+	previous, ok := s.previousData[pageURL]
+	if !ok || previous.Error != "" {
+		return
+	}
+	if previous.ETag != "" {
+		req.Header.Set("If-None-Match", previous.ETag)
+	}
+	if previous.LastModified != "" {
+		req.Header.Set("If-Modified-Since", previous.LastModified)
+	}
+}
+
+func (s *Scraper) responseValidators(resp *http.Response) pageValidators {
+	return pageValidators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+}
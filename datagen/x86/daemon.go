@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"arisa/robots"
+)
+
+const (
+	defaultDaemonInterval = 24 * time.Hour
+	discordMessageLimit   = 2000
+)
+
+// Daemon re-runs the scrape every interval until ctx is cancelled, posting
+// a summary of what changed to the webhook after each run. Every run
+// re-checks all pages, relying on ETag/Last-Modified to keep unchanged ones
+// cheap, since an incremental run would never notice an edited page.
+func (s *Scraper) Daemon(ctx context.Context, interval time.Duration) error {
+	s.force = true
+
+	for {
+		before, err := s.readDataset(s.outputFilename)
+		hadPrevious := err == nil
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.logger.Warn("Could not read current dataset for diffing", "error", err)
+		}
+
+		runErr := s.Run(ctx)
+		if ctx.Err() != nil {
+			s.logger.Info("Daemon stopping")
+			return nil
+		}
+		if runErr != nil {
+			s.logger.Error("Scheduled run failed", "error", runErr)
+		}
+
+		after, err := s.readDataset(s.outputFilename)
+		switch {
+		case err != nil:
+			s.logger.Warn("Could not read new dataset for diffing", "error", err)
+		case !hadPrevious:
+			s.logger.Info("No previous dataset to diff against, not notifying")
+		default:
+			s.notifyChanges(ctx, s.diffDatasets(before, after))
+		}
+
+		s.logger.Info("Next run scheduled", "at", time.Now().Add(interval).Format(time.RFC3339))
+		if err := s.sleep(ctx, interval); err != nil {
+			s.logger.Info("Daemon stopping")
+			return nil
+		}
+		s.resetRunState()
+	}
+}
+
+// resetRunState clears what one run accumulates so the next starts from the
+// dataset on disk like a fresh process would, robots.txt included.
+func (s *Scraper) resetRunState() {
+	s.previousData = make(map[string]InstructionData)
+	s.successfullyScraped = make(map[string]bool)
+	s.indexEntries = nil
+	s.metrics = newRunMetrics()
+	if s.robots != nil {
+		s.robots = robots.NewChecker(s.client, s.userAgent)
+	}
+}
+
+func (s *Scraper) notifyChanges(ctx context.Context, diff DatasetDiff) {
+	s.logger.Info("Dataset changes",
+		"added", len(diff.Added),
+		"changed", len(diff.Changed),
+		"removed", len(diff.Removed))
+
+	if diff.Empty() || s.webhookURL == "" {
+		return
+	}
+	if err := s.postWebhook(ctx, diff); err != nil {
+		s.logger.Warn("Failed to send change webhook", "error", err)
+	}
+}
+
+// postWebhook sends the diff as JSON, or as a chat message when the URL is
+// a Discord webhook.
+func (s *Scraper) postWebhook(ctx context.Context, diff DatasetDiff) error {
+	var payload any = struct {
+		Dataset string `json:"dataset"`
+		File    string `json:"file"`
+		Time    string `json:"time"`
+		DatasetDiff
+	}{"x86", s.outputFilename, time.Now().UTC().Format(time.RFC3339), diff}
+	if s.isDiscordWebhook() {
+		payload = map[string]string{"content": s.discordMessage(diff)}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", s.userAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+
+	s.logger.Info("Change webhook sent")
+	return nil
+}
+
+func (s *Scraper) isDiscordWebhook() bool {
+	return strings.Contains(s.webhookURL, "discord.com/api/webhooks/") ||
+		strings.Contains(s.webhookURL, "discordapp.com/api/webhooks/")
+}
+
+// discordMessage summarizes the diff within Discord's message length limit,
+// listing as many mnemonics as fit.
+func (s *Scraper) discordMessage(diff DatasetDiff) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**x86 dataset refreshed**: %d added, %d changed, %d removed",
+		len(diff.Added), len(diff.Changed), len(diff.Removed))
+
+	for _, section := range []struct {
+		title   string
+		entries []DiffEntry
+	}{
+		{"Added", diff.Added},
+		{"Changed", diff.Changed},
+		{"Removed", diff.Removed},
+	} {
+		if len(section.entries) == 0 {
+			continue
+		}
+		names := make([]string, len(section.entries))
+		for i, entry := range section.entries {
+			names[i] = entry.Name
+		}
+		fmt.Fprintf(&b, "\n%s: %s", section.title, strings.Join(names, ", "))
+	}
+
+	// Discord counts the limit in characters, and cutting inside one
+	// would leave invalid UTF-8.
+	message := []rune(b.String())
+	if len(message) > discordMessageLimit {
+		message = append(message[:discordMessageLimit-1], '…')
+	}
+	return string(message)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestDiscordMessageTruncatesOnRuneBoundary(t *testing.T) {
+	s := &Scraper{}
+	var diff DatasetDiff
+	for i := 0; i < 1000; i++ {
+		diff.Changed = append(diff.Changed, DiffEntry{Name: "VFMADD132PS—Fused"})
+	}
+
+	message := s.discordMessage(diff)
+	if !utf8.ValidString(message) {
+		t.Fatal("message is not valid UTF-8")
+	}
+	if n := utf8.RuneCountInString(message); n != discordMessageLimit {
+		t.Errorf("message has %d characters, want %d", n, discordMessageLimit)
+	}
+	if !strings.HasSuffix(message, "…") {
+		t.Errorf("message doesn't end in an ellipsis: %q", message[len(message)-20:])
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"arisa/dataset"
+)
+
+// diffIgnoredFields change on every fetch or say how a record was built
+// rather than what it says, so they don't count as edits.
+var diffIgnoredFields = []string{"contentHash", "etag", "lastModified", "provenance", "forms"}
+
+// DiffEntry is a record that was added, removed or changed. Changed records
+// list the fields that differ.
+type DiffEntry struct {
+	URL    string                `json:"url"`
+	Name   string                `json:"name"`
+	Fields []dataset.FieldChange `json:"fields,omitempty"`
+}
+
+// DatasetDiff is what changed between two saves of the dataset, matched by
+// URL and compared by content hash and field by field. Expanded mnemonic
+// records follow their page and are left out.
+type DatasetDiff struct {
+	Added   []DiffEntry `json:"added"`
+	Changed []DiffEntry `json:"changed"`
+	Removed []DiffEntry `json:"removed"`
+}
+
+func (d DatasetDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+func (s *Scraper) diffDatasets(before, after []InstructionData) DatasetDiff {
+	index := func(records []InstructionData) map[string]InstructionData {
+		byURL := make(map[string]InstructionData)
+		for _, data := range records {
+			if data.Parent == "" {
+				byURL[data.URL] = data
+			}
+		}
+		return byURL
+	}
+	old, current := index(before), index(after)
+
+	diff := DatasetDiff{Added: []DiffEntry{}, Changed: []DiffEntry{}, Removed: []DiffEntry{}}
+	for url, data := range current {
+		entry := DiffEntry{URL: url, Name: s.titleMnemonic(data)}
+		previous, ok := old[url]
+		if !ok {
+			diff.Added = append(diff.Added, entry)
+			continue
+		}
+		entry.Fields = s.diffFields(previous, data)
+		if len(entry.Fields) > 0 || previous.ContentHash != data.ContentHash || previous.Error != data.Error {
+			diff.Changed = append(diff.Changed, entry)
+		}
+	}
+	for url, data := range old {
+		if _, ok := current[url]; !ok {
+			diff.Removed = append(diff.Removed, DiffEntry{URL: url, Name: s.titleMnemonic(data)})
+		}
+	}
+
+	for _, entries := range [][]DiffEntry{diff.Added, diff.Changed, diff.Removed} {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].URL < entries[j].URL })
+	}
+	return diff
+}
+
+// diffFields lists the fields that differ between two versions of a page.
+// Forms are matched by opcode and syntax, so a new encoding shows up as an
+// added form rather than a changed list.
+func (s *Scraper) diffFields(before, after InstructionData) []dataset.FieldChange {
+	changes, err := dataset.DiffFields(before, after, diffIgnoredFields...)
+	if err != nil {
+		s.logger.Warn("Could not compare records", "url", after.URL, "error", err)
+	}
+
+	formKey := func(form InstructionForm) string { return form.Opcode + " " + form.Instruction }
+	if change, ok := dataset.DiffKeyed("forms", before.Forms, after.Forms, formKey); ok {
+		changes = append(changes, change)
+		sort.SliceStable(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	}
+	return changes
+}
+
+// Diff compares two datasets and prints the records that were added,
+// removed or changed, with the fields that changed, for reviewing a refresh
+// before publishing it.
+func (s *Scraper) Diff(args []string) error {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	format := flags.String("format", "text", "report format: text or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return fmt.Errorf("usage: diff [--format text|json] <old.json> <new.json>")
+	}
+
+	before, err := s.readDataset(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	after, err := s.readDataset(flags.Arg(1))
+	if err != nil {
+		return err
+	}
+	diff := s.diffDatasets(before, after)
+
+	switch *format {
+	case "json":
+		buffer := new(bytes.Buffer)
+		encoder := json.NewEncoder(buffer)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(diff); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		os.Stdout.Write(buffer.Bytes())
+	case "text":
+		s.writeDiffText(os.Stdout, diff)
+	default:
+		return fmt.Errorf("unsupported format %q", *format)
+	}
+	return nil
+}
+
+func (s *Scraper) writeDiffText(w io.Writer, diff DatasetDiff) {
+	sections := []struct {
+		title   string
+		marker  string
+		entries []DiffEntry
+	}{
+		{"Added", "+", diff.Added},
+		{"Removed", "-", diff.Removed},
+		{"Changed", "~", diff.Changed},
+	}
+	for _, section := range sections {
+		if len(section.entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s (%d):\n", section.title, len(section.entries))
+		for _, entry := range section.entries {
+			fmt.Fprintf(w, "  %s %s  %s\n", section.marker, entry.Name, entry.URL)
+			dataset.WriteFieldChanges(w, "      ", entry.Fields)
+		}
+	}
+	fmt.Fprintf(w, "%d added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const defaultDocsDir = "docs"
+
+// exceptionSection is a heading in a page's exceptions, keyed the way
+// InstructionData.Exceptions is.
+type exceptionSection struct {
+	key   string
+	title string
+}
+
+// exceptionModeTitles orders and names the exception sections the way the
+// SDM does; any other section follows them under its own name.
+var exceptionModeTitles = []exceptionSection{
+	{"protectedMode", "Protected Mode Exceptions"},
+	{"realAddressMode", "Real-Address Mode Exceptions"},
+	{"virtual8086Mode", "Virtual-8086 Mode Exceptions"},
+	{"compatibilityMode", "Compatibility Mode Exceptions"},
+	{"64BitMode", "64-Bit Mode Exceptions"},
+}
+
+// tableColumnRanks orders the columns of a raw table, which are stored as
+// maps and so have lost the page's order. The first keyword a lowercased
+// header contains decides its place.
+var tableColumnRanks = []string{"opcode", "instruction", "op/", "tuple", "operand", "64", "mode", "support", "cpuid", "description"}
+
+// Docs renders a dataset as one Markdown page per instruction, plus a
+// SUMMARY.md listing them by category that doubles as an mdBook summary.
+func (s *Scraper) Docs(args []string) error {
+	flags := flag.NewFlagSet("docs", flag.ContinueOnError)
+	outputDir := flags.String("out", defaultDocsDir, "directory to write the Markdown pages to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("usage: docs [--out dir] [file.json]")
+	}
+	filename := s.outputFilename
+	if flags.NArg() == 1 {
+		filename = flags.Arg(0)
+	}
+
+	instructions, err := s.readDataset(filename)
+	if err != nil {
+		return err
+	}
+
+	pages := s.docsPages(instructions)
+	for _, data := range pages {
+		target := filepath.Join(*outputDir, s.docsPageName(data))
+		if err := s.writeFileAtomic(target, []byte(s.renderInstructionDoc(data))); err != nil {
+			return fmt.Errorf("failed to write %s: %w", target, err)
+		}
+	}
+	if err := s.writeFileAtomic(filepath.Join(*outputDir, "SUMMARY.md"), []byte(s.renderDocsSummary(pages))); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+
+	s.logger.Info("Docs saved", "dir", *outputDir, "pages", len(pages))
+	return nil
+}
+
+// docsPages picks the records that get a page of their own, sorted by
+// title. Condition-code children are sections of their parent's page.
+func (s *Scraper) docsPages(instructions []InstructionData) []InstructionData {
+	var pages []InstructionData
+	for _, data := range instructions {
+		if data.Error == "" && data.Parent == "" {
+			pages = append(pages, data)
+		}
+	}
+	sort.SliceStable(pages, func(i, j int) bool { return s.docsTitle(pages[i]) < s.docsTitle(pages[j]) })
+	return pages
+}
+
+func (s *Scraper) docsTitle(data InstructionData) string {
+	return strings.Join(strings.Fields(data.InstructionName), " ")
+}
+
+// docsPageName names a page after the last segment of its URL, which the
+// site already keeps unique.
+func (s *Scraper) docsPageName(data InstructionData) string {
+	return s.docsSlug(data) + ".md"
+}
+
+// docsSlug is the last segment of a record's URL, with the colons that
+// join combined pages such as "psrlw:psrld:psrlq" swapped for underscores,
+// since Windows can't put them in a file name.
+func (s *Scraper) docsSlug(data InstructionData) string {
+	return strings.ReplaceAll(path.Base(strings.TrimSuffix(data.URL, "/")), ":", "_")
+}
+
+func (s *Scraper) renderDocsSummary(pages []InstructionData) string {
+	var categories []string
+	byCategory := make(map[string][]InstructionData)
+	for _, data := range pages {
+		if _, seen := byCategory[data.Category]; !seen {
+			categories = append(categories, data.Category)
+		}
+		byCategory[data.Category] = append(byCategory[data.Category], data)
+	}
+	sort.Strings(categories)
+
+	var b strings.Builder
+	b.WriteString("# Summary\n")
+	for _, category := range categories {
+		title := category
+		if title == "" {
+			title = "Uncategorized"
+		}
+		fmt.Fprintf(&b, "\n# %s\n\n", title)
+		for _, data := range byCategory[category] {
+			fmt.Fprintf(&b, "- [%s](%s)\n", s.markdownLinkText(s.docsTitle(data)), s.docsPageName(data))
+		}
+	}
+	return b.String()
+}
+
+func (s *Scraper) renderInstructionDoc(data InstructionData) string {
+	var sections []string
+	add := func(heading, body string) {
+		if strings.TrimSpace(body) != "" {
+			sections = append(sections, "## "+heading+"\n\n"+strings.TrimSpace(body))
+		}
+	}
+
+	header := "# " + s.docsTitle(data) + "\n\n"
+	if data.Category != "" {
+		header += "Category: " + data.Category + ". "
+	}
+	header += "Source: <" + data.URL + ">"
+	sections = append(sections, header)
+
+	forms := s.docsMarkdownTable(s.docsFormRows(data))
+	if forms != "" && len(data.Notes) > 0 {
+		forms += "\n\n" + s.docsNotes(data.Notes)
+	}
+	add("Forms", forms)
+	for _, table := range data.AdditionalTables {
+		add(s.docsTableHeading(table), s.docsMarkdownTable(s.docsRawRows(table.Rows)))
+	}
+	add("Instruction Operand Encoding", s.docsMarkdownTable(s.docsRawRows(data.OperandEncodingTable)))
+
+	description := data.DescriptionMarkdown
+	if description == "" {
+		description = strings.ReplaceAll(data.DescriptionText, "\n", "\n\n")
+	}
+	add("Description", description)
+	if data.OperationText != "" {
+		add("Operation", "```\n"+strings.Trim(data.OperationText, "\n")+"\n```")
+	}
+	add("Flags Affected", data.FlagsAffectedText)
+	if len(data.Intrinsics) > 0 {
+		add("Intel C/C++ Compiler Intrinsic Equivalent", "```c\n"+strings.Join(data.Intrinsics, "\n")+"\n```")
+	}
+	// Older datasets only have the flattened exception text, which the
+	// records can still be recovered from.
+	s.linkExceptionRecords(&data)
+	for _, mode := range s.docsExceptionModes(data.Exceptions) {
+		if records := data.ExceptionRecords[strings.TrimSuffix(mode.key, "¶")]; len(records) > 0 {
+			add(mode.title, s.docsMarkdownTable(s.docsExceptionRows(records)))
+		} else {
+			add(mode.title, s.docsList(data.Exceptions[mode.key]))
+		}
+	}
+	return strings.Join(sections, "\n\n") + "\n"
+}
+
+// docsFormRows prefers the normalized forms, which have a fixed column
+// order, and falls back to the raw details table for datasets without them.
+// Like the other row builders, its first row is the header.
+func (s *Scraper) docsFormRows(data InstructionData) [][]string {
+	if len(data.Forms) == 0 {
+		return s.docsRawRows(data.DetailsTable)
+	}
+
+	rows := [][]string{{"Opcode", "Instruction", "Op/En", "64-Bit Mode", "Compat/Leg Mode", "CPUID Feature Flag", "Description"}}
+	for _, form := range data.Forms {
+		rows = append(rows, s.docsCells(form.Opcode, form.Instruction, form.OpEn,
+			string(form.Valid64), string(form.ValidCompat), form.CPUID, form.Description))
+	}
+	return rows
+}
+
+func (s *Scraper) docsTableHeading(table DetailsTableGroup) string {
+	if table.Heading == "" {
+		return "Additional Forms"
+	}
+	return table.Heading
+}
+
+func (s *Scraper) docsRawRows(table []TableRow) [][]string {
+	if len(table) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var columns []string
+	for _, row := range table {
+		for column := range row {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Slice(columns, func(i, j int) bool {
+		ri, rj := s.tableColumnRank(columns[i]), s.tableColumnRank(columns[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return columns[i] < columns[j]
+	})
+
+	rows := [][]string{s.docsCells(columns...)}
+	for _, row := range table {
+		var cells []string
+		for _, column := range columns {
+			cells = append(cells, row[column])
+		}
+		rows = append(rows, s.docsCells(cells...))
+	}
+	return rows
+}
+
+// tableColumnRank places unnamed column_N headers last, in their page
+// order, after every named column.
+func (s *Scraper) tableColumnRank(column string) int {
+	if n, ok := strings.CutPrefix(column, "column_"); ok {
+		if index, err := strconv.Atoi(n); err == nil {
+			return len(tableColumnRanks) + 1 + index
+		}
+	}
+	lower := strings.ToLower(strings.ReplaceAll(column, " ", ""))
+	for rank, keyword := range tableColumnRanks {
+		if strings.Contains(lower, keyword) {
+			return rank
+		}
+	}
+	return len(tableColumnRanks)
+}
+
+// docsCells collapses each cell onto one line.
+func (s *Scraper) docsCells(cells ...string) []string {
+	collapsed := make([]string, len(cells))
+	for i, cell := range cells {
+		collapsed[i] = strings.Join(strings.Fields(cell), " ")
+	}
+	return collapsed
+}
+
+// docsMarkdownTable renders rows from the row builders, escaping the pipes
+// a cell can't contain.
+func (s *Scraper) docsMarkdownTable(rows [][]string) string {
+	escaped := make([][]string, len(rows))
+	for i, row := range rows {
+		escaped[i] = make([]string, len(row))
+		for j, cell := range row {
+			escaped[i][j] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+	}
+	return s.renderMarkdownTable(escaped)
+}
+
+func (s *Scraper) docsNotes(notes []TableNote) string {
+	var lines []string
+	for _, note := range notes {
+		lines = append(lines, fmt.Sprintf("%s. %s", note.Marker, note.Text))
+	}
+	return strings.Join(lines, "  \n")
+}
+
+func (s *Scraper) docsExceptionRows(records []ExceptionRecord) [][]string {
+	rows := [][]string{{"Exception", "Condition"}}
+	for _, record := range records {
+		rows = append(rows, s.docsCells(record.Vector, record.Condition))
+	}
+	return rows
+}
+
+func (s *Scraper) docsList(items []string) string {
+	var lines []string
+	for _, item := range s.docsListItems(items) {
+		lines = append(lines, "- "+item)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s *Scraper) docsListItems(items []string) []string {
+	var collapsed []string
+	for _, item := range items {
+		if item = strings.Join(strings.Fields(item), " "); item != "" {
+			collapsed = append(collapsed, item)
+		}
+	}
+	return collapsed
+}
+
+// docsExceptionModes lists a record's exception sections in SDM order,
+// with the sections it names itself (such as "SIMD Floating-Point")
+// sorted after the per-mode ones.
+func (s *Scraper) docsExceptionModes(exceptions map[string][]string) []exceptionSection {
+	var modes []exceptionSection
+	known := make(map[string]bool)
+	for _, mode := range exceptionModeTitles {
+		known[mode.key] = true
+		if _, ok := exceptions[mode.key]; ok {
+			modes = append(modes, mode)
+		}
+	}
+
+	var others []string
+	for key := range exceptions {
+		if !known[key] {
+			others = append(others, key)
+		}
+	}
+	sort.Strings(others)
+	for _, key := range others {
+		modes = append(modes, exceptionSection{key: key, title: s.exceptionSectionTitle(key)})
+	}
+	return modes
+}
+
+// exceptionSectionTitle turns a key parseExceptionModeName built, such as
+// "simdFloating-Point¶", back into a heading.
+func (s *Scraper) exceptionSectionTitle(key string) string {
+	key = strings.TrimSuffix(key, "¶")
+	for _, mode := range exceptionModeTitles {
+		if mode.key == key {
+			return mode.title
+		}
+	}
+
+	var b strings.Builder
+	var previous rune
+	for i, r := range key {
+		if i == 0 {
+			b.WriteString(strings.ToUpper(string(r)))
+		} else {
+			if r >= 'A' && r <= 'Z' && previous != '-' {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+		}
+		previous = r
+	}
+	title := strings.NewReplacer("Simd", "SIMD", "Fpu", "FPU").Replace(b.String())
+	if !strings.Contains(strings.ToLower(title), "exception") {
+		title += " Exceptions"
+	}
+	return title
+}
+
+func (s *Scraper) markdownLinkText(text string) string {
+	return strings.NewReplacer("[", `\[`, "]", `\]`).Replace(text)
+}
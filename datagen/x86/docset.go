@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"arisa/docset"
+)
+
+const docsetFilename = "x86.docset"
+
+var docsetInfo = docset.Info{Identifier: "arisa-x86", Name: "x86", Family: "x86"}
+
+// Docset builds a Dash docset from a dataset, with the same pages as the
+// docs subcommand and every mnemonic a page covers in the search index.
+func (s *Scraper) Docset(args []string) error {
+	flags := flag.NewFlagSet("docset", flag.ContinueOnError)
+	output := flags.String("out", docsetFilename, "docset bundle to write")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("usage: docset [--out x86.docset] [file.json]")
+	}
+	filename := s.outputFilename
+	if flags.NArg() == 1 {
+		filename = flags.Arg(0)
+	}
+
+	instructions, err := s.readDataset(filename)
+	if err != nil {
+		return err
+	}
+
+	pages := s.docsPages(instructions)
+	paths := make(map[string]string, len(pages))
+	var docsetPages []docset.Page
+	var entries []docset.Entry
+	for _, data := range pages {
+		page := s.docsetPage(data)
+		paths[data.URL] = page.Path
+		docsetPages = append(docsetPages, page)
+		for _, name := range s.docsetNames(data) {
+			entries = append(entries, docset.Entry{Name: name, Type: "Instruction", Path: page.Path})
+		}
+	}
+	// Condition-code children are found under their own mnemonic but land
+	// on their parent's page.
+	for _, data := range instructions {
+		if parentPath, ok := paths[data.Parent]; ok && data.Error == "" {
+			entries = append(entries, docset.Entry{Name: s.docsTitle(data), Type: "Instruction", Path: parentPath})
+		}
+	}
+
+	if err := docset.Write(*output, docsetInfo, docsetPages, entries); err != nil {
+		return err
+	}
+	s.logger.Info("Docset saved", "file", *output, "pages", len(docsetPages), "entries", len(entries))
+	return nil
+}
+
+// docsetNames lists the mnemonics a page documents: each of the
+// slash-separated names in its title, such as PSRLW, PSRLD, and PSRLQ,
+// and any aliases.
+func (s *Scraper) docsetNames(data InstructionData) []string {
+	mnemonics, _, _ := strings.Cut(s.docsTitle(data), "—")
+	var names []string
+	for _, name := range strings.Split(mnemonics, "/") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return append(names, data.Aliases...)
+}
+
+func (s *Scraper) docsetPage(data InstructionData) docset.Page {
+	page := docset.Page{
+		Path:     s.docsSlug(data) + ".html",
+		Title:    s.docsTitle(data),
+		Subtitle: data.Category,
+		Source:   data.URL,
+	}
+	add := func(section docset.Section) {
+		if len(section.Paragraphs) > 0 || len(section.Table) > 0 || len(section.List) > 0 || section.Code != "" {
+			page.Sections = append(page.Sections, section)
+		}
+	}
+
+	add(docset.Section{Heading: "Forms", Table: s.docsFormRows(data), List: s.docsetNotes(data.Notes)})
+	for _, table := range data.AdditionalTables {
+		add(docset.Section{Heading: s.docsTableHeading(table), Table: s.docsRawRows(table.Rows)})
+	}
+	add(docset.Section{Heading: "Instruction Operand Encoding", Table: s.docsRawRows(data.OperandEncodingTable)})
+	add(docset.Section{Heading: "Description", Paragraphs: s.docsListItems(strings.Split(data.DescriptionText, "\n"))})
+	add(docset.Section{Heading: "Operation", Code: strings.Trim(data.OperationText, "\n")})
+	add(docset.Section{Heading: "Flags Affected", Paragraphs: s.docsListItems(strings.Split(data.FlagsAffectedText, "\n"))})
+	add(docset.Section{Heading: "Intel C/C++ Compiler Intrinsic Equivalent", Code: strings.Join(data.Intrinsics, "\n")})
+
+	s.linkExceptionRecords(&data)
+	for _, mode := range s.docsExceptionModes(data.Exceptions) {
+		if records := data.ExceptionRecords[strings.TrimSuffix(mode.key, "¶")]; len(records) > 0 {
+			add(docset.Section{Heading: mode.title, Table: s.docsExceptionRows(records)})
+		} else {
+			add(docset.Section{Heading: mode.title, List: s.docsListItems(data.Exceptions[mode.key])})
+		}
+	}
+	return page
+}
+
+func (s *Scraper) docsetNotes(notes []TableNote) []string {
+	var items []string
+	for _, note := range notes {
+		items = append(items, fmt.Sprintf("%s. %s", note.Marker, note.Text))
+	}
+	return items
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// printPlan describes what a run would do with the index as it stands: which
+// pages it would scrape and why, how many it would leave alone, and which
+// saved records it would prune.
+func (s *Scraper) printPlan(w io.Writer, links []InstructionLink) error {
+	queued := make(map[string]bool, len(links))
+	for _, link := range links {
+		queued[link.URL] = true
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].URL < links[j].URL })
+
+	reasons := make(map[string]int)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, link := range links {
+		reason := s.scrapeReason(link.URL)
+		reasons[reason]++
+		fmt.Fprintf(tw, "scrape\t%s\t%s\t%s\n", reason, s.indexEntries[link.URL], link.URL)
+	}
+
+	stale := s.staleRecords()
+	for _, url := range stale {
+		fmt.Fprintf(tw, "prune\t\t%s\t%s\n", s.titleMnemonic(s.previousData[url]), url)
+	}
+
+	skipped := 0
+	for url := range s.indexEntries {
+		if !queued[url] {
+			skipped++
+		}
+	}
+
+	fmt.Fprintf(w, "Plan for %s (%d pages on the index, %d saved records)\n",
+		s.indexURL, len(s.indexEntries), len(s.previousData))
+	fmt.Fprintf(w, "  scrape: %d (new %d, failed %d, refresh %d)\n",
+		len(links), reasons["new"], reasons["failed"], reasons["refresh"])
+	fmt.Fprintf(w, "  skip:   %d (already scraped)\n", skipped)
+	fmt.Fprintf(w, "  prune:  %d\n", len(stale))
+	if len(links)+len(stale) > 0 {
+		fmt.Fprintln(w)
+	}
+	return tw.Flush()
+}
+
+// scrapeReason says why a queued page would be fetched: it was never
+// scraped, its last scrape failed, or --force, --offline or a filter asked
+// for it again.
+func (s *Scraper) scrapeReason(pageURL string) string {
+	previous, ok := s.previousData[pageURL]
+	switch {
+	case !ok:
+		return "new"
+	case previous.Error != "":
+		return "failed"
+	}
+	return "refresh"
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+type OpcodeEncoding struct {
+	PrefixClass     string   `json:"prefixClass"`
+	VectorLength    string   `json:"vectorLength,omitempty"`
+	MandatoryPrefix string   `json:"mandatoryPrefix,omitempty"`
+	Map             string   `json:"map"`
+	EscapeBytes     []string `json:"escapeBytes,omitempty"`
+	REX             bool     `json:"rex,omitempty"`
+	W               string   `json:"w,omitempty"`
+	VVVV            string   `json:"vvvv,omitempty"`
+	OpcodeByte      string   `json:"opcodeByte,omitempty"`
+	OpcodeBytes     []string `json:"opcodeBytes,omitempty"`
+	OpcodeRegister  string   `json:"opcodeRegister,omitempty"`
+	ModRM           string   `json:"modrm,omitempty"`
+	ModRMReg        *int     `json:"modrmReg,omitempty"`
+	Immediate       string   `json:"immediate,omitempty"`
+	Immediates      []string `json:"immediates,omitempty"`
+	ImmediateSize   int      `json:"immediateSize,omitempty"`
+	Masking         bool     `json:"masking,omitempty"`
+	ZeroMasking     bool     `json:"zeroMasking,omitempty"`
+	Broadcast       bool     `json:"broadcast,omitempty"`
+	Rounding        bool     `json:"rounding,omitempty"`
+	SAE             bool     `json:"sae,omitempty"`
+	EGPR            bool     `json:"egpr,omitempty"`
+	NDD             bool     `json:"ndd,omitempty"`
+	NF              bool     `json:"nf,omitempty"`
+	ZU              bool     `json:"zu,omitempty"`
+	SCC             bool     `json:"scc,omitempty"`
+}
+
+var (
+	vectorLengthPattern = regexp.MustCompile(`^(128|256|512|LIG|LLIG|LZ|LLZ|L0|L1|L128|L256)$`)
+	opcodeMapPattern    = regexp.MustCompile(`^(0F|0F38|0F3A|MAP[0-9]|M[0-9]|08|09|0A)$`)
+	immediatePattern    = regexp.MustCompile(`^(ib|iw|id|io|cb|cw|cd|cp|co|ct|is4)$`)
+	splitRegisterSuffix = regexp.MustCompile(`\+\s+(rb|rw|rd|ro|i)\b`)
+)
+
+// immediateSizes gives the width in bytes of each immediate or code-offset
+// token from the Intel SDM opcode column notation.
+var immediateSizes = map[string]int{
+	"ib": 1, "iw": 2, "id": 4, "io": 8,
+	"cb": 1, "cw": 2, "cd": 4, "cp": 6, "co": 8, "ct": 10,
+	"is4": 1,
+}
+
+var escapeBytes = map[string][]string{
+	"0F":   {"0F"},
+	"0F38": {"0F", "38"},
+	"0F3A": {"0F", "3A"},
+}
+
+func (s *Scraper) parseOpcodeEncoding(form InstructionForm) *OpcodeEncoding {
+	// Some pages break "B8+ rd" across cells; rejoin the register suffix
+	// so it stays attached to its opcode byte.
+	opcode := splitRegisterSuffix.ReplaceAllString(strings.ReplaceAll(form.Opcode, "*", ""), "+$1")
+	tokens := strings.Fields(opcode)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	var encoding OpcodeEncoding
+	rest := tokens
+
+	switch {
+	case strings.HasPrefix(tokens[0], "VEX."), strings.HasPrefix(tokens[0], "EVEX."), strings.HasPrefix(tokens[0], "XOP."),
+		strings.HasPrefix(tokens[0], "REX2."):
+		s.parseVectorPrefix(tokens[0], &encoding)
+		rest = tokens[1:]
+	default:
+		encoding.PrefixClass = "legacy"
+		rest = s.parseLegacyPrefixes(tokens, &encoding)
+	}
+
+	if encoding.PrefixClass == "legacy" {
+		encoding.EscapeBytes = escapeBytes[encoding.Map]
+	}
+
+	for _, token := range rest {
+		switch {
+		case opcodeBytePattern.MatchString(token) && encoding.ModRM == "" && encoding.Immediate == "":
+			if encoding.OpcodeByte == "" {
+				encoding.OpcodeByte = token
+			}
+			s.appendOpcodeByte(&encoding, token)
+		case strings.HasPrefix(token, "+") && encoding.OpcodeByte != "":
+			encoding.OpcodeByte += token
+			encoding.OpcodeRegister = token
+		case token == "/r" || (len(token) == 2 && token[0] == '/' && token[1] >= '0' && token[1] <= '7'):
+			encoding.ModRM = token
+			if token != "/r" {
+				reg := int(token[1] - '0')
+				encoding.ModRMReg = &reg
+			}
+		case immediatePattern.MatchString(strings.TrimPrefix(token, "/")):
+			immediate := strings.TrimPrefix(token, "/")
+			if encoding.Immediate == "" {
+				encoding.Immediate = immediate
+			}
+			encoding.Immediates = append(encoding.Immediates, immediate)
+			encoding.ImmediateSize += immediateSizes[immediate]
+		}
+	}
+
+	for _, operand := range form.Operands {
+		if strings.Contains(operand, "{k1}") || strings.Contains(operand, "{k2}") {
+			encoding.Masking = true
+		}
+		if strings.Contains(operand, "{z}") {
+			encoding.ZeroMasking = true
+		}
+		if strings.Contains(operand, "bcst") {
+			encoding.Broadcast = true
+		}
+		if strings.Contains(operand, "{er}") {
+			encoding.Rounding = true
+		}
+		if strings.Contains(operand, "{sae}") {
+			encoding.SAE = true
+		}
+	}
+
+	return &encoding
+}
+
+func (s *Scraper) appendOpcodeByte(encoding *OpcodeEncoding, token string) {
+	if i := strings.Index(token, "+"); i >= 0 {
+		encoding.OpcodeRegister = token[i:]
+		token = token[:i]
+	}
+	encoding.OpcodeBytes = append(encoding.OpcodeBytes, token)
+}
+
+func (s *Scraper) parseVectorPrefix(token string, encoding *OpcodeEncoding) {
+	parts := strings.Split(token, ".")
+	encoding.PrefixClass = parts[0]
+	encoding.Map = "0F"
+	switch encoding.PrefixClass {
+	case "XOP":
+		encoding.Map = ""
+	case "REX2":
+		encoding.Map = "legacy"
+		encoding.EGPR = true
+	}
+
+	for _, part := range parts[1:] {
+		switch {
+		case vectorLengthPattern.MatchString(part):
+			encoding.VectorLength = part
+			if part == "L128" || part == "L256" {
+				encoding.VectorLength = strings.TrimPrefix(part, "L")
+			}
+		case part == "66" || part == "F2" || part == "F3" || part == "NP":
+			encoding.MandatoryPrefix = part
+		case strings.HasPrefix(part, "66") || strings.HasPrefix(part, "F2") || strings.HasPrefix(part, "F3"):
+			encoding.MandatoryPrefix = part[:2]
+			if opcodeMapPattern.MatchString(part[2:]) {
+				encoding.Map = s.normalizeOpcodeMap(part[2:])
+			}
+		case encoding.PrefixClass == "REX2" && (part == "M0" || part == "M1"):
+			encoding.Map = map[string]string{"M0": "legacy", "M1": "0F"}[part]
+		case opcodeMapPattern.MatchString(part):
+			encoding.Map = s.normalizeOpcodeMap(part)
+			if encoding.Map == "MAP4" {
+				encoding.EGPR = true
+			}
+		case part == "W0" || part == "W1" || part == "WIG":
+			encoding.W = part
+		case part == "NDS" || part == "NDD" || part == "DDS":
+			encoding.VVVV = part
+		case part == "ND1" || part == "ND=1":
+			encoding.NDD = true
+			encoding.VVVV = "NDD"
+		case part == "NF1" || part == "NF=1":
+			encoding.NF = true
+		case part == "ZU1" || part == "ZU=1":
+			encoding.ZU = true
+		case part == "SCC":
+			encoding.SCC = true
+		}
+	}
+}
+
+func (s *Scraper) parseLegacyPrefixes(tokens []string, encoding *OpcodeEncoding) []string {
+	encoding.Map = "legacy"
+	i := 0
+
+	for i < len(tokens) {
+		token := tokens[i]
+		switch {
+		case token == "REX.W":
+			encoding.W = "W1"
+		case token == "REX":
+			encoding.REX = true
+		case token == "REX.R" || token == "+":
+		case token == "NP" || token == "NFx":
+			encoding.MandatoryPrefix = token
+		case (token == "66" || token == "F2" || token == "F3") && i+1 < len(tokens) && (tokens[i+1] == "0F" || tokens[i+1] == "REX.W" || tokens[i+1] == "REX"):
+			encoding.MandatoryPrefix = token
+		default:
+			if token != "0F" {
+				return tokens[i:]
+			}
+			encoding.Map = "0F"
+			if i+1 < len(tokens) && (tokens[i+1] == "38" || tokens[i+1] == "3A") {
+				encoding.Map = "0F" + tokens[i+1]
+				i++
+			}
+			return tokens[i+1:]
+		}
+		i++
+	}
+
+	return nil
+}
+
+func (s *Scraper) normalizeOpcodeMap(part string) string {
+	switch part {
+	case "M5":
+		return "MAP5"
+	case "M6":
+		return "MAP6"
+	case "08", "09", "0A":
+		return "XOP" + part
+	}
+	return part
+}
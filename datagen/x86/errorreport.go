@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	defaultErrorsFilename = "errors.json"
+	defaultMaxFailureRate = 0.05
+)
+
+// Exit codes, so automation can tell why a run failed without parsing logs.
+const (
+	exitFailure         = 1
+	exitTooManyFailures = 2
+	exitIncomplete      = 3
+	exitInterrupted     = 130
+)
+
+var (
+	errTooManyFailures = errors.New("too many pages failed")
+	errIncomplete      = errors.New("dataset is incomplete")
+)
+
+type FailedPage struct {
+	URL   string `json:"url"`
+	Class string `json:"class"`
+	Error string `json:"error"`
+}
+
+type ErrorReport struct {
+	Pages          int          `json:"pages"`
+	Failed         int          `json:"failed"`
+	FailureRate    float64      `json:"failureRate"`
+	MaxFailureRate float64      `json:"maxFailureRate"`
+	Failures       []FailedPage `json:"failures"`
+}
+
+// buildErrorReport lists the pages in the dataset that are still failing,
+// classed by what ended their last attempt, against every page on the
+// index. Failed pages are always retried, so an incremental run that only
+// re-fetched a handful still reports a rate for the whole dataset. Pages
+// that failed in an earlier run and weren't retried in this one are classed
+// as "other".
+func (s *Scraper) buildErrorReport(finalData []InstructionData) ErrorReport {
+	report := ErrorReport{
+		Pages:          max(len(s.indexEntries), len(finalData)),
+		MaxFailureRate: s.maxFailureRate,
+		Failures:       []FailedPage{},
+	}
+
+	for _, data := range finalData {
+		if data.Error == "" {
+			continue
+		}
+		report.Failures = append(report.Failures, FailedPage{
+			URL:   data.URL,
+			Class: s.metrics.failureKind(data.URL),
+			Error: data.Error,
+		})
+	}
+	sort.Slice(report.Failures, func(i, j int) bool {
+		return report.Failures[i].URL < report.Failures[j].URL
+	})
+
+	report.Failed = len(report.Failures)
+	if report.Pages > 0 {
+		report.FailureRate = float64(report.Failed) / float64(report.Pages)
+	}
+	return report
+}
+
+func (s *Scraper) saveErrorReport(report ErrorReport) error {
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode error report: %w", err)
+	}
+
+	if err := s.writeFileAtomic(s.errorsFilename, buffer.Bytes()); err != nil {
+		return fmt.Errorf("failed to write error report: %w", err)
+	}
+
+	s.logger.Info("Error report saved", "file", s.errorsFilename, "failed", report.Failed)
+	return nil
+}
+
+// checkFailureRate fails the run when more of the attempted pages failed
+// than --max-failure-rate allows.
+func (s *Scraper) checkFailureRate(report ErrorReport) error {
+	if report.FailureRate <= s.maxFailureRate {
+		return nil
+	}
+	return fmt.Errorf("%w: %d of %d (%.1f%%, limit %.1f%%)", errTooManyFailures,
+		report.Failed, report.Pages, report.FailureRate*100, s.maxFailureRate*100)
+}
+
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case errors.Is(err, errTooManyFailures):
+		return exitTooManyFailures
+	case errors.Is(err, errIncomplete):
+		return exitIncomplete
+	}
+	return exitFailure
+}
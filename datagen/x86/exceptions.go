@@ -0,0 +1,300 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const exceptionsFilename = "exceptions.json"
+
+type ExceptionVector struct {
+	Vector       int      `json:"vector"`
+	Mnemonic     string   `json:"mnemonic"`
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	ErrorCode    bool     `json:"errorCode"`
+	Reserved     bool     `json:"reserved,omitempty"`
+	CommonCauses []string `json:"commonCauses,omitempty"`
+}
+
+var exceptionVectors = []ExceptionVector{
+	{Vector: 0, Mnemonic: "#DE", Name: "Divide Error", Type: "Fault", CommonCauses: []string{
+		"DIV or IDIV with a divisor of zero",
+		"DIV or IDIV quotient too large for the destination operand",
+	}},
+	{Vector: 1, Mnemonic: "#DB", Name: "Debug", Type: "Fault/Trap", CommonCauses: []string{
+		"Instruction, data, or I/O breakpoint match",
+		"Single-step trap with EFLAGS.TF set",
+		"Task switch with the T flag set in the new TSS",
+		"INT1 instruction",
+	}},
+	{Vector: 2, Mnemonic: "NMI", Name: "Non-Maskable Interrupt", Type: "Interrupt", CommonCauses: []string{
+		"Assertion of the NMI pin or an NMI delivered through the APIC",
+	}},
+	{Vector: 3, Mnemonic: "#BP", Name: "Breakpoint", Type: "Trap", CommonCauses: []string{
+		"INT3 instruction",
+	}},
+	{Vector: 4, Mnemonic: "#OF", Name: "Overflow", Type: "Trap", CommonCauses: []string{
+		"INTO instruction executed with EFLAGS.OF set",
+	}},
+	{Vector: 5, Mnemonic: "#BR", Name: "BOUND Range Exceeded", Type: "Fault", CommonCauses: []string{
+		"BOUND instruction with an index outside the array bounds",
+	}},
+	{Vector: 6, Mnemonic: "#UD", Name: "Invalid Opcode", Type: "Fault", CommonCauses: []string{
+		"UD0, UD1, or UD2 instruction",
+		"Reserved or unsupported opcode",
+		"LOCK prefix on an instruction that does not support it",
+		"Instruction requires a CPUID feature that is not present or enabled",
+	}},
+	{Vector: 7, Mnemonic: "#NM", Name: "Device Not Available", Type: "Fault", CommonCauses: []string{
+		"Floating-point or SIMD instruction executed with CR0.TS or CR0.EM set",
+		"WAIT/FWAIT executed with CR0.MP and CR0.TS set",
+	}},
+	{Vector: 8, Mnemonic: "#DF", Name: "Double Fault", Type: "Abort", ErrorCode: true, CommonCauses: []string{
+		"Second exception raised while delivering a prior contributory or page-fault exception",
+	}},
+	{Vector: 9, Mnemonic: "CSO", Name: "Coprocessor Segment Overrun", Type: "Fault", Reserved: true, CommonCauses: []string{
+		"Floating-point instruction operand crossing a segment limit (386 and earlier only)",
+	}},
+	{Vector: 10, Mnemonic: "#TS", Name: "Invalid TSS", Type: "Fault", ErrorCode: true, CommonCauses: []string{
+		"Task switch or TSS access with an invalid TSS selector, limit, or segment",
+	}},
+	{Vector: 11, Mnemonic: "#NP", Name: "Segment Not Present", Type: "Fault", ErrorCode: true, CommonCauses: []string{
+		"Loading a segment register or gate whose present flag is clear",
+	}},
+	{Vector: 12, Mnemonic: "#SS", Name: "Stack-Segment Fault", Type: "Fault", ErrorCode: true, CommonCauses: []string{
+		"Stack operation or SS-relative access outside the SS segment limit",
+		"Non-canonical address in a stack reference in 64-bit mode",
+		"Loading SS with a not-present segment",
+	}},
+	{Vector: 13, Mnemonic: "#GP", Name: "General Protection", Type: "Fault", ErrorCode: true, CommonCauses: []string{
+		"Memory access outside a segment limit or with a NULL segment selector",
+		"Non-canonical memory address in 64-bit mode",
+		"Privileged instruction executed at CPL > 0",
+		"Writing reserved bits in a control register or MSR",
+		"Misaligned memory operand for an instruction requiring alignment",
+	}},
+	{Vector: 14, Mnemonic: "#PF", Name: "Page Fault", Type: "Fault", ErrorCode: true, CommonCauses: []string{
+		"Access to a not-present page",
+		"Write to a read-only page or user-mode access to a supervisor page",
+		"Instruction fetch from a no-execute page",
+		"Protection-key violation",
+	}},
+	{Vector: 15, Name: "Intel Reserved", Type: "Reserved", Reserved: true},
+	{Vector: 16, Mnemonic: "#MF", Name: "x87 FPU Floating-Point Error", Type: "Fault", CommonCauses: []string{
+		"Pending unmasked x87 FPU exception detected by a floating-point or WAIT/FWAIT instruction",
+	}},
+	{Vector: 17, Mnemonic: "#AC", Name: "Alignment Check", Type: "Fault", ErrorCode: true, CommonCauses: []string{
+		"Unaligned memory reference at CPL 3 with CR0.AM and EFLAGS.AC set",
+	}},
+	{Vector: 18, Mnemonic: "#MC", Name: "Machine Check", Type: "Abort", CommonCauses: []string{
+		"Internal machine error or bus error detected by the processor",
+	}},
+	{Vector: 19, Mnemonic: "#XM", Name: "SIMD Floating-Point Exception", Type: "Fault", CommonCauses: []string{
+		"Unmasked SSE/AVX floating-point exception with CR4.OSXMMEXCPT set",
+	}},
+	{Vector: 20, Mnemonic: "#VE", Name: "Virtualization Exception", Type: "Fault", CommonCauses: []string{
+		"EPT violation converted to a virtualization exception",
+	}},
+	{Vector: 21, Mnemonic: "#CP", Name: "Control Protection Exception", Type: "Fault", ErrorCode: true, CommonCauses: []string{
+		"Shadow stack return address mismatch",
+		"Missing ENDBRANCH at an indirect branch target",
+		"Invalid shadow stack token",
+	}},
+	{Vector: 22, Name: "Intel Reserved", Type: "Reserved", Reserved: true},
+	{Vector: 23, Name: "Intel Reserved", Type: "Reserved", Reserved: true},
+	{Vector: 24, Name: "Intel Reserved", Type: "Reserved", Reserved: true},
+	{Vector: 25, Name: "Intel Reserved", Type: "Reserved", Reserved: true},
+	{Vector: 26, Name: "Intel Reserved", Type: "Reserved", Reserved: true},
+	{Vector: 27, Name: "Intel Reserved", Type: "Reserved", Reserved: true},
+	{Vector: 28, Name: "Intel Reserved", Type: "Reserved", Reserved: true},
+	{Vector: 29, Name: "Intel Reserved", Type: "Reserved", Reserved: true},
+	{Vector: 30, Name: "Intel Reserved", Type: "Reserved", Reserved: true},
+	{Vector: 31, Name: "Intel Reserved", Type: "Reserved", Reserved: true},
+}
+
+type ExceptionRecord struct {
+	Vector    string `json:"vector,omitempty"`
+	Condition string `json:"condition,omitempty"`
+}
+
+var (
+	exceptionMnemonicPattern = regexp.MustCompile(`#([A-Z]{2})\b`)
+	exceptionVectorPattern   = regexp.MustCompile(`^#[A-Z]{2}(\([^)]*\))?`)
+	flattenedCellPattern     = regexp.MustCompile(`(?:^|;\s+)column_(\d+): `)
+)
+
+func (s *Scraper) linkExceptionVectors(data *InstructionData) {
+	known := make(map[string]bool)
+	for _, vector := range exceptionVectors {
+		if vector.Mnemonic != "" {
+			known[vector.Mnemonic] = true
+		}
+	}
+
+	links := make(map[string][]string)
+	for modeName, paragraphs := range data.Exceptions {
+		seen := make(map[string]bool)
+		var mnemonics []string
+		for _, paragraph := range paragraphs {
+			for _, match := range exceptionMnemonicPattern.FindAllString(paragraph, -1) {
+				if known[match] && !seen[match] {
+					seen[match] = true
+					mnemonics = append(mnemonics, match)
+				}
+			}
+		}
+		if len(mnemonics) > 0 {
+			sort.Strings(mnemonics)
+			links[modeName] = mnemonics
+		}
+	}
+
+	if len(links) > 0 {
+		data.ExceptionVectors = links
+	} else {
+		data.ExceptionVectors = nil
+	}
+}
+
+// exceptionRecordFromCells turns one exception table row into a record.
+// Rows continuing a rowspan'd vector carry only the condition, so they
+// inherit the vector of the row above.
+func (s *Scraper) exceptionRecordFromCells(cells []string, previous string) (ExceptionRecord, bool) {
+	var kept []string
+	for _, cell := range cells {
+		if cell = strings.Join(strings.Fields(cell), " "); cell != "" {
+			kept = append(kept, cell)
+		}
+	}
+	if len(kept) == 0 {
+		return ExceptionRecord{}, false
+	}
+
+	if exceptionVectorPattern.FindString(kept[0]) == kept[0] {
+		return ExceptionRecord{Vector: kept[0], Condition: strings.Join(kept[1:], " ")}, true
+	}
+	return ExceptionRecord{Vector: previous, Condition: strings.Join(kept, " ")}, true
+}
+
+func (s *Scraper) parseExceptionTable(table *goquery.Selection) []ExceptionRecord {
+	var records []ExceptionRecord
+	var vector string
+	table.Find("tr").Each(func(_ int, row *goquery.Selection) {
+		if row.Find("td").Length() == 0 {
+			return
+		}
+
+		var cells []string
+		row.Find("td").Each(func(_ int, cell *goquery.Selection) {
+			cells = append(cells, cell.Text())
+		})
+		if record, ok := s.exceptionRecordFromCells(cells, vector); ok {
+			vector = record.Vector
+			records = append(records, record)
+		}
+	})
+	return records
+}
+
+// parseExceptionParagraph handles prose entries such as "None." or "Same
+// exceptions as in protected mode.", which have no vector, and the
+// occasional paragraph that leads with one.
+func (s *Scraper) parseExceptionParagraph(text string) (ExceptionRecord, bool) {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return ExceptionRecord{}, false
+	}
+
+	if vector := exceptionVectorPattern.FindString(text); vector != "" {
+		return ExceptionRecord{Vector: vector, Condition: strings.TrimSpace(text[len(vector):])}, true
+	}
+	return ExceptionRecord{Condition: text}, true
+}
+
+// parseFlattenedExceptions recovers records from the "column_1: ...;
+// column_2: ...;" text that earlier scrapes stored in Exceptions.
+func (s *Scraper) parseFlattenedExceptions(paragraphs []string) []ExceptionRecord {
+	var records []ExceptionRecord
+	var vector string
+	for _, paragraph := range paragraphs {
+		if !strings.Contains(paragraph, "column_") {
+			if record, ok := s.parseExceptionParagraph(paragraph); ok {
+				records = append(records, record)
+			}
+			continue
+		}
+
+		for _, line := range strings.Split(paragraph, "\n") {
+			line = strings.TrimSuffix(strings.TrimSpace(line), ";")
+			keys := flattenedCellPattern.FindAllStringSubmatchIndex(line, -1)
+
+			columns := make(map[int]string, len(keys))
+			var order []int
+			for i, key := range keys {
+				end := len(line)
+				if i+1 < len(keys) {
+					end = keys[i+1][0]
+				}
+				n, _ := strconv.Atoi(line[key[2]:key[3]])
+				columns[n] = line[key[1]:end]
+				order = append(order, n)
+			}
+			sort.Ints(order)
+
+			var cells []string
+			for _, n := range order {
+				cells = append(cells, columns[n])
+			}
+			if record, ok := s.exceptionRecordFromCells(cells, vector); ok {
+				vector = record.Vector
+				records = append(records, record)
+			}
+		}
+	}
+	return records
+}
+
+func (s *Scraper) linkExceptionRecords(data *InstructionData) {
+	if data.ExceptionRecords != nil {
+		return
+	}
+
+	records := make(map[string][]ExceptionRecord)
+	for modeName, paragraphs := range data.Exceptions {
+		if parsed := s.parseFlattenedExceptions(paragraphs); len(parsed) > 0 {
+			records[strings.TrimSuffix(modeName, "¶")] = parsed
+		}
+	}
+	if len(records) > 0 {
+		data.ExceptionRecords = records
+	}
+}
+
+func (s *Scraper) saveExceptionVectors() error {
+	s.logger.Info("Saving exception vectors", "count", len(exceptionVectors))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(exceptionVectors); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(exceptionsFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("Exception vectors saved successfully", "file", exceptionsFilename)
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+)
+
+// isConditionFamily reports whether a page documents a condition-code
+// family such as Jcc, CMOVcc, SETcc, or "LOOP/LOOPcc", whose rows each
+// carry a different concrete mnemonic.
+func (s *Scraper) isConditionFamily(data InstructionData) bool {
+	title := strings.Fields(data.InstructionName + " ")
+	if len(title) == 0 {
+		return false
+	}
+
+	for _, name := range strings.Split(title[0], "/") {
+		if strings.HasSuffix(name, "cc") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Scraper) expandMnemonics(data InstructionData) []InstructionData {
+	if data.Error != "" || !s.isConditionFamily(data) {
+		return nil
+	}
+
+	var order []string
+	rows := make(map[string][]TableRow)
+	refs := make(map[string][][]string)
+	for i, row := range data.DetailsTable {
+		form, ok := s.normalizeFormRow(row)
+		if !ok || form.Mnemonic == "" {
+			continue
+		}
+		if _, seen := rows[form.Mnemonic]; !seen {
+			order = append(order, form.Mnemonic)
+		}
+		rows[form.Mnemonic] = append(rows[form.Mnemonic], row)
+		if data.DetailsNoteRefs != nil {
+			var markers []string
+			if i < len(data.DetailsNoteRefs) {
+				markers = data.DetailsNoteRefs[i]
+			}
+			refs[form.Mnemonic] = append(refs[form.Mnemonic], markers)
+		}
+	}
+
+	if len(order) < 2 {
+		return nil
+	}
+
+	var children []InstructionData
+	for _, mnemonic := range order {
+		child := InstructionData{
+			URL:                  data.URL + "#" + strings.ToLower(strings.ReplaceAll(mnemonic, " ", "-")),
+			Category:             data.Category,
+			InstructionName:      mnemonic,
+			Parent:               data.URL,
+			DetailsTable:         rows[mnemonic],
+			DetailsNoteRefs:      refs[mnemonic],
+			Notes:                data.Notes,
+			OperandEncodingTable: data.OperandEncodingTable,
+			DescriptionText:      data.DescriptionText,
+			DescriptionMarkdown:  data.DescriptionMarkdown,
+			OperationText:        data.OperationText,
+			FlagsAffectedText:    data.FlagsAffectedText,
+			Intrinsics:           data.Intrinsics,
+			Figures:              data.Figures,
+			Exceptions:           data.Exceptions,
+			ExceptionRecords:     data.ExceptionRecords,
+			Provenance:           make(map[string][]ProvenanceStep, len(data.Provenance)),
+		}
+		for field, steps := range data.Provenance {
+			child.Provenance[field] = steps
+		}
+
+		s.recordDerivedProvenance(&child, "detailsTable", "expandMnemonics", "detailsTable")
+		s.enrichInstruction(&child)
+		children = append(children, child)
+	}
+
+	return children
+}
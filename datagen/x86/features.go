@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+)
+
+// cpuidFlagRepairs fixes CPUID cells that the SDM typesets with footnote
+// markers, prose, or a space in the wrong place.
+var cpuidFlagRepairs = map[string]string{
+	"Both AES and AVX flags": "AES AVX",
+	"HLE or RTM":             "HLE RTM",
+	"HLE1":                   "HLE",
+	"AESKLEWIDE_KL":          "AESKLE WIDE_KL",
+	"AVX512VLA VX512DQ":      "AVX512VL AVX512DQ",
+	"AVX512D Q":              "AVX512DQ",
+}
+
+// parseFeatureFlags splits a CPUID Feature Flag cell into normalized flag
+// names. Hyphens become underscores so "AVX512-FP16" and "AMX-TILE" match
+// the spelling used for "AVX512_VNNI" and friends.
+func (s *Scraper) parseFeatureFlags(cpuid string) []string {
+	cpuid = strings.Join(strings.Fields(cpuid), " ")
+	if repaired, ok := cpuidFlagRepairs[cpuid]; ok {
+		cpuid = repaired
+	}
+
+	var flags []string
+	for _, token := range strings.Fields(cpuid) {
+		if token == "NA" || token == "N/A" || strings.Contains(token, "[") {
+			continue
+		}
+		flags = append(flags, strings.ToUpper(strings.ReplaceAll(token, "-", "_")))
+	}
+	return flags
+}
+
+func (s *Scraper) linkFeatureFlags(data *InstructionData) {
+	data.FeatureFlags = nil
+	seen := make(map[string]bool)
+	for i := range data.Forms {
+		data.Forms[i].FeatureFlags = s.parseFeatureFlags(data.Forms[i].CPUID)
+		for _, flag := range data.Forms[i].FeatureFlags {
+			if !seen[flag] {
+				seen[flag] = true
+				data.FeatureFlags = append(data.FeatureFlags, flag)
+			}
+		}
+	}
+}
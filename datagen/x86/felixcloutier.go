@@ -2,42 +2,79 @@ package main
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"io/ioutil"
+	"html"
 	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
+	"sort"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
+	"arisa/config"
+	"arisa/dataset"
+	"arisa/httpclient"
+	"arisa/robots"
+	"arisa/schema"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/charmbracelet/log"
+	"golang.org/x/time/rate"
 )
 
-const (
-	baseURL        = "https://www.felixcloutier.com"
-	indexURL       = baseURL + "/x86/"
-	outputFilename = "x86.json"
-	numWorkers     = 50
-	requestTimeout = 15 * time.Second
-)
+// defaultConfig is what a run uses when neither --config nor a flag says
+// otherwise.
+var defaultConfig = config.Scraper{
+	Workers:          50,
+	RequestTimeout:   15 * time.Second,
+	Output:           "x86.json",
+	BaseURL:          "https://www.felixcloutier.com/x86/",
+	UserAgent:        "x86-scraper/1.0",
+	MaxResponseBytes: 16 << 20,
+}
 
 type TableRow map[string]string
 
 type InstructionData struct {
-	URL                  string              `json:"url"`
-	Category             string              `json:"category"`
-	InstructionName      string              `json:"instructionName"`
-	DetailsTable         []TableRow          `json:"detailsTable"`
-	OperandEncodingTable []TableRow          `json:"operandEncodingTable"`
-	DescriptionText      string              `json:"descriptionText"`
-	OperationText        string              `json:"operationText"`
-	FlagsAffectedText    string              `json:"flagsAffectedText"`
-	Exceptions           map[string][]string `json:"exceptions"`
-	Error                string              `json:"error,omitempty"`
+	URL                  string                       `json:"url"`
+	Category             string                       `json:"category"`
+	Taxonomy             schema.Category              `json:"taxonomy,omitempty"`
+	InstructionName      string                       `json:"instructionName"`
+	Parent               string                       `json:"parent,omitempty"`
+	DetailsTable         []TableRow                   `json:"detailsTable"`
+	DetailsNoteRefs      [][]string                   `json:"detailsNoteRefs,omitempty"`
+	AdditionalTables     []DetailsTableGroup          `json:"additionalTables,omitempty"`
+	Notes                []TableNote                  `json:"notes,omitempty"`
+	OperandEncodingTable []TableRow                   `json:"operandEncodingTable"`
+	DescriptionText      string                       `json:"descriptionText"`
+	DescriptionMarkdown  string                       `json:"descriptionMarkdown,omitempty"`
+	OperationText        string                       `json:"operationText"`
+	OperationAST         []schema.Statement           `json:"operationAst,omitempty"`
+	FlagsAffectedText    string                       `json:"flagsAffectedText"`
+	Intrinsics           []string                     `json:"intrinsics,omitempty"`
+	Figures              []Figure                     `json:"figures,omitempty"`
+	FlagsAffected        map[string]FlagEffect        `json:"flagsAffected,omitempty"`
+	Exceptions           map[string][]string          `json:"exceptions"`
+	ExceptionRecords     map[string][]ExceptionRecord `json:"exceptionRecords,omitempty"`
+	ExceptionVectors     map[string][]string          `json:"exceptionVectors,omitempty"`
+	Forms                []InstructionForm            `json:"forms,omitempty"`
+	FeatureFlags         []string                     `json:"featureFlags,omitempty"`
+	AMX                  []AMXInfo                    `json:"amx,omitempty"`
+	SGXLeaves            []SGXLeaf                    `json:"sgxLeaves,omitempty"`
+	VMCSFields           string                       `json:"vmcsFields,omitempty"`
+	Provenance           map[string][]ProvenanceStep  `json:"-"`
+	ContentHash          string                       `json:"contentHash,omitempty"`
+	ETag                 string                       `json:"etag,omitempty"`
+	LastModified         string                       `json:"lastModified,omitempty"`
+	Aliases              []string                     `json:"aliases,omitempty"`
+	AliasValidators      map[string]pageValidators    `json:"aliasValidators,omitempty"`
+	Error                string                       `json:"error,omitempty"`
 }
 
 type InstructionLink struct {
@@ -47,12 +84,53 @@ type InstructionLink struct {
 
 type Scraper struct {
 	client              *http.Client
+	fetcher             Fetcher
+	userAgent           string
 	logger              *log.Logger
+	indexURL            string
+	outputFilename      string
+	workers             int
+	minWorkers          int
+	adaptive            bool
+	concurrency         *concurrencyLimit
+	progressInterval    time.Duration
+	checkpointEvery     int
+	metrics             *runMetrics
+	reportFilename      string
+	pushgatewayURL      string
+	robots              *robots.Checker
+	errorsFilename      string
+	maxFailureRate      float64
+	filter              *linkFilter
+	prune               bool
+	webhookURL          string
+	dryRun              bool
+	sqliteFilename      string
+	formsExportFilename string
+	parquetFilename     string
+	splitDir            string
+	legacyArray         bool
+	protobufFilename    string
+	unifiedFilename     string
+	formats             map[string]bool
+	compressions        []string
 	previousData        map[string]InstructionData
 	successfullyScraped map[string]bool
+	markdown            bool
+	figures             bool
+	retries             int
+	retryBaseDelay      time.Duration
+	retryMaxDelay       time.Duration
+	limiter             *rate.Limiter
+	requestDelay        time.Duration
+	requireComplete     bool
+	force               bool
+	cacheDir            string
+	offline             bool
+	indexEntries        map[string]string
 }
 
-func NewScraper() *Scraper {
+func NewScraper(cfg config.Scraper) (*Scraper, error) {
 	logger := log.NewWithOptions(os.Stderr, log.Options{
 		ReportCaller:    false,
 		ReportTimestamp: true,
@@ -60,49 +138,86 @@ func NewScraper() *Scraper {
 		Prefix:          "x86-scraper",
 	})
 
-	client := &http.Client{
-		Timeout: requestTimeout,
-		Transport: &http.Transport{
-			TLSClientConfig:   &tls.Config{InsecureSkipVerify: false},
-			DisableKeepAlives: false,
-			MaxIdleConns:      100,
-			IdleConnTimeout:   90 * time.Second,
-		},
+	client, err := httpclient.New(cfg, 100)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up HTTP client: %w", err)
 	}
 
-	return &Scraper{
+	scraper := &Scraper{
 		client:              client,
+		userAgent:           cfg.UserAgent,
+		robots:              robots.NewChecker(client, cfg.UserAgent),
 		logger:              logger,
+		indexURL:            cfg.BaseURL,
+		outputFilename:      cfg.Output,
+		workers:             cfg.Workers,
+		minWorkers:          defaultMinWorkers,
+		adaptive:            true,
+		progressInterval:    defaultProgressInterval,
+		checkpointEvery:     defaultCheckpointEvery,
+		metrics:             newRunMetrics(),
+		errorsFilename:      defaultErrorsFilename,
+		unifiedFilename:     defaultUnifiedFilename,
+		maxFailureRate:      defaultMaxFailureRate,
+		prune:               true,
 		previousData:        make(map[string]InstructionData),
 		successfullyScraped: make(map[string]bool),
+		retries:             defaultRetries,
+		retryBaseDelay:      defaultRetryBaseDelay,
+		retryMaxDelay:       defaultRetryMaxDelay,
 	}
+	scraper.fetcher = networkFetcher{scraper}
+	scraper.setRateLimit(defaultRequestsPerSecond, defaultRequestBurst)
+	return scraper, nil
 }
 
 func (s *Scraper) loadExistingData() error {
-	if _, err := os.Stat(outputFilename); os.IsNotExist(err) {
+	if _, err := dataset.Locate(s.outputFilename); os.IsNotExist(err) {
 		s.logger.Info("No existing data file found, starting fresh")
 		return nil
 	}
 
-	s.logger.Info("Loading existing data", "file", outputFilename)
+	s.logger.Info("Loading existing data", "file", s.outputFilename)
 
-	fileBytes, err := ioutil.ReadFile(outputFilename)
+	// A dataset that can't be read would otherwise mean re-scraping every
+	// page, so fall back to the copy saved before the last write.
+	loadedData, err := s.readDataset(s.outputFilename)
 	if err != nil {
-		s.logger.Warn("Could not read existing data file", "error", err)
-		return err
+		s.logger.Warn("Could not load existing data, trying backup", "error", err, "backup", s.backupPath())
+		var backupErr error
+		if loadedData, backupErr = s.readDataset(s.backupPath()); backupErr != nil {
+			s.logger.Warn("Could not load backup", "error", backupErr)
+			return err
+		}
 	}
 
-	var loadedData []InstructionData
-	if err := json.Unmarshal(fileBytes, &loadedData); err != nil {
-		s.logger.Warn("Could not unmarshal existing data", "error", err)
-		return err
+	provenance, err := s.loadProvenance()
+	if err != nil {
+		s.logger.Warn("Could not load provenance", "error", err)
 	}
 
 	for _, item := range loadedData {
+		if steps, ok := provenance[item.URL]; ok {
+			item.Provenance = steps
+		}
 		s.previousData[item.URL] = item
 		if item.Error == "" {
 			s.successfullyScraped[item.URL] = true
 		}
+
+		// Merged alias pages are restored as their own records so the
+		// duplicate pass sees them again instead of re-fetching them,
+		// each with the validators its own URL was served with.
+		for _, alias := range item.Aliases {
+			copied := item
+			copied.URL = alias
+			copied.Aliases = nil
+			copied.AliasValidators = nil
+			validators := item.AliasValidators[alias]
+			copied.ETag, copied.LastModified = validators.ETag, validators.LastModified
+			s.previousData[alias] = copied
+			s.successfullyScraped[alias] = true
+		}
 	}
 
 	s.logger.Info("Loaded previous data",
@@ -153,42 +268,144 @@ func (s *Scraper) extractTextFollowingHeader(doc *goquery.Document, headerID str
 	return strings.Join(content, "\n")
 }
 
-func (s *Scraper) parseInstructionPage(pageURL, category string) InstructionData {
-	data := InstructionData{
-		URL:      pageURL,
-		Category: category,
+var subscriptRunes = strings.NewReplacer(
+	"0", "₀", "1", "₁", "2", "₂", "3", "₃", "4", "₄",
+	"5", "₅", "6", "₆", "7", "₇", "8", "₈", "9", "₉",
+	"+", "₊", "-", "₋", "=", "₌", "(", "₍", ")", "₎",
+	"a", "ₐ", "e", "ₑ", "h", "ₕ", "i", "ᵢ", "j", "ⱼ", "k", "ₖ", "l", "ₗ",
+	"m", "ₘ", "n", "ₙ", "o", "ₒ", "p", "ₚ", "r", "ᵣ", "s", "ₛ", "t", "ₜ",
+	"u", "ᵤ", "v", "ᵥ", "x", "ₓ",
+)
+
+// subscript renders text with Unicode subscript characters, falling back
+// to an underscore prefix when some character has no subscript form.
+func (s *Scraper) subscript(text string) string {
+	converted := subscriptRunes.Replace(text)
+	for _, r := range converted {
+		if r < 0x80 {
+			return "_" + text
+		}
 	}
+	return converted
+}
 
-	req, err := http.NewRequest("GET", pageURL, nil)
-	if err != nil {
-		data.Error = fmt.Sprintf("failed to create request: %v", err)
-		return data
+// extractIntrinsics collects the prototypes from the "Intel C/C++ Compiler
+// Intrinsic Equivalent" section, one per entry. The heading's id varies
+// between pages, so it is matched by text. Entries come as <pre> lines,
+// table rows, or paragraphs; "None" placeholders are dropped.
+func (s *Scraper) extractIntrinsics(doc *goquery.Document) []string {
+	var intrinsics []string
+	add := func(text string) {
+		text = strings.Join(strings.Fields(text), " ")
+		if text != "" && !strings.EqualFold(strings.TrimSuffix(text, "."), "none") {
+			intrinsics = append(intrinsics, text)
+		}
 	}
-	req.Header.Set("User-Agent", "x86-scraper/1.0")
 
-	resp, err := s.client.Do(req)
-	if err != nil {
-		data.Error = fmt.Sprintf("failed to fetch URL: %v", err)
-		return data
+	doc.Find("h2").Each(func(_ int, header *goquery.Selection) {
+		if !strings.Contains(strings.ToLower(header.Text()), "intrinsic equivalent") {
+			return
+		}
+
+		currentNode := header.Next()
+		for currentNode.Length() > 0 && currentNode.Get(0).Data != "h2" {
+			switch {
+			case currentNode.Is("pre"):
+				// Long prototypes wrap; keep reading until the parentheses close.
+				var pending string
+				for _, line := range strings.Split(currentNode.Text(), "\n") {
+					pending += " " + line
+					if strings.Count(pending, "(") <= strings.Count(pending, ")") {
+						add(pending)
+						pending = ""
+					}
+				}
+				add(pending)
+			case currentNode.Is("table"):
+				currentNode.Find("tr").Each(func(_ int, row *goquery.Selection) {
+					var cells []string
+					row.Find("td").Each(func(_ int, cell *goquery.Selection) {
+						cells = append(cells, strings.TrimSpace(cell.Text()))
+					})
+					if len(cells) > 0 && !strings.EqualFold(strings.TrimSuffix(cells[len(cells)-1], "."), "none") {
+						add(strings.Join(cells, " "))
+					}
+				})
+			case currentNode.Is("p"):
+				add(currentNode.Text())
+			}
+			currentNode = currentNode.Next()
+		}
+	})
+	return intrinsics
+}
+
+// extractPreformattedFollowingHeader is extractTextFollowingHeader for
+// sections made of <pre> pseudocode. Indentation, line breaks, and symbols
+// like ≔ inside each block are kept verbatim; only blank lines around a
+// block are dropped, and blocks are separated by an empty line. <sub>
+// elements become Unicode subscripts so "SRC<sub>1</sub>" doesn't collapse
+// into "SRC1".
+func (s *Scraper) extractPreformattedFollowingHeader(doc *goquery.Document, headerID string) string {
+	var content []string
+	header := doc.Find(fmt.Sprintf("h2#%s", headerID))
+	if header.Length() > 0 {
+		currentNode := header.Next()
+		for currentNode.Length() > 0 && currentNode.Get(0).Data != "h2" {
+			switch {
+			case currentNode.Is("pre"):
+				currentNode.Find("sub").Each(func(_ int, sub *goquery.Selection) {
+					sub.ReplaceWithHtml(html.EscapeString(s.subscript(sub.Text())))
+				})
+				var lines []string
+				for _, line := range strings.Split(strings.Trim(currentNode.Text(), "\r\n"), "\n") {
+					lines = append(lines, strings.TrimRight(line, " \t\r"))
+				}
+				content = append(content, strings.Join(lines, "\n"))
+			case currentNode.Is("p"):
+				content = append(content, strings.TrimSpace(currentNode.Text()))
+			}
+			currentNode = currentNode.Next()
+		}
 	}
-	defer resp.Body.Close()
+	return strings.Join(content, "\n\n")
+}
 
-	if resp.StatusCode != http.StatusOK {
-		data.Error = fmt.Sprintf("bad status: %s", resp.Status)
-		return data
+func (s *Scraper) parseInstructionPage(ctx context.Context, pageURL, category string) InstructionData {
+	data := InstructionData{
+		URL:      pageURL,
+		Category: category,
 	}
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	doc, validators, err := s.fetchDocument(ctx, pageURL)
+	if errors.Is(err, errNotModified) {
+		s.logger.Debug("Page not modified", "url", pageURL)
+		s.metrics.observeNotModified()
+		previous := s.previousData[pageURL]
+		previous.Category = category
+		previous.ETag, previous.LastModified = validators.merge(previous.ETag, previous.LastModified)
+		return previous
+	}
 	if err != nil {
-		data.Error = fmt.Sprintf("failed to parse HTML: %v", err)
+		if ctx.Err() == nil {
+			s.metrics.observeFailure(pageURL, errorKind(err))
+		}
+		data.Error = err.Error()
 		return data
 	}
+	data.ETag, data.LastModified = validators.ETag, validators.LastModified
 
 	data.InstructionName = strings.TrimSpace(doc.Find("h1").First().Text())
 
 	allTables := doc.Find("table")
 	if allTables.Length() > 0 {
+		data.DetailsNoteRefs = s.collectNoteRefs(allTables.First())
 		data.DetailsTable = s.parseTableFromGoquery(allTables.First())
+		data.Notes = s.extractTableNotes(allTables.First())
+
+		additional, notes := s.extractAdditionalFormTables(allTables)
+		data.AdditionalTables = additional
+		data.Notes = append(data.Notes, notes...)
 
 		operandEncodingHeader := doc.Find("h2#instruction-operand-encoding")
 		if operandEncodingHeader.Length() > 0 {
@@ -209,24 +426,41 @@ func (s *Scraper) parseInstructionPage(pageURL, category string) InstructionData
 	}
 
 	data.DescriptionText = s.extractTextFollowingHeader(doc, "description")
-	data.OperationText = s.extractTextFollowingHeader(doc, "operation")
+	if s.markdown {
+		data.DescriptionMarkdown = s.extractMarkdownFollowingHeader(doc, "description", pageURL)
+	}
+	data.OperationText = s.extractPreformattedFollowingHeader(doc, "operation")
 	data.FlagsAffectedText = s.extractTextFollowingHeader(doc, "flags-affected")
+	data.Intrinsics = s.extractIntrinsics(doc)
+	data.Figures = s.extractFigures(ctx, doc, pageURL)
 
 	data.Exceptions = make(map[string][]string)
+	data.ExceptionRecords = make(map[string][]ExceptionRecord)
 	doc.Find("h2.exceptions").Each(func(_ int, exceptionHeader *goquery.Selection) {
 		modeName := s.parseExceptionModeName(exceptionHeader.Text())
 
 		var exceptionContent []string
+		var records []ExceptionRecord
 		currentNode := exceptionHeader.Next()
 		for currentNode.Length() > 0 && currentNode.Get(0).Data != "h2" {
 			if currentNode.Is("p") {
 				exceptionContent = append(exceptionContent, strings.TrimSpace(currentNode.Text()))
+				if record, ok := s.parseExceptionParagraph(currentNode.Text()); ok {
+					records = append(records, record)
+				}
 			} else if currentNode.Is("table") {
+				records = append(records, s.parseExceptionTable(currentNode)...)
+
 				var tableText strings.Builder
 				parsedTable := s.parseTableFromGoquery(currentNode)
 				for _, tr := range parsedTable {
-					for k, v := range tr {
-						tableText.WriteString(fmt.Sprintf("%s: %s; ", k, v))
+					keys := make([]string, 0, len(tr))
+					for k := range tr {
+						keys = append(keys, k)
+					}
+					sort.Strings(keys)
+					for _, k := range keys {
+						tableText.WriteString(fmt.Sprintf("%s: %s; ", k, tr[k]))
 					}
 					tableText.WriteString("\n")
 				}
@@ -235,13 +469,18 @@ func (s *Scraper) parseInstructionPage(pageURL, category string) InstructionData
 			currentNode = currentNode.Next()
 		}
 		data.Exceptions[modeName] = exceptionContent
+		if len(records) > 0 {
+			data.ExceptionRecords[modeName] = records
+		}
 	})
 
+	s.recordScrapeProvenance(&data, parserName+"/"+parserVersion)
+
 	return data
 }
 
 func (s *Scraper) parseExceptionModeName(text string) string {
-	text = strings.TrimSpace(text)
+	text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "¶"))
 
 	modeMap := map[string]string{
 		"64-Bit Mode":        "64BitMode",
@@ -269,26 +508,17 @@ func (s *Scraper) parseExceptionModeName(text string) string {
 	return "unknownMode"
 }
 
-func (s *Scraper) fetchInstructionLinks() ([]InstructionLink, error) {
+func (s *Scraper) fetchInstructionLinks(ctx context.Context) ([]InstructionLink, error) {
 	s.logger.Info("Fetching instruction links from index page")
 
-	resp, err := s.client.Get(indexURL)
+	doc, _, err := s.fetchDocument(ctx, s.indexURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch index page: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("bad status for index page: %s", resp.Status)
-	}
-
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse index page HTML: %w", err)
-	}
 
 	var linksToScrape []InstructionLink
 	processedURLs := make(map[string]bool)
+	s.indexEntries = make(map[string]string)
 
 	doc.Find("h2").Each(func(_ int, h2Selection *goquery.Selection) {
 		categoryName := strings.TrimSpace(h2Selection.Text())
@@ -306,8 +536,11 @@ func (s *Scraper) fetchInstructionLinks() ([]InstructionLink, error) {
 					return
 				}
 
+				name := strings.TrimSpace(linkSelection.Text())
+				s.indexEntries[fullURL] = name
+
 				if !processedURLs[fullURL] {
-					if _, previouslySuccessful := s.successfullyScraped[fullURL]; !previouslySuccessful {
+					if s.shouldScrape(name, fullURL) {
 						linksToScrape = append(linksToScrape, InstructionLink{
 							URL:      fullURL,
 							Category: categoryName,
@@ -323,6 +556,10 @@ func (s *Scraper) fetchInstructionLinks() ([]InstructionLink, error) {
 		"total_on_index", len(processedURLs),
 		"to_scrape", len(linksToScrape))
 
+	if s.filter != nil && len(linksToScrape) == 0 {
+		s.logger.Warn("No index links match --only/--match")
+	}
+
 	return linksToScrape, nil
 }
 
@@ -345,17 +582,13 @@ func (s *Scraper) isInstructionSection(categoryName string) bool {
 }
 
 func (s *Scraper) resolveURL(href string) string {
-	if strings.HasPrefix(href, "/x86/") {
-		return baseURL + href
-	}
-
 	if strings.HasPrefix(href, "http") {
 		return href
 	}
 
-	tempURL, err := url.Parse(indexURL)
+	tempURL, err := url.Parse(s.indexURL)
 	if err != nil {
-		s.logger.Error("Error parsing indexURL for relative path resolution", "error", err)
+		s.logger.Error("Error parsing index URL for relative path resolution", "error", err)
 		return ""
 	}
 
@@ -375,13 +608,16 @@ func (s *Scraper) resolveURL(href string) string {
 	return fullURL
 }
 
-func (s *Scraper) scrapeInstructions(links []InstructionLink) map[string]InstructionData {
+// scrapeInstructions fetches the given pages with a pool of workers. Once
+// ctx is cancelled, queued pages are skipped and pages whose fetch was cut
+// short are left out, so their previous records survive the partial save.
+func (s *Scraper) scrapeInstructions(ctx context.Context, links []InstructionLink, stream *recordStream) map[string]InstructionData {
 	if len(links) == 0 {
 		s.logger.Info("No new or failed URLs to scrape")
 		return make(map[string]InstructionData)
 	}
 
-	workers := numWorkers
+	workers := s.workers
 	if len(links) < workers {
 		workers = len(links)
 	}
@@ -390,6 +626,7 @@ func (s *Scraper) scrapeInstructions(links []InstructionLink) map[string]Instruc
 		"workers", workers,
 		"total_links", len(links))
 
+	s.concurrency = s.newConcurrencyLimit(workers)
 	jobs := make(chan InstructionLink, len(links))
 	results := make(chan InstructionData, len(links))
 	var wg sync.WaitGroup
@@ -399,11 +636,23 @@ func (s *Scraper) scrapeInstructions(links []InstructionLink) map[string]Instruc
 		go func(workerID int) {
 			defer wg.Done()
 			for link := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				if err := s.concurrency.acquire(ctx); err != nil {
+					continue
+				}
+
 				s.logger.Debug("Scraping instruction",
 					"worker", workerID,
 					"url", link.URL)
 
-				result := s.parseInstructionPage(link.URL, link.Category)
+				result := s.parseInstructionPage(ctx, link.URL, link.Category)
+				s.concurrency.release()
+				if result.Error != "" && ctx.Err() != nil {
+					continue
+				}
 				results <- result
 			}
 		}(i)
@@ -420,30 +669,129 @@ func (s *Scraper) scrapeInstructions(links []InstructionLink) map[string]Instruc
 	}()
 
 	scrapedData := make(map[string]InstructionData)
-	errorCount := 0
+	tracker := s.newProgress(len(links))
+	s.metrics.setQueued(len(links))
+	cp := s.openCheckpoint()
+
+	var tick <-chan time.Time
+	if interval := tracker.interval(s.progressInterval); interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
 
-	for result := range results {
-		if result.Error != "" {
-			s.logger.Error("Error scraping instruction",
-				"url", result.URL,
-				"error", result.Error)
-			errorCount++
-		} else {
-			s.logger.Debug("Successfully scraped instruction",
-				"url", result.URL,
-				"name", result.InstructionName)
+	for results != nil {
+		select {
+		case result, ok := <-results:
+			if !ok {
+				results = nil
+				continue
+			}
+			tracker.record(result)
+			s.metrics.observePage(result)
+			if result.Error != "" {
+				tracker.clear()
+				s.logger.Error("Error scraping instruction",
+					"url", result.URL,
+					"error", result.Error)
+			} else {
+				s.logger.Debug("Successfully scraped instruction",
+					"url", result.URL,
+					"name", result.InstructionName)
+			}
+			scrapedData[result.URL] = result
+			if err := cp.add(result); err != nil {
+				tracker.clear()
+				s.logger.Warn("Failed to checkpoint progress", "error", err)
+			}
+			if err := stream.add(result); err != nil {
+				tracker.clear()
+				s.logger.Warn("Failed to stream record", "url", result.URL, "error", err)
+			}
+		case <-tick:
+			s.reportProgress(tracker)
 		}
-		scrapedData[result.URL] = result
+	}
+	tracker.clear()
+	if err := cp.close(); err != nil {
+		s.logger.Warn("Failed to checkpoint progress", "error", err)
 	}
 
 	s.logger.Info("Scraping completed",
 		"scraped", len(scrapedData),
-		"errors", errorCount)
+		"errors", tracker.errors,
+		"duration", time.Since(tracker.start).Round(time.Millisecond))
 
 	return scrapedData
 }
 
-func (s *Scraper) saveData(currentData map[string]InstructionData) error {
+func (s *Scraper) enrichInstruction(data *InstructionData) {
+	s.linkExceptionRecords(data)
+	if data.ExceptionRecords != nil {
+		s.recordDerivedProvenance(data, "exceptionRecords", "linkExceptionRecords", "exceptions")
+	}
+
+	s.linkExceptionVectors(data)
+	s.recordDerivedProvenance(data, "exceptionVectors", "linkExceptionVectors", "exceptions")
+
+	s.buildForms(data)
+	s.recordDerivedProvenance(data, "forms", "buildForms", "detailsTable", "additionalTables", "operandEncodingTable")
+
+	s.linkFeatureFlags(data)
+	if data.FeatureFlags != nil {
+		s.recordDerivedProvenance(data, "featureFlags", "linkFeatureFlags", "forms")
+	}
+
+	s.linkFlagsAffected(data)
+	if data.FlagsAffected != nil {
+		s.recordDerivedProvenance(data, "flagsAffected", "linkFlagsAffected", "flagsAffectedText", "operationText", "forms")
+	}
+
+	s.linkOperationAST(data)
+	if data.OperationAST != nil {
+		s.recordDerivedProvenance(data, "operationAst", "linkOperationAST", "operationText")
+	}
+
+	s.linkAMX(data)
+	if data.AMX != nil {
+		s.recordDerivedProvenance(data, "amx", "linkAMX", "instructionName")
+	}
+
+	s.linkSGXLeaves(data)
+	if data.SGXLeaves != nil {
+		s.recordDerivedProvenance(data, "sgxLeaves", "linkSGXLeaves", "detailsTable", "operandEncodingTable")
+	}
+
+	s.linkVMCSFields(data)
+	if data.VMCSFields != "" {
+		s.recordDerivedProvenance(data, "vmcsFields", "linkVMCSFields")
+	}
+
+	s.linkTaxonomy(data)
+	s.recordDerivedProvenance(data, "taxonomy", "linkTaxonomy", "category", "featureFlags", "forms")
+}
+
+// finishRecord runs the per-page passes on a scraped record and returns it,
+// followed by the records expanded from its mnemonics, each with its content
+// hash set. An expanded page gives its forms to the children, which link
+// back to it, so no form is listed twice.
+func (s *Scraper) finishRecord(data InstructionData) []InstructionData {
+	s.normalizeInstruction(&data)
+	s.enrichInstruction(&data)
+
+	children := s.expandMnemonics(data)
+	if len(children) > 0 {
+		data.Forms = nil
+		delete(data.Provenance, "forms")
+	}
+	records := append([]InstructionData{data}, children...)
+	for i := range records {
+		records[i].ContentHash = s.contentHash(records[i])
+	}
+	return records
+}
+
+func (s *Scraper) buildFinalDataset(currentData map[string]InstructionData) []InstructionData {
 	s.logger.Info("Preparing final dataset")
 
 	finalData := make(map[string]InstructionData)
@@ -456,25 +804,85 @@ func (s *Scraper) saveData(currentData map[string]InstructionData) error {
 
 	var finalSlice []InstructionData
 	for _, data := range finalData {
-		finalSlice = append(finalSlice, data)
+		if data.Parent != "" {
+			continue
+		}
+		finalSlice = append(finalSlice, s.finishRecord(data)...)
+	}
+	finalSlice = s.mergeDuplicates(finalSlice)
+	s.disambiguateIForms(finalSlice)
+	for i := range finalSlice {
+		finalSlice[i].ContentHash = s.contentHash(finalSlice[i])
 	}
 
 	s.logger.Info("Final dataset prepared", "total_instructions", len(finalSlice))
+	return finalSlice
+}
+
+// titleMnemonic returns the mnemonic part of a page title such as
+// "ADD\n\t\t— Add", which may list several ("LGDT/LIDT").
+func (s *Scraper) titleMnemonic(data InstructionData) string {
+	if fields := strings.Fields(data.InstructionName); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// sortInstructions orders records by mnemonic and then URL, so a re-run over
+// unchanged pages writes the same bytes even though the records were
+// gathered from a map. encoding/json already sorts map keys.
+func (s *Scraper) sortInstructions(instructions []InstructionData) {
+	sort.SliceStable(instructions, func(i, j int) bool {
+		a := strings.ToUpper(s.titleMnemonic(instructions[i]))
+		b := strings.ToUpper(s.titleMnemonic(instructions[j]))
+		if a != b {
+			return a < b
+		}
+		return instructions[i].URL < instructions[j].URL
+	})
+}
+
+func (s *Scraper) saveData(finalSlice []InstructionData) error {
+	s.sortInstructions(finalSlice)
 
 	buffer := new(bytes.Buffer)
 	encoder := json.NewEncoder(buffer)
 	encoder.SetEscapeHTML(false)
 	encoder.SetIndent("", "  ")
 
-	if err := encoder.Encode(finalSlice); err != nil {
+	if err := encoder.Encode(s.datasetDocument(finalSlice)); err != nil {
 		return fmt.Errorf("failed to encode JSON: %w", err)
 	}
 
-	if err := ioutil.WriteFile(outputFilename, buffer.Bytes(), 0644); err != nil {
+	if err := s.validateDataset(buffer.Bytes()); err != nil {
+		return fmt.Errorf("dataset does not match its schema: %w", err)
+	}
+
+	if err := s.writeDataset(buffer.Bytes()); err != nil {
 		return fmt.Errorf("failed to write JSON to file: %w", err)
 	}
 
-	s.logger.Info("Data saved successfully", "file", outputFilename)
+	s.logger.Info("Data saved successfully", "file", s.outputFilename, "backup", s.backupPath())
+
+	if err := s.saveProvenance(finalSlice); err != nil {
+		return err
+	}
+
+	if err := s.writeCompressedCopies(buffer.Bytes()); err != nil {
+		return fmt.Errorf("failed to write compressed dataset: %w", err)
+	}
+
+	if s.formats["msgpack"] {
+		if err := s.saveMsgpack(finalSlice); err != nil {
+			return err
+		}
+	}
+
+	if s.formats["yaml"] {
+		if err := s.saveYAML(finalSlice); err != nil {
+			return err
+		}
+	}
 
 	errorCount := 0
 	for _, inst := range finalSlice {
@@ -490,31 +898,343 @@ func (s *Scraper) saveData(currentData map[string]InstructionData) error {
 	return nil
 }
 
-func (s *Scraper) Run() error {
+// Run scrapes, saves the dataset and its derived files, and then writes the
+// run report and pushes metrics when those are configured, whether or not
+// the scrape itself succeeded.
+func (s *Scraper) Run(ctx context.Context) error {
+	err := s.run(ctx)
+	if s.dryRun {
+		return err
+	}
+
+	report := s.metrics.report(err)
+	if s.reportFilename != "" {
+		if reportErr := s.saveReport(report); reportErr != nil {
+			s.logger.Warn("Failed to save run report", "error", reportErr)
+		}
+	}
+	if s.pushgatewayURL != "" {
+		if pushErr := s.pushMetrics(report); pushErr != nil {
+			s.logger.Warn("Failed to push metrics", "error", pushErr)
+		}
+	}
+	return err
+}
+
+func (s *Scraper) run(ctx context.Context) error {
 	s.logger.Info("Starting x86 instruction scraper")
 
 	if err := s.loadExistingData(); err != nil {
 		s.logger.Warn("Failed to load existing data, continuing with fresh start", "error", err)
 	}
 
-	links, err := s.fetchInstructionLinks()
+	if err := s.loadCheckpoint(); err != nil {
+		s.logger.Warn("Failed to load checkpoint", "error", err)
+	}
+
+	s.applyCrawlDelay(ctx)
+
+	links, err := s.fetchInstructionLinks(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to fetch instruction links: %w", err)
 	}
 
-	currentData := s.scrapeInstructions(links)
+	if s.dryRun {
+		return s.printPlan(os.Stdout, links)
+	}
 
-	if err := s.saveData(currentData); err != nil {
+	s.pruneStaleRecords()
+
+	stream := s.openRecordStream(links)
+	currentData := s.scrapeInstructions(ctx, links, stream)
+	if err := stream.close(); err != nil {
+		s.logger.Warn("Failed to finish JSON Lines output", "error", err)
+	}
+
+	finalData := s.buildFinalDataset(currentData)
+	if err := s.saveData(finalData); err != nil {
 		return fmt.Errorf("failed to save data: %w", err)
 	}
+	s.removeCheckpoint()
+
+	errorReport := s.buildErrorReport(finalData)
+	if s.errorsFilename != "" {
+		if err := s.saveErrorReport(errorReport); err != nil {
+			return fmt.Errorf("failed to save error report: %w", err)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		s.logger.Warn("Interrupted, saved progress so far", "scraped", len(currentData), "remaining", len(links)-len(currentData))
+		return fmt.Errorf("scrape interrupted: %w", err)
+	}
+
+	if err := s.saveDerivedFiles(finalData); err != nil {
+		return err
+	}
+
+	if s.formsExportFilename != "" {
+		if err := s.saveFormsExport(finalData); err != nil {
+			return fmt.Errorf("failed to save forms export: %w", err)
+		}
+	}
+
+	if s.parquetFilename != "" {
+		if err := s.saveFormsParquet(finalData); err != nil {
+			return fmt.Errorf("failed to save forms Parquet export: %w", err)
+		}
+	}
+
+	if s.splitDir != "" {
+		if err := s.saveSplit(finalData, s.splitDir, s.outputFilename); err != nil {
+			return fmt.Errorf("failed to save split dataset: %w", err)
+		}
+	}
+
+	if s.protobufFilename != "" {
+		if err := s.saveProtobuf(finalData); err != nil {
+			return fmt.Errorf("failed to save protobuf dataset: %w", err)
+		}
+	}
+
+	if s.sqliteFilename != "" {
+		if err := s.saveSQLite(finalData); err != nil {
+			return fmt.Errorf("failed to save SQLite database: %w", err)
+		}
+	}
+
+	if err := s.checkFailureRate(errorReport); err != nil {
+		return err
+	}
+
+	if err := s.verifyCompleteness(finalData); err != nil {
+		return fmt.Errorf("completeness check failed: %w", err)
+	}
 
 	s.logger.Info("Scraping completed successfully")
 	return nil
 }
 
+// saveDerivedFiles writes the files built from the final dataset next to
+// it: the unified cross-ISA dataset and the lookup tables.
+func (s *Scraper) saveDerivedFiles(finalData []InstructionData) error {
+	if err := s.saveUnified(finalData, s.unifiedFilename); err != nil {
+		return fmt.Errorf("failed to save unified dataset: %w", err)
+	}
+
+	if err := s.saveMnemonicIndex(finalData); err != nil {
+		return fmt.Errorf("failed to save mnemonic index: %w", err)
+	}
+
+	if err := s.saveExceptionVectors(); err != nil {
+		return fmt.Errorf("failed to save exception vectors: %w", err)
+	}
+
+	if err := s.saveOpcodeMaps(finalData); err != nil {
+		return fmt.Errorf("failed to save opcode maps: %w", err)
+	}
+
+	if err := s.saveAPX(finalData); err != nil {
+		return fmt.Errorf("failed to save APX data: %w", err)
+	}
+
+	if err := s.saveVMCSFields(); err != nil {
+		return fmt.Errorf("failed to save VMCS fields: %w", err)
+	}
+
+	if err := s.saveOpcodeHeader(finalData); err != nil {
+		return fmt.Errorf("failed to save opcode header: %w", err)
+	}
+
+	return nil
+}
+
 func main() {
-	scraper := NewScraper()
-	if err := scraper.Run(); err != nil {
-		scraper.logger.Fatal("Scraper failed", "error", err)
+	markdown := flag.Bool("markdown", false, "also emit instruction descriptions as Markdown")
+	figures := flag.Bool("figures", false, "download page figures into "+assetsDir+"/")
+	retries := flag.Int("retries", defaultRetries, "retry transient page failures this many times")
+	retryBase := flag.Duration("retry-base", defaultRetryBaseDelay, "initial backoff between retries")
+	retryMax := flag.Duration("retry-max", defaultRetryMaxDelay, "longest backoff between retries, including Retry-After")
+	requestsPerSecond := flag.Float64("rps", defaultRequestsPerSecond, "maximum requests per second across all workers (0 disables)")
+	burst := flag.Int("burst", defaultRequestBurst, "requests allowed at once before the rate limit applies")
+	requestDelay := flag.Duration("delay", 0, "extra politeness delay before every request")
+	force := flag.Bool("force", false, "re-check every page, skipping unchanged ones via ETag/Last-Modified")
+	cacheDir := flag.String("cache-dir", "", "store the raw HTML of every fetched page in this directory")
+	offline := flag.Bool("offline", false, "re-parse every page from --cache-dir without touching the network")
+	requireComplete := flag.Bool("require-complete", false, "fail when an indexed instruction is missing or errored")
+	progressInterval := flag.Duration("progress", defaultProgressInterval, "how often to log scrape progress when stderr is not a terminal (0 disables)")
+	checkpointEvery := flag.Int("checkpoint-every", defaultCheckpointEvery, "append scraped pages to a checkpoint file in batches of this many (0 disables)")
+	report := flag.String("report", "", "write a JSON run report (fetch counts, statuses, errors, latencies) to this file")
+	pushgateway := flag.String("pushgateway", "", "push run metrics to this Prometheus Pushgateway URL")
+	errorsFile := flag.String("errors", defaultErrorsFilename, "write the pages that failed this run to this file (empty disables)")
+	maxFailureRate := flag.Float64("max-failure-rate", defaultMaxFailureRate, "exit with status 2 when more than this fraction of indexed pages are failing")
+	formsExport := flag.String("forms-csv", "", "also write one row per instruction form into this CSV file (tab-separated if it ends in .tsv)")
+	formsParquet := flag.String("forms-parquet", "", "also write one row per instruction form into this Parquet file, for DuckDB and Pandas")
+	split := flag.String("split", "", "also write the dataset as one JSON file per feature extension (sse2.json, avx512.json, ...) plus a manifest into this directory")
+	legacyArray := flag.Bool("legacy-array", false, "write the dataset as a bare JSON array, without the metadata envelope")
+	format := flag.String("format", "json", "comma-separated dataset formats to write: json, jsonl, msgpack, yaml (JSON is always written)")
+	compress := flag.String("compress", "", "also write compressed copies of the dataset, as a comma-separated list of gz and zst")
+	protobufFile := flag.String("protobuf", "", "also write the dataset as a binary protobuf (arisa.schema.v1.X86Dataset) to this file")
+	unified := flag.String("unified", defaultUnifiedFilename, "file to write the dataset to in the unified cross-ISA schema (arisa/schema.Instruction), alongside the x86 dataset")
+	sqliteFile := flag.String("sqlite", "", "also write the dataset into this SQLite database")
+	dryRun := flag.Bool("dry-run", false, "fetch the index, print which pages would be scraped, skipped or pruned, and exit without writing anything")
+	daemon := flag.Bool("daemon", false, "keep running, re-scraping every --interval")
+	interval := flag.Duration("interval", defaultDaemonInterval, "time between runs in --daemon mode")
+	webhook := flag.String("webhook", "", "in --daemon mode, POST added/changed/removed instructions here (JSON, or a message for Discord webhooks)")
+	adaptive := flag.Bool("adaptive", true, "shrink the worker pool on 429/5xx responses and grow it back when they stop")
+	minWorkers := flag.Int("min-workers", defaultMinWorkers, "fewest workers --adaptive shrinks the pool to")
+	prune := flag.Bool("prune", true, "drop saved records whose pages are no longer on the index")
+	only := flag.String("only", "", "scrape just these comma-separated instructions, e.g. VPSHUFB,ADD")
+	match := flag.String("match", "", "scrape just the instructions whose name matches this regular expression")
+	ignoreRobots := flag.Bool("ignore-robots", false, "don't fetch or honor robots.txt")
+	settings := config.Bind(flag.CommandLine, "x86", defaultConfig)
+	flag.Parse()
+
+	cfg, err := settings.Resolve()
+	if err != nil {
+		log.Fatal("Invalid configuration", "error", err)
+	}
+
+	scraper, err := NewScraper(cfg)
+	if err != nil {
+		log.Fatal("Failed to create scraper", "error", err)
+	}
+	scraper.markdown = *markdown
+	scraper.figures = *figures
+	scraper.retries = *retries
+	scraper.retryBaseDelay = *retryBase
+	scraper.retryMaxDelay = *retryMax
+	scraper.setRateLimit(*requestsPerSecond, *burst)
+	scraper.requestDelay = *requestDelay
+	scraper.progressInterval = *progressInterval
+	scraper.checkpointEvery = *checkpointEvery
+	scraper.reportFilename = *report
+	scraper.pushgatewayURL = *pushgateway
+	scraper.errorsFilename = *errorsFile
+	scraper.maxFailureRate = *maxFailureRate
+	scraper.prune = *prune
+	scraper.adaptive = *adaptive
+	scraper.minWorkers = *minWorkers
+	scraper.webhookURL = *webhook
+	scraper.dryRun = *dryRun
+	scraper.sqliteFilename = *sqliteFile
+	scraper.formsExportFilename = *formsExport
+	scraper.parquetFilename = *formsParquet
+	scraper.splitDir = *split
+	scraper.legacyArray = *legacyArray
+	scraper.protobufFilename = *protobufFile
+	scraper.unifiedFilename = *unified
+	if scraper.formats, err = config.ParseFormats(*format, "jsonl", "msgpack", "yaml"); err != nil {
+		scraper.logger.Fatal("Invalid format", "error", err)
+	}
+	if scraper.compressions, err = dataset.ParseCompressions(*compress); err != nil {
+		scraper.logger.Fatal("Invalid compression", "error", err)
+	}
+	scraper.requireComplete = *requireComplete
+	scraper.force = *force
+	scraper.cacheDir = *cacheDir
+	scraper.offline = *offline
+	if scraper.offline && scraper.cacheDir == "" {
+		scraper.logger.Fatal("--offline requires --cache-dir")
+	}
+	if scraper.filter, err = newLinkFilter(*only, *match); err != nil {
+		scraper.logger.Fatal("Invalid filter", "error", err)
+	}
+	if scraper.offline {
+		scraper.fetcher = cacheFetcher{scraper}
+	}
+	if *ignoreRobots || scraper.offline {
+		scraper.robots = nil
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "lint" {
+		if err := scraper.Lint(args[1:]); err != nil {
+			scraper.logger.Fatal("Lint failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "schema" {
+		if err := scraper.Schema(args[1:]); err != nil {
+			scraper.logger.Fatal("Schema failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "validate" {
+		if err := scraper.Validate(args[1:]); err != nil {
+			scraper.logger.Fatal("Validation failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "docs" {
+		if err := scraper.Docs(args[1:]); err != nil {
+			scraper.logger.Fatal("Docs failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "docset" {
+		if err := scraper.Docset(args[1:]); err != nil {
+			scraper.logger.Fatal("Docset failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "diff" {
+		if err := scraper.Diff(args[1:]); err != nil {
+			scraper.logger.Fatal("Diff failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "split" {
+		if err := scraper.Split(args[1:]); err != nil {
+			scraper.logger.Fatal("Split failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "unified" {
+		if err := scraper.Unified(args[1:]); err != nil {
+			scraper.logger.Fatal("Unified export failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "rebuild" {
+		if err := scraper.Rebuild(args[1:]); err != nil {
+			scraper.logger.Fatal("Rebuild failed", "error", err)
+		}
+		return
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "man" {
+		if err := scraper.ManPages(args[1:]); err != nil {
+			scraper.logger.Fatal("Man pages failed", "error", err)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		// Restore the default handlers once the first signal arrives, so a
+		// second Ctrl-C kills a run that is taking too long to wind down.
+		<-ctx.Done()
+		stop()
+	}()
+
+	if *daemon && !*dryRun {
+		if err := scraper.Daemon(ctx, *interval); err != nil {
+			scraper.logger.Fatal("Daemon failed", "error", err)
+		}
+		return
+	}
+
+	if err := scraper.Run(ctx); err != nil {
+		scraper.logger.Error("Scraper failed", "error", err)
+		os.Exit(exitCode(err))
 	}
 }
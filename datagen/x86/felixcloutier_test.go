@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newTestScraper returns a scraper with the default configuration that
+// writes into a temporary directory and logs nowhere.
+func newTestScraper(t *testing.T, baseURL string) *Scraper {
+	t.Helper()
+	cfg := defaultConfig
+	cfg.Output = filepath.Join(t.TempDir(), "x86.json")
+	if baseURL != "" {
+		cfg.BaseURL = baseURL
+	}
+	s, err := NewScraper(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.logger.SetOutput(io.Discard)
+	s.robots = nil
+	return s
+}
+
+// servePage serves testdata/name at /x86/page with an ETag, answering 304
+// to requests that already have it.
+func servePage(t *testing.T, name string) *httptest.Server {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const etag = `"fixture"`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestParseInstructionPage(t *testing.T) {
+	server := servePage(t, "xchg.html")
+	s := newTestScraper(t, server.URL+"/x86/")
+	pageURL := server.URL + "/x86/xchg"
+
+	data := s.parseInstructionPage(context.Background(), pageURL, "Core Instructions")
+	if data.Error != "" {
+		t.Fatal(data.Error)
+	}
+	if data.ETag != `"fixture"` {
+		t.Errorf("ETag = %q", data.ETag)
+	}
+	if name := strings.Join(strings.Fields(data.InstructionName), " "); name != "XCHG — Exchange Register/Memory With Register" {
+		t.Errorf("InstructionName = %q", name)
+	}
+
+	if len(data.DetailsTable) != 6 {
+		t.Fatalf("got %d details rows, want 6", len(data.DetailsTable))
+	}
+	rex := data.DetailsTable[4]
+	if rex["Opcode"] != "REX + 86 /r" || rex["Instruction"] != "XCHG r/m8, r8" || rex["Compat/Leg Mode"] != "N.E." {
+		t.Errorf("details row 5 = %v", rex)
+	}
+	if !reflect.DeepEqual(data.DetailsNoteRefs, [][]string{nil, nil, nil, nil, {"*"}, nil}) {
+		t.Errorf("DetailsNoteRefs = %q", data.DetailsNoteRefs)
+	}
+	if len(data.Notes) != 1 || data.Notes[0].Marker != "*" || !strings.HasPrefix(data.Notes[0].Text, "In 64-bit mode, r/m8 can not be encoded") {
+		t.Errorf("Notes = %+v", data.Notes)
+	}
+
+	wantOperands := []TableRow{
+		{"Op/En": "O", "Operand 1": "AX/EAX/RAX (r, w)", "Operand 2": "opcode + rd (r, w)", "Operand 3": "N/A", "Operand 4": "N/A"},
+		{"Op/En": "O", "Operand 1": "opcode + rd (r, w)", "Operand 2": "AX/EAX/RAX (r, w)", "Operand 3": "N/A", "Operand 4": "N/A"},
+		{"Op/En": "MR", "Operand 1": "ModRM:r/m (r, w)", "Operand 2": "ModRM:reg (r)", "Operand 3": "N/A", "Operand 4": "N/A"},
+		{"Op/En": "RM", "Operand 1": "ModRM:reg (w)", "Operand 2": "ModRM:r/m (r)", "Operand 3": "N/A", "Operand 4": "N/A"},
+	}
+	if !reflect.DeepEqual(data.OperandEncodingTable, wantOperands) {
+		t.Errorf("OperandEncodingTable = %v", data.OperandEncodingTable)
+	}
+
+	if !strings.HasPrefix(data.DescriptionText, "Exchanges the contents") || strings.Count(data.DescriptionText, "\n") != 1 {
+		t.Errorf("DescriptionText = %q", data.DescriptionText)
+	}
+	if data.OperationText != "TEMP := DEST;\nDEST := SRC;\nSRC := TEMP;" {
+		t.Errorf("OperationText = %q", data.OperationText)
+	}
+	if data.FlagsAffectedText != "None." {
+		t.Errorf("FlagsAffectedText = %q", data.FlagsAffectedText)
+	}
+
+	wantExceptions := map[string][]ExceptionRecord{
+		"protectedMode": {
+			{Vector: "#GP(0)", Condition: "If either operand is in a non-writable segment."},
+			{Vector: "#GP(0)", Condition: "If a memory operand effective address is outside the CS, DS, ES, FS, or GS segment limit."},
+			{Vector: "#UD", Condition: "If the LOCK prefix is used but the destination is not a memory operand."},
+		},
+		"compatibilityMode": {
+			{Condition: "Same exceptions as in protected mode."},
+		},
+	}
+	if !reflect.DeepEqual(data.ExceptionRecords, wantExceptions) {
+		t.Errorf("ExceptionRecords = %+v", data.ExceptionRecords)
+	}
+	if steps := data.Provenance["operandEncodingTable"]; len(steps) != 1 || steps[0].Source != pageURL+"#instruction-operand-encoding" {
+		t.Errorf("operandEncodingTable provenance = %+v", steps)
+	}
+
+	// The derived passes turn the replayed tables into forms, with the
+	// REX row keeping its footnote.
+	records := s.finishRecord(data)
+	forms := records[0].Forms
+	if len(forms) != 6 {
+		t.Fatalf("got %d forms, want 6", len(forms))
+	}
+	if forms[4].OpEn != "MR" || !reflect.DeepEqual(forms[4].Notes, []string{"*"}) || forms[4].ValidCompat == forms[4].Valid64 {
+		t.Errorf("form 5 = %+v", forms[4])
+	}
+	if forms[5].OperandDetails[0].Encoding != "ModRM:reg" || forms[5].OperandDetails[1].Encoding != "ModRM:r/m" {
+		t.Errorf("form 6 operands = %+v", forms[5].OperandDetails)
+	}
+
+	// A second run asks conditionally and keeps the previous record.
+	s.previousData[pageURL] = data
+	again := s.parseInstructionPage(context.Background(), pageURL, "Core Instructions")
+	if again.Error != "" || !reflect.DeepEqual(again.DetailsTable, data.DetailsTable) || again.ETag != data.ETag {
+		t.Errorf("not-modified replay = %+v", again)
+	}
+}
+
+func TestSplitGluedNoteRefs(t *testing.T) {
+	s := newTestScraper(t, "")
+	data := InstructionData{DetailsTable: []TableRow{
+		{"Instruction": "XCHG r/m16, r16", "Description": "Exchange r16 with word from r/m16."},
+		{"Opcode/Instruction": "REX + 0F B0/r CMPXCHG r/m8**,r8", "Compat/Leg Mode": "Valid*", "Description": "Compare AL with r/m8 * 2."},
+	}}
+	s.splitGluedNoteRefs(&data)
+
+	if !reflect.DeepEqual(data.DetailsNoteRefs, [][]string{nil, {"*", "**"}}) {
+		t.Errorf("DetailsNoteRefs = %q", data.DetailsNoteRefs)
+	}
+	row := data.DetailsTable[1]
+	if row["Opcode/Instruction"] != "REX + 0F B0/r CMPXCHG r/m8,r8" || row["Compat/Leg Mode"] != "Valid" || row["Description"] != "Compare AL with r/m8 * 2." {
+		t.Errorf("row = %q", row)
+	}
+}
+
+func TestFinishRecordMovesFormsToChildren(t *testing.T) {
+	s := newTestScraper(t, "")
+	data := InstructionData{
+		URL:             "https://www.felixcloutier.com/x86/jcc",
+		InstructionName: "Jcc\n\t\t— Jump if Condition Is Met",
+		DetailsTable: []TableRow{
+			{"Opcode": "75 cb", "Instruction": "JNE rel8", "Op/En": "D", "64-Bit Mode": "Valid", "Compat/Leg Mode": "Valid", "Description": "Jump short if not equal (ZF=0)."},
+			{"Opcode": "75 cb", "Instruction": "JNZ rel8", "Op/En": "D", "64-Bit Mode": "Valid", "Compat/Leg Mode": "Valid", "Description": "Jump short if not zero (ZF=0)."},
+		},
+	}
+	records := s.finishRecord(data)
+
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want the page and 2 children", len(records))
+	}
+	if len(records[0].Forms) != 0 {
+		t.Errorf("page kept %d forms", len(records[0].Forms))
+	}
+	for _, child := range records[1:] {
+		if child.Parent != data.URL || len(child.Forms) != 1 || child.Forms[0].Mnemonic != child.InstructionName {
+			t.Errorf("child %s: parent %q, forms %+v", child.InstructionName, child.Parent, child.Forms)
+		}
+	}
+}
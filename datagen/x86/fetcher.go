@@ -0,0 +1,20 @@
+package main
+
+import (
+	"context"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Fetcher retrieves a page for the parsers. A nil document with
+// errNotModified means the previously scraped copy is still current.
+// networkFetcher and cacheFetcher are the two implementations; anything
+// else that can produce felixcloutier HTML, such as recorded fixtures, can
+// be swapped in through Scraper.fetcher.
+type Fetcher interface {
+	Fetch(ctx context.Context, pageURL string) (*goquery.Document, pageValidators, error)
+}
+
+func (s *Scraper) fetchDocument(ctx context.Context, pageURL string) (*goquery.Document, pageValidators, error) {
+	return s.fetcher.Fetch(ctx, pageURL)
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const assetsDir = "assets"
+
+type Figure struct {
+	Source  string `json:"source"`
+	Path    string `json:"path,omitempty"`
+	Alt     string `json:"alt,omitempty"`
+	Caption string `json:"caption,omitempty"`
+}
+
+// extractFigures lists the images and inline SVG diagrams on a page, such
+// as the operand layouts on the shuffle and permute pages. Their files are
+// only fetched into assetsDir when s.figures is set; otherwise the record
+// just points at the source.
+func (s *Scraper) extractFigures(ctx context.Context, doc *goquery.Document, pageURL string) []Figure {
+	base, _ := url.Parse(pageURL)
+	slug := path.Base(strings.TrimSuffix(base.Path, "/"))
+
+	var figures []Figure
+	doc.Find("img, svg").Each(func(_ int, sel *goquery.Selection) {
+		if sel.ParentsFiltered("svg").Length() > 0 {
+			return
+		}
+
+		figure := Figure{
+			Caption: strings.Join(strings.Fields(sel.Closest("figure").Find("figcaption").Text()), " "),
+		}
+
+		if sel.Is("img") {
+			src, _ := sel.Attr("src")
+			if src == "" {
+				return
+			}
+			figure.Source = src
+			if ref, err := url.Parse(src); err == nil && base != nil {
+				figure.Source = base.ResolveReference(ref).String()
+			}
+			figure.Alt, _ = sel.Attr("alt")
+			if s.figures {
+				figure.Path = s.downloadFigure(ctx, figure.Source)
+			}
+		} else {
+			figure.Source = pageURL
+			if id, ok := sel.Attr("id"); ok {
+				figure.Source += "#" + id
+			}
+			figure.Alt = strings.TrimSpace(sel.Find("title").First().Text())
+			if s.figures {
+				name := fmt.Sprintf("%s-figure-%d.svg", slug, len(figures)+1)
+				figure.Path = s.saveInlineFigure(sel, name)
+			}
+		}
+
+		figures = append(figures, figure)
+	})
+	return figures
+}
+
+// downloadFigure fetches an image into assetsDir and returns its path, or
+// "" when it couldn't be fetched. Images already on disk are reused so
+// re-runs and pages sharing a figure don't download it again.
+func (s *Scraper) downloadFigure(ctx context.Context, source string) string {
+	u, err := url.Parse(source)
+	if err != nil || path.Base(u.Path) == "/" || path.Base(u.Path) == "." {
+		s.logger.Warn("Skipping figure with unusable URL", "url", source)
+		return ""
+	}
+
+	target := filepath.Join(assetsDir, path.Base(u.Path))
+	if _, err := os.Stat(target); err == nil {
+		return filepath.ToSlash(target)
+	}
+
+	if s.offline {
+		s.logger.Warn("Skipping figure not yet downloaded in offline mode", "url", source)
+		return ""
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", source, nil)
+	if err != nil {
+		s.logger.Warn("Failed to create figure request", "url", source, "error", err)
+		return ""
+	}
+	req.Header.Set("User-Agent", s.userAgent)
+
+	if err := s.checkRobots(ctx, source); err != nil {
+		s.logger.Warn("Skipping figure", "url", source, "error", err)
+		return ""
+	}
+	if err := s.throttle(ctx); err != nil {
+		return ""
+	}
+	started := time.Now()
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.metrics.observeRequest(0, 0, time.Since(started))
+		s.logger.Warn("Failed to fetch figure", "url", source, "error", err)
+		return ""
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		s.metrics.observeRequest(resp.StatusCode, 0, time.Since(started))
+		s.logger.Warn("Failed to fetch figure", "url", source, "status", resp.Status)
+		return ""
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	s.metrics.observeRequest(resp.StatusCode, int64(len(body)), time.Since(started))
+	if err != nil {
+		s.logger.Warn("Failed to read figure", "url", source, "error", err)
+		return ""
+	}
+
+	if err := s.writeFileAtomic(target, body); err != nil {
+		s.logger.Warn("Failed to save figure", "url", source, "error", err)
+		return ""
+	}
+	return filepath.ToSlash(target)
+}
+
+func (s *Scraper) saveInlineFigure(sel *goquery.Selection, name string) string {
+	markup, err := goquery.OuterHtml(sel)
+	if err != nil {
+		s.logger.Warn("Failed to render inline figure", "figure", name, "error", err)
+		return ""
+	}
+
+	target := filepath.Join(assetsDir, name)
+	if err := s.writeFileAtomic(target, []byte(markup)); err != nil {
+		s.logger.Warn("Failed to save figure", "figure", name, "error", err)
+		return ""
+	}
+	return filepath.ToSlash(target)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// linkFilter narrows a run to some instructions, e.g. to debug one parser
+// problem without a full scrape. Links are matched on their index text
+// (the mnemonic) and on their URL's last path segment, ignoring case.
+type linkFilter struct {
+	only  map[string]bool
+	match *regexp.Regexp
+}
+
+// newLinkFilter builds a filter from --only's comma-separated mnemonics and
+// --match's pattern, which has to match a whole name. It returns nil when
+// both are empty.
+func newLinkFilter(only, match string) (*linkFilter, error) {
+	if only == "" && match == "" {
+		return nil, nil
+	}
+
+	filter := &linkFilter{only: make(map[string]bool)}
+	for _, name := range strings.Split(only, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			filter.only[strings.ToLower(name)] = true
+		}
+	}
+
+	if match != "" {
+		pattern, err := regexp.Compile("^(?i:" + match + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid --match pattern: %w", err)
+		}
+		filter.match = pattern
+	}
+	return filter, nil
+}
+
+func (f *linkFilter) matches(name, pageURL string) bool {
+	names := []string{name}
+	if u, err := url.Parse(pageURL); err == nil {
+		names = append(names, path.Base(strings.TrimSuffix(u.Path, "/")))
+	}
+
+	for _, candidate := range names {
+		if f.only[strings.ToLower(candidate)] {
+			return true
+		}
+		if f.match != nil && f.match.MatchString(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldScrape decides whether an index link is fetched this run. A filter
+// picks exactly the links it matches, whether or not they were scraped
+// before; otherwise only new and failed pages are, unless --force or
+// --offline asks for all of them.
+func (s *Scraper) shouldScrape(name, pageURL string) bool {
+	if s.filter != nil {
+		return s.filter.matches(name, pageURL)
+	}
+	if s.force || s.offline {
+		return true
+	}
+	return !s.successfullyScraped[pageURL]
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+type FlagEffect string
+
+const (
+	FlagSet        FlagEffect = "set"
+	FlagCleared    FlagEffect = "cleared"
+	FlagModified   FlagEffect = "modified"
+	FlagUndefined  FlagEffect = "undefined"
+	FlagUnaffected FlagEffect = "unaffected"
+	FlagTested     FlagEffect = "tested"
+)
+
+// statusFlags are the EFLAGS bits the model tracks, in EFLAGS bit order.
+var statusFlags = []string{"CF", "PF", "AF", "ZF", "SF", "DF", "OF"}
+
+var (
+	flagClausePattern    = regexp.MustCompile(`[.;\n]\s+|\.$|,?\s+and\s+the\s+`)
+	flagNamePattern      = regexp.MustCompile(`\b(CF|PF|AF|ZF|SF|DF|OF)\b`)
+	flagReferencePattern = regexp.MustCompile(`\b(CF|PF|AF|ZF|SF|DF|OF)\b(\s*(:=|←))?`)
+)
+
+// flagConditionalWords mark clauses where a flag depends on the result
+// ("set according to the result", "set if ..., otherwise cleared") rather
+// than being forced to a fixed value.
+var flagConditionalWords = []string{
+	"according to", "updated", "based on", "contains", "if ", "otherwise",
+	"loaded", "depending", "complement", "filled", "may be", "affected",
+}
+
+// classifyFlagClause decides what a single sentence or clause of the Flags
+// Affected prose does to the flags it names.
+func (s *Scraper) classifyFlagClause(clause string) FlagEffect {
+	lower := strings.ToLower(clause)
+	switch {
+	case strings.Contains(lower, "undefined"):
+		return FlagUndefined
+	case strings.Contains(lower, "unaffected"), strings.Contains(lower, "not affected"),
+		strings.Contains(lower, "unmodified"):
+		return FlagUnaffected
+	}
+
+	for _, word := range flagConditionalWords {
+		if strings.Contains(lower, word) {
+			return FlagModified
+		}
+	}
+
+	switch {
+	case strings.Contains(lower, "cleared"), strings.Contains(lower, "set to 0"):
+		return FlagCleared
+	case strings.Contains(lower, "set"):
+		return FlagSet
+	}
+	return FlagModified
+}
+
+func (s *Scraper) parseFlagsAffected(text string) map[string]FlagEffect {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	flags := make(map[string]FlagEffect)
+	fill := func(effect FlagEffect) {
+		for _, flag := range statusFlags {
+			if _, ok := flags[flag]; !ok {
+				flags[flag] = effect
+			}
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSuffix(text, ".")) {
+	case "none":
+		fill(FlagUnaffected)
+		return flags
+	case "all":
+		fill(FlagModified)
+		return flags
+	}
+
+	for _, clause := range flagClausePattern.Split(text, -1) {
+		lower := strings.ToLower(clause)
+		names := flagNamePattern.FindAllString(clause, -1)
+
+		switch {
+		case len(names) > 0:
+			effect := s.classifyFlagClause(clause)
+			for _, name := range names {
+				// A later conditional clause refines an earlier blanket one,
+				// as in "... are all cleared. ZF is set if SRC = 0".
+				if previous, ok := flags[name]; !ok || (effect == FlagModified && previous != FlagUndefined) {
+					flags[name] = effect
+				}
+			}
+		case strings.HasPrefix(lower, "other flags"), strings.HasPrefix(lower, "all flags"):
+			fill(s.classifyFlagClause(clause))
+		}
+	}
+
+	if len(flags) == 0 {
+		return nil
+	}
+	return flags
+}
+
+// linkFlagsAffected builds the per-flag model from the Flags Affected prose,
+// then marks flags the instruction reads but does not write as tested. Reads
+// come from the Operation pseudocode (any use other than an assignment) and
+// the form descriptions, which is where condition-code pages such as Jcc
+// spell out their conditions ("Jump short if above (CF=0 and ZF=0)").
+func (s *Scraper) linkFlagsAffected(data *InstructionData) {
+	data.FlagsAffected = s.parseFlagsAffected(data.FlagsAffectedText)
+
+	sources := []string{data.OperationText}
+	for _, form := range data.Forms {
+		sources = append(sources, form.Description)
+	}
+
+	for _, source := range sources {
+		for _, match := range flagReferencePattern.FindAllStringSubmatch(source, -1) {
+			if match[2] != "" {
+				continue
+			}
+			if effect, ok := data.FlagsAffected[match[1]]; ok && effect != FlagUnaffected {
+				continue
+			}
+			if data.FlagsAffected == nil {
+				data.FlagsAffected = make(map[string]FlagEffect)
+			}
+			data.FlagsAffected[match[1]] = FlagTested
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+type InstructionForm struct {
+	Opcode      string   `json:"opcode"`
+	Instruction string   `json:"instruction"`
+	Mnemonic    string   `json:"mnemonic"`
+	Operands    []string `json:"operands,omitempty"`
+	OpEn        string   `json:"opEn,omitempty"`
+	// Mode64, ModeCompat and ModeSupport are the raw mode cells that
+	// Valid64 and ValidCompat are parsed from. They are rebuilt from the
+	// details table on every run, so they stay out of the dataset.
+	Mode64         string          `json:"-"`
+	ModeCompat     string          `json:"-"`
+	ModeSupport    string          `json:"-"`
+	Valid64        ModeValidity    `json:"valid64,omitempty"`
+	ValidCompat    ModeValidity    `json:"validCompat,omitempty"`
+	CPUID          string          `json:"cpuid,omitempty"`
+	FeatureFlags   []string        `json:"featureFlags,omitempty"`
+	Description    string          `json:"description,omitempty"`
+	IForms         []string        `json:"iforms,omitempty"`
+	Encoding       *OpcodeEncoding `json:"encoding,omitempty"`
+	OperandDetails []FormOperand   `json:"operandDetails,omitempty"`
+	Notes          []string        `json:"notes,omitempty"`
+	Table          string          `json:"table,omitempty"`
+}
+
+var (
+	opcodeBytePattern   = regexp.MustCompile(`^[0-9A-F]{2}(\+(rb|rw|rd|ro|i))?$`)
+	opcodeTokenPatterns = map[string]bool{
+		"+": true, "ib": true, "iw": true, "id": true, "io": true,
+		"cb": true, "cw": true, "cd": true, "cp": true, "co": true, "ct": true,
+		"REX": true, "NP": true, "NFx": true, "+rb": true, "+rw": true, "+rd": true, "+ro": true, "+i": true,
+	}
+	mnemonicPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]*(x[0-9]+[A-Z0-9]*)?$`)
+)
+
+func (s *Scraper) buildForms(data *InstructionData) {
+	slots := s.parseOperandSlots(data.OperandEncodingTable)
+
+	var forms []InstructionForm
+	for _, group := range s.detailsTableGroups(data) {
+		for i, row := range group.Rows {
+			form, ok := s.normalizeFormRow(row)
+			if !ok {
+				continue
+			}
+			form.Table = group.Heading
+			form.OperandDetails = s.buildFormOperands(form, slots)
+			if len(data.Notes) > 0 {
+				var markers []string
+				if i < len(group.NoteRefs) {
+					markers = group.NoteRefs[i]
+				}
+				s.linkFormNotes(&form, markers, data.Notes, group.NoteRefs != nil)
+			}
+			forms = append(forms, form)
+		}
+	}
+	data.Forms = forms
+}
+
+func (s *Scraper) normalizeFormRow(row TableRow) (InstructionForm, bool) {
+	var form InstructionForm
+	var combined string
+
+	headerless := true
+	for key := range row {
+		if !strings.HasPrefix(key, "column_") {
+			headerless = false
+			break
+		}
+	}
+
+	if headerless {
+		form.Opcode = row["column_1"]
+		form.Instruction = row["column_2"]
+		form.Mode64 = row["column_3"]
+		form.ModeCompat = row["column_4"]
+		form.Description = row["column_5"]
+	} else {
+		garbled := false
+		for key, value := range row {
+			header := strings.ToLower(strings.Join(strings.Fields(key), ""))
+			switch {
+			case strings.Contains(header, "\n") || len(header) > 40:
+				garbled = true
+				combined = value
+			case strings.HasPrefix(header, "opcode") && strings.Contains(header, "instruction"):
+				combined = value
+			case strings.HasPrefix(header, "opcode"):
+				form.Opcode = value
+			case header == "instruction":
+				form.Instruction = value
+			case strings.HasPrefix(header, "op/") || header == "openk" || header == "open" || header == "en":
+				form.OpEn = value
+			case strings.HasPrefix(header, "64/32"):
+				form.ModeSupport = value
+			case strings.HasPrefix(header, "64-bitmode"):
+				form.Mode64 = value
+			case strings.Contains(header, "legmode"):
+				form.ModeCompat = value
+			case strings.HasPrefix(header, "cpuid"):
+				form.CPUID = value
+			case header == "description":
+				form.Description = value
+			case header == "support":
+				form.ModeSupport = value
+			}
+		}
+
+		if garbled {
+			form.OpEn = row["column_2"]
+			form.CPUID = row["column_4"]
+		} else if form.Opcode == "" && row["column_1"] != "" {
+			form.Opcode = row["column_1"]
+		}
+	}
+
+	if combined != "" {
+		form.Opcode, form.Instruction = s.splitOpcodeInstruction(combined)
+	}
+
+	form.Opcode = strings.Join(strings.Fields(form.Opcode), " ")
+	form.Instruction = strings.Join(strings.Fields(form.Instruction), " ")
+	form.Mnemonic, form.Operands = s.splitInstructionSyntax(form.Instruction)
+
+	if form.Opcode == "" && form.Instruction == "" {
+		return form, false
+	}
+
+	s.linkModeValidity(&form)
+	form.IForms = s.buildIForms(form)
+	form.Encoding = s.parseOpcodeEncoding(form)
+	return form, true
+}
+
+func (s *Scraper) splitOpcodeInstruction(text string) (string, string) {
+	tokens := strings.Fields(text)
+	for i, token := range tokens {
+		if s.isOpcodeToken(token) {
+			continue
+		}
+		if mnemonicPattern.MatchString(token) {
+			return strings.Join(tokens[:i], " "), strings.Join(tokens[i:], " ")
+		}
+	}
+	return text, ""
+}
+
+func (s *Scraper) isOpcodeToken(token string) bool {
+	token = strings.TrimSuffix(token, "*")
+	switch {
+	case opcodeTokenPatterns[token]:
+		return true
+	case opcodeBytePattern.MatchString(token):
+		return true
+	case strings.HasPrefix(token, "/"):
+		return true
+	case strings.HasPrefix(token, "REX."), strings.HasPrefix(token, "VEX."),
+		strings.HasPrefix(token, "EVEX."), strings.HasPrefix(token, "XOP."):
+		return true
+	}
+	return false
+}
+
+func (s *Scraper) splitInstructionSyntax(instruction string) (string, []string) {
+	tokens := strings.Fields(instruction)
+	var mnemonicParts []string
+	i := 0
+	for ; i < len(tokens); i++ {
+		token := strings.TrimSuffix(tokens[i], "*")
+		if !mnemonicPattern.MatchString(token) || strings.HasSuffix(tokens[i], ",") {
+			break
+		}
+		if len(mnemonicParts) > 0 && !s.isPrefixMnemonic(mnemonicParts[len(mnemonicParts)-1]) {
+			break
+		}
+		mnemonicParts = append(mnemonicParts, token)
+	}
+
+	var operands []string
+	rest := strings.Join(tokens[i:], " ")
+	for _, operand := range strings.Split(rest, ",") {
+		operand = strings.TrimSpace(operand)
+		if operand != "" {
+			operands = append(operands, operand)
+		}
+	}
+
+	return strings.Join(mnemonicParts, " "), operands
+}
+
+func (s *Scraper) isPrefixMnemonic(mnemonic string) bool {
+	switch mnemonic {
+	case "LOCK", "REP", "REPE", "REPZ", "REPNE", "REPNZ":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"arisa/parquet"
+)
+
+var formsExportHeader = []string{
+	"url", "mnemonic", "instruction", "opcode", "operands", "opEn",
+	"valid64", "validCompat", "modeSupport", "cpuid", "featureFlags", "table", "description",
+}
+
+// saveFormsExport flattens every form into one row of a CSV file, or a TSV
+// file when the name ends in .tsv, for spreadsheets and grep.
+func (s *Scraper) saveFormsExport(instructions []InstructionData) error {
+	buffer := new(bytes.Buffer)
+	writer := csv.NewWriter(buffer)
+	if strings.EqualFold(filepath.Ext(s.formsExportFilename), ".tsv") {
+		writer.Comma = '\t'
+	}
+
+	if err := writer.Write(formsExportHeader); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	rows := formsExportRows(instructions)
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write rows: %w", err)
+	}
+
+	if err := s.writeFileAtomic(s.formsExportFilename, buffer.Bytes()); err != nil {
+		return fmt.Errorf("failed to write forms export: %w", err)
+	}
+
+	s.logger.Info("Saved forms export", "file", s.formsExportFilename, "forms", len(rows))
+	return nil
+}
+
+// saveFormsParquet writes the same rows as saveFormsExport into a Parquet
+// file, for loading into DuckDB or Pandas alongside other timing tables.
+func (s *Scraper) saveFormsParquet(instructions []InstructionData) error {
+	rows := formsExportRows(instructions)
+	encoded, err := parquet.Encode(formsExportHeader, rows)
+	if err != nil {
+		return fmt.Errorf("failed to encode forms: %w", err)
+	}
+
+	if err := s.writeFileAtomic(s.parquetFilename, encoded); err != nil {
+		return fmt.Errorf("failed to write forms Parquet export: %w", err)
+	}
+
+	s.logger.Info("Saved forms Parquet export", "file", s.parquetFilename, "forms", len(rows))
+	return nil
+}
+
+// formsExportRows flattens every form of the successfully scraped pages
+// into one row under formsExportHeader. Lists such as operands and feature
+// flags are joined into a single cell.
+func formsExportRows(instructions []InstructionData) [][]string {
+	var rows [][]string
+	for _, data := range instructions {
+		if data.Error != "" {
+			continue
+		}
+		for _, form := range data.Forms {
+			rows = append(rows, []string{
+				data.URL,
+				form.Mnemonic,
+				form.Instruction,
+				form.Opcode,
+				strings.Join(form.Operands, ", "),
+				form.OpEn,
+				string(form.Valid64),
+				string(form.ValidCompat),
+				form.ModeSupport,
+				form.CPUID,
+				strings.Join(form.FeatureFlags, " "),
+				form.Table,
+				form.Description,
+			})
+		}
+	}
+	return rows
+}
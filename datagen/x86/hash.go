@@ -0,0 +1,86 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+)
+
+// contentHash fingerprints what a record says about the instruction. Where
+// and when it was found (URL, parent, index category, provenance, HTTP
+// validators) is left out, so felixcloutier's alias pages, which serve the
+// same page under another name, hash the same. encoding/json sorts map
+// keys, which keeps the hash stable across runs.
+func (s *Scraper) contentHash(data InstructionData) string {
+	data.URL = ""
+	data.Parent = ""
+	data.Category = ""
+	data.InstructionName = ""
+	data.Provenance = nil
+	data.ContentHash = ""
+	data.Aliases = nil
+	data.AliasValidators = nil
+	data.ETag = ""
+	data.LastModified = ""
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(encoded)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// mergeDuplicates reports records whose content hash matches another
+// record's and keeps only the one with the lowest URL, listing the others as
+// its aliases so lookups by those URLs still resolve.
+func (s *Scraper) mergeDuplicates(instructions []InstructionData) []InstructionData {
+	groups := make(map[string][]int)
+	for i := range instructions {
+		instructions[i].Aliases = nil
+		instructions[i].AliasValidators = nil
+		if instructions[i].Error != "" || instructions[i].ContentHash == "" {
+			continue
+		}
+		groups[instructions[i].ContentHash] = append(groups[instructions[i].ContentHash], i)
+	}
+
+	drop := make(map[int]bool)
+	for _, indexes := range groups {
+		if len(indexes) < 2 {
+			continue
+		}
+		sort.Slice(indexes, func(i, j int) bool {
+			return instructions[indexes[i]].URL < instructions[indexes[j]].URL
+		})
+
+		kept := &instructions[indexes[0]]
+		kept.AliasValidators = make(map[string]pageValidators)
+		for _, i := range indexes[1:] {
+			alias := instructions[i]
+			kept.Aliases = append(kept.Aliases, alias.URL)
+			if alias.ETag != "" || alias.LastModified != "" {
+				kept.AliasValidators[alias.URL] = pageValidators{ETag: alias.ETag, LastModified: alias.LastModified}
+			}
+			drop[i] = true
+		}
+		if len(kept.AliasValidators) == 0 {
+			kept.AliasValidators = nil
+		}
+		s.logger.Warn("Duplicate content", "url", kept.URL, "aliases", kept.Aliases)
+	}
+
+	if len(drop) == 0 {
+		return instructions
+	}
+
+	merged := make([]InstructionData, 0, len(instructions)-len(drop))
+	for i, data := range instructions {
+		if !drop[i] {
+			merged = append(merged, data)
+		}
+	}
+	s.logger.Info("Merged duplicate records", "dropped", len(drop))
+	return merged
+}
@@ -0,0 +1,47 @@
+package main
+
+import "testing"
+
+// TestAliasValidators checks that a merged alias page keeps the validators
+// its own URL was served with across a save and load, rather than taking
+// the canonical page's.
+func TestAliasValidators(t *testing.T) {
+	s := newTestScraper(t, "")
+	page := func(url, etag, lastModified string) InstructionData {
+		data := InstructionData{
+			URL:             url,
+			InstructionName: "MOVSD— Move or Merge Scalar Double Precision Floating-Point Value",
+			DescriptionText: "Moves a scalar double precision floating-point value.",
+			ETag:            etag,
+			LastModified:    lastModified,
+		}
+		data.ContentHash = s.contentHash(data)
+		return data
+	}
+	records := s.mergeDuplicates([]InstructionData{
+		page("https://www.felixcloutier.com/x86/movsd", `"canonical"`, "Mon, 01 Jan 2024 00:00:00 GMT"),
+		page("https://www.felixcloutier.com/x86/movsd-1", `"alias"`, "Tue, 02 Jan 2024 00:00:00 GMT"),
+	})
+	if len(records) != 1 {
+		t.Fatalf("got %d records after merging, want 1", len(records))
+	}
+	if err := s.saveData(records); err != nil {
+		t.Fatal(err)
+	}
+
+	s.previousData = make(map[string]InstructionData)
+	if err := s.loadExistingData(); err != nil {
+		t.Fatal(err)
+	}
+	for url, want := range map[string]string{
+		"https://www.felixcloutier.com/x86/movsd":   `"canonical"`,
+		"https://www.felixcloutier.com/x86/movsd-1": `"alias"`,
+	} {
+		if got := s.previousData[url].ETag; got != want {
+			t.Errorf("%s has ETag %s, want %s", url, got, want)
+		}
+	}
+	if got := s.previousData["https://www.felixcloutier.com/x86/movsd-1"].LastModified; got != "Tue, 02 Jan 2024 00:00:00 GMT" {
+		t.Errorf("alias has Last-Modified %q", got)
+	}
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	operandDecoratorPattern = regexp.MustCompile(`\{[^}]*\}`)
+	iformSanitizePattern    = regexp.MustCompile(`[^A-Za-z0-9]+`)
+)
+
+var iformOperandClasses = map[string]string{
+	"r8": "GPR8", "r16": "GPRv", "r32": "GPRv", "r64": "GPRv",
+	"reg": "GPRv",
+	"m":   "MEM", "mem": "MEM", "m8": "MEMb", "m16": "MEMw", "m32": "MEMd", "m64": "MEMq",
+	"m80": "MEMt", "m128": "MEMdq", "m256": "MEMqq", "m512": "MEMzmm",
+	"m16int": "MEMw", "m32int": "MEMd", "m64int": "MEMq",
+	"m32fp": "MEMf32", "m64fp": "MEMf64", "m80fp": "MEMf80", "m80bcd": "MEMt",
+	"m16&16": "MEMd", "m16&32": "MEMq", "m16&64": "MEMdq", "m32&32": "MEMq",
+	"m16:16": "MEMp", "m16:32": "MEMp", "m16:64": "MEMp2",
+	"m14/28byte": "MEMmxsave", "m94/108byte": "MEMmxsave", "m2byte": "MEMw", "m512byte": "MEMmxsave",
+	"imm8": "IMMb", "imm16": "IMMw", "imm32": "IMMd", "imm64": "IMMv",
+	"rel8": "RELBRb", "rel16": "RELBRz", "rel32": "RELBRz",
+	"ptr16:16": "PTRp", "ptr16:32": "PTRp",
+	"moffs8": "MEMb", "moffs16": "MEMv", "moffs32": "MEMv", "moffs64": "MEMv",
+	"AL": "AL", "CL": "CL", "AX": "OrAX", "EAX": "OrAX", "RAX": "OrAX", "DX": "DX",
+	"Sreg": "SEG", "CS": "CS", "DS": "DS", "ES": "ES", "FS": "FS", "GS": "GS", "SS": "SS",
+	"CR0-CR7": "CR", "CR8": "CR", "DR0-DR7": "DR",
+	"ST(0)": "X87", "ST(i)": "X87", "ST": "X87",
+	"mm": "MMXq", "mm1": "MMXq", "mm2": "MMXq",
+	"xmm": "XMMdq", "ymm": "YMMqq", "zmm": "ZMMu",
+	"k": "MASKmskw", "bnd": "BND", "tmm": "TMM",
+	"vm32x": "MEMvsib", "vm32y": "MEMvsib", "vm32z": "MEMvsib",
+	"vm64x": "MEMvsib", "vm64y": "MEMvsib", "vm64z": "MEMvsib",
+	"1": "ONE",
+}
+
+var numberedRegisterPattern = regexp.MustCompile(`^(xmm|ymm|zmm|mm|k|bnd|tmm)[0-9]*$`)
+
+func (s *Scraper) buildIForms(form InstructionForm) []string {
+	if form.Mnemonic == "" {
+		return nil
+	}
+
+	base := strings.ReplaceAll(form.Mnemonic, " ", "_")
+	iforms := []string{base}
+
+	for _, operand := range form.Operands {
+		alternatives := s.iformOperandAlternatives(operand)
+		if len(alternatives) == 0 {
+			continue
+		}
+
+		var expanded []string
+		for _, prefix := range iforms {
+			for _, alternative := range alternatives {
+				expanded = append(expanded, prefix+"_"+alternative)
+			}
+		}
+		iforms = expanded
+	}
+
+	if s.hasSizedGPROperand(form.Operands) {
+		for i, iform := range iforms {
+			iforms[i] = strings.ReplaceAll(strings.ReplaceAll(iform, "_IMMw", "_IMMz"), "_IMMd", "_IMMz")
+		}
+	}
+
+	seen := make(map[string]bool)
+	var unique []string
+	for _, iform := range iforms {
+		if !seen[iform] {
+			seen[iform] = true
+			unique = append(unique, iform)
+		}
+	}
+	return unique
+}
+
+func (s *Scraper) iformOperandAlternatives(operand string) []string {
+	operand = operandDecoratorPattern.ReplaceAllString(operand, "")
+	operand = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(operand), "*"))
+	if operand == "" {
+		return nil
+	}
+
+	if strings.HasPrefix(operand, "r/m") {
+		size := strings.TrimPrefix(operand, "r/m")
+		register := s.iformOperandClass("r" + size)
+		memory := "MEMv"
+		if size == "8" {
+			memory = "MEMb"
+		}
+		return []string{register, memory}
+	}
+
+	if strings.HasPrefix(operand, "reg/m") {
+		return []string{"GPR32", s.iformOperandClass("m" + strings.TrimPrefix(operand, "reg/m"))}
+	}
+
+	parts := strings.Split(operand, "/")
+	var alternatives []string
+	for _, part := range parts {
+		if strings.HasSuffix(part, "bcst") {
+			continue
+		}
+		alternatives = append(alternatives, s.iformOperandClass(part))
+	}
+	return alternatives
+}
+
+func (s *Scraper) iformOperandClass(operand string) string {
+	if class, ok := iformOperandClasses[operand]; ok {
+		return class
+	}
+
+	if match := numberedRegisterPattern.FindStringSubmatch(operand); match != nil {
+		return iformOperandClasses[match[1]]
+	}
+
+	if strings.HasPrefix(operand, "r") {
+		if class, ok := iformOperandClasses[strings.TrimRight(operand, "ab")]; ok {
+			return class
+		}
+	}
+
+	return strings.ToUpper(iformSanitizePattern.ReplaceAllString(operand, ""))
+}
+
+func (s *Scraper) hasSizedGPROperand(operands []string) bool {
+	for _, operand := range operands {
+		operand = strings.TrimSuffix(strings.TrimSpace(operand), "*")
+		switch operand {
+		case "r16", "r32", "r64", "r/m16", "r/m32", "r/m64", "AX", "EAX", "RAX":
+			return true
+		}
+	}
+	return false
+}
+
+// iformSuffixComponents are the encoding details disambiguateIForms can
+// name, in the order their suffixes are written. Empty values add nothing.
+var iformSuffixComponents = []func(InstructionForm) string{
+	func(form InstructionForm) string { return strings.ToUpper(encodingOf(form).PrefixClass) },
+	func(form InstructionForm) string { return encodingOf(form).MandatoryPrefix },
+	func(form InstructionForm) string {
+		if opcodeMap := encodingOf(form).Map; opcodeMap != "legacy" {
+			return opcodeMap
+		}
+		return ""
+	},
+	func(form InstructionForm) string { return strings.Join(encodingOf(form).OpcodeBytes, "") },
+	func(form InstructionForm) string {
+		if reg := encodingOf(form).ModRMReg; reg != nil {
+			return "R" + strconv.Itoa(*reg)
+		}
+		return ""
+	},
+	func(form InstructionForm) string {
+		if encodingOf(form).REX {
+			return "REX"
+		}
+		return ""
+	},
+	func(form InstructionForm) string { return encodingOf(form).W },
+	func(form InstructionForm) string {
+		if length := encodingOf(form).VectorLength; length != "" && !strings.HasPrefix(length, "L") {
+			return "L" + length
+		}
+		return encodingOf(form).VectorLength
+	},
+	func(form InstructionForm) string { return strings.ToUpper(encodingOf(form).Immediate) },
+}
+
+func encodingOf(form InstructionForm) OpcodeEncoding {
+	if form.Encoding == nil {
+		return OpcodeEncoding{}
+	}
+	return *form.Encoding
+}
+
+// disambiguateIForms gives every iform a single owner: one opcode column on
+// one page. buildIForms names forms by mnemonic and operand classes alone,
+// so ADD's 01 /r, 03 /r and their REX.W forms all come out ADD_GPRv_GPRv.
+// Like XED, each clash is settled with suffixes for the encoding details
+// that differ, giving ADD_GPRv_GPRv_01, ADD_GPRv_GPRv_03_W1 and so on,
+// falling back to the opcode column and then the page when those agree. A
+// form with nothing to add, such as ADD_MEMv_GPRv beside its REX.W twin,
+// keeps the plain name.
+// Forms that share an opcode column, such as ADD r/m16 and r/m32, share
+// the iform.
+func (s *Scraper) disambiguateIForms(instructions []InstructionData) {
+	type owner struct{ record, form int }
+	owners := make(map[string]map[string][]owner)
+	for i, data := range instructions {
+		for j, form := range data.Forms {
+			identity := data.URL + " " + form.Opcode
+			for _, iform := range form.IForms {
+				if owners[iform] == nil {
+					owners[iform] = make(map[string][]owner)
+				}
+				owners[iform][identity] = append(owners[iform][identity], owner{i, j})
+			}
+		}
+	}
+
+	for iform, identities := range owners {
+		if len(identities) < 2 {
+			continue
+		}
+
+		keys := make([]string, 0, len(identities))
+		for key := range identities {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		forms := make([]InstructionForm, len(keys))
+		pages := make([]string, len(keys))
+		for i, key := range keys {
+			first := identities[key][0]
+			forms[i] = instructions[first.record].Forms[first.form]
+			pages[i] = instructions[first.record].URL
+		}
+
+		for i, suffix := range s.iformSuffixes(forms, pages) {
+			if suffix == "" {
+				continue
+			}
+			for _, owner := range identities[keys[i]] {
+				names := instructions[owner.record].Forms[owner.form].IForms
+				for k := range names {
+					if names[k] == iform {
+						names[k] = iform + "_" + suffix
+					}
+				}
+			}
+		}
+	}
+}
+
+// iformSuffixes names what sets each of forms apart, one suffix per form.
+func (s *Scraper) iformSuffixes(forms []InstructionForm, pages []string) []string {
+	parts := make([][]string, len(forms))
+	add := func(value func(int) string) {
+		values := make([]string, len(forms))
+		varies := false
+		for i := range forms {
+			values[i] = value(i)
+			varies = varies || values[i] != values[0]
+		}
+		if !varies {
+			return
+		}
+		for i, v := range values {
+			if v != "" {
+				parts[i] = append(parts[i], v)
+			}
+		}
+	}
+	suffixes := func() []string {
+		joined := make([]string, len(parts))
+		for i, part := range parts {
+			joined[i] = strings.Join(part, "_")
+		}
+		return joined
+	}
+	distinct := func() bool {
+		seen := make(map[string]bool)
+		for _, suffix := range suffixes() {
+			if seen[suffix] {
+				return false
+			}
+			seen[suffix] = true
+		}
+		return true
+	}
+
+	for _, component := range iformSuffixComponents {
+		add(func(i int) string { return component(forms[i]) })
+	}
+	if !distinct() {
+		add(func(i int) string {
+			return strings.ToUpper(iformSanitizePattern.ReplaceAllString(forms[i].Opcode, ""))
+		})
+	}
+	if !distinct() {
+		add(func(i int) string {
+			page := pages[i][strings.LastIndex(pages[i], "/")+1:]
+			return strings.ToUpper(iformSanitizePattern.ReplaceAllString(page, ""))
+		})
+	}
+	return suffixes()
+}
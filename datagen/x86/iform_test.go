@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDisambiguateIForms(t *testing.T) {
+	s := newTestScraper(t, "")
+	data := InstructionData{
+		URL:             "https://www.felixcloutier.com/x86/add",
+		InstructionName: "ADD\n\t\t— Add",
+		DetailsTable: []TableRow{
+			{"Opcode": "01 /r", "Instruction": "ADD r/m16, r16", "Op/En": "MR", "64-Bit Mode": "Valid", "Compat/Leg Mode": "Valid"},
+			{"Opcode": "01 /r", "Instruction": "ADD r/m32, r32", "Op/En": "MR", "64-Bit Mode": "Valid", "Compat/Leg Mode": "Valid"},
+			{"Opcode": "REX.W + 01 /r", "Instruction": "ADD r/m64, r64", "Op/En": "MR", "64-Bit Mode": "Valid", "Compat/Leg Mode": "N.E."},
+			{"Opcode": "03 /r", "Instruction": "ADD r32, r/m32", "Op/En": "RM", "64-Bit Mode": "Valid", "Compat/Leg Mode": "Valid"},
+		},
+	}
+	records := s.finishRecord(data)
+	s.disambiguateIForms(records)
+
+	want := [][]string{
+		{"ADD_GPRv_GPRv_01", "ADD_MEMv_GPRv"},
+		{"ADD_GPRv_GPRv_01", "ADD_MEMv_GPRv"},
+		{"ADD_GPRv_GPRv_01_W1", "ADD_MEMv_GPRv_W1"},
+		{"ADD_GPRv_GPRv_03", "ADD_GPRv_MEMv"},
+	}
+	for i, form := range records[0].Forms {
+		if !reflect.DeepEqual(form.IForms, want[i]) {
+			t.Errorf("%s: iforms = %q, want %q", form.Opcode, form.IForms, want[i])
+		}
+	}
+}
+
+// TestDatasetIFormsUnique checks that every iform in the committed dataset
+// names the forms of a single opcode column on a single page.
+func TestDatasetIFormsUnique(t *testing.T) {
+	s := newTestScraper(t, "")
+	records, err := s.readDataset("x86.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owners := make(map[string]string)
+	for _, data := range records {
+		for _, form := range data.Forms {
+			owner := data.URL + " " + form.Opcode
+			for _, iform := range form.IForms {
+				if previous, ok := owners[iform]; ok && previous != owner {
+					t.Errorf("%s names both %q and %q", iform, previous, owner)
+				}
+				owners[iform] = owner
+			}
+		}
+	}
+}
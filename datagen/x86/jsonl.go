@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"arisa/config"
+)
+
+// recordStream writes the dataset as JSON Lines while the run is still
+// going: records carried over from the last run first, then each page as
+// soon as it is scraped, one flushed line per record. Consumers can follow
+// the file as it grows and never hold more than one record. Since nothing is
+// buffered, alias pages aren't merged the way they are in the JSON dataset,
+// and each gets its own line.
+type recordStream struct {
+	scraper *Scraper
+	file    *os.File
+	writer  *bufio.Writer
+}
+
+// openRecordStream returns nil unless --format asked for jsonl, or when the
+// file can't be created; the run goes on without it either way.
+func (s *Scraper) openRecordStream(links []InstructionLink) *recordStream {
+	if !s.formats["jsonl"] {
+		return nil
+	}
+
+	target := config.FormatPath(s.outputFilename, "jsonl")
+	file, err := os.Create(target)
+	if err != nil {
+		s.logger.Warn("Failed to create JSON Lines output, continuing without it", "file", target, "error", err)
+		return nil
+	}
+	stream := &recordStream{scraper: s, file: file, writer: bufio.NewWriter(file)}
+
+	queued := make(map[string]bool, len(links))
+	for _, link := range links {
+		queued[link.URL] = true
+	}
+	var carried []string
+	for url, data := range s.previousData {
+		if data.Parent == "" && !queued[url] {
+			carried = append(carried, url)
+		}
+	}
+	sort.Strings(carried)
+	for _, url := range carried {
+		if err := stream.add(s.previousData[url]); err != nil {
+			s.logger.Warn("Failed to stream record", "url", url, "error", err)
+		}
+	}
+	return stream
+}
+
+// add finishes a record and writes it, with any records expanded from it,
+// straight through to the file.
+func (r *recordStream) add(data InstructionData) error {
+	if r == nil {
+		return nil
+	}
+
+	for _, record := range r.scraper.finishRecord(data) {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to encode record: %w", err)
+		}
+		r.writer.Write(line)
+		r.writer.WriteByte('\n')
+	}
+	if err := r.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write record: %w", err)
+	}
+	return nil
+}
+
+func (r *recordStream) close() error {
+	if r == nil {
+		return nil
+	}
+	err := r.writer.Flush()
+	if closeErr := r.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		r.scraper.logger.Info("JSON Lines saved successfully", "file", r.file.Name())
+	}
+	return err
+}
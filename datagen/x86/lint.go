@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+type LintSeverity string
+
+const (
+	SeverityError   LintSeverity = "error"
+	SeverityWarning LintSeverity = "warning"
+	SeverityInfo    LintSeverity = "info"
+)
+
+var (
+	// opcodeConditionPattern matches parenthesized qualifiers such as
+	// "(EAX=4)" or "(mod=11)" that select a leaf or form but are not bytes.
+	opcodeConditionPattern = regexp.MustCompile(`\([^()]*=[^()]*\)`)
+	modRMFieldPattern      = regexp.MustCompile(`^!?\(?11\)?:(rrr|[01]{3}):(bbb|[01]{3})$`)
+)
+
+type LintFinding struct {
+	Index    int          `json:"index"`
+	URL      string       `json:"url"`
+	Name     string       `json:"name"`
+	Rule     string       `json:"rule"`
+	Severity LintSeverity `json:"severity"`
+	Message  string       `json:"message"`
+}
+
+type LintReport struct {
+	File     string               `json:"file"`
+	Records  int                  `json:"records"`
+	Summary  map[LintSeverity]int `json:"summary"`
+	Findings []LintFinding        `json:"findings"`
+}
+
+// lintDataset checks every record for the gaps that tend to slip through a
+// scrape unnoticed. Errors mark records that are unusable as published,
+// warnings mark ones that are probably wrong, and info marks ones that are
+// merely thin, since plenty of instructions really do raise no exceptions.
+func (s *Scraper) lintDataset(instructions []InstructionData) []LintFinding {
+	var findings []LintFinding
+	add := func(i int, rule string, severity LintSeverity, format string, args ...interface{}) {
+		findings = append(findings, LintFinding{
+			Index:    i,
+			URL:      instructions[i].URL,
+			Name:     s.lintName(instructions[i]),
+			Rule:     rule,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	// Children share their parent's prose, so duplicates are only
+	// suspicious when they cross pages.
+	pages := make(map[string]string)
+	firstIndex := make(map[string]int)
+
+	for i, data := range instructions {
+		if data.Error != "" {
+			add(i, "scrape-error", SeverityError, "record was saved with an error: %s", data.Error)
+			continue
+		}
+
+		if strings.TrimSpace(data.DescriptionText) == "" {
+			add(i, "empty-description", SeverityWarning, "description is empty")
+		}
+
+		if len(data.OperandEncodingTable) == 0 && len(data.Forms) > 0 {
+			add(i, "missing-operand-encoding", SeverityWarning, "no operand encoding table for %d forms", len(data.Forms))
+		}
+
+		if len(data.Exceptions) == 0 && len(data.ExceptionRecords) == 0 {
+			add(i, "no-exceptions", SeverityInfo, "no exception entries")
+		}
+
+		for _, form := range data.Forms {
+			if form.Opcode == "" && len(data.SGXLeaves) > 0 {
+				// Leaf tables select by EAX value and have no opcode column.
+				continue
+			}
+			if problem := s.opcodeProblem(form.Opcode); problem != "" {
+				add(i, "malformed-opcode", SeverityWarning, "%s: opcode %q %s", form.Instruction, form.Opcode, problem)
+			}
+		}
+
+		if data.DescriptionText == "" && data.OperationText == "" {
+			continue
+		}
+		page := data.URL
+		if data.Parent != "" {
+			page = data.Parent
+		}
+		key := s.contentKey(data)
+		if other, ok := pages[key]; ok && other != page {
+			first := instructions[firstIndex[key]]
+			add(i, "duplicate-content", SeverityWarning, "description and operation match %s", first.URL)
+			continue
+		}
+		if _, ok := pages[key]; !ok {
+			pages[key] = page
+			firstIndex[key] = i
+		}
+	}
+
+	return findings
+}
+
+// opcodeProblem describes what is wrong with an opcode column, or returns
+// "" when every token is something the SDM notation allows. Most problems
+// are cells the site glued together, like "0F B1/r" or "/r ib1".
+func (s *Scraper) opcodeProblem(opcode string) string {
+	if strings.TrimSpace(opcode) == "" {
+		return "is empty"
+	}
+	opcode = opcodeConditionPattern.ReplaceAllString(opcode, " ")
+	opcode = splitRegisterSuffix.ReplaceAllString(opcode, "+$1")
+
+	hasByte := false
+	for _, token := range strings.Fields(opcode) {
+		switch {
+		case s.isOpcodeToken(token), opcodeMapPattern.MatchString(token), modRMFieldPattern.MatchString(token),
+			strings.HasPrefix(token, "REX2."):
+		default:
+			return fmt.Sprintf("has unexpected token %q", token)
+		}
+		if opcodeBytePattern.MatchString(strings.TrimSuffix(token, "*")) {
+			hasByte = true
+		}
+	}
+	if !hasByte {
+		return "has no opcode byte"
+	}
+	return ""
+}
+
+// lintName keeps findings on one line by naming the record by its title
+// mnemonic rather than the full multi-line title.
+func (s *Scraper) lintName(data InstructionData) string {
+	if name := s.titleMnemonic(data); name != "" {
+		return name
+	}
+	return data.URL
+}
+
+func (s *Scraper) contentKey(data InstructionData) string {
+	sum := sha256.Sum256([]byte(data.DescriptionText + "\x00" + data.OperationText))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *Scraper) Lint(args []string) error {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	format := flags.String("format", "text", "report format: text or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: lint [--format text|json] <file.json>")
+	}
+	filename := flags.Arg(0)
+
+	instructions, err := s.readDataset(filename)
+	if err != nil {
+		return err
+	}
+
+	report := LintReport{
+		File:     filename,
+		Records:  len(instructions),
+		Summary:  map[LintSeverity]int{SeverityError: 0, SeverityWarning: 0, SeverityInfo: 0},
+		Findings: s.lintDataset(instructions),
+	}
+	for _, finding := range report.Findings {
+		report.Summary[finding.Severity]++
+	}
+
+	switch *format {
+	case "json":
+		buffer := new(bytes.Buffer)
+		encoder := json.NewEncoder(buffer)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		os.Stdout.Write(buffer.Bytes())
+	case "text":
+		for _, finding := range report.Findings {
+			fmt.Fprintf(os.Stdout, "%s: %s [%s] %s (%s)\n", finding.Severity, finding.Name, finding.Rule, finding.Message, finding.URL)
+		}
+		fmt.Fprintf(os.Stdout, "%d records: %d errors, %d warnings, %d info\n", report.Records,
+			report.Summary[SeverityError], report.Summary[SeverityWarning], report.Summary[SeverityInfo])
+	default:
+		return fmt.Errorf("unsupported format %q", *format)
+	}
+
+	if report.Summary[SeverityError] > 0 {
+		return fmt.Errorf("%d lint errors in %s", report.Summary[SeverityError], filename)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"arisa/manpage"
+)
+
+const (
+	defaultManDir = "man"
+	manSection    = "7x86"
+	manManual     = "x86 Instructions"
+)
+
+// ManPages writes a man page per instruction page, laid out for a MANPATH
+// directory, so after copying the output into /usr/local/share/man (or
+// adding it to MANPATH) "man 7x86 vpshufb" works. Every other mnemonic a
+// page covers, including condition-code children, gets a link page.
+func (s *Scraper) ManPages(args []string) error {
+	flags := flag.NewFlagSet("man", flag.ContinueOnError)
+	outputDir := flags.String("out", defaultManDir, "man directory to write the pages under")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("usage: man [--out dir] [file.json]")
+	}
+	filename := s.outputFilename
+	if flags.NArg() == 1 {
+		filename = flags.Arg(0)
+	}
+
+	instructions, err := s.readDataset(filename)
+	if err != nil {
+		return err
+	}
+
+	written := make(map[string]bool)
+	primaries := make(map[string]string)
+	var links [][2]string
+	pages := s.docsPages(instructions)
+	for _, data := range pages {
+		names := s.docsetNames(data)
+		primary := s.docsSlug(data)
+		if len(names) > 0 && !written[manpage.FileName(names[0])] {
+			primary = names[0]
+		} else {
+			names = append([]string{primary}, names...)
+		}
+
+		_, summary, _ := strings.Cut(s.docsTitle(data), "—")
+		content := manpage.Render(s.docsetPage(data), names, strings.TrimSpace(summary), manSection, manManual)
+		if err := s.writeFileAtomic(filepath.Join(*outputDir, manpage.Path(primary, manSection)), content); err != nil {
+			return fmt.Errorf("failed to write man page for %s: %w", primary, err)
+		}
+		written[manpage.FileName(primary)] = true
+		primaries[data.URL] = primary
+		for _, name := range names[1:] {
+			links = append(links, [2]string{name, primary})
+		}
+	}
+	for _, data := range instructions {
+		if primary, ok := primaries[data.Parent]; ok && data.Error == "" {
+			links = append(links, [2]string{s.docsTitle(data), primary})
+		}
+	}
+
+	linked := 0
+	for _, link := range links {
+		name, target := link[0], link[1]
+		if written[manpage.FileName(name)] {
+			continue
+		}
+		if err := s.writeFileAtomic(filepath.Join(*outputDir, manpage.Path(name, manSection)), manpage.Link(target, manSection)); err != nil {
+			return fmt.Errorf("failed to write man page link for %s: %w", name, err)
+		}
+		written[manpage.FileName(name)] = true
+		linked++
+	}
+
+	s.logger.Info("Man pages saved", "dir", *outputDir, "pages", len(pages), "links", linked)
+	return nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// extractMarkdownFollowingHeader renders the section after an h2 as
+// Markdown, keeping the links, emphasis, inline code, lists, and tables
+// that extractTextFollowingHeader flattens away. Relative links are
+// resolved against the page URL so they still work outside the site.
+func (s *Scraper) extractMarkdownFollowingHeader(doc *goquery.Document, headerID, pageURL string) string {
+	base, _ := url.Parse(pageURL)
+
+	var blocks []string
+	header := doc.Find(fmt.Sprintf("h2#%s", headerID))
+	if header.Length() > 0 {
+		currentNode := header.Next()
+		for currentNode.Length() > 0 && currentNode.Get(0).Data != "h2" {
+			if block := s.markdownBlock(currentNode, base); block != "" {
+				blocks = append(blocks, block)
+			}
+			currentNode = currentNode.Next()
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+func (s *Scraper) markdownBlock(sel *goquery.Selection, base *url.URL) string {
+	switch {
+	case sel.Is("p"):
+		return strings.TrimSpace(s.markdownInline(sel, base))
+	case sel.Is("pre"):
+		return "```\n" + strings.Trim(sel.Text(), "\r\n") + "\n```"
+	case sel.Is("ul"), sel.Is("ol"):
+		var items []string
+		ordered := sel.Is("ol")
+		sel.ChildrenFiltered("li").Each(func(i int, li *goquery.Selection) {
+			marker := "-"
+			if ordered {
+				marker = fmt.Sprintf("%d.", i+1)
+			}
+			items = append(items, marker+" "+strings.TrimSpace(s.markdownInline(li, base)))
+		})
+		return strings.Join(items, "\n")
+	case sel.Is("table"):
+		return s.markdownTable(sel, base)
+	}
+	return ""
+}
+
+func (s *Scraper) markdownTable(table *goquery.Selection, base *url.URL) string {
+	var rows [][]string
+	table.Find("tr").Each(func(_ int, tr *goquery.Selection) {
+		var cells []string
+		tr.Find("th, td").Each(func(_ int, cell *goquery.Selection) {
+			text := strings.Join(strings.Fields(s.markdownInline(cell, base)), " ")
+			cells = append(cells, strings.ReplaceAll(text, "|", `\|`))
+		})
+		if len(cells) > 0 {
+			rows = append(rows, cells)
+		}
+	})
+	return s.renderMarkdownTable(rows)
+}
+
+// renderMarkdownTable lays out rows as a pipe table with the first row as
+// its header, padding short rows to the widest one.
+func (s *Scraper) renderMarkdownTable(rows [][]string) string {
+	if len(rows) == 0 {
+		return ""
+	}
+
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	var lines []string
+	for i, row := range rows {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", width))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s *Scraper) markdownInline(sel *goquery.Selection, base *url.URL) string {
+	var b strings.Builder
+	sel.Contents().Each(func(_ int, child *goquery.Selection) {
+		node := child.Get(0)
+		if node.Type == html.TextNode {
+			b.WriteString(s.collapseSpace(node.Data))
+			return
+		}
+		if node.Type != html.ElementNode {
+			return
+		}
+
+		inner := s.markdownInline(child, base)
+		switch node.Data {
+		case "a":
+			href, _ := child.Attr("href")
+			if href == "" || strings.TrimSpace(inner) == "" {
+				b.WriteString(inner)
+				return
+			}
+			if ref, err := url.Parse(href); err == nil && base != nil {
+				href = base.ResolveReference(ref).String()
+			}
+			fmt.Fprintf(&b, "[%s](%s)", strings.TrimSpace(inner), href)
+		case "em", "i":
+			s.writeWrapped(&b, inner, "*")
+		case "strong", "b":
+			s.writeWrapped(&b, inner, "**")
+		case "code":
+			s.writeWrapped(&b, child.Text(), "`")
+		case "sub":
+			b.WriteString(s.subscript(child.Text()))
+		case "br":
+			b.WriteString("  \n")
+		default:
+			b.WriteString(inner)
+		}
+	})
+	return b.String()
+}
+
+// writeWrapped wraps text in a Markdown delimiter, keeping surrounding
+// whitespace outside it since "* word*" doesn't render as emphasis.
+func (s *Scraper) writeWrapped(b *strings.Builder, text, delimiter string) {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		b.WriteString(text)
+		return
+	}
+	if strings.TrimLeft(text, " \t\n") != text {
+		b.WriteString(" ")
+	}
+	b.WriteString(delimiter + trimmed + delimiter)
+	if strings.TrimRight(text, " \t\n") != text {
+		b.WriteString(" ")
+	}
+}
+
+func (s *Scraper) collapseSpace(text string) string {
+	collapsed := strings.Join(strings.Fields(text), " ")
+	if collapsed == "" {
+		if text != "" {
+			return " "
+		}
+		return ""
+	}
+	if strings.TrimLeft(text, " \t\r\n") != text {
+		collapsed = " " + collapsed
+	}
+	if strings.TrimRight(text, " \t\r\n") != text {
+		collapsed += " "
+	}
+	return collapsed
+}
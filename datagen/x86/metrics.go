@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// runMetrics counts what a run did over the network. Workers update it
+// concurrently, so every method takes the lock.
+type runMetrics struct {
+	mu          sync.Mutex
+	started     time.Time
+	requests    int
+	retries     int
+	cacheReads  int
+	notModified int
+	pagesQueued int
+	pagesOK     int
+	pagesFailed int
+	bytes       int64
+	statuses    map[int]int
+	errors      map[string]int
+	failures    map[string]string
+	durations   []time.Duration
+}
+
+func newRunMetrics() *runMetrics {
+	return &runMetrics{
+		started:  time.Now(),
+		statuses: make(map[int]int),
+		errors:   make(map[string]int),
+		failures: make(map[string]string),
+	}
+}
+
+// observeRequest records one HTTP request. status is zero when no response
+// came back.
+func (m *runMetrics) observeRequest(status int, size int64, elapsed time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests++
+	if status != 0 {
+		m.statuses[status]++
+	}
+	m.bytes += size
+	m.durations = append(m.durations, elapsed)
+}
+
+func (m *runMetrics) observeRetry() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries++
+}
+
+func (m *runMetrics) observeCacheRead() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cacheReads++
+}
+
+func (m *runMetrics) observeNotModified() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notModified++
+}
+
+func (m *runMetrics) observePage(data InstructionData) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if data.Error == "" {
+		m.pagesOK++
+	} else {
+		m.pagesFailed++
+	}
+}
+
+// observeFailure buckets a failed page by the kind of error that ended its
+// last attempt.
+func (m *runMetrics) observeFailure(pageURL, kind string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors[kind]++
+	m.failures[pageURL] = kind
+}
+
+func (m *runMetrics) failureKind(pageURL string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if kind, ok := m.failures[pageURL]; ok {
+		return kind
+	}
+	return "other"
+}
+
+func (m *runMetrics) setQueued(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pagesQueued = n
+}
+
+// errorKind names the class of a fetch failure for the error breakdown.
+func errorKind(err error) string {
+	var fetchErr *fetchError
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.As(err, &fetchErr):
+		return fetchErr.kind
+	}
+	return "other"
+}
+
+// networkErrorKind tells timeouts apart from other transport failures.
+func networkErrorKind(err error) string {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return "timeout"
+	}
+	return "network"
+}
+
+type DurationSummary struct {
+	P50 float64 `json:"p50Ms"`
+	P90 float64 `json:"p90Ms"`
+	P99 float64 `json:"p99Ms"`
+	Max float64 `json:"maxMs"`
+}
+
+type RunReport struct {
+	StartedAt        time.Time       `json:"startedAt"`
+	FinishedAt       time.Time       `json:"finishedAt"`
+	DurationSeconds  float64         `json:"durationSeconds"`
+	Succeeded        bool            `json:"succeeded"`
+	Error            string          `json:"error,omitempty"`
+	PagesQueued      int             `json:"pagesQueued"`
+	PagesScraped     int             `json:"pagesScraped"`
+	PagesFailed      int             `json:"pagesFailed"`
+	PagesNotModified int             `json:"pagesNotModified"`
+	Requests         int             `json:"requests"`
+	Retries          int             `json:"retries"`
+	CacheReads       int             `json:"cacheReads"`
+	BytesDownloaded  int64           `json:"bytesDownloaded"`
+	StatusCodes      map[string]int  `json:"statusCodes"`
+	Errors           map[string]int  `json:"errors"`
+	RequestDuration  DurationSummary `json:"requestDuration"`
+}
+
+func (m *runMetrics) report(runErr error) RunReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	finished := time.Now()
+	report := RunReport{
+		StartedAt:        m.started,
+		FinishedAt:       finished,
+		DurationSeconds:  finished.Sub(m.started).Seconds(),
+		Succeeded:        runErr == nil,
+		PagesQueued:      m.pagesQueued,
+		PagesScraped:     m.pagesOK,
+		PagesFailed:      m.pagesFailed,
+		PagesNotModified: m.notModified,
+		Requests:         m.requests,
+		Retries:          m.retries,
+		CacheReads:       m.cacheReads,
+		BytesDownloaded:  m.bytes,
+		StatusCodes:      make(map[string]int),
+		Errors:           make(map[string]int),
+		RequestDuration:  summarizeDurations(m.durations),
+	}
+	if runErr != nil {
+		report.Error = runErr.Error()
+	}
+	for status, count := range m.statuses {
+		report.StatusCodes[strconv.Itoa(status)] = count
+	}
+	for kind, count := range m.errors {
+		report.Errors[kind] = count
+	}
+	return report
+}
+
+// summarizeDurations picks nearest-rank percentiles, in milliseconds.
+func summarizeDurations(durations []time.Duration) DurationSummary {
+	if len(durations) == 0 {
+		return DurationSummary{}
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	percentile := func(p float64) float64 {
+		rank := int(p*float64(len(sorted))+0.999999) - 1
+		rank = min(max(rank, 0), len(sorted)-1)
+		return float64(sorted[rank]) / float64(time.Millisecond)
+	}
+	return DurationSummary{
+		P50: percentile(0.50),
+		P90: percentile(0.90),
+		P99: percentile(0.99),
+		Max: float64(sorted[len(sorted)-1]) / float64(time.Millisecond),
+	}
+}
+
+func (s *Scraper) saveReport(report RunReport) error {
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode run report: %w", err)
+	}
+
+	if err := s.writeFileAtomic(s.reportFilename, buffer.Bytes()); err != nil {
+		return fmt.Errorf("failed to write run report: %w", err)
+	}
+
+	s.logger.Info("Run report saved", "file", s.reportFilename)
+	return nil
+}
+
+// pushMetrics sends the report to a Prometheus Pushgateway in the text
+// exposition format, replacing the metrics of the previous push for the
+// same job.
+func (s *Scraper) pushMetrics(report RunReport) error {
+	var body bytes.Buffer
+	metric := func(name, help, labels string, value float64) {
+		fmt.Fprintf(&body, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		fmt.Fprintf(&body, "%s%s %s\n", name, labels, strconv.FormatFloat(value, 'g', -1, 64))
+	}
+	labeled := func(name, help, label string, values map[string]int) {
+		fmt.Fprintf(&body, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(&body, "%s{%s=%q} %d\n", name, label, key, values[key])
+		}
+	}
+
+	succeeded := 0.0
+	if report.Succeeded {
+		succeeded = 1
+	}
+	metric("arisa_scrape_success", "Whether the last run finished without error.", "", succeeded)
+	metric("arisa_scrape_finished_timestamp_seconds", "When the last run finished.", "", float64(report.FinishedAt.Unix()))
+	metric("arisa_scrape_duration_seconds", "How long the last run took.", "", report.DurationSeconds)
+	metric("arisa_scrape_pages_queued", "Pages the last run set out to scrape.", "", float64(report.PagesQueued))
+	metric("arisa_scrape_pages_scraped", "Pages scraped without error.", "", float64(report.PagesScraped))
+	metric("arisa_scrape_pages_failed", "Pages that failed after all retries.", "", float64(report.PagesFailed))
+	metric("arisa_scrape_pages_not_modified", "Pages the server reported unchanged.", "", float64(report.PagesNotModified))
+	metric("arisa_scrape_requests", "HTTP requests sent.", "", float64(report.Requests))
+	metric("arisa_scrape_retries", "Requests retried after a transient failure.", "", float64(report.Retries))
+	metric("arisa_scrape_bytes_downloaded", "Response body bytes read.", "", float64(report.BytesDownloaded))
+	labeled("arisa_scrape_http_responses", "HTTP responses by status code.", "code", report.StatusCodes)
+	labeled("arisa_scrape_page_errors", "Failed pages by error kind.", "kind", report.Errors)
+
+	fmt.Fprintf(&body, "# HELP arisa_scrape_request_duration_milliseconds Request latency percentiles.\n")
+	fmt.Fprintf(&body, "# TYPE arisa_scrape_request_duration_milliseconds gauge\n")
+	for _, q := range []struct {
+		quantile string
+		value    float64
+	}{
+		{"0.5", report.RequestDuration.P50},
+		{"0.9", report.RequestDuration.P90},
+		{"0.99", report.RequestDuration.P99},
+		{"1", report.RequestDuration.Max},
+	} {
+		fmt.Fprintf(&body, "arisa_scrape_request_duration_milliseconds{quantile=%q} %s\n", q.quantile, strconv.FormatFloat(q.value, 'g', -1, 64))
+	}
+
+	target, err := url.JoinPath(s.pushgatewayURL, "metrics", "job", "arisa_x86")
+	if err != nil {
+		return fmt.Errorf("failed to build pushgateway URL: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.client.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "PUT", target, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create pushgateway request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to push metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("pushgateway returned %s", resp.Status)
+	}
+
+	s.logger.Info("Metrics pushed", "url", target)
+	return nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const mnemonicsFilename = "mnemonics.json"
+
+var flagConditionPattern = regexp.MustCompile(`\([A-Z]{2}\s*[=≠!][^)]*\)`)
+
+type MnemonicEntry struct {
+	Mnemonic  string `json:"mnemonic"`
+	Canonical string `json:"canonical"`
+	URL       string `json:"url"`
+}
+
+// mnemonicAliasKey identifies forms that encode the same instruction, so
+// two mnemonics sharing it on one page (SAL/SHL, JNE/JNZ, WAIT/FWAIT) are
+// spellings of each other. Sharing an opcode is not enough: CWD/CDQ and
+// JCXZ/JECXZ differ by operand or address size, which only the description
+// tells apart. Condition-code aliases word their descriptions differently
+// but carry the same flag test, such as "(ZF=0)", so that is compared
+// instead when present.
+func (s *Scraper) mnemonicAliasKey(form InstructionForm) string {
+	if form.Opcode == "" {
+		return ""
+	}
+
+	condition := form.Description
+	if match := flagConditionPattern.FindString(condition); match != "" {
+		condition = strings.Join(strings.Fields(match), "")
+	}
+	return form.Opcode + "|" + strings.Join(form.Operands, ",") + "|" + condition
+}
+
+// buildMnemonicIndex maps every mnemonic and alias to the record that
+// documents it. The first mnemonic on a page to use an encoding is its
+// canonical name; later ones with the same encoding point at it. Per-
+// mnemonic child records win over their parent page, and mnemonics that
+// only appear in a page title ("SAL/SAR/SHL/SHR") fall back to that page.
+func (s *Scraper) buildMnemonicIndex(instructions []InstructionData) []MnemonicEntry {
+	urls := make(map[string]string)
+	for _, data := range instructions {
+		if data.Parent != "" && data.Error == "" {
+			urls[strings.ToUpper(data.InstructionName)] = data.URL
+		}
+	}
+
+	entries := make(map[string]MnemonicEntry)
+	add := func(mnemonic, canonical, url string) {
+		if mnemonic == "" {
+			return
+		}
+		if _, ok := entries[mnemonic]; ok {
+			return
+		}
+		if child, ok := urls[canonical]; ok {
+			url = child
+		}
+		entries[mnemonic] = MnemonicEntry{Mnemonic: mnemonic, Canonical: canonical, URL: url}
+	}
+
+	// Expanded pages keep their forms on the child records, so encodings
+	// are compared across the whole page the children came from.
+	canonicals := make(map[string]map[string]string)
+	for _, data := range instructions {
+		if data.Error != "" {
+			continue
+		}
+
+		page := data.URL
+		if data.Parent != "" {
+			page = data.Parent
+		}
+		canonical := canonicals[page]
+		if canonical == nil {
+			canonical = make(map[string]string)
+			canonicals[page] = canonical
+		}
+		for _, form := range data.Forms {
+			mnemonic := strings.ToUpper(form.Mnemonic)
+			key := s.mnemonicAliasKey(form)
+			if first, ok := canonical[key]; ok && key != "" && first != mnemonic {
+				add(mnemonic, first, page)
+				continue
+			}
+			if key != "" {
+				canonical[key] = mnemonic
+			}
+			add(mnemonic, mnemonic, page)
+		}
+	}
+
+	for _, data := range instructions {
+		if data.Parent != "" || data.Error != "" {
+			continue
+		}
+		title := strings.Fields(data.InstructionName + " ")
+		if len(title) == 0 {
+			continue
+		}
+		for _, name := range strings.Split(title[0], "/") {
+			name = strings.ToUpper(name)
+			if mnemonicPattern.MatchString(name) && !strings.HasSuffix(name, "CC") {
+				add(name, name, data.URL)
+			}
+		}
+	}
+
+	index := make([]MnemonicEntry, 0, len(entries))
+	for _, entry := range entries {
+		index = append(index, entry)
+	}
+	sort.Slice(index, func(i, j int) bool {
+		return index[i].Mnemonic < index[j].Mnemonic
+	})
+	return index
+}
+
+func (s *Scraper) saveMnemonicIndex(instructions []InstructionData) error {
+	index := s.buildMnemonicIndex(instructions)
+	s.logger.Info("Saving mnemonic index", "count", len(index))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(index); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(mnemonicsFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("Mnemonic index saved successfully", "file", mnemonicsFilename)
+	return nil
+}
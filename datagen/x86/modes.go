@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+)
+
+type ModeValidity string
+
+const (
+	ModeValid        ModeValidity = "Valid"
+	ModeInvalid      ModeValidity = "Invalid"
+	ModeNotEncodable ModeValidity = "N.E."
+	ModeNotPrefix    ModeValidity = "N.P."
+	ModeNotSupported ModeValidity = "N.S."
+)
+
+var modeValidityAliases = map[string]ModeValidity{
+	"VALID":   ModeValid,
+	"V":       ModeValid,
+	"INVALID": ModeInvalid,
+	"INV.":    ModeInvalid,
+	"I":       ModeInvalid,
+	"N.E.":    ModeNotEncodable,
+	"N.E":     ModeNotEncodable,
+	"N.P.":    ModeNotPrefix,
+	"N.P":     ModeNotPrefix,
+	"N.S.":    ModeNotSupported,
+	"N.S":     ModeNotSupported,
+}
+
+// parseModeValidity normalizes a single mode cell. Footnote markers ("N.E.1",
+// "Valid*") and stray spaces ("N. E.") are dropped before matching, and
+// anything unrecognized, such as "N/A", yields an empty validity.
+func (s *Scraper) parseModeValidity(cell string) ModeValidity {
+	cell = strings.ToUpper(strings.Join(strings.Fields(cell), ""))
+	cell = strings.Map(func(r rune) rune {
+		if r == '*' || (r >= '0' && r <= '9') {
+			return -1
+		}
+		return r
+	}, cell)
+	return modeValidityAliases[cell]
+}
+
+// splitModeSupport splits a combined "64/32 bit Mode Support" cell like
+// "V/N.E." into its 64-bit and compat/legacy halves.
+func (s *Scraper) splitModeSupport(cell string) (ModeValidity, ModeValidity) {
+	cell = strings.Join(strings.Fields(cell), "")
+	parts := strings.SplitN(cell, "/", 2)
+	if len(parts) == 1 && len(cell) == 2 {
+		parts = []string{cell[:1], cell[1:]}
+	}
+	if len(parts) != 2 {
+		return "", ""
+	}
+	return s.parseModeValidity(parts[0]), s.parseModeValidity(parts[1])
+}
+
+func (s *Scraper) linkModeValidity(form *InstructionForm) {
+	if strings.Contains(form.Mode64, "/") {
+		form.Valid64, form.ValidCompat = s.splitModeSupport(form.Mode64)
+		return
+	}
+
+	form.Valid64 = s.parseModeValidity(form.Mode64)
+	form.ValidCompat = s.parseModeValidity(form.ModeCompat)
+	if form.ModeSupport != "" && form.Valid64 == "" && form.ValidCompat == "" {
+		form.Valid64, form.ValidCompat = s.splitModeSupport(form.ModeSupport)
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"arisa/config"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// saveMsgpack writes the dataset as MessagePack using the JSON field names,
+// so consumers can switch decoders without remapping keys.
+func (s *Scraper) saveMsgpack(instructions []InstructionData) error {
+	buffer := new(bytes.Buffer)
+	encoder := msgpack.NewEncoder(buffer)
+	encoder.SetCustomStructTag("json")
+	encoder.SetSortMapKeys(true)
+
+	if err := encoder.Encode(instructions); err != nil {
+		return fmt.Errorf("failed to encode MessagePack: %w", err)
+	}
+
+	target := config.FormatPath(s.outputFilename, "msgpack")
+	if err := s.writeFileAtomic(target, buffer.Bytes()); err != nil {
+		return fmt.Errorf("failed to write MessagePack to file: %w", err)
+	}
+
+	s.logger.Info("MessagePack saved successfully", "file", target, "bytes", buffer.Len())
+	return nil
+}
@@ -0,0 +1,17 @@
+package main
+
+import "arisa/textnorm"
+
+// normalizeInstruction applies textnorm.Text to every string reachable from
+// the record, including table headers used as map keys. Headers that
+// normalize to the same key keep their scraped spelling and are reported,
+// since merging them would drop all but one of their cells.
+func (s *Scraper) normalizeInstruction(data *InstructionData) {
+	for _, collision := range textnorm.Value(data) {
+		s.logger.Warn("Keys collide after normalization, keeping them as scraped",
+			"url", data.URL,
+			"field", collision.Path,
+			"key", collision.Key,
+			"keys", collision.Keys)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeInstruction(t *testing.T) {
+	s := newTestScraper(t, "")
+	logs := new(bytes.Buffer)
+	s.logger.SetOutput(logs)
+
+	// Text as scraped from felixcloutier.com/x86/ret and /x86/xrstor.
+	data := InstructionData{
+		URL:             "https://www.felixcloutier.com/x86/ret",
+		InstructionName: "RET\n\t\t— Return From Procedure",
+		DetailsTable: []TableRow{{
+			"Opcode":           "C3",
+			"Instruction":      "RET",
+			"64-Bit Mode":      "Valid",
+			"64-Bit\u00a0Mode": "N.E.",
+		}},
+		DescriptionText: "Refer to Chapter 6, “Procedure Calls, Interrupts, and Exceptions‚” and Chapter 17",
+		OperationText:   "XRSTOR_INFO := \uf0e1CPL,VMXNR,LAXA,COMPMASK\uf0f1;",
+	}
+	s.normalizeInstruction(&data)
+
+	if !strings.Contains(data.DescriptionText, "Exceptions,” and") {
+		t.Errorf("DescriptionText = %q", data.DescriptionText)
+	}
+	if data.OperationText != "XRSTOR_INFO := ⟨CPL,VMXNR,LAXA,COMPMASK⟩;" {
+		t.Errorf("OperationText = %q", data.OperationText)
+	}
+
+	row := data.DetailsTable[0]
+	if row["64-Bit Mode"] != "Valid" || row["64-Bit\u00a0Mode"] != "N.E." {
+		t.Errorf("colliding headers were merged: %q", row)
+	}
+	if !strings.Contains(logs.String(), "Keys collide after normalization") || !strings.Contains(logs.String(), "DetailsTable[0]") {
+		t.Errorf("collision wasn't reported: %s", logs)
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+type TableNote struct {
+	Marker string `json:"marker"`
+	Text   string `json:"text"`
+}
+
+var (
+	noteMarkerPattern  = regexp.MustCompile(`^(\d+|\*+)\.?\s+(.+)$`)
+	noteHeadingPattern = regexp.MustCompile(`(?i)^notes?:\s*`)
+	gluedMarkerPattern = regexp.MustCompile(`([^\s*])(\*+)`)
+)
+
+// collectNoteRefs records the footnote markers (<sup>1</sup>, "*") each
+// body row of a details table references, then removes them from the DOM
+// so parseTableFromGoquery no longer glues them onto cell text, as in
+// "FNSTCW1" or "/r1". The result is indexed like parseTableFromGoquery's
+// rows.
+func (s *Scraper) collectNoteRefs(table *goquery.Selection) [][]string {
+	var refs [][]string
+	found := false
+	table.Find("tr").Each(func(i int, row *goquery.Selection) {
+		if i == 0 || row.Find("td").Length() == 0 {
+			row.Find("sup").Remove()
+			return
+		}
+
+		var markers []string
+		seen := make(map[string]bool)
+		row.Find("sup").Each(func(_ int, sup *goquery.Selection) {
+			for _, marker := range strings.FieldsFunc(sup.Text(), func(r rune) bool { return r == ',' || r == ' ' }) {
+				if !seen[marker] {
+					seen[marker] = true
+					markers = append(markers, marker)
+				}
+			}
+		})
+		row.Find("sup").Remove()
+
+		if len(markers) > 0 {
+			found = true
+		}
+		refs = append(refs, markers)
+	})
+
+	if !found {
+		return nil
+	}
+	return refs
+}
+
+// splitGluedNoteRefs moves the "*" markers that datasets written before
+// collectNoteRefs left glued onto details cells, as in "r/m8*" or
+// "Valid*", into DetailsNoteRefs. Numbered markers can't be told apart
+// from the digits they are glued to, as in "imm81", so they stay.
+func (s *Scraper) splitGluedNoteRefs(data *InstructionData) {
+	if data.DetailsNoteRefs != nil {
+		return
+	}
+
+	refs := make([][]string, len(data.DetailsTable))
+	found := false
+	for i, row := range data.DetailsTable {
+		seen := make(map[string]bool)
+		for column, cell := range row {
+			if strings.Contains(column, "Description") {
+				continue
+			}
+			for _, match := range gluedMarkerPattern.FindAllStringSubmatch(cell, -1) {
+				if !seen[match[2]] {
+					seen[match[2]] = true
+					refs[i] = append(refs[i], match[2])
+				}
+			}
+			row[column] = gluedMarkerPattern.ReplaceAllString(cell, "$1")
+		}
+		sort.Strings(refs[i])
+		if len(refs[i]) > 0 {
+			found = true
+		}
+	}
+
+	if found {
+		data.DetailsNoteRefs = refs
+	}
+}
+
+// extractTableNotes reads the "NOTES:" block that follows a details table.
+// Notes are numbered ("1. ...") or starred ("* ..."); unmarked notes and
+// list items are numbered by position.
+func (s *Scraper) extractTableNotes(table *goquery.Selection) []TableNote {
+	var notes []TableNote
+	inNotes := false
+
+	add := func(text string) {
+		text = strings.Join(strings.Fields(text), " ")
+		if text == "" {
+			return
+		}
+		if match := noteMarkerPattern.FindStringSubmatch(text); match != nil {
+			notes = append(notes, TableNote{Marker: match[1], Text: match[2]})
+			return
+		}
+		if inNotes {
+			notes = append(notes, TableNote{Marker: strconv.Itoa(len(notes) + 1), Text: text})
+		}
+	}
+
+	currentNode := table.Next()
+	for currentNode.Length() > 0 && !currentNode.Is("h2") && !currentNode.Is("table") {
+		text := strings.TrimSpace(currentNode.Text())
+		if heading := noteHeadingPattern.FindString(text); heading != "" {
+			inNotes = true
+			text = text[len(heading):]
+		}
+
+		switch {
+		case inNotes && (currentNode.Is("ol") || currentNode.Is("ul")):
+			currentNode.Find("li").Each(func(_ int, li *goquery.Selection) {
+				add(li.Text())
+			})
+		case inNotes || noteMarkerPattern.MatchString(text):
+			add(text)
+		}
+		currentNode = currentNode.Next()
+	}
+	return notes
+}
+
+// linkFormNotes attaches to each form the notes its details row references.
+// When no row carries a marker the notes qualify the whole table, so every
+// form gets all of them.
+func (s *Scraper) linkFormNotes(form *InstructionForm, markers []string, notes []TableNote, anyRefs bool) {
+	form.Notes = nil
+	if !anyRefs {
+		for _, note := range notes {
+			form.Notes = append(form.Notes, note.Marker)
+		}
+		return
+	}
+
+	known := make(map[string]bool, len(notes))
+	for _, note := range notes {
+		known[note.Marker] = true
+	}
+	for _, marker := range markers {
+		if known[marker] {
+			form.Notes = append(form.Notes, marker)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const opcodeMapsFilename = "opcode_maps.json"
+
+type OpcodeMapRef struct {
+	Mnemonic        string `json:"mnemonic"`
+	Instruction     string `json:"instruction"`
+	PrefixClass     string `json:"prefixClass"`
+	MandatoryPrefix string `json:"mandatoryPrefix,omitempty"`
+	ModRM           string `json:"modrm,omitempty"`
+	URL             string `json:"url"`
+}
+
+type OpcodeMapEntry struct {
+	Opcode       string         `json:"opcode"`
+	Instructions []OpcodeMapRef `json:"instructions"`
+}
+
+type OpcodeMap struct {
+	Name    string           `json:"name"`
+	Escape  string           `json:"escape"`
+	Entries []OpcodeMapEntry `json:"entries"`
+}
+
+type ModRMEntry struct {
+	Value            string `json:"value"`
+	Mod              int    `json:"mod"`
+	Reg              int    `json:"reg"`
+	RM               int    `json:"rm"`
+	EffectiveAddress string `json:"effectiveAddress"`
+	Displacement     string `json:"displacement,omitempty"`
+	SIB              bool   `json:"sib,omitempty"`
+}
+
+type SIBEntry struct {
+	Value    string `json:"value"`
+	Scale    int    `json:"scale"`
+	Index    string `json:"index"`
+	Base     string `json:"base"`
+	Address  string `json:"address"`
+	BaseNote string `json:"baseNote,omitempty"`
+}
+
+type OpcodeMaps struct {
+	Maps    []OpcodeMap  `json:"maps"`
+	ModRM16 []ModRMEntry `json:"modrm16"`
+	ModRM32 []ModRMEntry `json:"modrm32"`
+	SIB     []SIBEntry   `json:"sib"`
+}
+
+var opcodeMapEscapes = []struct {
+	name   string
+	escape string
+}{
+	{"legacy", ""},
+	{"0F", "0F"},
+	{"0F38", "0F 38"},
+	{"0F3A", "0F 3A"},
+}
+
+var (
+	registers32   = []string{"EAX", "ECX", "EDX", "EBX", "ESP", "EBP", "ESI", "EDI"}
+	modRM16Bases  = []string{"BX+SI", "BX+DI", "BP+SI", "BP+DI", "SI", "DI", "BP", "BX"}
+	displacements = []string{"", "disp8", "", ""}
+)
+
+func (s *Scraper) buildOpcodeMaps(instructions []InstructionData) []OpcodeMap {
+	cells := make(map[string]map[string][]OpcodeMapRef)
+	for _, escape := range opcodeMapEscapes {
+		cells[escape.name] = make(map[string][]OpcodeMapRef)
+	}
+
+	for _, data := range instructions {
+		page := data.URL
+		if data.Parent != "" {
+			page = data.Parent
+		}
+		for _, form := range data.Forms {
+			encoding := form.Encoding
+			if encoding == nil || encoding.OpcodeByte == "" || cells[encoding.Map] == nil {
+				continue
+			}
+
+			ref := OpcodeMapRef{
+				Mnemonic:        form.Mnemonic,
+				Instruction:     form.Instruction,
+				PrefixClass:     encoding.PrefixClass,
+				MandatoryPrefix: encoding.MandatoryPrefix,
+				ModRM:           encoding.ModRM,
+				URL:             page,
+			}
+			for _, opcode := range s.expandOpcodeByte(encoding.OpcodeByte) {
+				cells[encoding.Map][opcode] = append(cells[encoding.Map][opcode], ref)
+			}
+		}
+	}
+
+	var maps []OpcodeMap
+	for _, escape := range opcodeMapEscapes {
+		opcodeMap := OpcodeMap{Name: escape.name, Escape: escape.escape}
+		for opcode, refs := range cells[escape.name] {
+			sort.Slice(refs, func(i, j int) bool {
+				if refs[i].PrefixClass != refs[j].PrefixClass {
+					return refs[i].PrefixClass < refs[j].PrefixClass
+				}
+				return refs[i].Instruction < refs[j].Instruction
+			})
+			opcodeMap.Entries = append(opcodeMap.Entries, OpcodeMapEntry{Opcode: opcode, Instructions: refs})
+		}
+		sort.Slice(opcodeMap.Entries, func(i, j int) bool {
+			return opcodeMap.Entries[i].Opcode < opcodeMap.Entries[j].Opcode
+		})
+		maps = append(maps, opcodeMap)
+	}
+
+	return maps
+}
+
+func (s *Scraper) expandOpcodeByte(opcodeByte string) []string {
+	value, err := strconv.ParseUint(opcodeByte[:2], 16, 8)
+	if err != nil {
+		return nil
+	}
+
+	count := uint64(1)
+	if strings.Contains(opcodeByte, "+") {
+		count = 8
+	}
+
+	var opcodes []string
+	for i := uint64(0); i < count && value+i < 256; i++ {
+		opcodes = append(opcodes, fmt.Sprintf("%02X", value+i))
+	}
+	return opcodes
+}
+
+func (s *Scraper) buildModRM16() []ModRMEntry {
+	var entries []ModRMEntry
+	for value := 0; value < 256; value++ {
+		mod, reg, rm := value>>6, (value>>3)&7, value&7
+		entry := ModRMEntry{Value: fmt.Sprintf("%02X", value), Mod: mod, Reg: reg, RM: rm}
+
+		switch {
+		case mod == 3:
+			entry.EffectiveAddress = "r/m register " + strconv.Itoa(rm)
+		case mod == 0 && rm == 6:
+			entry.EffectiveAddress = "[disp16]"
+			entry.Displacement = "disp16"
+		case mod == 0:
+			entry.EffectiveAddress = "[" + modRM16Bases[rm] + "]"
+		default:
+			entry.Displacement = "disp8"
+			if mod == 2 {
+				entry.Displacement = "disp16"
+			}
+			entry.EffectiveAddress = "[" + modRM16Bases[rm] + "+" + entry.Displacement + "]"
+		}
+
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func (s *Scraper) buildModRM32() []ModRMEntry {
+	var entries []ModRMEntry
+	for value := 0; value < 256; value++ {
+		mod, reg, rm := value>>6, (value>>3)&7, value&7
+		entry := ModRMEntry{Value: fmt.Sprintf("%02X", value), Mod: mod, Reg: reg, RM: rm}
+
+		displacement := displacements[mod]
+		if mod == 2 {
+			displacement = "disp32"
+		}
+
+		switch {
+		case mod == 3:
+			entry.EffectiveAddress = registers32[rm]
+		case mod == 0 && rm == 5:
+			entry.EffectiveAddress = "[disp32] (RIP+disp32 in 64-bit mode)"
+			displacement = "disp32"
+		case rm == 4:
+			entry.SIB = true
+			entry.EffectiveAddress = "[SIB]"
+			if displacement != "" {
+				entry.EffectiveAddress = "[SIB+" + displacement + "]"
+			}
+		case displacement != "":
+			entry.EffectiveAddress = "[" + registers32[rm] + "+" + displacement + "]"
+		default:
+			entry.EffectiveAddress = "[" + registers32[rm] + "]"
+		}
+
+		entry.Displacement = displacement
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func (s *Scraper) buildSIB() []SIBEntry {
+	var entries []SIBEntry
+	for value := 0; value < 256; value++ {
+		scale, index, base := 1<<(value>>6), (value>>3)&7, value&7
+		entry := SIBEntry{Value: fmt.Sprintf("%02X", value), Scale: scale, Base: registers32[base]}
+
+		var address []string
+		if base == 5 {
+			entry.BaseNote = "no base with disp32 if ModRM.mod = 00, otherwise [EBP]"
+			address = append(address, "*")
+		} else {
+			address = append(address, registers32[base])
+		}
+
+		if index == 4 {
+			entry.Index = "none"
+		} else {
+			entry.Index = registers32[index]
+			address = append(address, registers32[index]+"*"+strconv.Itoa(scale))
+		}
+
+		entry.Address = "[" + strings.Join(address, "+") + "]"
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func (s *Scraper) saveOpcodeMaps(instructions []InstructionData) error {
+	opcodeMaps := OpcodeMaps{
+		Maps:    s.buildOpcodeMaps(instructions),
+		ModRM16: s.buildModRM16(),
+		ModRM32: s.buildModRM32(),
+		SIB:     s.buildSIB(),
+	}
+
+	s.logger.Info("Saving opcode maps", "maps", len(opcodeMaps.Maps))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(opcodeMaps); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(opcodeMapsFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("Opcode maps saved successfully", "file", opcodeMapsFilename)
+	return nil
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type FormOperand struct {
+	Syntax   string `json:"syntax"`
+	Type     string `json:"type"`
+	Kind     string `json:"kind"`
+	Width    int    `json:"width,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	Access   string `json:"access,omitempty"`
+}
+
+type operandSlot struct {
+	Encoding string
+	Access   string
+}
+
+var (
+	operandDecorationPattern = regexp.MustCompile(`\s*\{[^}]*\}`)
+	operandAccessPattern     = regexp.MustCompile(`^(.*?)\s*\(([rRwW][rRwW, ]*)\)$`)
+	operandWidthPattern      = regexp.MustCompile(`^(?:r|m|imm|rel|moffs|r/m)(\d+)`)
+	operandColumnPattern     = regexp.MustCompile(`^operands?(\d+)`)
+	fixedRegisterPattern     = regexp.MustCompile(`^[A-Z][A-Z0-9]*$`)
+)
+
+var registerWidths = map[string]int{
+	"AL": 8, "CL": 8, "DL": 8, "BL": 8,
+	"AX": 16, "CX": 16, "DX": 16, "BX": 16,
+	"EAX": 32, "ECX": 32, "EDX": 32, "EBX": 32,
+	"RAX": 64, "RCX": 64, "RDX": 64, "RBX": 64,
+	"CS": 16, "DS": 16, "ES": 16, "FS": 16, "GS": 16, "SS": 16, "Sreg": 16,
+	"mm": 64, "xmm": 128, "ymm": 256, "zmm": 512, "k": 64, "bnd": 128,
+}
+
+// parseOperandSlots indexes the operand encoding table by Op/En so each
+// form can look up how its operands are encoded and accessed. An Op/En can
+// have several rows, as XCHG's "O" has one for each operand order, so every
+// row is kept in table order. Rows whose header collapsed into a single cell
+// are skipped since their columns can't be told apart reliably.
+func (s *Scraper) parseOperandSlots(table []TableRow) map[string][][]operandSlot {
+	slots := make(map[string][][]operandSlot)
+	for _, row := range table {
+		var opEn string
+		columns := make(map[int]string)
+
+		for key, value := range row {
+			header := strings.ToLower(strings.Join(strings.Fields(key), ""))
+			switch {
+			case header == "op/en":
+				opEn = value
+			case strings.HasPrefix(header, "column_"):
+				n, err := strconv.Atoi(strings.TrimPrefix(header, "column_"))
+				if err != nil {
+					continue
+				}
+				if n == 1 {
+					opEn = value
+				} else {
+					columns[n-1] = value
+				}
+			default:
+				if match := operandColumnPattern.FindStringSubmatch(header); match != nil {
+					n, _ := strconv.Atoi(match[1])
+					columns[n] = value
+				}
+			}
+		}
+
+		opEn = strings.TrimSpace(opEn)
+		if opEn == "" || len(opEn) > 8 || len(columns) == 0 {
+			continue
+		}
+
+		var operands []operandSlot
+		for n := 1; ; n++ {
+			value, ok := columns[n]
+			if !ok {
+				break
+			}
+			operands = append(operands, s.parseOperandSlot(value))
+		}
+		slots[opEn] = append(slots[opEn], operands)
+	}
+	return slots
+}
+
+func (s *Scraper) parseOperandSlot(value string) operandSlot {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "N/A" || value == "NA" || value == "" {
+		return operandSlot{}
+	}
+
+	match := operandAccessPattern.FindStringSubmatch(value)
+	if match == nil {
+		return operandSlot{Encoding: value}
+	}
+
+	access := strings.ToLower(match[2])
+	var mode string
+	if strings.Contains(access, "r") {
+		mode += "r"
+	}
+	if strings.Contains(access, "w") {
+		mode += "w"
+	}
+	return operandSlot{Encoding: match[1], Access: mode}
+}
+
+func (s *Scraper) buildFormOperands(form InstructionForm, slots map[string][][]operandSlot) []FormOperand {
+	if len(form.Operands) == 0 {
+		return nil
+	}
+
+	encoded := s.matchOperandSlots(form.Operands, slots[form.OpEn])
+	operands := make([]FormOperand, 0, len(form.Operands))
+	for i, syntax := range form.Operands {
+		operand := s.classifyOperand(syntax)
+		if i < len(encoded) {
+			operand.Encoding = encoded[i].Encoding
+			operand.Access = encoded[i].Access
+		}
+		if operand.Access == "" && operand.Kind == "immediate" {
+			operand.Access = "r"
+		}
+		operands = append(operands, operand)
+	}
+	return operands
+}
+
+// matchOperandSlots picks the row of an Op/En that fits a form's operands
+// in order. A row naming fixed registers, such as "AX/EAX/RAX", only fits
+// where the form has one of them; of the rows that fit best, the first wins.
+func (s *Scraper) matchOperandSlots(operands []string, rows [][]operandSlot) []operandSlot {
+	var best []operandSlot
+	bestScore := 0
+	for n, row := range rows {
+		score := 0
+		for i, syntax := range operands {
+			if i >= len(row) {
+				break
+			}
+			registers := fixedRegisters(row[i].Encoding)
+			if registers == nil {
+				continue
+			}
+			typ := strings.ToUpper(s.classifyOperand(syntax).Type)
+			if registers[typ] {
+				score++
+			} else {
+				score -= len(operands)
+			}
+		}
+		if n == 0 || score > bestScore {
+			best, bestScore = row, score
+		}
+	}
+	return best
+}
+
+// fixedRegisters returns the registers an encoding such as "AL/AX/EAX/RAX"
+// or "CL" names, or nil when it describes how an operand is encoded rather
+// than which register it is.
+func fixedRegisters(encoding string) map[string]bool {
+	parts := strings.Split(encoding, "/")
+	registers := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if !fixedRegisterPattern.MatchString(part) {
+			return nil
+		}
+		registers[part] = true
+	}
+	return registers
+}
+
+// classifyOperand maps an operand from the Instruction column, such as
+// "r/m32", "xmm2/m128{k1}{z}", or "imm8", to a normalized type and a kind
+// of register, memory, register/memory, immediate, relative, or pointer.
+func (s *Scraper) classifyOperand(syntax string) FormOperand {
+	typ := strings.TrimSpace(operandDecorationPattern.ReplaceAllString(strings.ReplaceAll(syntax, "*", ""), ""))
+	operand := FormOperand{Syntax: syntax, Type: typ}
+	lower := strings.ToLower(typ)
+
+	switch {
+	case strings.HasPrefix(lower, "imm") || typ == "1":
+		operand.Kind = "immediate"
+	case strings.HasPrefix(lower, "rel"):
+		operand.Kind = "relative"
+	case strings.HasPrefix(lower, "ptr"):
+		operand.Kind = "pointer"
+	case strings.HasPrefix(lower, "r/m") || s.isRegisterOrMemory(lower):
+		operand.Kind = "register/memory"
+	case strings.HasPrefix(lower, "m") && !strings.HasPrefix(lower, "mm"), strings.HasPrefix(lower, "vm"):
+		operand.Kind = "memory"
+	default:
+		operand.Kind = "register"
+	}
+
+	if match := operandWidthPattern.FindStringSubmatch(lower); match != nil {
+		operand.Width, _ = strconv.Atoi(match[1])
+	} else if width, ok := registerWidths[strings.TrimRight(typ, "0123456789")]; ok {
+		operand.Width = width
+	} else if width, ok := registerWidths[strings.TrimRight(lower, "0123456789")]; ok {
+		operand.Width = width
+	}
+
+	return operand
+}
+
+func (s *Scraper) isRegisterOrMemory(operand string) bool {
+	parts := strings.Split(operand, "/")
+	if len(parts) < 2 {
+		return false
+	}
+	last := parts[len(parts)-1]
+	return strings.HasPrefix(last, "m") && !strings.HasPrefix(last, "mm")
+}
@@ -0,0 +1,66 @@
+package main
+
+import "testing"
+
+func TestBuildFormsMatchesOperandOrder(t *testing.T) {
+	s := &Scraper{}
+	data := InstructionData{
+		DetailsTable: []TableRow{
+			{"Opcode": "90+rw", "Instruction": "XCHG AX, r16", "Op/En": "O", "64-Bit Mode": "Valid", "Compat/Leg Mode": "Valid"},
+			{"Opcode": "90+rw", "Instruction": "XCHG r16, AX", "Op/En": "O", "64-Bit Mode": "Valid", "Compat/Leg Mode": "Valid"},
+			{"Opcode": "REX.W + 90+rd", "Instruction": "XCHG r64, RAX", "Op/En": "O", "64-Bit Mode": "Valid", "Compat/Leg Mode": "N.E."},
+			{"Opcode": "86 /r", "Instruction": "XCHG r/m8, r8", "Op/En": "MR", "64-Bit Mode": "Valid", "Compat/Leg Mode": "Valid"},
+		},
+		OperandEncodingTable: []TableRow{
+			{"Op/En": "O", "Operand 1": "AX/EAX/RAX (r, w)", "Operand 2": "opcode + rd (r, w)", "Operand 3": "N/A", "Operand 4": "N/A"},
+			{"Op/En": "O", "Operand 1": "opcode + rd (r, w)", "Operand 2": "AX/EAX/RAX (r, w)", "Operand 3": "N/A", "Operand 4": "N/A"},
+			{"Op/En": "MR", "Operand 1": "ModRM:r/m (r, w)", "Operand 2": "ModRM:reg (r)", "Operand 3": "N/A", "Operand 4": "N/A"},
+		},
+	}
+	s.buildForms(&data)
+
+	want := map[string][]string{
+		"XCHG AX, r16":  {"AX/EAX/RAX", "opcode + rd"},
+		"XCHG r16, AX":  {"opcode + rd", "AX/EAX/RAX"},
+		"XCHG r64, RAX": {"opcode + rd", "AX/EAX/RAX"},
+		"XCHG r/m8, r8": {"ModRM:r/m", "ModRM:reg"},
+	}
+	if len(data.Forms) != len(want) {
+		t.Fatalf("got %d forms, want %d", len(data.Forms), len(want))
+	}
+	for _, form := range data.Forms {
+		encodings, ok := want[form.Instruction]
+		if !ok {
+			t.Errorf("unexpected form %q", form.Instruction)
+			continue
+		}
+		if len(form.OperandDetails) != len(encodings) {
+			t.Errorf("%s: got %d operands, want %d", form.Instruction, len(form.OperandDetails), len(encodings))
+			continue
+		}
+		for i, operand := range form.OperandDetails {
+			if operand.Encoding != encodings[i] {
+				t.Errorf("%s: operand %d encoding = %q, want %q", form.Instruction, i+1, operand.Encoding, encodings[i])
+			}
+		}
+	}
+}
+
+func TestFixedRegisters(t *testing.T) {
+	tests := []struct {
+		encoding string
+		want     int
+	}{
+		{"AL/AX/EAX/RAX", 4},
+		{"CL", 1},
+		{"ModRM:reg", 0},
+		{"opcode + rd", 0},
+		{"imm8", 0},
+		{"Implicit XMM0", 0},
+	}
+	for _, test := range tests {
+		if got := len(fixedRegisters(test.encoding)); got != test.want {
+			t.Errorf("fixedRegisters(%q) has %d registers, want %d", test.encoding, got, test.want)
+		}
+	}
+}
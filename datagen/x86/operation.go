@@ -0,0 +1,9 @@
+package main
+
+import "arisa/pseudocode"
+
+// linkOperationAST parses the Operation pseudocode into statements. The
+// parse is best-effort, so OperationText stays the reference.
+func (s *Scraper) linkOperationAST(data *InstructionData) {
+	data.OperationAST = pseudocode.Parse(data.OperationText)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"arisa/dataset"
+)
+
+// backupPath is where the previous dataset is kept while a new one is
+// written over it.
+func (s *Scraper) backupPath() string {
+	return s.outputFilename + ".bak"
+}
+
+// writeDataset replaces the dataset with content, first copying the current
+// file to backupPath. Both writes are atomic, so a crash or full disk leaves
+// either the old or the new dataset in place, never a truncated one.
+func (s *Scraper) writeDataset(content []byte) error {
+	previous, err := os.ReadFile(s.outputFilename)
+	switch {
+	case err == nil && !json.Valid(previous):
+		// Keep the last good backup rather than replacing it with a
+		// dataset loadExistingData already had to skip.
+		s.logger.Warn("Previous data is corrupt, not backing it up", "file", s.outputFilename)
+	case err == nil:
+		if err := s.writeFileAtomic(s.backupPath(), previous); err != nil {
+			return fmt.Errorf("failed to back up previous data: %w", err)
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("failed to read previous data for backup: %w", err)
+	}
+
+	return s.writeFileAtomic(s.outputFilename, content)
+}
+
+// readDataset reads the records of a dataset file. Datasets written before
+// provenance moved to its own file carry it in each record, where it is
+// still picked up.
+func (s *Scraper) readDataset(filename string) ([]InstructionData, error) {
+	fileBytes, err := dataset.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+
+	records, _, err := dataset.Unwrap(fileBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", filename, err)
+	}
+	var instructions []InstructionData
+	if err := json.Unmarshal(records, &instructions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", filename, err)
+	}
+
+	if bytes.Contains(records, []byte(`"provenance":`)) {
+		var embedded []struct {
+			Provenance map[string][]ProvenanceStep `json:"provenance"`
+		}
+		if err := json.Unmarshal(records, &embedded); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", filename, err)
+		}
+		for i := range instructions {
+			instructions[i].Provenance = embedded[i].Provenance
+		}
+	}
+	return instructions, nil
+}
+
+// writeFileAtomic writes through a temporary file so a worker never
+// observes a half-written figure or cached page another worker is saving,
+// and an interrupted save never leaves a truncated dataset behind.
+func (s *Scraper) writeFileAtomic(target string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(target))
+}
+
+// syncDir flushes a directory, so a file renamed into it survives a crash
+// along with its contents.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open directory: %w", err)
+	}
+	defer dir.Close()
+	if err := dir.Sync(); err != nil {
+		return fmt.Errorf("failed to sync directory: %w", err)
+	}
+	return nil
+}
+
+// writeCompressedCopies writes each --compress copy of the dataset next to
+// it, such as x86.json.zst, for shipping in releases. readDataset opens them
+// as readily as the plain file.
+func (s *Scraper) writeCompressedCopies(content []byte) error {
+	for _, extension := range s.compressions {
+		compressed, err := dataset.Compress(content, extension)
+		if err != nil {
+			return err
+		}
+
+		target := s.outputFilename + "." + extension
+		if err := s.writeFileAtomic(target, compressed); err != nil {
+			return fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		s.logger.Info("Compressed copy saved", "file", target, "bytes", len(compressed))
+	}
+	return nil
+}
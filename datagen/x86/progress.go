@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
+)
+
+const (
+	defaultProgressInterval = 5 * time.Second
+	progressRedrawInterval  = 250 * time.Millisecond
+	progressBarWidth        = 30
+)
+
+// progress tracks a scrape as results come off the results channel. On a
+// terminal it redraws a single status line; otherwise it is reported as
+// periodic log lines so it shows up in CI and cron logs.
+type progress struct {
+	total  int
+	done   int
+	errors int
+	start  time.Time
+	tty    bool
+}
+
+func (s *Scraper) newProgress(total int) *progress {
+	return &progress{
+		total: total,
+		start: time.Now(),
+		tty:   isatty.IsTerminal(os.Stderr.Fd()) || isatty.IsCygwinTerminal(os.Stderr.Fd()),
+	}
+}
+
+// interval is how often the progress is reported, or zero when it isn't.
+func (p *progress) interval(configured time.Duration) time.Duration {
+	if configured <= 0 {
+		return 0
+	}
+	if p.tty {
+		return progressRedrawInterval
+	}
+	return configured
+}
+
+func (p *progress) record(result InstructionData) {
+	p.done++
+	if result.Error != "" {
+		p.errors++
+	}
+}
+
+// stats returns the share of pages done, the pages per second so far and
+// the estimated time left at that rate.
+func (p *progress) stats() (float64, float64, time.Duration) {
+	percent := 100.0
+	if p.total > 0 {
+		percent = float64(p.done) * 100 / float64(p.total)
+	}
+
+	elapsed := time.Since(p.start).Seconds()
+	if elapsed <= 0 || p.done == 0 {
+		return percent, 0, 0
+	}
+	rate := float64(p.done) / elapsed
+	eta := time.Duration(float64(p.total-p.done) / rate * float64(time.Second))
+	return percent, rate, eta.Round(time.Second)
+}
+
+func (s *Scraper) reportProgress(p *progress) {
+	percent, rate, eta := p.stats()
+
+	if !p.tty {
+		s.logger.Info("Progress",
+			"done", p.done,
+			"total", p.total,
+			"percent", fmt.Sprintf("%.1f", percent),
+			"pages_per_sec", fmt.Sprintf("%.1f", rate),
+			"errors", p.errors,
+			"eta", eta)
+		return
+	}
+
+	filled := progressBarWidth * p.done / max(p.total, 1)
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", progressBarWidth-filled)
+	fmt.Fprintf(os.Stderr, "\r\033[K[%s] %5.1f%% %d/%d  %.1f pages/s  %d errors  ETA %s",
+		bar, percent, p.done, p.total, rate, p.errors, eta)
+}
+
+// clear erases the status line so a log line doesn't run into it. It does
+// nothing when progress goes to the log.
+func (p *progress) clear() {
+	if p.tty {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+}
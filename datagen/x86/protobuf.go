@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"arisa/schemapb"
+	"google.golang.org/protobuf/proto"
+)
+
+// saveProtobuf writes the dataset as a binary schemapb.X86Dataset, which is
+// a fraction of the size of the indented JSON and much cheaper to parse on
+// phones and embedded targets.
+func (s *Scraper) saveProtobuf(instructions []InstructionData) error {
+	dataset := &schemapb.X86Dataset{Instructions: make([]*schemapb.X86Instruction, 0, len(instructions))}
+	for _, data := range instructions {
+		dataset.Instructions = append(dataset.Instructions, s.protoInstruction(data))
+	}
+
+	encoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(dataset)
+	if err != nil {
+		return fmt.Errorf("failed to encode protobuf: %w", err)
+	}
+
+	if err := s.writeFileAtomic(s.protobufFilename, encoded); err != nil {
+		return fmt.Errorf("failed to write protobuf to file: %w", err)
+	}
+
+	s.logger.Info("Saved protobuf dataset", "file", s.protobufFilename, "bytes", len(encoded))
+	return nil
+}
+
+func (s *Scraper) protoInstruction(data InstructionData) *schemapb.X86Instruction {
+	instruction := &schemapb.X86Instruction{
+		Url:                 data.URL,
+		Category:            data.Category,
+		Taxonomy:            string(data.Taxonomy),
+		InstructionName:     data.InstructionName,
+		Parent:              data.Parent,
+		DescriptionText:     data.DescriptionText,
+		DescriptionMarkdown: data.DescriptionMarkdown,
+		OperationText:       data.OperationText,
+		FlagsAffectedText:   data.FlagsAffectedText,
+		Intrinsics:          data.Intrinsics,
+		FeatureFlags:        data.FeatureFlags,
+		VmcsFields:          data.VMCSFields,
+		ContentHash:         data.ContentHash,
+		Aliases:             data.Aliases,
+		Error:               data.Error,
+	}
+
+	if len(data.FlagsAffected) > 0 {
+		instruction.FlagsAffected = make(map[string]string, len(data.FlagsAffected))
+		for flag, effect := range data.FlagsAffected {
+			instruction.FlagsAffected[flag] = string(effect)
+		}
+	}
+
+	for _, form := range data.Forms {
+		instruction.Forms = append(instruction.Forms, s.protoForm(form))
+	}
+
+	modes := make([]string, 0, len(data.ExceptionRecords))
+	for mode := range data.ExceptionRecords {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+	for _, mode := range modes {
+		for _, record := range data.ExceptionRecords[mode] {
+			instruction.Exceptions = append(instruction.Exceptions, &schemapb.X86Exception{
+				Mode:      mode,
+				Vector:    record.Vector,
+				Condition: record.Condition,
+			})
+		}
+	}
+	return instruction
+}
+
+func (s *Scraper) protoForm(form InstructionForm) *schemapb.X86Form {
+	converted := &schemapb.X86Form{
+		Opcode:       form.Opcode,
+		Instruction:  form.Instruction,
+		Mnemonic:     form.Mnemonic,
+		Operands:     form.Operands,
+		OpEn:         form.OpEn,
+		Valid64:      string(form.Valid64),
+		ValidCompat:  string(form.ValidCompat),
+		ModeSupport:  form.ModeSupport,
+		Cpuid:        form.CPUID,
+		FeatureFlags: form.FeatureFlags,
+		Description:  form.Description,
+		Iforms:       form.IForms,
+		Notes:        form.Notes,
+		Table:        form.Table,
+	}
+
+	for _, operand := range form.OperandDetails {
+		converted.OperandDetails = append(converted.OperandDetails, &schemapb.X86Operand{
+			Syntax:   operand.Syntax,
+			Type:     operand.Type,
+			Kind:     operand.Kind,
+			Width:    int32(operand.Width),
+			Encoding: operand.Encoding,
+			Access:   operand.Access,
+		})
+	}
+
+	if encoding := form.Encoding; encoding != nil {
+		converted.Encoding = &schemapb.X86Encoding{
+			PrefixClass:     encoding.PrefixClass,
+			VectorLength:    encoding.VectorLength,
+			MandatoryPrefix: encoding.MandatoryPrefix,
+			Map:             encoding.Map,
+			EscapeBytes:     encoding.EscapeBytes,
+			Rex:             encoding.REX,
+			W:               encoding.W,
+			Vvvv:            encoding.VVVV,
+			OpcodeByte:      encoding.OpcodeByte,
+			OpcodeBytes:     encoding.OpcodeBytes,
+			OpcodeRegister:  encoding.OpcodeRegister,
+			Modrm:           encoding.ModRM,
+			Immediate:       encoding.Immediate,
+			Immediates:      encoding.Immediates,
+			ImmediateSize:   int32(encoding.ImmediateSize),
+			Masking:         encoding.Masking,
+			ZeroMasking:     encoding.ZeroMasking,
+			Broadcast:       encoding.Broadcast,
+			Rounding:        encoding.Rounding,
+			Sae:             encoding.SAE,
+			Egpr:            encoding.EGPR,
+			Ndd:             encoding.NDD,
+			Nf:              encoding.NF,
+			Zu:              encoding.ZU,
+			Scc:             encoding.SCC,
+		}
+		if encoding.ModRMReg != nil {
+			converted.Encoding.ModrmReg = proto.Int32(int32(*encoding.ModRMReg))
+		}
+	}
+	return converted
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"arisa/dataset"
+)
+
+const (
+	parserName    = "felixcloutier"
+	parserVersion = "1.0"
+)
+
+type ProvenanceStep = dataset.ProvenanceStep
+
+var scrapedFieldAnchors = map[string]string{
+	"category":             "",
+	"instructionName":      "",
+	"detailsTable":         "",
+	"additionalTables":     "",
+	"notes":                "",
+	"operandEncodingTable": "#instruction-operand-encoding",
+	"descriptionText":      "#description",
+	"descriptionMarkdown":  "#description",
+	"operationText":        "#operation",
+	"flagsAffectedText":    "#flags-affected",
+	"intrinsics":           "",
+	"figures":              "",
+	"exceptions":           "",
+}
+
+func (s *Scraper) recordScrapeProvenance(data *InstructionData, parser string) {
+	data.Provenance = make(map[string][]ProvenanceStep)
+	for field, anchor := range scrapedFieldAnchors {
+		// Markdown is only rendered with -markdown.
+		if field == "descriptionMarkdown" && data.DescriptionMarkdown == "" {
+			continue
+		}
+		source := data.URL + anchor
+		if field == "category" {
+			source = s.indexURL
+		}
+		data.Provenance[field] = []ProvenanceStep{{
+			Pass:   "scrape",
+			Source: source,
+			Parser: parser,
+		}}
+	}
+}
+
+func (s *Scraper) recordDerivedProvenance(data *InstructionData, field, pass string, inputs ...string) {
+	if data.Provenance == nil {
+		s.recordScrapeProvenance(data, "legacy")
+	}
+
+	var chain []ProvenanceStep
+	for _, input := range inputs {
+		chain = append(chain, data.Provenance[input]...)
+	}
+	chain = append(chain, ProvenanceStep{
+		Pass:   pass,
+		Parser: parserName + "/" + parserVersion,
+		Inputs: inputs,
+	})
+	data.Provenance[field] = chain
+}
+
+// saveProvenance writes every record's provenance to the file beside the
+// dataset, where `arisa explain` reads it.
+func (s *Scraper) saveProvenance(instructions []InstructionData) error {
+	provenance := make(dataset.Provenance, len(instructions))
+	for _, data := range instructions {
+		if len(data.Provenance) > 0 {
+			provenance[data.URL] = data.Provenance
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(provenance); err != nil {
+		return fmt.Errorf("failed to encode provenance: %w", err)
+	}
+
+	target := dataset.ProvenancePath(s.outputFilename)
+	if err := s.writeFileAtomic(target, buffer.Bytes()); err != nil {
+		return fmt.Errorf("failed to write provenance: %w", err)
+	}
+	s.logger.Info("Provenance saved successfully", "file", target)
+	return nil
+}
+
+// loadProvenance reads the provenance saved beside the dataset, so the
+// passes re-run over saved records extend the chains they were scraped
+// with. A dataset without the file gets an empty one.
+func (s *Scraper) loadProvenance() (dataset.Provenance, error) {
+	path := dataset.ProvenancePath(s.outputFilename)
+	if _, err := dataset.Locate(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return dataset.ReadProvenance(path)
+}
@@ -0,0 +1,40 @@
+package main
+
+import "sort"
+
+// staleRecords lists the previously saved pages that are no longer linked
+// from the index. An empty index means the index couldn't be read properly,
+// and nothing is stale rather than everything.
+func (s *Scraper) staleRecords() []string {
+	if !s.prune || len(s.indexEntries) == 0 {
+		return nil
+	}
+
+	var stale []string
+	for url, data := range s.previousData {
+		if data.Parent != "" {
+			continue
+		}
+		if _, ok := s.indexEntries[url]; !ok {
+			stale = append(stale, url)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// pruneStaleRecords drops the staleRecords, so pages felixcloutier deleted
+// or renamed don't linger in the dataset forever.
+func (s *Scraper) pruneStaleRecords() {
+	stale := s.staleRecords()
+	if len(stale) == 0 {
+		return
+	}
+
+	for _, url := range stale {
+		s.logger.Info("Pruning record no longer on the index", "url", url)
+		delete(s.previousData, url)
+		delete(s.successfullyScraped, url)
+	}
+	s.logger.Warn("Pruned stale records", "count", len(stale))
+}
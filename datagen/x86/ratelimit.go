@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultRequestsPerSecond = 5
+	defaultRequestBurst      = 5
+)
+
+// setRateLimit caps how fast all workers together may send requests. The
+// bucket starts full, so the first burst requests go out at once; a
+// requestsPerSecond of zero or less turns the limit off.
+func (s *Scraper) setRateLimit(requestsPerSecond float64, burst int) {
+	if requestsPerSecond <= 0 {
+		s.limiter = nil
+		return
+	}
+	s.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), max(burst, 1))
+}
+
+// throttle blocks until the shared limiter allows another request and then
+// waits out the politeness delay. Every request to felixcloutier.com goes
+// through it. It returns early with the context's error on cancellation.
+func (s *Scraper) throttle(ctx context.Context) error {
+	if s.limiter != nil {
+		// Reserve rather than Wait: Wait gives up early when the delay would
+		// overrun a deadline, which would look like a failed fetch.
+		reservation := s.limiter.Reserve()
+		if err := s.sleep(ctx, reservation.Delay()); err != nil {
+			reservation.Cancel()
+			return err
+		}
+	}
+	return s.sleep(ctx, s.requestDelay)
+}
+
+// sleep waits for d or until ctx is cancelled, whichever comes first.
+func (s *Scraper) sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
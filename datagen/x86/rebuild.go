@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+)
+
+// Rebuild re-runs the per-page passes over the saved dataset and writes it
+// back, along with the files derived from it, without fetching anything.
+// Each record keeps the tables and text its page was scraped into, which is
+// all the passes read, so a dataset written before a pass existed picks up
+// its fields.
+func (s *Scraper) Rebuild(args []string) error {
+	flags := flag.NewFlagSet("rebuild", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("usage: rebuild")
+	}
+
+	if err := s.loadExistingData(); err != nil {
+		return err
+	}
+	if len(s.previousData) == 0 {
+		return fmt.Errorf("no dataset to rebuild at %s", s.outputFilename)
+	}
+
+	for url, data := range s.previousData {
+		s.splitGluedNoteRefs(&data)
+		s.previousData[url] = data
+	}
+
+	finalData := s.buildFinalDataset(nil)
+	if err := s.saveData(finalData); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+	return s.saveDerivedFiles(finalData)
+}
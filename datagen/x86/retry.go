@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"arisa/httpclient"
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	defaultRetries        = 3
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay  = 30 * time.Second
+)
+
+// fetchError is a failed page fetch. Transient failures (network errors,
+// 429, 5xx) are retryable, and retryAfter carries the server's Retry-After
+// when it sent one. kind classifies the failure for the run report.
+type fetchError struct {
+	message    string
+	kind       string
+	retryable  bool
+	retryAfter time.Duration
+}
+
+func (e *fetchError) Error() string {
+	return e.message
+}
+
+// networkFetcher fetches pages from the site itself.
+type networkFetcher struct {
+	*Scraper
+}
+
+// Fetch fetches and parses a page, retrying transient failures with
+// exponential backoff so a momentary outage doesn't leave an Error record
+// that only a second run would clear. It returns errNotModified when the
+// server confirms the previously scraped copy is current.
+func (s networkFetcher) Fetch(ctx context.Context, pageURL string) (*goquery.Document, pageValidators, error) {
+	for attempt := 0; ; attempt++ {
+		doc, validators, err := s.fetchDocumentOnce(ctx, pageURL)
+		if err == nil {
+			if doc == nil {
+				return nil, validators, errNotModified
+			}
+			return doc, validators, nil
+		}
+
+		if !err.retryable || attempt >= s.retries {
+			if attempt > 0 {
+				return nil, pageValidators{}, fmt.Errorf("%w (after %d attempts)", err, attempt+1)
+			}
+			return nil, pageValidators{}, err
+		}
+
+		s.metrics.observeRetry()
+		delay := s.retryDelay(attempt, err.retryAfter)
+		s.logger.Warn("Retrying page",
+			"url", pageURL,
+			"attempt", attempt+1,
+			"delay", delay,
+			"error", err.message)
+		if err := s.sleep(ctx, delay); err != nil {
+			return nil, pageValidators{}, err
+		}
+	}
+}
+
+// fetchDocumentOnce makes a single request. A 304 comes back as a nil
+// document with no error.
+func (s *Scraper) fetchDocumentOnce(ctx context.Context, pageURL string) (*goquery.Document, pageValidators, *fetchError) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, pageValidators{}, &fetchError{message: fmt.Sprintf("failed to create request: %v", err), kind: "request"}
+	}
+	req.Header.Set("User-Agent", s.userAgent)
+	s.setConditionalHeaders(req, pageURL)
+
+	if err := s.checkRobots(ctx, pageURL); err != nil {
+		return nil, pageValidators{}, &fetchError{message: err.Error(), kind: "robots"}
+	}
+	if err := s.throttle(ctx); err != nil {
+		return nil, pageValidators{}, &fetchError{message: fmt.Sprintf("failed to fetch URL: %v", err), kind: "canceled"}
+	}
+	started := time.Now()
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.metrics.observeRequest(0, 0, time.Since(started))
+		return nil, pageValidators{}, &fetchError{message: fmt.Sprintf("failed to fetch URL: %v", err), kind: networkErrorKind(err), retryable: ctx.Err() == nil}
+	}
+	defer resp.Body.Close()
+
+	s.concurrency.observe(resp.StatusCode, started)
+	validators := s.responseValidators(resp)
+	if resp.StatusCode == http.StatusNotModified {
+		s.metrics.observeRequest(resp.StatusCode, 0, time.Since(started))
+		return nil, validators, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		s.metrics.observeRequest(resp.StatusCode, 0, time.Since(started))
+		return nil, pageValidators{}, &fetchError{
+			message:    fmt.Sprintf("bad status: %s", resp.Status),
+			kind:       fmt.Sprintf("http_%d", resp.StatusCode),
+			retryable:  resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500,
+			retryAfter: s.parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	// The page is parsed as it streams in rather than buffered first, unless
+	// it is also being recorded to the cache.
+	body := &countingReader{reader: resp.Body}
+	var recorded bytes.Buffer
+	var reader io.Reader = body
+	if s.cacheDir != "" {
+		reader = io.TeeReader(body, &recorded)
+	}
+	doc, err := goquery.NewDocumentFromReader(reader)
+	s.metrics.observeRequest(resp.StatusCode, body.count, time.Since(started))
+
+	switch {
+	case errors.Is(body.err, httpclient.ErrResponseTooLarge):
+		return nil, pageValidators{}, &fetchError{message: fmt.Sprintf("failed to read body: %v", body.err), kind: "too_large"}
+	case body.err != nil:
+		// A body cut off mid-read is as transient as a dropped connection.
+		return nil, pageValidators{}, &fetchError{message: fmt.Sprintf("failed to read body: %v", body.err), kind: networkErrorKind(body.err), retryable: ctx.Err() == nil}
+	case err != nil:
+		return nil, pageValidators{}, &fetchError{message: fmt.Sprintf("failed to parse HTML: %v", err), kind: "parse", retryable: true}
+	}
+
+	s.cachePage(pageURL, recorded.Bytes())
+	return doc, validators, nil
+}
+
+// retryDelay picks how long to wait before retry number attempt+1. Without
+// a Retry-After it waits somewhere in the upper half of an exponentially
+// growing window, so workers that failed together don't retry together.
+// Either way the wait is capped at retryMaxDelay.
+func (s *Scraper) retryDelay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, s.retryMaxDelay)
+	}
+
+	window := s.retryBaseDelay << attempt
+	if window <= 0 || window > s.retryMaxDelay {
+		window = s.retryMaxDelay
+	}
+	return window/2 + rand.N(window/2+1)
+}
+
+// countingReader counts the bytes read through it and keeps the first read
+// error other than io.EOF, which the HTML parser would otherwise report as
+// a parse failure.
+type countingReader struct {
+	reader io.Reader
+	count  int64
+	err    error
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	if err != nil && err != io.EOF && r.err == nil {
+		r.err = err
+	}
+	return n, err
+}
+
+// parseRetryAfter reads a Retry-After header given either as seconds or as
+// an HTTP date.
+func (s *Scraper) parseRetryAfter(header string) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		if delay := time.Until(when); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// checkRobots refuses URLs the host's robots.txt disallows. It does nothing
+// with --ignore-robots or --offline, when s.robots is nil.
+func (s *Scraper) checkRobots(ctx context.Context, pageURL string) error {
+	if s.robots == nil {
+		return nil
+	}
+	return s.robots.Check(ctx, pageURL)
+}
+
+// applyCrawlDelay slows the shared rate limit down to the index host's
+// Crawl-delay when that is stricter than --rps and --delay already are.
+func (s *Scraper) applyCrawlDelay(ctx context.Context) {
+	if s.robots == nil {
+		return
+	}
+
+	rules, err := s.robots.Rules(ctx, s.indexURL)
+	if err != nil {
+		s.logger.Warn("Could not read robots.txt, treating the site as disallowed", "error", err)
+		return
+	}
+
+	delay := rules.CrawlDelay()
+	if delay <= 0 || delay <= s.requestDelay {
+		return
+	}
+	if s.limiter != nil && time.Duration(float64(time.Second)/float64(s.limiter.Limit())) >= delay {
+		return
+	}
+
+	s.logger.Info("Honoring robots.txt Crawl-delay", "delay", delay)
+	s.setRateLimit(float64(time.Second)/float64(delay), 1)
+}
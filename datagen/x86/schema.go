@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"arisa/dataset"
+)
+
+const (
+	schemaFilename = "x86.schema.json"
+	schemaID       = "https://raw.githubusercontent.com/aprlfm/Arisa/main/datagen/x86/" + schemaFilename
+
+	// schemaVersion goes in every dataset's envelope. Bump it when a
+	// change to InstructionData would break existing consumers.
+	schemaVersion = 1
+)
+
+// datasetSchema generates the JSON Schema for x86.json from InstructionData,
+// so it can't drift from what saveData writes.
+func (s *Scraper) datasetSchema() ([]byte, error) {
+	return dataset.Schema(InstructionData{}, schemaID, "x86 instructions")
+}
+
+// datasetDocument is what saveData encodes: the records in their metadata
+// envelope, or on their own with --legacy-array.
+func (s *Scraper) datasetDocument(instructions []InstructionData) any {
+	if s.legacyArray {
+		return instructions
+	}
+	return dataset.Envelope{
+		Metadata: dataset.StableMetadata(dataset.NewMetadata("x86-scraper", schemaVersion, []string{s.indexURL}, len(instructions)), instructions, s.outputFilename),
+		Records:  instructions,
+	}
+}
+
+// validateDataset checks an encoded dataset against datasetSchema before it
+// is written, so a struct change that breaks the published shape fails the
+// run instead of shipping.
+func (s *Scraper) validateDataset(content []byte) error {
+	schema, err := s.datasetSchema()
+	if err != nil {
+		return err
+	}
+	validator, err := dataset.NewValidator(schema)
+	if err != nil {
+		return err
+	}
+	return validator.Validate(content)
+}
+
+// Schema prints the dataset schema, or writes it to the given file.
+func (s *Scraper) Schema(args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: schema [file]")
+	}
+
+	schema, err := s.datasetSchema()
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		_, err := os.Stdout.Write(schema)
+		return err
+	}
+	if err := s.writeFileAtomic(args[0], schema); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	s.logger.Info("Schema saved", "file", args[0])
+	return nil
+}
+
+// Validate checks dataset files, plain or compressed, against the schema,
+// which defaults to the one generated from the structs. With no files it
+// checks the output dataset.
+func (s *Scraper) Validate(args []string) error {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	schemaFile := flags.String("schema", "", "validate against this schema file instead of the built-in one")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{s.outputFilename}
+	}
+
+	var schema []byte
+	var err error
+	if *schemaFile != "" {
+		schema, err = os.ReadFile(*schemaFile)
+	} else {
+		schema, err = s.datasetSchema()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load schema: %w", err)
+	}
+	validator, err := dataset.NewValidator(schema)
+	if err != nil {
+		return err
+	}
+
+	return validator.ValidateFiles(os.Stdout, files)
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type SGXStructure struct {
+	Name      string `json:"name"`
+	Size      int    `json:"size"`
+	Alignment int    `json:"alignment"`
+}
+
+type SGXRegister struct {
+	Register    string `json:"register"`
+	Direction   string `json:"direction"`
+	Description string `json:"description"`
+	Structure   string `json:"structure,omitempty"`
+}
+
+type SGXLeaf struct {
+	Function   string         `json:"function"`
+	Leaf       string         `json:"leaf"`
+	EAX        int            `json:"eax"`
+	Registers  []SGXRegister  `json:"registers,omitempty"`
+	Structures []SGXStructure `json:"structures,omitempty"`
+}
+
+var sgxStructures = map[string]SGXStructure{
+	"SECS":       {Name: "SECS", Size: 4096, Alignment: 4096},
+	"TCS":        {Name: "TCS", Size: 4096, Alignment: 4096},
+	"PAGEINFO":   {Name: "PAGEINFO", Size: 32, Alignment: 32},
+	"SECINFO":    {Name: "SECINFO", Size: 64, Alignment: 64},
+	"PCMD":       {Name: "PCMD", Size: 128, Alignment: 128},
+	"SIGSTRUCT":  {Name: "SIGSTRUCT", Size: 1808, Alignment: 4096},
+	"EINITTOKEN": {Name: "EINITTOKEN", Size: 304, Alignment: 512},
+	"REPORT":     {Name: "REPORT", Size: 432, Alignment: 512},
+	"TARGETINFO": {Name: "TARGETINFO", Size: 512, Alignment: 512},
+	"KEYREQUEST": {Name: "KEYREQUEST", Size: 512, Alignment: 512},
+	"REPORTDATA": {Name: "REPORTDATA", Size: 64, Alignment: 128},
+	"RDINFO":     {Name: "RDINFO", Size: 32, Alignment: 32},
+}
+
+var sgxRegisterOverrides = map[string][]string{
+	"EENTER":      {"EAX", "EAX", "RBX", "RCX", "RCX"},
+	"ESETCONTEXT": {"EAX", "EAX", "RCX", "RDX"},
+}
+
+var sgxFallbackLeaves = map[string]SGXLeaf{
+	"EEXIT": {Function: "ENCLU", Leaf: "EEXIT", EAX: 0x04},
+}
+
+var (
+	sgxLeafPattern      = regexp.MustCompile(`EAX = ([0-9A-F]+)H (ENCL[SUV])\[(\w+)\]`)
+	sgxOperandPattern   = regexp.MustCompile(`^(.*?)\s*\(((?:In|Out)[^)]*)\)$`)
+	sgxStructurePattern = regexp.MustCompile(`\b[A-Z]{3,}\b`)
+)
+
+func (s *Scraper) linkSGXLeaves(data *InstructionData) {
+	data.SGXLeaves = nil
+	if data.Category != "SGX Instructions" {
+		return
+	}
+
+	var leaves []SGXLeaf
+	for _, row := range data.DetailsTable {
+		for _, value := range row {
+			for _, match := range sgxLeafPattern.FindAllStringSubmatch(value, -1) {
+				eax, err := strconv.ParseUint(match[1], 16, 8)
+				if err != nil {
+					continue
+				}
+				leaves = append(leaves, SGXLeaf{Function: match[2], Leaf: match[3], EAX: int(eax)})
+			}
+		}
+	}
+
+	if len(leaves) == 0 {
+		page := strings.ToUpper(data.URL[strings.LastIndex(data.URL, "/")+1:])
+		if leaf, ok := sgxFallbackLeaves[page]; ok {
+			leaves = append(leaves, leaf)
+		}
+	}
+
+	registers := s.parseSGXRegisters(data.OperandEncodingTable)
+	for i := range leaves {
+		leaves[i].Registers = s.assignSGXRegisters(leaves[i].Leaf, registers)
+		leaves[i].Structures = s.collectSGXStructures(leaves[i].Registers)
+	}
+
+	sort.Slice(leaves, func(i, j int) bool {
+		return leaves[i].EAX < leaves[j].EAX
+	})
+	data.SGXLeaves = leaves
+}
+
+func (s *Scraper) parseSGXRegisters(table []TableRow) []SGXRegister {
+	if len(table) == 0 {
+		return nil
+	}
+
+	row := table[0]
+	var registers []SGXRegister
+	for column := 2; ; column++ {
+		value, ok := row["column_"+strconv.Itoa(column)]
+		if !ok {
+			break
+		}
+
+		value = strings.Join(strings.Fields(value), " ")
+		match := sgxOperandPattern.FindStringSubmatch(value)
+		if match == nil {
+			if column == 2 {
+				registers = append(registers, SGXRegister{Direction: "In", Description: "Leaf function"})
+			}
+			continue
+		}
+
+		registers = append(registers, SGXRegister{
+			Direction:   match[2],
+			Description: match[1],
+		})
+	}
+	return registers
+}
+
+func (s *Scraper) assignSGXRegisters(leaf string, parsed []SGXRegister) []SGXRegister {
+	if len(parsed) == 0 {
+		return nil
+	}
+
+	registers := make([]SGXRegister, len(parsed))
+	copy(registers, parsed)
+	registers[0].Description = "Leaf function " + leaf
+
+	if override, ok := sgxRegisterOverrides[leaf]; ok && len(override) == len(registers) {
+		for i := range registers {
+			registers[i].Register = override[i]
+		}
+	} else {
+		general := []string{"RBX", "RCX", "RDX"}
+		registers[0].Register = "EAX"
+		next := 0
+		for i := 1; i < len(registers); i++ {
+			description := strings.ToLower(registers[i].Description)
+			if i == 1 && strings.HasPrefix(registers[i].Direction, "Out") && strings.Contains(description, "error") {
+				registers[i].Register = "EAX"
+				continue
+			}
+			if next < len(general) {
+				registers[i].Register = general[next]
+				next++
+			}
+		}
+	}
+
+	for i := range registers {
+		for _, name := range sgxStructurePattern.FindAllString(registers[i].Description, -1) {
+			if _, ok := sgxStructures[name]; ok {
+				registers[i].Structure = name
+				break
+			}
+		}
+	}
+	return registers
+}
+
+func (s *Scraper) collectSGXStructures(registers []SGXRegister) []SGXStructure {
+	var structures []SGXStructure
+	seen := make(map[string]bool)
+	for _, register := range registers {
+		if register.Structure != "" && !seen[register.Structure] {
+			seen[register.Structure] = true
+			structures = append(structures, sgxStructures[register.Structure])
+		}
+	}
+	return structures
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"arisa/dataset"
+)
+
+// splitFamilies fold the per-subset AVX-512 and AMX flags into one file
+// each, since nobody wants AVX512VL without AVX512F.
+var splitFamilies = []string{"AVX512", "AMX"}
+
+var splitGroupPattern = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Split writes a dataset as one file per feature extension, for consumers
+// that only care about a subset.
+func (s *Scraper) Split(args []string) error {
+	flags := flag.NewFlagSet("split", flag.ContinueOnError)
+	outputDir := flags.String("out", "x86", "directory to write the per-extension files and manifest to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("usage: split [--out dir] [file.json]")
+	}
+	filename := s.outputFilename
+	if flags.NArg() == 1 {
+		filename = flags.Arg(0)
+	}
+
+	instructions, err := s.readDataset(filename)
+	if err != nil {
+		return err
+	}
+	return s.saveSplit(instructions, *outputDir, filename)
+}
+
+// saveSplit writes dir/<extension>.json for every feature extension, such
+// as sse2.json or avx512.json, plus a manifest naming source as the dataset
+// they came from. A page whose forms span several extensions appears in
+// each of their files.
+func (s *Scraper) saveSplit(instructions []InstructionData, dir, source string) error {
+	groups := make(map[string][]InstructionData)
+	for _, data := range instructions {
+		if data.Error != "" {
+			continue
+		}
+		for _, group := range s.splitGroups(data) {
+			groups[group] = append(groups[group], data)
+		}
+	}
+
+	manifest, err := dataset.WriteSplit(dir, filepath.Base(source), groups, s.writeFileAtomic)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Saved split dataset", "dir", dir, "files", len(manifest.Files))
+	return nil
+}
+
+// splitGroups names the files a record belongs in: its feature extension
+// families, or its taxonomy when no form carries a CPUID flag.
+func (s *Scraper) splitGroups(data InstructionData) []string {
+	var groups []string
+	seen := make(map[string]bool)
+	for _, flag := range data.FeatureFlags {
+		for _, family := range splitFamilies {
+			if strings.HasPrefix(flag, family) {
+				flag = family
+			}
+		}
+		group := strings.Trim(splitGroupPattern.ReplaceAllString(strings.ToLower(flag), "_"), "_")
+		if group != "" && !seen[group] {
+			seen[group] = true
+			groups = append(groups, group)
+		}
+	}
+
+	if len(groups) == 0 {
+		taxonomy := data.Taxonomy
+		if taxonomy == "" {
+			taxonomy = s.classifyCategory(&data)
+		}
+		groups = append(groups, string(taxonomy))
+	}
+	return groups
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"sort"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqliteSchema lays the dataset out for ad-hoc queries: one row per page,
+// per form, per form operand and per listed exception, with indices on the
+// columns those queries join and filter on.
+const sqliteSchema = `
+CREATE TABLE instructions (
+	id INTEGER PRIMARY KEY,
+	url TEXT NOT NULL UNIQUE,
+	name TEXT NOT NULL,
+	category TEXT,
+	taxonomy TEXT,
+	parent TEXT,
+	description TEXT,
+	operation TEXT,
+	flags_affected TEXT,
+	content_hash TEXT,
+	error TEXT
+);
+CREATE TABLE forms (
+	id INTEGER PRIMARY KEY,
+	instruction_id INTEGER NOT NULL REFERENCES instructions(id),
+	opcode TEXT,
+	instruction TEXT,
+	mnemonic TEXT,
+	op_en TEXT,
+	valid64 TEXT,
+	valid_compat TEXT,
+	cpuid TEXT,
+	prefix_class TEXT,
+	map TEXT,
+	vector_length TEXT,
+	w TEXT,
+	description TEXT
+);
+CREATE TABLE operands (
+	form_id INTEGER NOT NULL REFERENCES forms(id),
+	position INTEGER NOT NULL,
+	syntax TEXT,
+	type TEXT,
+	kind TEXT,
+	width INTEGER,
+	encoding TEXT,
+	access TEXT,
+	PRIMARY KEY (form_id, position)
+);
+CREATE TABLE exceptions (
+	instruction_id INTEGER NOT NULL REFERENCES instructions(id),
+	mode TEXT NOT NULL,
+	vector TEXT,
+	condition TEXT
+);
+CREATE INDEX instructions_name ON instructions(name);
+CREATE INDEX forms_instruction ON forms(instruction_id);
+CREATE INDEX forms_mnemonic ON forms(mnemonic);
+CREATE INDEX forms_prefix_class ON forms(prefix_class);
+CREATE INDEX exceptions_instruction ON exceptions(instruction_id);
+CREATE INDEX exceptions_vector_mode ON exceptions(vector, mode);
+`
+
+// saveSQLite writes the dataset into a fresh SQLite database next to the
+// JSON. It is built under a temporary name and renamed into place, so
+// readers never see a half-written database.
+func (s *Scraper) saveSQLite(instructions []InstructionData) error {
+	temp := s.sqliteFilename + ".tmp"
+	if err := os.Remove(temp); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale temp database: %w", err)
+	}
+
+	db, err := sql.Open("sqlite3", temp)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	err = s.writeSQLite(db, instructions)
+	if closeErr := db.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(temp)
+		return err
+	}
+
+	if err := os.Rename(temp, s.sqliteFilename); err != nil {
+		os.Remove(temp)
+		return fmt.Errorf("failed to move database into place: %w", err)
+	}
+
+	s.logger.Info("Saved SQLite database", "file", s.sqliteFilename, "instructions", len(instructions))
+	return nil
+}
+
+func (s *Scraper) writeSQLite(db *sql.DB, instructions []InstructionData) error {
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		return fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	insertInstruction, err := tx.Prepare(`INSERT INTO instructions
+		(url, name, category, taxonomy, parent, description, operation, flags_affected, content_hash, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare instruction insert: %w", err)
+	}
+	insertForm, err := tx.Prepare(`INSERT INTO forms
+		(instruction_id, opcode, instruction, mnemonic, op_en, valid64, valid_compat, cpuid, prefix_class, map, vector_length, w, description)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare form insert: %w", err)
+	}
+	insertOperand, err := tx.Prepare(`INSERT INTO operands
+		(form_id, position, syntax, type, kind, width, encoding, access)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare operand insert: %w", err)
+	}
+	insertException, err := tx.Prepare(`INSERT INTO exceptions
+		(instruction_id, mode, vector, condition)
+		VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare exception insert: %w", err)
+	}
+
+	for _, data := range instructions {
+		result, err := insertInstruction.Exec(data.URL, data.InstructionName, data.Category, string(data.Taxonomy),
+			data.Parent, data.DescriptionText, data.OperationText, data.FlagsAffectedText, data.ContentHash, data.Error)
+		if err != nil {
+			return fmt.Errorf("failed to insert %s: %w", data.URL, err)
+		}
+		instructionID, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to insert %s: %w", data.URL, err)
+		}
+
+		for _, form := range data.Forms {
+			var prefixClass, opcodeMap, vectorLength, w string
+			if form.Encoding != nil {
+				prefixClass = form.Encoding.PrefixClass
+				opcodeMap = form.Encoding.Map
+				vectorLength = form.Encoding.VectorLength
+				w = form.Encoding.W
+			}
+			result, err := insertForm.Exec(instructionID, form.Opcode, form.Instruction, form.Mnemonic, form.OpEn,
+				string(form.Valid64), string(form.ValidCompat), form.CPUID, prefixClass, opcodeMap, vectorLength, w, form.Description)
+			if err != nil {
+				return fmt.Errorf("failed to insert form %q of %s: %w", form.Instruction, data.URL, err)
+			}
+			formID, err := result.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("failed to insert form %q of %s: %w", form.Instruction, data.URL, err)
+			}
+
+			for i, operand := range form.OperandDetails {
+				if _, err := insertOperand.Exec(formID, i+1, operand.Syntax, operand.Type, operand.Kind,
+					operand.Width, operand.Encoding, operand.Access); err != nil {
+					return fmt.Errorf("failed to insert operand %q of %s: %w", operand.Syntax, data.URL, err)
+				}
+			}
+		}
+
+		modes := make([]string, 0, len(data.ExceptionRecords))
+		for mode := range data.ExceptionRecords {
+			modes = append(modes, mode)
+		}
+		sort.Strings(modes)
+		for _, mode := range modes {
+			for _, record := range data.ExceptionRecords[mode] {
+				if _, err := insertException.Exec(instructionID, mode, record.Vector, record.Condition); err != nil {
+					return fmt.Errorf("failed to insert exception of %s: %w", data.URL, err)
+				}
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+type DetailsTableGroup struct {
+	Heading  string     `json:"heading,omitempty"`
+	Rows     []TableRow `json:"rows"`
+	NoteRefs [][]string `json:"noteRefs,omitempty"`
+}
+
+// isFormTable reports whether a table lists instruction forms, which is the
+// only kind with an Opcode column; operand encoding tables head theirs
+// "Op/En" and exception tables have no header row.
+func (s *Scraper) isFormTable(table *goquery.Selection) bool {
+	found := false
+	table.Find("th").EachWithBreak(func(_ int, th *goquery.Selection) bool {
+		found = strings.Contains(strings.ToLower(th.Text()), "opcode")
+		return !found
+	})
+	return found
+}
+
+// tableHeading names a table by the closest heading before it.
+func (s *Scraper) tableHeading(table *goquery.Selection) string {
+	heading := table.PrevAll().Filter("h1, h2, h3, h4").First()
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(heading.Text()), "¶"))
+}
+
+// extractAdditionalFormTables collects the form tables after the first one,
+// such as the per-variant tables on the MOV and VMOVDQA pages, along with
+// any notes that follow them.
+func (s *Scraper) extractAdditionalFormTables(allTables *goquery.Selection) ([]DetailsTableGroup, []TableNote) {
+	var groups []DetailsTableGroup
+	var notes []TableNote
+	allTables.Slice(1, allTables.Length()).Each(func(_ int, table *goquery.Selection) {
+		if !s.isFormTable(table) {
+			return
+		}
+
+		group := DetailsTableGroup{Heading: s.tableHeading(table)}
+		group.NoteRefs = s.collectNoteRefs(table)
+		group.Rows = s.parseTableFromGoquery(table)
+		if len(group.Rows) > 0 {
+			groups = append(groups, group)
+			notes = append(notes, s.extractTableNotes(table)...)
+		}
+	})
+	return groups, notes
+}
+
+// detailsTableGroups returns the first details table followed by any
+// additional form tables, so callers can treat them uniformly.
+func (s *Scraper) detailsTableGroups(data *InstructionData) []DetailsTableGroup {
+	groups := []DetailsTableGroup{{Rows: data.DetailsTable, NoteRefs: data.DetailsNoteRefs}}
+	return append(groups, data.AdditionalTables...)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	"arisa/schema"
+)
+
+// systemMnemonics are the privileged or OS-facing instructions that
+// felixcloutier files under "Core Instructions" with no feature flag to
+// tell them apart.
+var systemMnemonics = map[string]bool{
+	"ARPL": true, "CLAC": true, "CLTS": true, "HLT": true, "INVD": true,
+	"INVLPG": true, "INVPCID": true, "IRET": true, "IRETD": true, "IRETQ": true,
+	"LAR": true, "LGDT": true, "LIDT": true, "LLDT": true, "LMSW": true,
+	"LSL": true, "LTR": true, "MONITOR": true, "MWAIT": true, "PCONFIG": true,
+	"RDMSR": true, "RDPMC": true, "RSM": true, "SGDT": true, "SIDT": true,
+	"SLDT": true, "SMSW": true, "STAC": true, "STR": true, "SWAPGS": true,
+	"SYSCALL": true, "SYSENTER": true, "SYSEXIT": true, "SYSRET": true,
+	"VERR": true, "VERW": true, "WBINVD": true, "WBNOINVD": true, "WRMSR": true,
+	"XRSTORS": true, "XSAVES": true, "XSETBV": true,
+}
+
+// vectorRegisterPattern matches MMX and SSE/AVX register operands, which
+// mark the SIMD instructions whose CPUID column didn't survive scraping.
+var vectorRegisterPattern = regexp.MustCompile(`^(mm|xmm|ymm|zmm)\d*\b`)
+
+// classifyCategory places a record in the shared taxonomy. The index page's
+// category settles SGX, SMX, VMX, and Xeon Phi pages outright; "Core
+// Instructions" covers everything else, so those are refined by their
+// CPUID feature flags, vector register operands, x87 escape opcodes, and a
+// list of system mnemonics.
+func (s *Scraper) classifyCategory(data *InstructionData) schema.Category {
+	category := schema.NormalizeCategory(data.Category)
+	if category != schema.CategoryGeneralPurpose && category != schema.CategoryOther {
+		return category
+	}
+
+	if flags := schema.NormalizeCategory(strings.Join(data.FeatureFlags, " ")); flags != schema.CategoryGeneralPurpose && flags != schema.CategoryOther {
+		return flags
+	}
+
+	for _, form := range data.Forms {
+		for _, operand := range form.Operands {
+			if vectorRegisterPattern.MatchString(operand) {
+				return schema.CategorySIMD
+			}
+		}
+		if form.Encoding == nil || form.Encoding.Map != "legacy" || form.Encoding.OpcodeByte == "" {
+			continue
+		}
+		if opcode := form.Encoding.OpcodeByte[:2]; opcode >= "D8" && opcode <= "DF" {
+			return schema.CategoryFloatingPoint
+		}
+	}
+
+	for _, name := range strings.Split(s.titleMnemonic(*data), "/") {
+		if systemMnemonics[strings.ToUpper(name)] {
+			return schema.CategorySystem
+		}
+	}
+	if strings.Contains(data.InstructionName, "Control Registers") || strings.Contains(data.InstructionName, "Debug Registers") {
+		return schema.CategorySystem
+	}
+
+	return schema.CategoryGeneralPurpose
+}
+
+func (s *Scraper) linkTaxonomy(data *InstructionData) {
+	data.Taxonomy = s.classifyCategory(data)
+}
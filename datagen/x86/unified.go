@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+
+	"arisa/dataset"
+	"arisa/schema"
+)
+
+// defaultUnifiedFilename is where every run writes the dataset in the
+// unified schema, next to the x86 dataset it is converted from.
+const defaultUnifiedFilename = "x86_unified.json"
+
+// Unified converts a dataset to the ISA-neutral schema.Instruction shape
+// shared with the JVM generator.
+func (s *Scraper) Unified(args []string) error {
+	flags := flag.NewFlagSet("unified", flag.ContinueOnError)
+	output := flags.String("out", defaultUnifiedFilename, "file to write the unified dataset to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("usage: unified [--out file] [file.json]")
+	}
+	filename := s.outputFilename
+	if flags.NArg() == 1 {
+		filename = flags.Arg(0)
+	}
+
+	instructions, err := s.readDataset(filename)
+	if err != nil {
+		return err
+	}
+	return s.saveUnified(instructions, *output)
+}
+
+// saveUnified writes the pages that scraped cleanly as schema.Instruction
+// records in a metadata envelope, validated against the unified schema.
+func (s *Scraper) saveUnified(instructions []InstructionData, filename string) error {
+	records := make([]schema.Instruction, 0, len(instructions))
+	for _, data := range instructions {
+		if data.Error != "" {
+			continue
+		}
+		records = append(records, s.unifiedInstruction(data))
+	}
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	document := dataset.Envelope{
+		Metadata: dataset.StableMetadata(dataset.NewMetadata("x86-scraper", schema.InstructionSchemaVersion, []string{s.indexURL}, len(records)), records, filename),
+		Records:  records,
+	}
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("failed to encode unified dataset: %w", err)
+	}
+
+	unifiedSchema, err := dataset.Schema(schema.Instruction{}, schema.InstructionSchemaID, "Arisa instructions")
+	if err != nil {
+		return err
+	}
+	validator, err := dataset.NewValidator(unifiedSchema)
+	if err != nil {
+		return err
+	}
+	if err := validator.Validate(buffer.Bytes()); err != nil {
+		return fmt.Errorf("unified dataset does not match its schema: %w", err)
+	}
+
+	if err := s.writeFileAtomic(filename, buffer.Bytes()); err != nil {
+		return fmt.Errorf("failed to write unified dataset: %w", err)
+	}
+	s.logger.Info("Saved unified dataset", "file", filename, "count", len(records))
+	return nil
+}
+
+// unifiedInstruction converts a page. The first mnemonic in its title
+// names the record and the others become aliases; each form is an
+// encoding with its own operands, since forms of one page differ in them.
+func (s *Scraper) unifiedInstruction(data InstructionData) schema.Instruction {
+	names := s.docsetNames(data)
+	_, summary, _ := strings.Cut(s.docsTitle(data), "—")
+
+	instruction := schema.Instruction{
+		ISA:         schema.ISAX86,
+		Summary:     strings.TrimSpace(summary),
+		Category:    data.Taxonomy,
+		Encodings:   []schema.Encoding{},
+		Description: data.DescriptionText,
+		Semantics: schema.Semantics{
+			Operation:    data.OperationText,
+			OperationAST: data.OperationAST,
+			Exceptions:   data.Exceptions,
+		},
+		Extensions: data.FeatureFlags,
+		Source: schema.Provenance{
+			Generator:   "x86-scraper",
+			Source:      "felixcloutier",
+			URL:         data.URL,
+			ContentHash: data.ContentHash,
+		},
+		X86: &schema.X86Details{
+			Title:      s.docsTitle(data),
+			Group:      data.Category,
+			Parent:     data.Parent,
+			Intrinsics: data.Intrinsics,
+		},
+	}
+	if instruction.Category == "" {
+		instruction.Category = s.classifyCategory(&data)
+	}
+	if len(names) > 0 {
+		instruction.Mnemonic = names[0]
+		instruction.X86.Aliases = names[1:]
+	}
+	if len(data.FlagsAffected) > 0 {
+		instruction.Semantics.Flags = make(map[string]string, len(data.FlagsAffected))
+		for flag, effect := range data.FlagsAffected {
+			instruction.Semantics.Flags[flag] = string(effect)
+		}
+	}
+	for _, form := range data.Forms {
+		instruction.Encodings = append(instruction.Encodings, s.unifiedEncoding(form))
+	}
+	return instruction
+}
+
+func (s *Scraper) unifiedEncoding(form InstructionForm) schema.Encoding {
+	encoding := schema.Encoding{
+		Mnemonic:   form.Mnemonic,
+		Syntax:     form.Instruction,
+		Opcode:     form.Opcode,
+		Extensions: form.FeatureFlags,
+		X86: &schema.X86Encoding{
+			OpEn:        form.OpEn,
+			Valid64:     string(form.Valid64),
+			ValidCompat: string(form.ValidCompat),
+			CPUID:       form.CPUID,
+		},
+	}
+	if form.Encoding != nil {
+		encoding.X86.Class = strings.ToLower(form.Encoding.PrefixClass)
+		encoding.X86.Map = form.Encoding.Map
+		encoding.X86.VectorLength = form.Encoding.VectorLength
+		encoding.X86.W = form.Encoding.W
+	}
+	for _, operand := range form.OperandDetails {
+		encoding.Operands = append(encoding.Operands, schema.Operand{
+			Name:     operand.Syntax,
+			Type:     operand.Type,
+			Kind:     operand.Kind,
+			Width:    operand.Width,
+			Access:   operand.Access,
+			Encoding: operand.Encoding,
+		})
+	}
+	return encoding
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+const vmcsFilename = "vmcs.json"
+
+type VMCSField struct {
+	Name       string `json:"name"`
+	Encoding   string `json:"encoding"`
+	Index      int    `json:"index"`
+	Type       string `json:"type"`
+	Width      string `json:"width"`
+	AccessType string `json:"accessType"`
+	ReadOnly   bool   `json:"readOnly"`
+}
+
+var vmcsFieldTypes = []string{"control", "vm-exit information", "guest state", "host state"}
+
+var vmcsFieldWidths = []string{"16-bit", "64-bit", "32-bit", "natural-width"}
+
+var vmcsFieldNames = map[uint32]string{
+	0x0000: "Virtual-processor identifier (VPID)",
+	0x0002: "Posted-interrupt notification vector",
+	0x0004: "EPTP index",
+	0x0006: "HLAT prefix size",
+	0x0008: "Last PID-pointer index",
+	0x0800: "Guest ES selector",
+	0x0802: "Guest CS selector",
+	0x0804: "Guest SS selector",
+	0x0806: "Guest DS selector",
+	0x0808: "Guest FS selector",
+	0x080A: "Guest GS selector",
+	0x080C: "Guest LDTR selector",
+	0x080E: "Guest TR selector",
+	0x0810: "Guest interrupt status",
+	0x0812: "PML index",
+	0x0814: "Guest UINV",
+	0x0C00: "Host ES selector",
+	0x0C02: "Host CS selector",
+	0x0C04: "Host SS selector",
+	0x0C06: "Host DS selector",
+	0x0C08: "Host FS selector",
+	0x0C0A: "Host GS selector",
+	0x0C0C: "Host TR selector",
+	0x2000: "Address of I/O bitmap A",
+	0x2002: "Address of I/O bitmap B",
+	0x2004: "Address of MSR bitmaps",
+	0x2006: "VM-exit MSR-store address",
+	0x2008: "VM-exit MSR-load address",
+	0x200A: "VM-entry MSR-load address",
+	0x200C: "Executive-VMCS pointer",
+	0x200E: "PML address",
+	0x2010: "TSC offset",
+	0x2012: "Virtual-APIC address",
+	0x2014: "APIC-access address",
+	0x2016: "Posted-interrupt descriptor address",
+	0x2018: "VM-function controls",
+	0x201A: "EPT pointer (EPTP)",
+	0x201C: "EOI-exit bitmap 0",
+	0x201E: "EOI-exit bitmap 1",
+	0x2020: "EOI-exit bitmap 2",
+	0x2022: "EOI-exit bitmap 3",
+	0x2024: "EPTP-list address",
+	0x2026: "VMREAD-bitmap address",
+	0x2028: "VMWRITE-bitmap address",
+	0x202A: "Virtualization-exception information address",
+	0x202C: "XSS-exiting bitmap",
+	0x202E: "ENCLS-exiting bitmap",
+	0x2030: "Sub-page-permission-table pointer",
+	0x2032: "TSC multiplier",
+	0x2034: "Tertiary processor-based VM-execution controls",
+	0x2036: "ENCLV-exiting bitmap",
+	0x2038: "Low PASID directory address",
+	0x203A: "High PASID directory address",
+	0x203C: "Shared EPT pointer",
+	0x203E: "PCONFIG-exiting bitmap",
+	0x2040: "Hypervisor-managed linear-address translation pointer (HLATP)",
+	0x2042: "PID-pointer table address",
+	0x2044: "Secondary VM-exit controls",
+	0x2400: "Guest-physical address",
+	0x2800: "VMCS link pointer",
+	0x2802: "Guest IA32_DEBUGCTL",
+	0x2804: "Guest IA32_PAT",
+	0x2806: "Guest IA32_EFER",
+	0x2808: "Guest IA32_PERF_GLOBAL_CTRL",
+	0x280A: "Guest PDPTE0",
+	0x280C: "Guest PDPTE1",
+	0x280E: "Guest PDPTE2",
+	0x2810: "Guest PDPTE3",
+	0x2812: "Guest IA32_BNDCFGS",
+	0x2814: "Guest IA32_RTIT_CTL",
+	0x2816: "Guest IA32_LBR_CTL",
+	0x2818: "Guest IA32_PKRS",
+	0x2C00: "Host IA32_PAT",
+	0x2C02: "Host IA32_EFER",
+	0x2C04: "Host IA32_PERF_GLOBAL_CTRL",
+	0x2C06: "Host IA32_PKRS",
+	0x4000: "Pin-based VM-execution controls",
+	0x4002: "Primary processor-based VM-execution controls",
+	0x4004: "Exception bitmap",
+	0x4006: "Page-fault error-code mask",
+	0x4008: "Page-fault error-code match",
+	0x400A: "CR3-target count",
+	0x400C: "Primary VM-exit controls",
+	0x400E: "VM-exit MSR-store count",
+	0x4010: "VM-exit MSR-load count",
+	0x4012: "VM-entry controls",
+	0x4014: "VM-entry MSR-load count",
+	0x4016: "VM-entry interruption-information field",
+	0x4018: "VM-entry exception error code",
+	0x401A: "VM-entry instruction length",
+	0x401C: "TPR threshold",
+	0x401E: "Secondary processor-based VM-execution controls",
+	0x4020: "PLE_Gap",
+	0x4022: "PLE_Window",
+	0x4024: "Instruction-timeout control",
+	0x4400: "VM-instruction error",
+	0x4402: "Exit reason",
+	0x4404: "VM-exit interruption information",
+	0x4406: "VM-exit interruption error code",
+	0x4408: "IDT-vectoring information field",
+	0x440A: "IDT-vectoring error code",
+	0x440C: "VM-exit instruction length",
+	0x440E: "VM-exit instruction information",
+	0x4800: "Guest ES limit",
+	0x4802: "Guest CS limit",
+	0x4804: "Guest SS limit",
+	0x4806: "Guest DS limit",
+	0x4808: "Guest FS limit",
+	0x480A: "Guest GS limit",
+	0x480C: "Guest LDTR limit",
+	0x480E: "Guest TR limit",
+	0x4810: "Guest GDTR limit",
+	0x4812: "Guest IDTR limit",
+	0x4814: "Guest ES access rights",
+	0x4816: "Guest CS access rights",
+	0x4818: "Guest SS access rights",
+	0x481A: "Guest DS access rights",
+	0x481C: "Guest FS access rights",
+	0x481E: "Guest GS access rights",
+	0x4820: "Guest LDTR access rights",
+	0x4822: "Guest TR access rights",
+	0x4824: "Guest interruptibility state",
+	0x4826: "Guest activity state",
+	0x4828: "Guest SMBASE",
+	0x482A: "Guest IA32_SYSENTER_CS",
+	0x482E: "VMX-preemption timer value",
+	0x4C00: "Host IA32_SYSENTER_CS",
+	0x6000: "CR0 guest/host mask",
+	0x6002: "CR4 guest/host mask",
+	0x6004: "CR0 read shadow",
+	0x6006: "CR4 read shadow",
+	0x6008: "CR3-target value 0",
+	0x600A: "CR3-target value 1",
+	0x600C: "CR3-target value 2",
+	0x600E: "CR3-target value 3",
+	0x6400: "Exit qualification",
+	0x6402: "I/O RCX",
+	0x6404: "I/O RSI",
+	0x6406: "I/O RDI",
+	0x6408: "I/O RIP",
+	0x640A: "Guest-linear address",
+	0x6800: "Guest CR0",
+	0x6802: "Guest CR3",
+	0x6804: "Guest CR4",
+	0x6806: "Guest ES base",
+	0x6808: "Guest CS base",
+	0x680A: "Guest SS base",
+	0x680C: "Guest DS base",
+	0x680E: "Guest FS base",
+	0x6810: "Guest GS base",
+	0x6812: "Guest LDTR base",
+	0x6814: "Guest TR base",
+	0x6816: "Guest GDTR base",
+	0x6818: "Guest IDTR base",
+	0x681A: "Guest DR7",
+	0x681C: "Guest RSP",
+	0x681E: "Guest RIP",
+	0x6820: "Guest RFLAGS",
+	0x6822: "Guest pending debug exceptions",
+	0x6824: "Guest IA32_SYSENTER_ESP",
+	0x6826: "Guest IA32_SYSENTER_EIP",
+	0x6828: "Guest IA32_S_CET",
+	0x682A: "Guest SSP",
+	0x682C: "Guest IA32_INTERRUPT_SSP_TABLE_ADDR",
+	0x6C00: "Host CR0",
+	0x6C02: "Host CR3",
+	0x6C04: "Host CR4",
+	0x6C06: "Host FS base",
+	0x6C08: "Host GS base",
+	0x6C0A: "Host TR base",
+	0x6C0C: "Host GDTR base",
+	0x6C0E: "Host IDTR base",
+	0x6C10: "Host IA32_SYSENTER_ESP",
+	0x6C12: "Host IA32_SYSENTER_EIP",
+	0x6C14: "Host RSP",
+	0x6C16: "Host RIP",
+	0x6C18: "Host IA32_S_CET",
+	0x6C1A: "Host SSP",
+	0x6C1C: "Host IA32_INTERRUPT_SSP_TABLE_ADDR",
+}
+
+func (s *Scraper) buildVMCSFields() []VMCSField {
+	var fields []VMCSField
+	for encoding, name := range vmcsFieldNames {
+		field := s.decodeVMCSField(encoding, name)
+		fields = append(fields, field)
+
+		if field.Width == "64-bit" {
+			high := s.decodeVMCSField(encoding|1, name+" (high)")
+			fields = append(fields, high)
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Encoding < fields[j].Encoding
+	})
+	return fields
+}
+
+func (s *Scraper) decodeVMCSField(encoding uint32, name string) VMCSField {
+	fieldType := vmcsFieldTypes[(encoding>>10)&3]
+	accessType := "full"
+	if encoding&1 != 0 {
+		accessType = "high"
+	}
+
+	return VMCSField{
+		Name:       name,
+		Encoding:   fmt.Sprintf("0x%04X", encoding),
+		Index:      int((encoding >> 1) & 0x1FF),
+		Type:       fieldType,
+		Width:      vmcsFieldWidths[(encoding>>13)&3],
+		AccessType: accessType,
+		ReadOnly:   fieldType == "vm-exit information",
+	}
+}
+
+func (s *Scraper) linkVMCSFields(data *InstructionData) {
+	data.VMCSFields = ""
+	page := strings.ToUpper(data.URL[strings.LastIndex(data.URL, "/")+1:])
+	if page == "VMREAD" || page == "VMWRITE" {
+		data.VMCSFields = vmcsFilename
+	}
+}
+
+func (s *Scraper) saveVMCSFields() error {
+	fields := s.buildVMCSFields()
+	s.logger.Info("Saving VMCS fields", "count", len(fields))
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(fields); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if err := ioutil.WriteFile(vmcsFilename, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JSON to file: %w", err)
+	}
+
+	s.logger.Info("VMCS fields saved successfully", "file", vmcsFilename)
+	return nil
+}